@@ -7,6 +7,7 @@ import (
 	"time"
 
 	"github.com/centrifuge/go-centrifuge/httpapi"
+	"github.com/centrifuge/go-centrifuge/notification"
 	"github.com/centrifuge/go-centrifuge/utils/httputils"
 	"github.com/go-chi/render"
 	logging "github.com/ipfs/go-log"
@@ -59,6 +60,9 @@ func (c apiServer) Start(ctx context.Context, wg *sync.WaitGroup, startupErr cha
 		Handler: mux,
 	}
 
+	// end the event streams so that the shutdown doesn't wait for them
+	srv.RegisterOnShutdown(notification.GetBroker().CloseSubscriptions)
+
 	startUpErrOut := make(chan error)
 	go func(startUpErrInner chan<- error) {
 		log.Infof("HTTP API running at: %s\n", c.config.GetServerAddress())
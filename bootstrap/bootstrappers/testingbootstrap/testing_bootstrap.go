@@ -22,6 +22,7 @@ import (
 	"github.com/centrifuge/go-centrifuge/identity/ideth"
 	"github.com/centrifuge/go-centrifuge/jobs/jobsv1"
 	"github.com/centrifuge/go-centrifuge/nft"
+	"github.com/centrifuge/go-centrifuge/notification"
 	"github.com/centrifuge/go-centrifuge/p2p"
 	"github.com/centrifuge/go-centrifuge/pending"
 	"github.com/centrifuge/go-centrifuge/queue"
@@ -42,6 +43,7 @@ var bootstrappers = []bootstrap.TestBootstrapper{
 	ethereum.Bootstrapper{},
 	&ideth.Bootstrapper{},
 	&configstore.Bootstrapper{},
+	notification.Bootstrapper{},
 	anchors.Bootstrapper{},
 	documents.Bootstrapper{},
 	&entityrelationship.Bootstrapper{},
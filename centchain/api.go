@@ -12,12 +12,14 @@ import (
 	"github.com/centrifuge/go-centrifuge/identity"
 	"github.com/centrifuge/go-centrifuge/jobs"
 	"github.com/centrifuge/go-centrifuge/queue"
+	"github.com/centrifuge/go-centrifuge/signer"
 	gsrpc "github.com/centrifuge/go-substrate-rpc-client"
 	"github.com/centrifuge/go-substrate-rpc-client/client"
 	"github.com/centrifuge/go-substrate-rpc-client/rpc/author"
 	"github.com/centrifuge/go-substrate-rpc-client/signature"
 	"github.com/centrifuge/go-substrate-rpc-client/types"
 	logging "github.com/ipfs/go-log"
+	"golang.org/x/crypto/blake2b"
 )
 
 const (
@@ -44,11 +46,18 @@ type API interface {
 	GetMetadataLatest() (*types.Metadata, error)
 
 	// SubmitExtrinsic signs the given call with the provided KeyRingPair and submits an extrinsic.
+	// Fails with ErrInsufficientBalance if the account can't pay the estimated fee.
 	// Returns transaction hash, latest block number before extrinsic submission, and signature attached with the extrinsic.
 	SubmitExtrinsic(ctx context.Context, meta *types.Metadata, c types.Call, krp signature.KeyringPair) (txHash types.Hash, bn types.BlockNumber, sig types.MultiSignature, err error)
 
+	// EstimateFee returns the fee the chain charges for the signed extrinsic.
+	EstimateFee(ext types.Extrinsic) (*FeeInfo, error)
+
+	// GetBalance returns the balance of the account with the public key.
+	GetBalance(meta *types.Metadata, accountID []byte) (*Balance, error)
+
 	// SubmitAndWatch returns function that submits and watches an extrinsic, implements transaction.Submitter
-	SubmitAndWatch(ctx context.Context, meta *types.Metadata, c types.Call, krp signature.KeyringPair) func(accountID identity.DID, jobID jobs.JobID, jobMan jobs.Manager, errOut chan<- error)
+	SubmitAndWatch(ctx context.Context, meta *types.Metadata, c types.Call, krp signature.KeyringPair) func(ctx context.Context, accountID identity.DID, jobID jobs.JobID, jobMan jobs.Manager, errOut chan<- error)
 }
 
 // SubstrateAPI exposes Substrate API functions
@@ -62,6 +71,8 @@ type SubstrateAPI interface {
 	GetStorageLatest(key types.StorageKey, target interface{}) error
 	GetStorage(key types.StorageKey, target interface{}, blockHash types.Hash) error
 	GetBlock(blockHash types.Hash) (*types.SignedBlock, error)
+	GetFinalizedHead() (types.Hash, error)
+	GetHeader(blockHash types.Hash) (*types.Header, error)
 }
 
 // Config defines functions to get centchain details
@@ -69,6 +80,8 @@ type Config interface {
 	GetCentChainIntervalRetry() time.Duration
 	GetCentChainMaxRetries() int
 	GetCentChainAccount() (acc config.CentChainAccount, err error)
+	GetCentChainSignerBackend() string
+	GetCentChainSignerURL() string
 }
 
 type defaultSubstrateAPI struct {
@@ -91,6 +104,14 @@ func (dsa *defaultSubstrateAPI) GetBlock(blockHash types.Hash) (*types.SignedBlo
 	return dsa.sapi.RPC.Chain.GetBlock(blockHash)
 }
 
+func (dsa *defaultSubstrateAPI) GetFinalizedHead() (types.Hash, error) {
+	return dsa.sapi.RPC.Chain.GetFinalizedHead()
+}
+
+func (dsa *defaultSubstrateAPI) GetHeader(blockHash types.Hash) (*types.Header, error) {
+	return dsa.sapi.RPC.Chain.GetHeader(blockHash)
+}
+
 func (dsa *defaultSubstrateAPI) GetStorage(key types.StorageKey, target interface{}, blockHash types.Hash) error {
 	_, err := dsa.sapi.RPC.State.GetStorage(key, target, blockHash)
 	return err
@@ -170,7 +191,17 @@ func (a *api) SubmitExtrinsic(ctx context.Context, meta *types.Metadata, c types
 		Tip:         0,
 	}
 
-	err = ext.Sign(krp, o)
+	s, err := signer.NewSubstrateSigner(a.config.GetCentChainSignerBackend(), a.config.GetCentChainSignerURL(), krp)
+	if err != nil {
+		return txHash, bn, sig, err
+	}
+
+	err = signExtrinsic(&ext, s, o)
+	if err != nil {
+		return txHash, bn, sig, err
+	}
+
+	err = a.checkBalance(meta, ext, s.PublicKey())
 	if err != nil {
 		return txHash, bn, sig, err
 	}
@@ -186,6 +217,57 @@ func (a *api) SubmitExtrinsic(ctx context.Context, meta *types.Metadata, c types
 	return txHash, startBlockNumber, ext.Signature.Signature, err
 }
 
+// signExtrinsic signs the extrinsic with the signer the same way types.Extrinsic.Sign does with a keyring pair.
+func signExtrinsic(ext *types.Extrinsic, s signer.SubstrateSigner, o types.SignatureOptions) error {
+	mb, err := types.EncodeToBytes(ext.Method)
+	if err != nil {
+		return err
+	}
+
+	era := o.Era
+	if !o.Era.IsMortalEra {
+		era = types.ExtrinsicEra{IsImmortalEra: true}
+	}
+
+	payload := types.ExtrinsicPayloadV4{
+		ExtrinsicPayloadV3: types.ExtrinsicPayloadV3{
+			Method:      mb,
+			Era:         era,
+			Nonce:       o.Nonce,
+			Tip:         o.Tip,
+			SpecVersion: o.SpecVersion,
+			GenesisHash: o.GenesisHash,
+			BlockHash:   o.BlockHash,
+		},
+	}
+
+	b, err := types.EncodeToBytes(payload)
+	if err != nil {
+		return err
+	}
+
+	// payloads longer than 256 bytes are signed by their hash
+	if len(b) > 256 {
+		h := blake2b.Sum256(b)
+		b = h[:]
+	}
+
+	sig, err := s.Sign(b)
+	if err != nil {
+		return err
+	}
+
+	ext.Signature = types.ExtrinsicSignatureV4{
+		Signer:    types.NewAddressFromAccountID(s.PublicKey()),
+		Signature: types.MultiSignature{IsSr25519: true, AsSr25519: types.NewSignature(sig)},
+		Era:       era,
+		Nonce:     o.Nonce,
+		Tip:       o.Tip,
+	}
+	ext.Version |= types.ExtrinsicBitSigned
+	return nil
+}
+
 func (a *api) QueueCentChainEXTStatusTask(
 	accountID identity.DID,
 	jobID jobs.JobID,
@@ -272,8 +354,8 @@ func (a *api) SubmitWithRetries(ctx context.Context, meta *types.Metadata, c typ
 }
 
 // SubmitAndWatch is submitting a CentChain transaction and starts a task to wait for the transaction result
-func (a *api) SubmitAndWatch(ctx context.Context, meta *types.Metadata, c types.Call, krp signature.KeyringPair) func(accountID identity.DID, jobID jobs.JobID, jobsMan jobs.Manager, errOut chan<- error) {
-	return func(accountID identity.DID, jobID jobs.JobID, jobMan jobs.Manager, errOut chan<- error) {
+func (a *api) SubmitAndWatch(ctx context.Context, meta *types.Metadata, c types.Call, krp signature.KeyringPair) func(ctx context.Context, accountID identity.DID, jobID jobs.JobID, jobsMan jobs.Manager, errOut chan<- error) {
+	return func(ctx context.Context, accountID identity.DID, jobID jobs.JobID, jobMan jobs.Manager, errOut chan<- error) {
 		tx, bn, msig, err := a.SubmitWithRetries(ctx, meta, c, krp)
 		if err != nil {
 			errOut <- err
@@ -4,6 +4,8 @@ package centchain
 
 import (
 	"context"
+	"encoding/json"
+	"math/big"
 	"os"
 	"testing"
 	"time"
@@ -13,8 +15,10 @@ import (
 	"github.com/centrifuge/go-centrifuge/config"
 	"github.com/centrifuge/go-centrifuge/contextutil"
 	"github.com/centrifuge/go-centrifuge/errors"
+	"github.com/centrifuge/go-centrifuge/signer"
 	"github.com/centrifuge/go-centrifuge/testingutils"
 	"github.com/centrifuge/go-centrifuge/utils"
+	"github.com/centrifuge/go-substrate-rpc-client/signature"
 	"github.com/centrifuge/go-substrate-rpc-client/types"
 	"github.com/ethereum/go-ethereum/common/hexutil"
 	"github.com/stretchr/testify/assert"
@@ -70,7 +74,7 @@ func TestApi_SubmitExtrinsic(t *testing.T) {
 
 	// failed getGenesisHash
 	mockSAPI := new(MockSubstrateAPI)
-	api := NewAPI(mockSAPI, nil, nil)
+	api := NewAPI(mockSAPI, cfg, nil)
 	ctx := context.Background()
 	mockSAPI.On("GetBlockHash", mock.Anything).Return(types.Hash{}, errors.New("failed to get block hash")).Once()
 	_, _, _, err = api.SubmitExtrinsic(ctx, meta, c, krp)
@@ -90,9 +94,29 @@ func TestApi_SubmitExtrinsic(t *testing.T) {
 	assert.Error(t, err)
 	assert.Contains(t, err.Error(), "nonce value not found in the context")
 
+	// failed to estimate fee
+	ctx = contextutil.WithNonce(context.Background(), 3)
+	mockSAPI.On("Call", mock.Anything, "payment_queryInfo", mock.Anything).Return(errors.New("failed to query info")).Once()
+	_, _, _, err = api.SubmitExtrinsic(ctx, meta, c, krp)
+	assert.True(t, errors.IsOfType(ErrFeeEstimation, err))
+
+	// failed to get balance
+	mockFee(mockSAPI, "100").Once()
+	mockSAPI.On("GetStorageLatest", mock.Anything, mock.Anything).Return(errors.New("failed to get account")).Once()
+	_, _, _, err = api.SubmitExtrinsic(ctx, meta, c, krp)
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "failed to get account")
+
+	// insufficient balance
+	mockFee(mockSAPI, "100").Once()
+	mockBalance(mockSAPI, 99).Once()
+	_, _, _, err = api.SubmitExtrinsic(ctx, meta, c, krp)
+	assert.True(t, errors.IsOfType(ErrInsufficientBalance, err))
+
 	// failed to get latest block
+	mockFee(mockSAPI, "100")
+	mockBalance(mockSAPI, 100)
 	mockClient := new(MockClient)
-	ctx = contextutil.WithNonce(context.Background(), 3)
 	mockSAPI.On("GetClient").Return(mockClient)
 	mockSAPI.On("GetBlockLatest", mock.Anything).Return(nil, errors.New("failed to get latest block")).Once()
 	_, _, _, err = api.SubmitExtrinsic(ctx, meta, c, krp)
@@ -153,6 +177,8 @@ func TestApi_SubmitWithRetries(t *testing.T) {
 
 	// Success
 	mockSAPI.On("GetBlockHash", mock.Anything).Return(types.Hash(utils.RandomByte32()), nil).Once()
+	mockFee(mockSAPI, "100").Once()
+	mockBalance(mockSAPI, 1000).Once()
 	mockSAPI.On("GetRuntimeVersionLatest").Return(types.NewRuntimeVersion(), nil)
 	mockClient := new(MockClient)
 	mockSAPI.On("GetClient").Return(mockClient)
@@ -163,3 +189,149 @@ func TestApi_SubmitWithRetries(t *testing.T) {
 	assert.Equal(t, uint32(1), tapi.accounts[cacc.ID]) //Incremented nonce
 	mockSAPI.AssertExpectations(t)
 }
+
+func mockFee(sapi *MockSubstrateAPI, fee string) *mock.Call {
+	return sapi.On("Call", mock.Anything, "payment_queryInfo", mock.Anything).Run(func(args mock.Arguments) {
+		args.Get(0).(*runtimeDispatchInfo).PartialFee = json.Number(fee)
+	}).Return(nil)
+}
+
+func mockBalance(sapi *MockSubstrateAPI, free int64) *mock.Call {
+	return sapi.On("GetStorageLatest", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+		args.Get(1).(*types.AccountInfo).Data.Free = types.NewU128(*big.NewInt(free))
+	}).Return(nil)
+}
+
+func TestApi_EstimateFee(t *testing.T) {
+	meta := MetaDataWithCall("Anchor.commit")
+	c, err := types.NewCall(meta, "Anchor.commit", types.NewHash(utils.RandomSlice(32)))
+	assert.NoError(t, err)
+	ext := types.NewExtrinsic(c)
+	mockSAPI := new(MockSubstrateAPI)
+	api := NewAPI(mockSAPI, cfg, nil)
+
+	// invalid fee
+	mockFee(mockSAPI, "0x10").Once()
+	_, err = api.EstimateFee(ext)
+	assert.True(t, errors.IsOfType(ErrFeeEstimation, err))
+
+	// success
+	mockFee(mockSAPI, "125000000").Once()
+	fee, err := api.EstimateFee(ext)
+	assert.NoError(t, err)
+	assert.Equal(t, big.NewInt(125000000), fee.PartialFee)
+	mockSAPI.AssertExpectations(t)
+}
+
+func TestApi_GetBalance(t *testing.T) {
+	meta := MetaDataWithCall("Anchor.commit")
+	mockSAPI := new(MockSubstrateAPI)
+	api := NewAPI(mockSAPI, cfg, nil)
+	mockSAPI.On("GetStorageLatest", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+		info := args.Get(1).(*types.AccountInfo)
+		info.Data.Free = types.NewU128(*big.NewInt(100))
+		info.Data.FreeFrozen = types.NewU128(*big.NewInt(30))
+	}).Return(nil).Once()
+	b, err := api.GetBalance(meta, utils.RandomSlice(32))
+	assert.NoError(t, err)
+	assert.Equal(t, big.NewInt(100), b.Free)
+	assert.Equal(t, big.NewInt(0), b.Reserved)
+	assert.Equal(t, big.NewInt(70), b.Spendable())
+
+	// frozen above free
+	b.FeeFrozen = big.NewInt(200)
+	assert.Equal(t, big.NewInt(0), b.Spendable())
+	mockSAPI.AssertExpectations(t)
+}
+
+type testSubstrateSigner struct {
+	payloads [][]byte
+}
+
+func (s *testSubstrateSigner) PublicKey() []byte {
+	return []byte{1, 2, 3}
+}
+
+func (s *testSubstrateSigner) Sign(payload []byte) ([]byte, error) {
+	s.payloads = append(s.payloads, payload)
+	return utils.RandomSlice(64), nil
+}
+
+func TestSignExtrinsic(t *testing.T) {
+	meta := MetaDataWithCall("Anchor.commit")
+	c, err := types.NewCall(
+		meta,
+		"Anchor.commit",
+		types.NewHash(utils.RandomSlice(32)),
+		types.NewHash(utils.RandomSlice(32)),
+		types.NewHash(utils.RandomSlice(32)),
+		types.NewMoment(time.Now()))
+	assert.NoError(t, err)
+	ext := types.NewExtrinsic(c)
+	s := new(testSubstrateSigner)
+	o := types.SignatureOptions{
+		BlockHash:   types.NewHash(utils.RandomSlice(32)),
+		GenesisHash: types.NewHash(utils.RandomSlice(32)),
+		Nonce:       types.UCompact(4),
+	}
+
+	assert.NoError(t, signExtrinsic(&ext, s, o))
+	assert.True(t, ext.IsSigned())
+	assert.Len(t, s.payloads, 1)
+	assert.True(t, len(s.payloads[0]) <= 256)
+	assert.True(t, ext.Signature.Era.IsImmortalEra)
+	assert.Equal(t, types.UCompact(4), ext.Signature.Nonce)
+	assert.Equal(t, types.NewAddressFromAccountID(s.PublicKey()), ext.Signature.Signer)
+	assert.True(t, ext.Signature.Signature.IsSr25519)
+}
+
+// recordingSigner records the payloads signed by the signer.
+type recordingSigner struct {
+	signer.SubstrateSigner
+	payloads [][]byte
+}
+
+func (s *recordingSigner) Sign(payload []byte) ([]byte, error) {
+	s.payloads = append(s.payloads, payload)
+	return s.SubstrateSigner.Sign(payload)
+}
+
+func TestSignExtrinsic_keyring(t *testing.T) {
+	krp := signature.TestKeyringPairAlice
+	ks, err := signer.NewSubstrateSigner(signer.BackendKeystore, "", krp)
+	assert.NoError(t, err)
+	s := &recordingSigner{SubstrateSigner: ks}
+	meta := MetaDataWithCall("Anchor.commit")
+	c, err := types.NewCall(
+		meta,
+		"Anchor.commit",
+		types.NewHash(utils.RandomSlice(32)),
+		types.NewHash(utils.RandomSlice(32)),
+		types.NewHash(utils.RandomSlice(32)),
+		types.NewMoment(time.Now()))
+	assert.NoError(t, err)
+	o := types.SignatureOptions{
+		BlockHash:   types.NewHash(utils.RandomSlice(32)),
+		GenesisHash: types.NewHash(utils.RandomSlice(32)),
+		Nonce:       types.UCompact(4),
+		SpecVersion: 1,
+	}
+
+	ext := types.NewExtrinsic(c)
+	assert.NoError(t, signExtrinsic(&ext, s, o))
+	assert.Len(t, s.payloads, 1)
+	ok, err := signature.Verify(s.payloads[0], ext.Signature.Signature.AsSr25519[:], krp.URI)
+	assert.NoError(t, err)
+	assert.True(t, ok)
+
+	// same extrinsic as signed with the keyring pair
+	expected := types.NewExtrinsic(c)
+	assert.NoError(t, expected.Sign(krp, o))
+	assert.Equal(t, expected.Signature.Signer, ext.Signature.Signer)
+	assert.Equal(t, expected.Signature.Era, ext.Signature.Era)
+	assert.Equal(t, expected.Signature.Nonce, ext.Signature.Nonce)
+	assert.Equal(t, expected.Signature.Tip, ext.Signature.Tip)
+	ok, err = signature.Verify(s.payloads[0], expected.Signature.Signature.AsSr25519[:], krp.URI)
+	assert.NoError(t, err)
+	assert.True(t, ok)
+}
@@ -0,0 +1,114 @@
+package centchain
+
+import (
+	"encoding/json"
+	"math/big"
+
+	"github.com/centrifuge/go-centrifuge/errors"
+	"github.com/centrifuge/go-substrate-rpc-client/types"
+)
+
+// FeeInfo is the fee estimated by the chain for an extrinsic.
+type FeeInfo struct {
+	Weight uint64
+	Class  string
+
+	// PartialFee is the fee in the smallest CFG unit, without the tip.
+	PartialFee *big.Int
+}
+
+// runtimeDispatchInfo is the result of payment_queryInfo.
+// The fee is a decimal string or a number depending on the runtime version.
+type runtimeDispatchInfo struct {
+	Weight     uint64      `json:"weight"`
+	Class      string      `json:"class"`
+	PartialFee json.Number `json:"partialFee"`
+}
+
+// Balance is the balance of a Centrifuge chain account in the smallest CFG unit.
+type Balance struct {
+	Free       *big.Int
+	Reserved   *big.Int
+	MiscFrozen *big.Int
+	FeeFrozen  *big.Int
+}
+
+// Spendable returns the part of the free balance that can pay fees.
+func (b Balance) Spendable() *big.Int {
+	s := new(big.Int).Sub(b.Free, b.FeeFrozen)
+	if s.Sign() < 0 {
+		return new(big.Int)
+	}
+
+	return s
+}
+
+// EstimateFee returns the fee the chain charges for the signed extrinsic.
+func (a *api) EstimateFee(ext types.Extrinsic) (*FeeInfo, error) {
+	enc, err := types.EncodeToHexString(ext)
+	if err != nil {
+		return nil, errors.NewTypedError(ErrFeeEstimation, err)
+	}
+
+	var info runtimeDispatchInfo
+	err = a.sapi.Call(&info, "payment_queryInfo", enc)
+	if err != nil {
+		return nil, errors.NewTypedError(ErrFeeEstimation, err)
+	}
+
+	fee, ok := new(big.Int).SetString(info.PartialFee.String(), 10)
+	if !ok {
+		return nil, errors.NewTypedError(ErrFeeEstimation, errors.New("invalid partial fee %q", info.PartialFee))
+	}
+
+	return &FeeInfo{Weight: info.Weight, Class: info.Class, PartialFee: fee}, nil
+}
+
+// GetBalance returns the balance of the account with the public key.
+func (a *api) GetBalance(meta *types.Metadata, accountID []byte) (*Balance, error) {
+	key, err := types.CreateStorageKey(meta, "System", "Account", accountID, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var accountInfo types.AccountInfo
+	err = a.sapi.GetStorageLatest(key, &accountInfo)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Balance{
+		Free:       u128ToBig(accountInfo.Data.Free),
+		Reserved:   u128ToBig(accountInfo.Data.Reserved),
+		MiscFrozen: u128ToBig(accountInfo.Data.MiscFrozen),
+		FeeFrozen:  u128ToBig(accountInfo.Data.FreeFrozen),
+	}, nil
+}
+
+// checkBalance fails with ErrInsufficientBalance if the signer of the extrinsic can't pay its fee.
+func (a *api) checkBalance(meta *types.Metadata, ext types.Extrinsic, accountID []byte) error {
+	fee, err := a.EstimateFee(ext)
+	if err != nil {
+		return err
+	}
+
+	balance, err := a.GetBalance(meta, accountID)
+	if err != nil {
+		return errors.New("failed to get balance: %v", err)
+	}
+
+	spendable := balance.Spendable()
+	if spendable.Cmp(fee.PartialFee) < 0 {
+		return errors.NewTypedError(ErrInsufficientBalance, errors.New("fee %s, spendable balance %s", fee.PartialFee, spendable))
+	}
+
+	return nil
+}
+
+func u128ToBig(v types.U128) *big.Int {
+	if v.Int == nil {
+		return new(big.Int)
+	}
+
+	return new(big.Int).Set(v.Int)
+}
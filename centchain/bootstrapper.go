@@ -6,6 +6,7 @@ import (
 	"github.com/centrifuge/go-centrifuge/errors"
 	"github.com/centrifuge/go-centrifuge/jobs"
 	"github.com/centrifuge/go-centrifuge/queue"
+	"github.com/centrifuge/go-centrifuge/storage"
 	gsrpc "github.com/centrifuge/go-substrate-rpc-client"
 )
 
@@ -32,15 +33,32 @@ func (Bootstrapper) Bootstrap(context map[string]interface{}) error {
 	}
 	queueSrv := context[bootstrap.BootstrappedQueueServer].(*queue.Server)
 
+	repo, ok := context[storage.BootstrappedDB].(storage.Repository)
+	if !ok {
+		return errors.New("storage not initialised")
+	}
+
 	sapi, err := gsrpc.NewSubstrateAPI(cfg.GetCentChainNodeURL())
 	if err != nil {
 		return err
 	}
 	centSAPI := &defaultSubstrateAPI{sapi}
 	client := NewAPI(centSAPI, cfg, queueSrv)
-	extStatusTask := NewExtrinsicStatusTask(cfg.GetCentChainIntervalRetry(), cfg.GetCentChainMaxRetries(), txManager, centSAPI.GetBlockHash, centSAPI.GetBlock, centSAPI.GetMetadataLatest, centSAPI.GetStorage)
+	indexer := NewEventIndexer(cfg, centSAPI, repo)
+
+	// extrinsics wait for the indexer to reach their blocks if it runs
+	var finality EventIndexer
+	if cfg.GetCentChainEventIndexerInterval() > 0 {
+		finality = indexer
+	}
+
+	extStatusTask := NewExtrinsicStatusTask(
+		cfg.GetCentChainIntervalRetry(), cfg.GetCentChainMaxRetries(), txManager, centSAPI.GetBlockHash, centSAPI.GetBlock,
+		centSAPI.GetMetadataLatest, centSAPI.GetStorage, cfg.GetCentChainConfirmationDepth(), centSAPI.GetFinalizedHead, centSAPI.GetHeader,
+		finality)
 	queueSrv.RegisterTaskType(extStatusTask.TaskTypeName(), extStatusTask)
 	context[BootstrappedCentChainClient] = client
+	context[BootstrappedEventIndexer] = indexer
 
 	return nil
 }
@@ -8,4 +8,16 @@ const (
 
 	// ErrBlockNotReady error when block is not ready yet
 	ErrBlockNotReady = errors.Error("required result to be 32 bytes, but got 0")
+
+	// ErrFeeEstimation error when the chain fails to estimate the fee of an extrinsic
+	ErrFeeEstimation = errors.Error("failed to estimate extrinsic fee")
+
+	// ErrInsufficientBalance error when the account can't pay the fee of an extrinsic
+	ErrInsufficientBalance = errors.Error("insufficient balance to pay extrinsic fee")
+
+	// ErrNoCheckpoint error when the event indexer has not indexed any block yet
+	ErrNoCheckpoint = errors.Error("event indexer checkpoint not found")
+
+	// ErrEventsDecode error when the events of a block can't be decoded with the latest metadata
+	ErrEventsDecode = errors.Error("failed to decode block events")
 )
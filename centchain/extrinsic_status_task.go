@@ -1,6 +1,7 @@
 package centchain
 
 import (
+	"context"
 	"time"
 
 	"github.com/centrifuge/go-centrifuge/errors"
@@ -26,6 +27,9 @@ const (
 
 	// TransactionAccountParam contains the name  of the account
 	TransactionAccountParam string = "Account ID"
+
+	// InclusionChain is the chain recorded in the job inclusions of centchain extrinsics
+	InclusionChain = "centchain"
 )
 
 // ExtrinsicStatusTask struct for the task to check a cent-chain transaction
@@ -39,6 +43,14 @@ type ExtrinsicStatusTask struct {
 	getBlock          func(blockHash types.Hash) (*types.SignedBlock, error)
 	getMetadataLatest func() (*types.Metadata, error)
 	getStorage        func(key types.StorageKey, target interface{}, blockHash types.Hash) error
+	getFinalizedHead  func() (types.Hash, error)
+	getHeader         func(blockHash types.Hash) (*types.Header, error)
+
+	// confirmationDepth is the number of finalized blocks, including the block of the extrinsic, required to confirm it
+	confirmationDepth int
+
+	// indexer, if set, signals the blocks reaching the confirmation depth instead of polling the finalized head
+	indexer EventIndexer
 
 	//extHash is the cent-chain extrinsic hash
 	extHash string
@@ -62,6 +74,10 @@ func NewExtrinsicStatusTask(
 	getBlock func(blockHash types.Hash) (*types.SignedBlock, error),
 	getMetadataLatest func() (*types.Metadata, error),
 	getStorage func(key types.StorageKey, target interface{}, blockHash types.Hash) error,
+	confirmationDepth int,
+	getFinalizedHead func() (types.Hash, error),
+	getHeader func(blockHash types.Hash) (*types.Header, error),
+	indexer EventIndexer,
 ) *ExtrinsicStatusTask {
 	return &ExtrinsicStatusTask{
 		intervalRetry:     intervalRetry,
@@ -71,6 +87,10 @@ func NewExtrinsicStatusTask(
 		getBlock:          getBlock,
 		getMetadataLatest: getMetadataLatest,
 		getStorage:        getStorage,
+		confirmationDepth: confirmationDepth,
+		getFinalizedHead:  getFinalizedHead,
+		getHeader:         getHeader,
+		indexer:           indexer,
 	}
 }
 
@@ -93,9 +113,18 @@ func (est *ExtrinsicStatusTask) Copy() (gocelery.CeleryTask, error) {
 		getStorage:        est.getStorage,
 		getBlock:          est.getBlock,
 		getBlockHash:      est.getBlockHash,
+		confirmationDepth: est.confirmationDepth,
+		getFinalizedHead:  est.getFinalizedHead,
+		getHeader:         est.getHeader,
+		indexer:           est.indexer,
 	}, nil
 }
 
+// JobDetails returns the account and the job of the task.
+func (est *ExtrinsicStatusTask) JobDetails() (identity.DID, jobs.JobID) {
+	return est.accountID, est.JobID
+}
+
 // ParseKwargs - define a method to parse gocelery params
 func (est *ExtrinsicStatusTask) ParseKwargs(kwargs map[string]interface{}) (err error) {
 	err = est.ParseJobID(est.TaskTypeName(), kwargs)
@@ -160,10 +189,18 @@ func (est *ExtrinsicStatusTask) RunTask() (resp interface{}, err error) {
 		err = est.UpdateJobWithValue(est.accountID, est.TaskTypeName(), err, jobValue)
 	}()
 
-	return est.processRunTask()
+	inclusion, err := est.processRunTask()
+	if err != nil {
+		return nil, err
+	}
+
+	return nil, est.JobManager.UpdateJobInclusion(est.accountID, est.JobID, *inclusion)
 }
 
-func (est *ExtrinsicStatusTask) processRunTask() (resp interface{}, err error) {
+// processRunTask looks for the extrinsic from the block it was submitted at and waits for the block to be finalized
+// up to the confirmation depth. The extrinsic is looked for again if its block is not part of the finalized chain.
+func (est *ExtrinsicStatusTask) processRunTask() (inclusion *jobs.Inclusion, err error) {
+	startBlock := est.fromBlock
 	var current int
 	for {
 
@@ -198,9 +235,102 @@ func (est *ExtrinsicStatusTask) processRunTask() (resp interface{}, err error) {
 
 		log.Infof("Found extrinsic %s in block %d", est.extHash, est.fromBlock)
 
-		return nil, est.parseExtrinsicStatus(nhBlock, foundIdx)
+		err = est.parseExtrinsicStatus(nhBlock, foundIdx)
+		if err != nil {
+			return nil, err
+		}
+
+		inclusion = &jobs.Inclusion{
+			Chain:         InclusionChain,
+			TxHash:        est.extHash,
+			BlockNumber:   uint64(est.fromBlock),
+			BlockHash:     nhBlock.Hex(),
+			Confirmations: 1,
+		}
+
+		if est.confirmationDepth > 0 {
+			inclusion.Confirmations, err = est.waitForFinality(uint64(est.fromBlock), &current)
+			if err != nil {
+				return nil, err
+			}
+
+			// the block is finalized, so the hash at its height is the canonical one
+			fhBlock, err := est.getBlockHash(uint64(est.fromBlock))
+			if err != nil {
+				return nil, err
+			}
+
+			if fhBlock != nhBlock {
+				log.Warningf("Block %d of extrinsic %s was reorged, looking for its new inclusion from block %d", est.fromBlock, est.extHash, startBlock)
+				est.fromBlock = startBlock
+				current++
+				continue
+			}
+		}
+
+		inclusion.ConfirmedAt = time.Now().UTC()
+		return inclusion, nil
+	}
+
+}
+
+// waitForFinality waits until the finalized head is at the confirmation depth from the block number and returns the
+// number of finalized blocks, including the block.
+func (est *ExtrinsicStatusTask) waitForFinality(number uint64, current *int) (uint64, error) {
+	if est.indexer != nil {
+		return est.waitForIndexedBlock(number, current)
+	}
+
+	for {
+		if *current >= est.maxRetries {
+			return 0, errors.NewTypedError(ErrCentChainTransaction, errors.New("max tries reached waiting for the finality of block %d of extrinsic %s", number, est.extHash))
+		}
+
+		fh, err := est.getFinalizedHead()
+		if err != nil {
+			return 0, err
+		}
+
+		header, err := est.getHeader(fh)
+		if err != nil {
+			return 0, err
+		}
+
+		finalized := uint64(header.Number)
+		if finalized >= number && finalized-number+1 >= uint64(est.confirmationDepth) {
+			return finalized - number + 1, nil
+		}
+
+		log.Infof("Block %d of extrinsic %s not at the confirmation depth %d yet, finalized head is %d", number, est.extHash, est.confirmationDepth, finalized)
+		*current++
+		time.Sleep(est.intervalRetry)
+	}
+}
+
+// waitForIndexedBlock waits until the event indexer indexes the block number, which happens once the block is at
+// the confirmation depth, and returns the number of finalized blocks, including the block.
+// The wait is bounded by the remaining retries.
+func (est *ExtrinsicStatusTask) waitForIndexedBlock(number uint64, current *int) (uint64, error) {
+	if *current >= est.maxRetries {
+		return 0, errors.NewTypedError(ErrCentChainTransaction, errors.New("max tries reached waiting for the finality of block %d of extrinsic %s", number, est.extHash))
+	}
+
+	if cp, err := est.indexer.Checkpoint(); err == nil && cp.Number >= number {
+		return cp.Number - number + uint64(est.confirmationDepth), nil
+	}
+
+	// the later blocks match as well, so a block indexed before the wait starts only delays it by a block
+	ctx, cancel := context.WithTimeout(context.Background(), time.Duration(est.maxRetries-*current)*est.intervalRetry)
+	defer cancel()
+	be, err := est.indexer.WaitForEvents(ctx, func(be BlockEvents) bool {
+		return be.Number >= number
+	})
+	if err != nil {
+		*current = est.maxRetries
+		return 0, errors.NewTypedError(ErrCentChainTransaction, errors.New("max tries reached waiting for the finality of block %d of extrinsic %s", number, est.extHash))
 	}
 
+	return be.Number - number + uint64(est.confirmationDepth), nil
 }
 
 func (est *ExtrinsicStatusTask) parseExtrinsicStatus(nhBlock types.Hash, foundIdx int) error {
@@ -9,6 +9,8 @@ import (
 
 	"github.com/centrifuge/go-centrifuge/errors"
 	"github.com/centrifuge/go-centrifuge/jobs"
+	"github.com/centrifuge/go-centrifuge/storage/leveldb"
+	"github.com/centrifuge/go-centrifuge/testingutils"
 	testingidentity "github.com/centrifuge/go-centrifuge/testingutils/identity"
 	"github.com/centrifuge/go-centrifuge/utils"
 	"github.com/centrifuge/go-substrate-rpc-client/types"
@@ -105,7 +107,7 @@ func TestExtrinsicStatusTask_ParseKwargs(t *testing.T) {
 
 func TestExtrinsicStatusTask_ProcessRunTask(t *testing.T) {
 	t.Skip()
-	task := NewExtrinsicStatusTask(1*time.Second, 10, nil, getBlockHash, getBlock, getMetadataLatest, getStorage)
+	task := NewExtrinsicStatusTask(1*time.Second, 10, nil, getBlockHash, getBlock, getMetadataLatest, getStorage, 0, nil, nil, nil)
 	jobID := jobs.NewJobID().String()
 	did := testingidentity.GenerateRandomDID()
 	kwargs := map[string]interface{}{
@@ -237,3 +239,89 @@ func getStorage(key types.StorageKey, target interface{}, blockHash types.Hash)
 
 	return types.DecodeFromBytes(bb, target)
 }
+
+func TestExtrinsicStatusTask_waitForFinality(t *testing.T) {
+	finalized := types.BlockNumber(9)
+	var calls int
+	getFinalizedHead := func() (types.Hash, error) {
+		calls++
+		return types.NewHash(utils.RandomSlice(32)), nil
+	}
+	getHeader := func(blockHash types.Hash) (*types.Header, error) {
+		return &types.Header{Number: finalized}, nil
+	}
+	task := NewExtrinsicStatusTask(time.Millisecond, 3, nil, getBlockHash, getBlock, getMetadataLatest, getStorage, 2, getFinalizedHead, getHeader, nil)
+	task.extHash = "0x1"
+
+	// block not finalized
+	var current int
+	_, err := task.waitForFinality(10, &current)
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "max tries reached waiting for the finality of block 10")
+	assert.Equal(t, 3, calls)
+
+	// block finalized, confirmation depth not reached
+	current = 0
+	finalized = 10
+	_, err = task.waitForFinality(10, &current)
+	assert.Error(t, err)
+
+	// confirmation depth reached
+	current = 0
+	finalized = 12
+	confirmations, err := task.waitForFinality(10, &current)
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(3), confirmations)
+	assert.Equal(t, 0, current)
+}
+
+func TestExtrinsicStatusTask_waitForIndexedBlock(t *testing.T) {
+	defer testingutils.MockConfigOption(cfg, "centChain.confirmationDepth", 0)()
+	db, err := leveldb.NewLevelDBStorage(leveldb.GetRandomTestStoragePath())
+	assert.NoError(t, err)
+	sapi := new(MockSubstrateAPI)
+	idx := NewEventIndexer(cfg, sapi, leveldb.NewLevelDBRepository(db)).(*indexer)
+	task := NewExtrinsicStatusTask(time.Millisecond, 3, nil, getBlockHash, getBlock, getMetadataLatest, getStorage, 2, nil, nil, idx)
+	task.extHash = "0x1"
+
+	// block not indexed
+	var current int
+	_, err = task.waitForFinality(10, &current)
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "max tries reached waiting for the finality of block 10")
+
+	// block indexed while waiting
+	task.intervalRetry = time.Second
+	sapi.On("GetMetadataLatest").Return(MetaDataWithCall("Anchor.commit"), nil)
+	sapi.On("GetBlockHash").Return(types.NewHash(utils.RandomSlice(32)), nil)
+	sapi.On("GetBlock").Return(new(types.SignedBlock), nil)
+	sapi.On("GetStorage").Return(nil)
+	sapi.On("GetBlockLatest").Return(latestBlock(11), nil).Once()
+	type result struct {
+		confirmations uint64
+		err           error
+	}
+	done := make(chan result)
+	go func() {
+		current := 0
+		confirmations, err := task.waitForFinality(10, &current)
+		done <- result{confirmations, err}
+	}()
+	for waiting := false; !waiting; time.Sleep(time.Millisecond) {
+		idx.mu.RLock()
+		waiting = len(idx.handlers) == 1
+		idx.mu.RUnlock()
+	}
+	_, err = idx.index()
+	assert.NoError(t, err)
+	res := <-done
+	assert.NoError(t, res.err)
+	assert.Equal(t, uint64(3), res.confirmations)
+
+	// block indexed before the wait
+	current = 0
+	confirmations, err := task.waitForFinality(9, &current)
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(4), confirmations)
+	sapi.AssertExpectations(t)
+}
@@ -0,0 +1,334 @@
+package centchain
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sync"
+	"time"
+
+	"github.com/centrifuge/go-centrifuge/errors"
+	"github.com/centrifuge/go-centrifuge/storage"
+	"github.com/centrifuge/go-substrate-rpc-client/types"
+)
+
+const (
+	// BootstrappedEventIndexer is the key mapped to the centchain EventIndexer.
+	BootstrappedEventIndexer = "BootstrappedCentChainEventIndexer"
+
+	// checkpointKey is the repository key of the last block indexed.
+	checkpointKey = "centchain_event_checkpoint"
+
+	// maxBlocksPerRun bounds the blocks indexed in a run, so that the indexer catches up over several runs after a downtime.
+	maxBlocksPerRun = 100
+)
+
+// BlockEvents holds the decoded events of an indexed block.
+type BlockEvents struct {
+	Number uint64
+	Hash   types.Hash
+	Block  types.Block
+	Events *Events
+}
+
+// EventHandler handles the events of an indexed block.
+// Errors are logged, the block is not indexed again.
+type EventHandler func(be BlockEvents) error
+
+// EventIndexer follows the centchain blocks at the confirmation depth and calls the registered handlers with their decoded events.
+// It runs as a node server and resumes from its checkpoint after a restart.
+// Extrinsic status tasks wait on it for the blocks of their extrinsics to reach the confirmation depth.
+type EventIndexer interface {
+	Name() string
+	Start(ctx context.Context, wg *sync.WaitGroup, startupErr chan<- error)
+
+	// RegisterHandler registers the handler of the events of every indexed block, replacing any handler with the same name.
+	RegisterHandler(name string, handler EventHandler)
+
+	// UnregisterHandler removes the handler with the name.
+	UnregisterHandler(name string)
+
+	// WaitForEvents waits for the first block indexed after the call that matches.
+	WaitForEvents(ctx context.Context, match func(be BlockEvents) bool) (BlockEvents, error)
+
+	// Checkpoint returns the last indexed block.
+	Checkpoint() (*Checkpoint, error)
+}
+
+// IndexerConfig is the config of the event indexer.
+type IndexerConfig interface {
+	GetCentChainEventIndexerInterval() time.Duration
+	GetCentChainConfirmationDepth() int
+}
+
+// Checkpoint is the last block indexed by the event indexer.
+type Checkpoint struct {
+	Number    uint64
+	Hash      types.Hash
+	UpdatedAt time.Time
+}
+
+// JSON returns the json representation of the checkpoint.
+func (c *Checkpoint) JSON() ([]byte, error) {
+	return json.Marshal(c)
+}
+
+// FromJSON loads the checkpoint from json bytes.
+func (c *Checkpoint) FromJSON(data []byte) error {
+	return json.Unmarshal(data, c)
+}
+
+// Type returns the type of the Checkpoint.
+func (c *Checkpoint) Type() reflect.Type {
+	return reflect.TypeOf(c)
+}
+
+type indexer struct {
+	config IndexerConfig
+	sapi   SubstrateAPI
+	repo   storage.Repository
+
+	mu       sync.RWMutex
+	handlers map[string]EventHandler
+	waiters  int
+}
+
+// NewEventIndexer returns an event indexer that runs as a node server.
+func NewEventIndexer(config IndexerConfig, sapi SubstrateAPI, repo storage.Repository) EventIndexer {
+	repo.Register(new(Checkpoint))
+	return &indexer{
+		config:   config,
+		sapi:     sapi,
+		repo:     repo,
+		handlers: make(map[string]EventHandler),
+	}
+}
+
+// Name returns the name of the event indexer server.
+func (i *indexer) Name() string {
+	return "CentChainEventIndexer"
+}
+
+// Start indexes the new blocks every interval until the context is done.
+func (i *indexer) Start(ctx context.Context, wg *sync.WaitGroup, startupErr chan<- error) {
+	defer wg.Done()
+	interval := i.config.GetCentChainEventIndexerInterval()
+	if interval <= 0 {
+		log.Info("centchain event indexer disabled")
+		<-ctx.Done()
+		return
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			log.Info("centchain event indexer stopped")
+			return
+		case <-ticker.C:
+			if _, err := i.index(); err != nil {
+				log.Errorf("failed to index centchain events: %v", err)
+			}
+		}
+	}
+}
+
+// RegisterHandler registers the handler with the name.
+func (i *indexer) RegisterHandler(name string, handler EventHandler) {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	i.handlers[name] = handler
+}
+
+// UnregisterHandler removes the handler with the name.
+func (i *indexer) UnregisterHandler(name string) {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	delete(i.handlers, name)
+}
+
+// WaitForEvents registers a handler matching the indexed blocks until a block matches or the context is done.
+func (i *indexer) WaitForEvents(ctx context.Context, match func(be BlockEvents) bool) (BlockEvents, error) {
+	i.mu.Lock()
+	i.waiters++
+	name := fmt.Sprintf("waiter-%d", i.waiters)
+	i.mu.Unlock()
+
+	found := make(chan BlockEvents, 1)
+	i.RegisterHandler(name, func(be BlockEvents) error {
+		if match(be) {
+			select {
+			case found <- be:
+			default:
+			}
+		}
+
+		return nil
+	})
+	defer i.UnregisterHandler(name)
+
+	select {
+	case be := <-found:
+		return be, nil
+	case <-ctx.Done():
+		return BlockEvents{}, ctx.Err()
+	}
+}
+
+// Checkpoint returns the last indexed block.
+func (i *indexer) Checkpoint() (*Checkpoint, error) {
+	if !i.repo.Exists([]byte(checkpointKey)) {
+		return nil, ErrNoCheckpoint
+	}
+
+	m, err := i.repo.Get([]byte(checkpointKey))
+	if err != nil {
+		return nil, err
+	}
+
+	return m.(*Checkpoint), nil
+}
+
+// head returns the number of the last block at the confirmation depth.
+// The depth is counted on the finalized head, a zero depth follows the latest block.
+func (i *indexer) head() (number uint64, ok bool, err error) {
+	depth := uint64(i.config.GetCentChainConfirmationDepth())
+	if depth == 0 {
+		block, err := i.sapi.GetBlockLatest()
+		if err != nil {
+			return 0, false, err
+		}
+
+		return uint64(block.Block.Header.Number), true, nil
+	}
+
+	fh, err := i.sapi.GetFinalizedHead()
+	if err != nil {
+		return 0, false, err
+	}
+
+	header, err := i.sapi.GetHeader(fh)
+	if err != nil {
+		return 0, false, err
+	}
+
+	finalized := uint64(header.Number)
+	if finalized+1 < depth {
+		return 0, false, nil
+	}
+
+	return finalized + 1 - depth, true, nil
+}
+
+// index indexes the blocks after the checkpoint up to the confirmation depth and returns the number of blocks indexed.
+// Without a checkpoint, the indexer starts at the current block.
+func (i *indexer) index() (int, error) {
+	head, ok, err := i.head()
+	if err != nil || !ok {
+		return 0, err
+	}
+
+	next := head
+	cp, err := i.Checkpoint()
+	if err == nil {
+		next = cp.Number + 1
+	} else if err != ErrNoCheckpoint {
+		return 0, err
+	}
+
+	if next > head {
+		return 0, nil
+	}
+
+	end := head
+	if end-next >= maxBlocksPerRun {
+		end = next + maxBlocksPerRun - 1
+	}
+
+	meta, err := i.sapi.GetMetadataLatest()
+	if err != nil {
+		return 0, err
+	}
+
+	key, err := types.CreateStorageKey(meta, "System", "Events", nil, nil)
+	if err != nil {
+		return 0, err
+	}
+
+	var count int
+	for n := next; n <= end; n++ {
+		be, err := i.blockEvents(meta, key, n)
+		switch {
+		case err == nil:
+			i.dispatch(be)
+		case errors.IsOfType(ErrEventsDecode, err):
+			// decoding the block again fails the same way, skip it instead of stalling the checkpoint
+			log.Errorf("skipping centchain block %d: %v", n, err)
+		default:
+			return count, errors.New("failed to index block %d: %v", n, err)
+		}
+
+		batch := storage.NewBatch()
+		batch.Put([]byte(checkpointKey), &Checkpoint{Number: n, Hash: be.Hash, UpdatedAt: time.Now().UTC()})
+		err = i.repo.Write(batch)
+		if err != nil {
+			return count, errors.New("failed to save checkpoint of block %d: %v", n, err)
+		}
+
+		count++
+	}
+
+	return count, nil
+}
+
+// blockEvents fetches the block with the number and decodes its events.
+func (i *indexer) blockEvents(meta *types.Metadata, key types.StorageKey, number uint64) (be BlockEvents, err error) {
+	be.Number = number
+	be.Hash, err = i.sapi.GetBlockHash(number)
+	if err != nil {
+		return be, err
+	}
+
+	block, err := i.sapi.GetBlock(be.Hash)
+	if err != nil {
+		return be, err
+	}
+	be.Block = block.Block
+
+	var er types.EventRecordsRaw
+	err = i.sapi.GetStorage(key, &er, be.Hash)
+	if err != nil {
+		return be, err
+	}
+
+	be.Events = new(Events)
+	// blocks without events have no events storage
+	if len(er) == 0 {
+		return be, nil
+	}
+
+	err = er.DecodeEventRecords(meta, be.Events)
+	if err != nil {
+		return be, errors.NewTypedError(ErrEventsDecode, err)
+	}
+
+	return be, nil
+}
+
+// dispatch calls the handlers with the block events.
+func (i *indexer) dispatch(be BlockEvents) {
+	i.mu.RLock()
+	handlers := make(map[string]EventHandler, len(i.handlers))
+	for name, h := range i.handlers {
+		handlers[name] = h
+	}
+	i.mu.RUnlock()
+
+	for name, h := range handlers {
+		if err := h(be); err != nil {
+			log.Errorf("centchain event handler %s failed for block %d: %v", name, be.Number, err)
+		}
+	}
+}
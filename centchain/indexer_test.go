@@ -0,0 +1,186 @@
+// +build unit
+
+package centchain
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/centrifuge/go-centrifuge/errors"
+	"github.com/centrifuge/go-centrifuge/storage/leveldb"
+	"github.com/centrifuge/go-centrifuge/testingutils"
+	"github.com/centrifuge/go-centrifuge/utils"
+	"github.com/centrifuge/go-substrate-rpc-client/types"
+	"github.com/stretchr/testify/assert"
+)
+
+// undecodableEvents holds an event of a module missing from the metadata.
+var undecodableEvents = types.EventRecordsRaw{0x04, 0x00, 0x00, 0x00, 0x00, 0x00, 0xff, 0xff}
+
+func latestBlock(n uint32) *types.SignedBlock {
+	b := new(types.SignedBlock)
+	b.Block.Header.Number = types.BlockNumber(n)
+	return b
+}
+
+func TestIndexer_index(t *testing.T) {
+	defer testingutils.MockConfigOption(cfg, "centChain.confirmationDepth", 0)()
+	db, err := leveldb.NewLevelDBStorage(leveldb.GetRandomTestStoragePath())
+	assert.NoError(t, err)
+	repo := leveldb.NewLevelDBRepository(db)
+	sapi := new(MockSubstrateAPI)
+	idx := NewEventIndexer(cfg, sapi, repo).(*indexer)
+	var indexed []uint64
+	idx.RegisterHandler("test", func(be BlockEvents) error {
+		indexed = append(indexed, be.Number)
+		assert.NotNil(t, be.Events)
+		return nil
+	})
+	idx.RegisterHandler("failing", func(be BlockEvents) error {
+		return errors.New("failed to handle events")
+	})
+	_, err = idx.Checkpoint()
+	assert.Equal(t, ErrNoCheckpoint, err)
+
+	hash := types.NewHash(utils.RandomSlice(32))
+	sapi.On("GetMetadataLatest").Return(MetaDataWithCall("Anchor.commit"), nil)
+	sapi.On("GetBlockHash").Return(hash, nil)
+	sapi.On("GetBlock").Return(new(types.SignedBlock), nil)
+	sapi.On("GetStorage").Return(nil)
+
+	// starts at the current block
+	sapi.On("GetBlockLatest").Return(latestBlock(5), nil).Once()
+	n, err := idx.index()
+	assert.NoError(t, err)
+	assert.Equal(t, 1, n)
+	assert.Equal(t, []uint64{5}, indexed)
+
+	// follows the blocks after the checkpoint
+	sapi.On("GetBlockLatest").Return(latestBlock(8), nil).Once()
+	n, err = idx.index()
+	assert.NoError(t, err)
+	assert.Equal(t, 3, n)
+	assert.Equal(t, []uint64{5, 6, 7, 8}, indexed)
+	cp, err := idx.Checkpoint()
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(8), cp.Number)
+	assert.Equal(t, hash, cp.Hash)
+
+	// resumes from the checkpoint after a restart
+	idx = NewEventIndexer(cfg, sapi, repo).(*indexer)
+	sapi.On("GetBlockLatest").Return(latestBlock(8), nil).Once()
+	n, err = idx.index()
+	assert.NoError(t, err)
+	assert.Equal(t, 0, n)
+
+	// waits for the matching block
+	sapi.On("GetBlockLatest").Return(latestBlock(10), nil).Once()
+	go func() {
+		time.Sleep(100 * time.Millisecond)
+		_, err := idx.index()
+		assert.NoError(t, err)
+	}()
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	be, err := idx.WaitForEvents(ctx, func(be BlockEvents) bool {
+		return be.Number == 10
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(10), be.Number)
+	assert.Len(t, idx.handlers, 0)
+
+	// blocks at the confirmation depth of the finalized head
+	defer testingutils.MockConfigOption(cfg, "centChain.confirmationDepth", 2)()
+	sapi.On("GetFinalizedHead").Return(hash, nil)
+	sapi.On("GetHeader", hash).Return(&types.Header{Number: 12}, nil).Once()
+	head, ok, err := idx.head()
+	assert.NoError(t, err)
+	assert.True(t, ok)
+	assert.Equal(t, uint64(11), head)
+	sapi.On("GetHeader", hash).Return(&types.Header{Number: 0}, nil).Once()
+	_, ok, err = idx.head()
+	assert.NoError(t, err)
+	assert.False(t, ok)
+	sapi.AssertExpectations(t)
+}
+
+func TestIndexer_blockEvents(t *testing.T) {
+	db, err := leveldb.NewLevelDBStorage(leveldb.GetRandomTestStoragePath())
+	assert.NoError(t, err)
+	sapi := new(MockSubstrateAPI)
+	idx := NewEventIndexer(cfg, sapi, leveldb.NewLevelDBRepository(db)).(*indexer)
+	meta := MetaDataWithCall("Anchor.commit")
+	key, err := types.CreateStorageKey(meta, "System", "Events", nil, nil)
+	assert.NoError(t, err)
+
+	// failed to get block
+	sapi.On("GetBlockHash").Return(types.NewHash(utils.RandomSlice(32)), nil)
+	sapi.On("GetBlock").Return(nil, errors.New("failed to get block")).Once()
+	_, err = idx.blockEvents(meta, key, 1)
+	assert.Error(t, err)
+
+	// failed to get events
+	sapi.On("GetBlock").Return(new(types.SignedBlock), nil)
+	sapi.On("GetStorage").Return(errors.New("failed to get storage")).Once()
+	_, err = idx.blockEvents(meta, key, 1)
+	assert.Error(t, err)
+
+	// block without events
+	sapi.On("GetStorage").Return(nil).Once()
+	be, err := idx.blockEvents(meta, key, 1)
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(1), be.Number)
+	assert.NotNil(t, be.Events)
+
+	// events of an unknown module
+	sapi.On("GetStorage").Return(nil, undecodableEvents).Once()
+	_, err = idx.blockEvents(meta, key, 1)
+	assert.Error(t, err)
+	assert.True(t, errors.IsOfType(ErrEventsDecode, err))
+	sapi.AssertExpectations(t)
+}
+
+func TestIndexer_index_undecodableBlock(t *testing.T) {
+	defer testingutils.MockConfigOption(cfg, "centChain.confirmationDepth", 0)()
+	db, err := leveldb.NewLevelDBStorage(leveldb.GetRandomTestStoragePath())
+	assert.NoError(t, err)
+	sapi := new(MockSubstrateAPI)
+	idx := NewEventIndexer(cfg, sapi, leveldb.NewLevelDBRepository(db)).(*indexer)
+	var indexed []uint64
+	idx.RegisterHandler("test", func(be BlockEvents) error {
+		indexed = append(indexed, be.Number)
+		return nil
+	})
+
+	hash := types.NewHash(utils.RandomSlice(32))
+	sapi.On("GetMetadataLatest").Return(MetaDataWithCall("Anchor.commit"), nil)
+	sapi.On("GetBlockHash").Return(hash, nil)
+	sapi.On("GetBlock").Return(new(types.SignedBlock), nil)
+	sapi.On("GetBlockLatest").Return(latestBlock(5), nil).Once()
+	sapi.On("GetStorage").Return(nil).Once()
+	_, err = idx.index()
+	assert.NoError(t, err)
+
+	// the undecodable block is skipped and the next ones are indexed
+	sapi.On("GetBlockLatest").Return(latestBlock(7), nil).Once()
+	sapi.On("GetStorage").Return(nil, undecodableEvents).Once()
+	sapi.On("GetStorage").Return(nil).Once()
+	n, err := idx.index()
+	assert.NoError(t, err)
+	assert.Equal(t, 2, n)
+	assert.Equal(t, []uint64{5, 7}, indexed)
+	cp, err := idx.Checkpoint()
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(7), cp.Number)
+
+	// failures to fetch a block stop the run and are retried
+	sapi.On("GetBlockLatest").Return(latestBlock(8), nil).Once()
+	sapi.On("GetStorage").Return(errors.New("failed to get storage")).Once()
+	_, err = idx.index()
+	assert.Error(t, err)
+	cp, err = idx.Checkpoint()
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(7), cp.Number)
+	sapi.AssertExpectations(t)
+}
@@ -4,6 +4,7 @@ package centchain
 
 import (
 	"context"
+	"reflect"
 	"strings"
 
 	"github.com/centrifuge/go-centrifuge/identity"
@@ -32,7 +33,7 @@ func (ms *MockSubstrateAPI) GetMetadataLatest() (*types.Metadata, error) {
 }
 
 func (ms *MockSubstrateAPI) Call(result interface{}, method string, args ...interface{}) error {
-	argss := ms.Called()
+	argss := ms.Called(result, method, args)
 	return argss.Error(0)
 }
 
@@ -48,8 +49,23 @@ func (ms *MockSubstrateAPI) GetBlock(blockHash types.Hash) (*types.SignedBlock,
 	return md, args.Error(1)
 }
 
+func (ms *MockSubstrateAPI) GetFinalizedHead() (types.Hash, error) {
+	args := ms.Called()
+	md, _ := args.Get(0).(types.Hash)
+	return md, args.Error(1)
+}
+
+func (ms *MockSubstrateAPI) GetHeader(blockHash types.Hash) (*types.Header, error) {
+	args := ms.Called(blockHash)
+	md, _ := args.Get(0).(*types.Header)
+	return md, args.Error(1)
+}
+
 func (ms *MockSubstrateAPI) GetStorage(key types.StorageKey, target interface{}, blockHash types.Hash) error {
 	args := ms.Called()
+	if len(args) > 1 {
+		reflect.ValueOf(target).Elem().Set(reflect.ValueOf(args.Get(1)))
+	}
 	return args.Error(0)
 }
 
@@ -90,7 +106,19 @@ func (m *MockAPI) SubmitExtrinsic(ctx context.Context, meta *types.Metadata, c t
 	return txHash, bn, sig, args.Error(3)
 }
 
-func (m *MockAPI) SubmitAndWatch(ctx context.Context, meta *types.Metadata, c types.Call, krp signature.KeyringPair) func(accountID identity.DID, jobID jobs.JobID, jobMan jobs.Manager, errOut chan<- error) {
+func (m *MockAPI) EstimateFee(ext types.Extrinsic) (*FeeInfo, error) {
+	args := m.Called(ext)
+	fee, _ := args.Get(0).(*FeeInfo)
+	return fee, args.Error(1)
+}
+
+func (m *MockAPI) GetBalance(meta *types.Metadata, accountID []byte) (*Balance, error) {
+	args := m.Called(meta, accountID)
+	b, _ := args.Get(0).(*Balance)
+	return b, args.Error(1)
+}
+
+func (m *MockAPI) SubmitAndWatch(ctx context.Context, meta *types.Metadata, c types.Call, krp signature.KeyringPair) func(ctx context.Context, accountID identity.DID, jobID jobs.JobID, jobMan jobs.Manager, errOut chan<- error) {
 	//args := m.Called(ctx, meta, c, krp)
 	return nil
 }
@@ -3,6 +3,7 @@ package main
 import (
 	"github.com/centrifuge/go-centrifuge/config"
 	"github.com/centrifuge/go-centrifuge/migration"
+	"github.com/centrifuge/go-centrifuge/storage/backend"
 	"github.com/spf13/cobra"
 )
 
@@ -26,5 +27,15 @@ func init() {
 func doMigrate() error {
 	cfg := config.LoadConfiguration(cfgFile)
 	runner := migration.NewMigrationRunner()
+	if b := cfg.GetStorageBackend(); b != "" && b != backend.LevelDB {
+		repo, err := backend.NewDataRepository(cfg)
+		if err != nil {
+			return err
+		}
+		defer repo.Close()
+
+		return runner.RunRepositoryMigrations(repo)
+	}
+
 	return runner.RunMigrations(cfg.GetStoragePath())
 }
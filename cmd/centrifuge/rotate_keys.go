@@ -0,0 +1,35 @@
+package main
+
+import (
+	"github.com/centrifuge/go-centrifuge/cmd"
+	"github.com/spf13/cobra"
+)
+
+func init() {
+
+	//specific param
+	var accountParam string
+	var signingParam bool
+	var p2pParam bool
+	var revokeParam bool
+
+	var rotateKeysCmd = &cobra.Command{
+		Use:   "rotatekeys",
+		Short: "rotate the keys of an account and add them to its identity",
+		Long:  `Generates new keys, adds them to the identity of the account and updates the account. The node must be stopped.`,
+		Run: func(cm *cobra.Command, args []string) {
+			//cm requires a config file
+			cfgFile := ensureConfigFile()
+			err := cmd.RotateKeys(cfgFile, accountParam, signingParam, p2pParam, revokeParam)
+			if err != nil {
+				log.Fatal(err)
+			}
+		},
+	}
+
+	rotateKeysCmd.Flags().StringVarP(&accountParam, "account", "a", "", "account DID (default is the main identity)")
+	rotateKeysCmd.Flags().BoolVarP(&signingParam, "signing", "s", true, "Rotate the signing key of the account")
+	rotateKeysCmd.Flags().BoolVarP(&p2pParam, "p2p", "p", false, "Rotate the p2p key of the node")
+	rotateKeysCmd.Flags().BoolVarP(&revokeParam, "revoke", "r", false, "Revoke the old keys once the new keys are added")
+	rootCmd.AddCommand(rotateKeysCmd)
+}
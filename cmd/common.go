@@ -161,3 +161,65 @@ func CommandBootstrap(cfgFile string) (map[string]interface{}, context.CancelFun
 	go n.Start(cx, e)
 	return ctx, canc, nil
 }
+
+// RotateKeys rotates the keys of the account and waits until the new keys are added to the identity.
+// The main account is used if accountID is empty.
+func RotateKeys(cfgFile, accountID string, signing, p2p, revoke bool) error {
+	ctx, canc, _ := CommandBootstrap(cfgFile)
+	db := ctx[storage.BootstrappedDB].(storage.Repository)
+	dbCfg := ctx[storage.BootstrappedConfigDB].(storage.Repository)
+	defer db.Close()
+	defer dbCfg.Close()
+	defer canc()
+
+	err := (&configstore.Bootstrapper{}).Bootstrap(ctx)
+	if err != nil {
+		return err
+	}
+
+	cfgSrv := ctx[config.BootstrappedConfigStorage].(config.Service)
+	rotator := ctx[configstore.BootstrappedKeyRotator].(configstore.KeyRotator)
+	did, err := ctx[bootstrap.BootstrappedConfig].(config.Configuration).GetIdentityID()
+	if err != nil {
+		return err
+	}
+
+	if accountID != "" {
+		id, err := identity.NewDIDFromString(accountID)
+		if err != nil {
+			return err
+		}
+		did = id[:]
+	}
+
+	acc, err := cfgSrv.GetAccount(did)
+	if err != nil {
+		return err
+	}
+
+	ctxh, err := contextutil.New(context.Background(), acc)
+	if err != nil {
+		return err
+	}
+
+	keys, jobID, done, err := rotator.RotateKeys(ctxh, configstore.RotateKeysRequest{
+		Signing:   signing,
+		P2P:       p2p,
+		RevokeOld: revoke,
+	})
+	if err != nil {
+		return err
+	}
+
+	log.Infof("Waiting for the keys to be added to the identity. Job ID: %s", jobID.String())
+	err = <-done
+	if err != nil {
+		return err
+	}
+
+	for _, k := range keys {
+		log.Infof("Rotated %s key: %s -> %s", k.Purpose, k.OldKey.String(), k.NewKey.String())
+	}
+
+	return nil
+}
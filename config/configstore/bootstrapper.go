@@ -5,6 +5,7 @@ import (
 	"github.com/centrifuge/go-centrifuge/config"
 	"github.com/centrifuge/go-centrifuge/errors"
 	"github.com/centrifuge/go-centrifuge/identity"
+	"github.com/centrifuge/go-centrifuge/jobs"
 	"github.com/centrifuge/go-centrifuge/storage"
 )
 
@@ -30,6 +31,11 @@ func (*Bootstrapper) Bootstrap(context map[string]interface{}) error {
 		return errors.New("identity service not initialised")
 	}
 
+	jobMan, ok := context[jobs.BootstrappedService].(jobs.Manager)
+	if !ok {
+		return errors.New("jobs manager not initialised")
+	}
+
 	repo := &repo{configdb}
 	service := &service{repo, idFactory, idService, func() ProtocolSetter {
 		return context[bootstrap.BootstrappedPeer].(ProtocolSetter)
@@ -62,5 +68,12 @@ func (*Bootstrapper) Bootstrap(context map[string]interface{}) error {
 		}
 	}
 	context[config.BootstrappedConfigStorage] = service
+	// queue server is not bootstrapped for commands
+	queue, _ := context[bootstrap.BootstrappedQueueServer].(RevocationQueuer)
+	context[BootstrappedKeyRotator] = newKeyRotator(service, idService, jobMan, func() P2PKeySwitcher {
+		// p2p host is not running for commands
+		s, _ := context[bootstrap.BootstrappedPeer].(P2PKeySwitcher)
+		return s
+	}, queue)
 	return nil
 }
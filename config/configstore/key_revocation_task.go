@@ -0,0 +1,138 @@
+package configstore
+
+import (
+	"time"
+
+	"github.com/centrifuge/go-centrifuge/errors"
+	"github.com/centrifuge/go-centrifuge/identity"
+	"github.com/centrifuge/go-centrifuge/utils"
+	"github.com/centrifuge/gocelery"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+const (
+	// KeyRevocationTaskName is the name of the task that revokes the old key of a rotation
+	KeyRevocationTaskName = "KeyRevocationTask"
+
+	// RevocationPurposeParam holds the name of the purpose of the revoked key
+	RevocationPurposeParam = "RevocationPurposeParam"
+
+	// RevocationKeyParam holds the hex encoded key that is revoked
+	RevocationKeyParam = "RevocationKeyParam"
+
+	// RevocationAccountsParam holds the identities the key is revoked from
+	RevocationAccountsParam = "RevocationAccountsParam"
+)
+
+// RevocationQueuer queues the delayed revocations of the rotated keys.
+type RevocationQueuer interface {
+	RegisterTaskType(name string, task interface{})
+	EnqueueJobAt(taskName string, params map[string]interface{}, at time.Time) error
+}
+
+// keyRevocationTask revokes the old key of a rotation once the revocation is due.
+// The task is queued, so delayed revocations are resumed if the node restarts before they are due.
+type keyRevocationTask struct {
+	rotator *keyRotator
+
+	// state
+	purpose  identity.Purpose
+	oldKey   [32]byte
+	accounts []identity.DID
+}
+
+// TaskTypeName returns KeyRevocationTaskName.
+func (t *keyRevocationTask) TaskTypeName() string {
+	return KeyRevocationTaskName
+}
+
+// Copy returns a new instance of keyRevocationTask.
+func (t *keyRevocationTask) Copy() (gocelery.CeleryTask, error) {
+	return &keyRevocationTask{rotator: t.rotator}, nil
+}
+
+// ParseKwargs parses the purpose, the key, and the identities of the revocation.
+func (t *keyRevocationTask) ParseKwargs(kwargs map[string]interface{}) error {
+	name, ok := kwargs[RevocationPurposeParam].(string)
+	if !ok {
+		return errors.New("missing key purpose")
+	}
+
+	t.purpose = identity.GetPurposeByName(name)
+	if t.purpose.Name == "" {
+		return errors.New("unknown key purpose %s", name)
+	}
+
+	key, ok := kwargs[RevocationKeyParam].(string)
+	if !ok {
+		return errors.New("missing key")
+	}
+
+	kb, err := hexutil.Decode(key)
+	if err != nil {
+		return errors.New("failed to decode key: %v", err)
+	}
+
+	t.oldKey, err = utils.SliceToByte32(kb)
+	if err != nil {
+		return err
+	}
+
+	accs, ok := kwargs[RevocationAccountsParam].([]interface{})
+	if !ok {
+		return errors.New("missing accounts")
+	}
+
+	t.accounts = nil
+	for _, acc := range accs {
+		id, ok := acc.(string)
+		if !ok {
+			return errors.New("malformed account %v", acc)
+		}
+
+		did, err := identity.NewDIDFromString(id)
+		if err != nil {
+			return err
+		}
+
+		t.accounts = append(t.accounts, did)
+	}
+
+	return nil
+}
+
+// RunTask revokes the key from the identities of the accounts.
+func (t *keyRevocationTask) RunTask() (interface{}, error) {
+	rot := &rotation{purpose: t.purpose, oldKey: t.oldKey}
+	for _, did := range t.accounts {
+		acc, err := t.rotator.getAccount(did[:])
+		if err != nil {
+			accLog.Errorf("failed to revoke old %s key: %v", t.purpose.Name, err)
+			return nil, err
+		}
+
+		rot.accounts = append(rot.accounts, acc)
+	}
+
+	err := t.rotator.revoke(rot)
+	if err != nil {
+		accLog.Errorf("failed to revoke old %s key: %v", t.purpose.Name, err)
+		return nil, err
+	}
+
+	return true, nil
+}
+
+// revocationParams returns the params of the task that revokes the old key of the rotation.
+func revocationParams(rot *rotation) map[string]interface{} {
+	var accounts []string
+	for _, did := range rot.key.Accounts {
+		accounts = append(accounts, did.String())
+	}
+
+	return map[string]interface{}{
+		RevocationPurposeParam:  rot.purpose.Name,
+		RevocationKeyParam:      hexutil.Encode(rot.oldKey[:]),
+		RevocationAccountsParam: accounts,
+	}
+}
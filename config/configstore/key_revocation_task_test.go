@@ -0,0 +1,58 @@
+// +build unit
+
+package configstore
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/centrifuge/go-centrifuge/errors"
+	"github.com/centrifuge/go-centrifuge/identity"
+	"github.com/centrifuge/go-centrifuge/testingutils/commons"
+	"github.com/centrifuge/go-centrifuge/testingutils/testingjobs"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestKeyRevocationTask(t *testing.T) {
+	idService := &testingcommons.MockIdentityService{}
+	repo, _, err := getRandomStorage()
+	assert.NoError(t, err)
+	repo.RegisterAccount(&Account{})
+	svc := DefaultService(repo, idService)
+	acc, err := NewAccount("main", cfg)
+	assert.NoError(t, err)
+	_, err = svc.CreateAccount(acc)
+	assert.NoError(t, err)
+	r := newKeyRotator(svc, idService, new(testingjobs.MockJobManager), func() P2PKeySwitcher { return nil }, nil)
+	task := &keyRevocationTask{rotator: r.(*keyRotator)}
+
+	// missing params
+	assert.Error(t, task.ParseKwargs(map[string]interface{}{}))
+
+	// unknown purpose
+	assert.Error(t, task.ParseKwargs(map[string]interface{}{RevocationPurposeParam: "unknown"}))
+
+	// params are decoded from the persisted queue
+	rot, err := newRotation(identity.KeyPurposeSigning, []*Account{acc.(*Account)}, func(acc *Account) {}, nil)
+	assert.NoError(t, err)
+	data, err := json.Marshal(revocationParams(rot))
+	assert.NoError(t, err)
+	var kwargs map[string]interface{}
+	assert.NoError(t, json.Unmarshal(data, &kwargs))
+	ct, err := task.Copy()
+	assert.NoError(t, err)
+	assert.NoError(t, ct.ParseKwargs(kwargs))
+
+	// failed revocation
+	idService.On("RevokeKey", mock.Anything, rot.oldKey).Return(errors.New("failed")).Once()
+	_, err = ct.RunTask()
+	assert.Error(t, err)
+
+	// success
+	idService.On("RevokeKey", mock.Anything, rot.oldKey).Return(nil).Once()
+	res, err := ct.RunTask()
+	assert.NoError(t, err)
+	assert.Equal(t, true, res)
+	idService.AssertExpectations(t)
+}
@@ -0,0 +1,423 @@
+package configstore
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/centrifuge/go-centrifuge/config"
+	"github.com/centrifuge/go-centrifuge/contextutil"
+	"github.com/centrifuge/go-centrifuge/crypto"
+	"github.com/centrifuge/go-centrifuge/errors"
+	"github.com/centrifuge/go-centrifuge/identity"
+	"github.com/centrifuge/go-centrifuge/jobs"
+	"github.com/centrifuge/go-centrifuge/utils"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+const (
+	// BootstrappedKeyRotator is the key to the KeyRotator in bootstrap context
+	BootstrappedKeyRotator = "BootstrappedKeyRotator"
+
+	// ErrNoKeysToRotate is returned when the rotation request doesn't select any key
+	ErrNoKeysToRotate = errors.Error("no keys selected for rotation")
+
+	// ErrNodeP2PKeyNotUsed is returned when the p2p key of an account is not the key of the node p2p host
+	ErrNodeP2PKeyNotUsed = errors.Error("account doesn't use the p2p key of the node")
+
+	// ErrKeyRotationInProgress is returned when a key rotation is requested while another one is running
+	ErrKeyRotationInProgress = errors.Error("key rotation in progress")
+)
+
+// P2PKeySwitcher switches the key of the running p2p host.
+type P2PKeySwitcher interface {
+	SwitchP2PKey(pub, priv string) error
+}
+
+// RotateKeysRequest selects the keys to rotate.
+type RotateKeysRequest struct {
+	// Signing rotates the signing key of the account
+	Signing bool
+
+	// P2P rotates the p2p key of the node, which is shared by the accounts of the node
+	P2P bool
+
+	// RevokeOld revokes the old keys once the new keys are added
+	RevokeOld bool
+
+	// RevokeAfter delays the revocation of the old keys
+	RevokeAfter time.Duration
+}
+
+// RotatedKey holds the old and the new key of a purpose.
+type RotatedKey struct {
+	Purpose string        `json:"purpose"`
+	OldKey  hexutil.Bytes `json:"old_key"`
+	NewKey  hexutil.Bytes `json:"new_key"`
+
+	// Accounts are the accounts whose identities get the new key
+	Accounts []identity.DID `json:"accounts"`
+
+	// RevokeAt is the time the old key is revoked at. Nil if the old key is kept.
+	RevokeAt *time.Time `json:"revoke_at,omitempty"`
+}
+
+// KeyRotator rotates the keys of the accounts.
+type KeyRotator interface {
+	// RotateKeys generates the new keys for the account in the context and returns them.
+	// The new keys are added to the identities, the accounts are updated, and the old keys are revoked
+	// within the returned job.
+	RotateKeys(ctx context.Context, req RotateKeysRequest) ([]RotatedKey, jobs.JobID, chan error, error)
+}
+
+// keyRotator implements KeyRotator.
+type keyRotator struct {
+	cfgSrv             config.Service
+	idService          identity.Service
+	jobMan             jobs.Manager
+	p2pKeySwitcherFind func() P2PKeySwitcher
+	queue              RevocationQueuer
+
+	mu       sync.Mutex
+	rotating bool
+}
+
+// newKeyRotator returns a KeyRotator.
+// The revocation task is registered on the queue if one is passed.
+func newKeyRotator(cfgSrv config.Service, idService identity.Service, jobMan jobs.Manager, switcherFinder func() P2PKeySwitcher, queue RevocationQueuer) KeyRotator {
+	r := &keyRotator{
+		cfgSrv:             cfgSrv,
+		idService:          idService,
+		jobMan:             jobMan,
+		p2pKeySwitcherFind: switcherFinder,
+		queue:              queue,
+	}
+
+	if queue != nil {
+		queue.RegisterTaskType(KeyRevocationTaskName, &keyRevocationTask{rotator: r})
+	}
+
+	return r
+}
+
+// rotation holds the state of a rotation that is applied within the job.
+type rotation struct {
+	key         RotatedKey
+	purpose     identity.Purpose
+	oldKey      [32]byte
+	newKey      [32]byte
+	accounts    []*Account
+	apply       func(acc *Account)
+	commitFiles func() error
+}
+
+func (r *keyRotator) RotateKeys(ctx context.Context, req RotateKeysRequest) ([]RotatedKey, jobs.JobID, chan error, error) {
+	if !req.Signing && !req.P2P {
+		return nil, jobs.NilJobID(), nil, ErrNoKeysToRotate
+	}
+
+	did, err := contextutil.AccountDID(ctx)
+	if err != nil {
+		return nil, jobs.NilJobID(), nil, err
+	}
+
+	nc, err := r.cfgSrv.GetConfig()
+	if err != nil {
+		return nil, jobs.NilJobID(), nil, err
+	}
+
+	acc, err := r.getAccount(did[:])
+	if err != nil {
+		return nil, jobs.NilJobID(), nil, err
+	}
+
+	r.mu.Lock()
+	if r.rotating {
+		r.mu.Unlock()
+		return nil, jobs.NilJobID(), nil, ErrKeyRotationInProgress
+	}
+	r.rotating = true
+	r.mu.Unlock()
+
+	var rotations []*rotation
+	if req.Signing {
+		rot, err := r.prepareSigningRotation(nc, acc, did)
+		if err != nil {
+			r.done()
+			return nil, jobs.NilJobID(), nil, err
+		}
+		rotations = append(rotations, rot)
+	}
+
+	if req.P2P {
+		rot, err := r.prepareP2PRotation(nc, acc)
+		if err != nil {
+			r.done()
+			return nil, jobs.NilJobID(), nil, err
+		}
+		rotations = append(rotations, rot)
+	}
+
+	var keys []RotatedKey
+	for _, rot := range rotations {
+		if req.RevokeOld {
+			at := time.Now().UTC().Add(req.RevokeAfter)
+			rot.key.RevokeAt = &at
+		}
+		keys = append(keys, rot.key)
+	}
+
+	jobID, done, err := r.jobMan.ExecuteWithinJob(contextutil.Copy(ctx), did, jobs.NilJobID(), "rotate keys",
+		func(ctx context.Context, accountID identity.DID, jobID jobs.JobID, jobMan jobs.Manager, errOut chan<- error) {
+			defer r.done()
+			errOut <- r.rotate(rotations, req)
+		})
+	if err != nil {
+		r.done()
+		return nil, jobs.NilJobID(), nil, err
+	}
+
+	return keys, jobID, done, nil
+}
+
+func (r *keyRotator) done() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.rotating = false
+}
+
+func (r *keyRotator) getAccount(id []byte) (*Account, error) {
+	acc, err := r.cfgSrv.GetAccount(id)
+	if err != nil {
+		return nil, err
+	}
+
+	a, ok := acc.(*Account)
+	if !ok {
+		return nil, errors.New("invalid account type: %T", acc)
+	}
+
+	return a, nil
+}
+
+// prepareSigningRotation generates a new signing key for the account in its keystore directory.
+func (r *keyRotator) prepareSigningRotation(nc config.Configuration, acc *Account, did identity.DID) (*rotation, error) {
+	ts := time.Now().UTC().Unix()
+	pub, err := createKeyPath(nc.GetAccountsKeystore(), &did, fmt.Sprintf("signingKey-%d.pub.pem", ts))
+	if err != nil {
+		return nil, err
+	}
+
+	priv, err := createKeyPath(nc.GetAccountsKeystore(), &did, fmt.Sprintf("signingKey-%d.key.pem", ts))
+	if err != nil {
+		return nil, err
+	}
+
+	err = crypto.GenerateSigningKeyPair(pub, priv, crypto.CurveSecp256K1)
+	if err != nil {
+		return nil, err
+	}
+
+	kp := KeyPair{Pub: pub, Pvt: priv}
+	apply := func(acc *Account) {
+		acc.SigningKeyPair = kp
+		acc.keys = nil
+	}
+
+	return newRotation(identity.KeyPurposeSigning, []*Account{acc}, apply, nil)
+}
+
+// prepareP2PRotation generates a new p2p key next to the p2p key of the node.
+// The key files are replaced once the new key is added to the identities of the accounts using the node p2p key.
+func (r *keyRotator) prepareP2PRotation(nc config.Configuration, acc *Account) (*rotation, error) {
+	pub, priv := nc.GetP2PKeyPair()
+	if acc.P2PKeyPair.Pub != pub {
+		return nil, ErrNodeP2PKeyNotUsed
+	}
+
+	accs, err := r.cfgSrv.GetAccounts()
+	if err != nil {
+		return nil, err
+	}
+
+	var accounts []*Account
+	for _, a := range accs {
+		a, ok := a.(*Account)
+		if !ok || a.P2PKeyPair.Pub != pub {
+			continue
+		}
+
+		accounts = append(accounts, a)
+	}
+
+	newPub, newPriv := pub+".new", priv+".new"
+	err = crypto.GenerateSigningKeyPair(newPub, newPriv, crypto.CurveEd25519)
+	if err != nil {
+		return nil, err
+	}
+
+	// the accounts keep the configured paths, the key files are replaced instead
+	apply := func(acc *Account) {
+		acc.P2PKeyPair = KeyPair{Pub: newPub, Pvt: newPriv}
+		acc.keys = nil
+	}
+
+	commit := func() error {
+		suffix := fmt.Sprintf(".%d", time.Now().UTC().Unix())
+		for _, f := range [][2]string{{pub, newPub}, {priv, newPriv}} {
+			if err := os.Rename(f[0], f[0]+suffix); err != nil {
+				return err
+			}
+
+			if err := os.Rename(f[1], f[0]); err != nil {
+				return err
+			}
+		}
+
+		if s := r.p2pKeySwitcherFind(); s != nil {
+			return s.SwitchP2PKey(pub, priv)
+		}
+
+		return nil
+	}
+
+	return newRotation(identity.KeyPurposeP2PDiscovery, accounts, apply, commit)
+}
+
+// newRotation derives the old and the new key of the purpose from the accounts.
+func newRotation(purpose identity.Purpose, accounts []*Account, apply func(acc *Account), commitFiles func() error) (*rotation, error) {
+	if len(accounts) < 1 {
+		return nil, errors.New("no accounts to rotate the key for")
+	}
+
+	oldKey, err := accountKey(accounts[0], purpose)
+	if err != nil {
+		return nil, err
+	}
+
+	nacc := *accounts[0]
+	apply(&nacc)
+	newKey, err := accountKey(&nacc, purpose)
+	if err != nil {
+		return nil, err
+	}
+
+	var dids []identity.DID
+	for _, acc := range accounts {
+		did, err := identity.NewDIDFromBytes(acc.GetIdentityID())
+		if err != nil {
+			return nil, err
+		}
+		dids = append(dids, did)
+	}
+
+	// key files are replaced in place instead of updating the accounts
+	if commitFiles != nil {
+		apply = func(acc *Account) {}
+	}
+
+	return &rotation{
+		key: RotatedKey{
+			Purpose:  purpose.Name,
+			OldKey:   oldKey[:],
+			NewKey:   newKey[:],
+			Accounts: dids,
+		},
+		purpose:     purpose,
+		oldKey:      oldKey,
+		newKey:      newKey,
+		accounts:    accounts,
+		apply:       apply,
+		commitFiles: commitFiles,
+	}, nil
+}
+
+// accountKey returns the identity key of the account for the purpose.
+func accountKey(acc *Account, purpose identity.Purpose) ([32]byte, error) {
+	keys, err := acc.GetKeys()
+	if err != nil {
+		return [32]byte{}, err
+	}
+
+	return utils.SliceToByte32(keys[purpose.Name].PublicKey)
+}
+
+// rotate adds the new keys to the identities, updates the accounts, and revokes the old keys if requested.
+func (r *keyRotator) rotate(rotations []*rotation, req RotateKeysRequest) error {
+	for _, rot := range rotations {
+		for _, acc := range rot.accounts {
+			ctx, err := contextutil.New(context.Background(), acc)
+			if err != nil {
+				return err
+			}
+
+			purpose := rot.purpose.Value
+			err = r.idService.AddKey(ctx, identity.NewKey(rot.newKey, &purpose, big.NewInt(identity.KeyTypeECDSA), 0))
+			if err != nil {
+				return errors.New("failed to add %s key to %s: %v", rot.purpose.Name, hexutil.Encode(acc.GetIdentityID()), err)
+			}
+
+			// reload the account in case it was updated meanwhile
+			nacc, err := r.getAccount(acc.GetIdentityID())
+			if err != nil {
+				return err
+			}
+
+			rot.apply(nacc)
+			_, err = r.cfgSrv.UpdateAccount(nacc)
+			if err != nil {
+				return err
+			}
+		}
+
+		if rot.commitFiles != nil {
+			if err := rot.commitFiles(); err != nil {
+				return errors.New("failed to switch the %s key: %v", rot.purpose.Name, err)
+			}
+		}
+
+		accLog.Infof("rotated %s key of %d account(s)", rot.purpose.Name, len(rot.accounts))
+		if !req.RevokeOld {
+			continue
+		}
+
+		if req.RevokeAfter <= 0 {
+			if err := r.revoke(rot); err != nil {
+				return err
+			}
+			continue
+		}
+
+		if r.queue == nil {
+			return errors.New("failed to queue the revocation of the old %s key: queue not initialised", rot.purpose.Name)
+		}
+
+		err := r.queue.EnqueueJobAt(KeyRevocationTaskName, revocationParams(rot), *rot.key.RevokeAt)
+		if err != nil {
+			return errors.New("failed to queue the revocation of the old %s key: %v", rot.purpose.Name, err)
+		}
+	}
+
+	return nil
+}
+
+// revoke revokes the old key of the rotation from the identities of the accounts.
+func (r *keyRotator) revoke(rot *rotation) error {
+	for _, acc := range rot.accounts {
+		ctx, err := contextutil.New(context.Background(), acc)
+		if err != nil {
+			return err
+		}
+
+		err = r.idService.RevokeKey(ctx, rot.oldKey)
+		if err != nil {
+			return errors.New("failed to revoke %s key of %s: %v", rot.purpose.Name, hexutil.Encode(acc.GetIdentityID()), err)
+		}
+	}
+
+	accLog.Infof("revoked old %s key of %d account(s)", rot.purpose.Name, len(rot.accounts))
+	return nil
+}
@@ -0,0 +1,134 @@
+// +build unit
+
+package configstore
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/centrifuge/go-centrifuge/contextutil"
+	"github.com/centrifuge/go-centrifuge/crypto"
+	"github.com/centrifuge/go-centrifuge/errors"
+	"github.com/centrifuge/go-centrifuge/identity"
+	"github.com/centrifuge/go-centrifuge/jobs"
+	"github.com/centrifuge/go-centrifuge/testingutils/commons"
+	"github.com/centrifuge/go-centrifuge/testingutils/testingjobs"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type mockP2PKeySwitcher struct {
+	mock.Mock
+}
+
+func (m *mockP2PKeySwitcher) SwitchP2PKey(pub, priv string) error {
+	return m.Called(pub, priv).Error(0)
+}
+
+type mockRevocationQueuer struct {
+	mock.Mock
+}
+
+func (m *mockRevocationQueuer) RegisterTaskType(name string, task interface{}) {
+	m.Called(name, task)
+}
+
+func (m *mockRevocationQueuer) EnqueueJobAt(taskName string, params map[string]interface{}, at time.Time) error {
+	return m.Called(taskName, params, at).Error(0)
+}
+
+func TestKeyRotator_RotateKeys_invalid(t *testing.T) {
+	idService := &testingcommons.MockIdentityService{}
+	repo, _, err := getRandomStorage()
+	assert.Nil(t, err)
+	svc := DefaultService(repo, idService)
+	r := newKeyRotator(svc, idService, new(testingjobs.MockJobManager), func() P2PKeySwitcher { return nil }, nil)
+
+	// no keys selected
+	_, _, _, err = r.RotateKeys(context.Background(), RotateKeysRequest{})
+	assert.True(t, errors.IsOfType(ErrNoKeysToRotate, err))
+
+	// missing account
+	_, _, _, err = r.RotateKeys(context.Background(), RotateKeysRequest{Signing: true})
+	assert.Error(t, err)
+	assert.False(t, r.(*keyRotator).rotating)
+}
+
+func TestKeyRotator_RotateKeys_p2p(t *testing.T) {
+	dir, err := ioutil.TempDir("", "p2p-keys")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+	pub, priv := filepath.Join(dir, "p2p.pub.pem"), filepath.Join(dir, "p2p.key.pem")
+	assert.NoError(t, crypto.GenerateSigningKeyPair(pub, priv, crypto.CurveEd25519))
+
+	idService := &testingcommons.MockIdentityService{}
+	repo, _, err := getRandomStorage()
+	assert.NoError(t, err)
+	repo.RegisterConfig(&NodeConfig{})
+	repo.RegisterAccount(&Account{})
+	svc := DefaultService(repo, idService)
+	nc := NewNodeConfig(cfg).(*NodeConfig)
+	nc.MainIdentity.P2PKeyPair = KeyPair{Pub: pub, Pvt: priv}
+	assert.NoError(t, repo.CreateConfig(nc))
+	acc, err := NewAccount("main", cfg)
+	assert.NoError(t, err)
+	acc.(*Account).P2PKeyPair = KeyPair{Pub: pub, Pvt: priv}
+	_, err = svc.CreateAccount(acc)
+	assert.NoError(t, err)
+	ctx, err := contextutil.New(context.Background(), acc)
+	assert.NoError(t, err)
+
+	// the job adds the new key and switches the key of the p2p host
+	jobMan := new(testingjobs.MockJobManager)
+	done := make(chan error, 1)
+	jobID := jobs.NewJobID()
+	jobMan.On("ExecuteWithinJob", mock.Anything, mock.Anything, jobs.NilJobID(), "rotate keys", mock.Anything).
+		Run(func(args mock.Arguments) {
+			work := args.Get(4).(func(context.Context, identity.DID, jobs.JobID, jobs.Manager, chan<- error))
+			work(context.Background(), args.Get(1).(identity.DID), jobID, jobMan, done)
+		}).Return(jobID, done, nil).Once()
+	idService.On("AddKey", mock.Anything, mock.Anything).Return(nil).Once()
+	switcher := new(mockP2PKeySwitcher)
+	switcher.On("SwitchP2PKey", pub, priv).Return(nil).Once()
+	queue := new(mockRevocationQueuer)
+	queue.On("RegisterTaskType", KeyRevocationTaskName, mock.Anything).Return().Once()
+	queue.On("EnqueueJobAt", KeyRevocationTaskName, mock.Anything, mock.Anything).Return(nil).Once()
+	r := newKeyRotator(svc, idService, jobMan, func() P2PKeySwitcher { return switcher }, queue)
+	keys, id, _, err := r.RotateKeys(ctx, RotateKeysRequest{P2P: true, RevokeOld: true, RevokeAfter: time.Hour})
+	assert.NoError(t, err)
+	assert.NoError(t, <-done)
+	assert.Equal(t, jobID, id)
+	assert.Len(t, keys, 1)
+	assert.Equal(t, identity.KeyPurposeP2PDiscovery.Name, keys[0].Purpose)
+	assert.NotEqual(t, keys[0].OldKey, keys[0].NewKey)
+	assert.False(t, r.(*keyRotator).rotating)
+
+	// the revocation of the old key is queued for later
+	params := queue.Calls[1].Arguments.Get(1).(map[string]interface{})
+	assert.Equal(t, identity.KeyPurposeP2PDiscovery.Name, params[RevocationPurposeParam])
+	assert.Equal(t, keys[0].OldKey.String(), params[RevocationKeyParam])
+	did, err := identity.NewDIDFromBytes(acc.GetIdentityID())
+	assert.NoError(t, err)
+	assert.Equal(t, []string{did.String()}, params[RevocationAccountsParam])
+	assert.Equal(t, *keys[0].RevokeAt, queue.Calls[1].Arguments.Get(2).(time.Time))
+
+	// the new key replaced the key files and the old key files are kept
+	nacc, err := svc.GetAccount(acc.GetIdentityID())
+	assert.NoError(t, err)
+	key, err := accountKey(nacc.(*Account), identity.KeyPurposeP2PDiscovery)
+	assert.NoError(t, err)
+	assert.Equal(t, []byte(keys[0].NewKey), key[:])
+	old, err := filepath.Glob(pub + ".*")
+	assert.NoError(t, err)
+	assert.Len(t, old, 1)
+	_, err = os.Stat(pub + ".new")
+	assert.True(t, os.IsNotExist(err))
+	jobMan.AssertExpectations(t)
+	idService.AssertExpectations(t)
+	switcher.AssertExpectations(t)
+	queue.AssertExpectations(t)
+}
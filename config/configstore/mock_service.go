@@ -3,8 +3,11 @@
 package configstore
 
 import (
+	"context"
+
 	coredocumentpb "github.com/centrifuge/centrifuge-protobufs/gen/go/coredocument"
 	"github.com/centrifuge/go-centrifuge/config"
+	"github.com/centrifuge/go-centrifuge/jobs"
 	"github.com/stretchr/testify/mock"
 )
 
@@ -62,3 +65,15 @@ func (m MockService) Sign(accountID, payload []byte) (*coredocumentpb.Signature,
 	sig, _ := args.Get(0).(*coredocumentpb.Signature)
 	return sig, args.Error(1)
 }
+
+type MockKeyRotator struct {
+	mock.Mock
+}
+
+func (m *MockKeyRotator) RotateKeys(ctx context.Context, req RotateKeysRequest) ([]RotatedKey, jobs.JobID, chan error, error) {
+	args := m.Called(ctx, req)
+	keys, _ := args.Get(0).([]RotatedKey)
+	jobID, _ := args.Get(1).(jobs.JobID)
+	done, _ := args.Get(2).(chan error)
+	return keys, jobID, done, args.Error(3)
+}
@@ -38,16 +38,43 @@ type NodeConfig struct {
 	P2PExternalIP                  string
 	P2PConnectionTimeout           time.Duration
 	P2PResponseDelay               time.Duration
+	P2PChunkSize                   int
+	P2PTransferPath                string
+	P2PMaxTransferSize             int
+	P2PRateLimit                   int
+	P2PMaxConcurrentRequests       int
+	P2PBanThreshold                int
+	P2PBanDuration                 time.Duration
+	SignatureCollectionMode        string
+	SignatureQuorum                int
+	SignatureRetryInterval         time.Duration
+	SignatureCollectionTimeout     time.Duration
 	ServerPort                     int
 	ServerAddress                  string
 	NumWorkers                     int
 	TaskRetries                    int
 	WorkerWaitTimeMS               int
+	JobRetentionSuccess            time.Duration
+	JobRetentionFailed             time.Duration
+	JobRetentionCancelled          time.Duration
+	JobPruneInterval               time.Duration
+	JobArchivePath                 string
+	WebhookMaxAttempts             int
+	WebhookRetryBackoff            time.Duration
+	WebhookMaxRetryBackoff         time.Duration
+	WebhookTimeout                 time.Duration
+	StorageBackend                 string
 	EthereumNodeURL                string
 	EthereumContextReadWaitTimeout time.Duration
 	EthereumContextWaitTimeout     time.Duration
 	EthereumIntervalRetry          time.Duration
 	EthereumMaxRetries             int
+	EthereumTxCheckInterval        time.Duration
+	EthereumTxBumpTimeout          time.Duration
+	EthereumGasPriceBump           int
+	EthereumSignerBackend          string
+	EthereumSignerURL              string
+	EthereumConfirmationDepth      int
 	EthereumMaxGasPrice            *big.Int
 	EthereumGasLimits              map[config.ContractOp]uint64
 	NetworkString                  string
@@ -57,10 +84,17 @@ type NodeConfig struct {
 	SmartContractBytecode          map[config.ContractName]string
 	PprofEnabled                   bool
 	LowEntropyNFTTokenEnabled      bool
+	NFTRegistryAdapters            map[string]string
+	NFTTransferWatchInterval       time.Duration
+	NFTTransferPushDocuments       bool
 	DebugLogEnabled                bool
 	CentChainNodeURL               string
 	CentChainIntervalRetry         time.Duration
 	CentChainMaxRetries            int
+	CentChainSignerBackend         string
+	CentChainSignerURL             string
+	CentChainConfirmationDepth     int
+	CentChainEventIndexerInterval  time.Duration
 	CentChainAnchorLifespan        time.Duration
 }
 
@@ -144,6 +178,61 @@ func (nc *NodeConfig) GetP2PResponseDelay() time.Duration {
 	return nc.P2PResponseDelay
 }
 
+// GetP2PChunkSize refer the interface
+func (nc *NodeConfig) GetP2PChunkSize() int {
+	return nc.P2PChunkSize
+}
+
+// GetP2PTransferPath refer the interface
+func (nc *NodeConfig) GetP2PTransferPath() string {
+	return nc.P2PTransferPath
+}
+
+// GetP2PMaxTransferSize refer the interface
+func (nc *NodeConfig) GetP2PMaxTransferSize() int {
+	return nc.P2PMaxTransferSize
+}
+
+// GetP2PRateLimit refer the interface
+func (nc *NodeConfig) GetP2PRateLimit() int {
+	return nc.P2PRateLimit
+}
+
+// GetP2PMaxConcurrentRequests refer the interface
+func (nc *NodeConfig) GetP2PMaxConcurrentRequests() int {
+	return nc.P2PMaxConcurrentRequests
+}
+
+// GetP2PBanThreshold refer the interface
+func (nc *NodeConfig) GetP2PBanThreshold() int {
+	return nc.P2PBanThreshold
+}
+
+// GetP2PBanDuration refer the interface
+func (nc *NodeConfig) GetP2PBanDuration() time.Duration {
+	return nc.P2PBanDuration
+}
+
+// GetSignatureCollectionMode refer the interface
+func (nc *NodeConfig) GetSignatureCollectionMode() string {
+	return nc.SignatureCollectionMode
+}
+
+// GetSignatureQuorum refer the interface
+func (nc *NodeConfig) GetSignatureQuorum() int {
+	return nc.SignatureQuorum
+}
+
+// GetSignatureRetryInterval refer the interface
+func (nc *NodeConfig) GetSignatureRetryInterval() time.Duration {
+	return nc.SignatureRetryInterval
+}
+
+// GetSignatureCollectionTimeout refer the interface
+func (nc *NodeConfig) GetSignatureCollectionTimeout() time.Duration {
+	return nc.SignatureCollectionTimeout
+}
+
 // GetServerPort refer the interface
 func (nc *NodeConfig) GetServerPort() int {
 	return nc.ServerPort
@@ -169,6 +258,61 @@ func (nc *NodeConfig) GetWorkerWaitTimeMS() int {
 	return nc.WorkerWaitTimeMS
 }
 
+// GetJobRetentionSuccess refer the interface
+func (nc *NodeConfig) GetJobRetentionSuccess() time.Duration {
+	return nc.JobRetentionSuccess
+}
+
+// GetJobRetentionFailed refer the interface
+func (nc *NodeConfig) GetJobRetentionFailed() time.Duration {
+	return nc.JobRetentionFailed
+}
+
+// GetJobRetentionCancelled refer the interface
+func (nc *NodeConfig) GetJobRetentionCancelled() time.Duration {
+	return nc.JobRetentionCancelled
+}
+
+// GetJobPruneInterval refer the interface
+func (nc *NodeConfig) GetJobPruneInterval() time.Duration {
+	return nc.JobPruneInterval
+}
+
+// GetJobArchivePath refer the interface
+func (nc *NodeConfig) GetJobArchivePath() string {
+	return nc.JobArchivePath
+}
+
+// GetWebhookMaxAttempts refer the interface
+func (nc *NodeConfig) GetWebhookMaxAttempts() int {
+	return nc.WebhookMaxAttempts
+}
+
+// GetWebhookRetryBackoff refer the interface
+func (nc *NodeConfig) GetWebhookRetryBackoff() time.Duration {
+	return nc.WebhookRetryBackoff
+}
+
+// GetWebhookMaxRetryBackoff refer the interface
+func (nc *NodeConfig) GetWebhookMaxRetryBackoff() time.Duration {
+	return nc.WebhookMaxRetryBackoff
+}
+
+// GetWebhookTimeout refer the interface
+func (nc *NodeConfig) GetWebhookTimeout() time.Duration {
+	return nc.WebhookTimeout
+}
+
+// GetStorageBackend refer the interface
+func (nc *NodeConfig) GetStorageBackend() string {
+	return nc.StorageBackend
+}
+
+// GetStoragePostgresDSN refer the interface
+func (nc *NodeConfig) GetStoragePostgresDSN() string {
+	panic("irrelevant, NodeConfig#GetStoragePostgresDSN must not be used")
+}
+
 // GetEthereumNodeURL refer the interface
 func (nc *NodeConfig) GetEthereumNodeURL() string {
 	return nc.EthereumNodeURL
@@ -194,6 +338,36 @@ func (nc *NodeConfig) GetEthereumMaxRetries() int {
 	return nc.EthereumMaxRetries
 }
 
+// GetEthereumTxCheckInterval refer the interface
+func (nc *NodeConfig) GetEthereumTxCheckInterval() time.Duration {
+	return nc.EthereumTxCheckInterval
+}
+
+// GetEthereumTxBumpTimeout refer the interface
+func (nc *NodeConfig) GetEthereumTxBumpTimeout() time.Duration {
+	return nc.EthereumTxBumpTimeout
+}
+
+// GetEthereumGasPriceBump refer the interface
+func (nc *NodeConfig) GetEthereumGasPriceBump() int {
+	return nc.EthereumGasPriceBump
+}
+
+// GetEthereumSignerBackend refer the interface
+func (nc *NodeConfig) GetEthereumSignerBackend() string {
+	return nc.EthereumSignerBackend
+}
+
+// GetEthereumSignerURL refer the interface
+func (nc *NodeConfig) GetEthereumSignerURL() string {
+	return nc.EthereumSignerURL
+}
+
+// GetEthereumConfirmationDepth refer the interface
+func (nc *NodeConfig) GetEthereumConfirmationDepth() int {
+	return nc.EthereumConfirmationDepth
+}
+
 // GetEthereumMaxGasPrice refer the interface
 func (nc *NodeConfig) GetEthereumMaxGasPrice() *big.Int {
 	return nc.EthereumMaxGasPrice
@@ -259,6 +433,26 @@ func (nc *NodeConfig) GetCentChainMaxRetries() int {
 	return nc.CentChainMaxRetries
 }
 
+// GetCentChainSignerBackend refer the interface
+func (nc *NodeConfig) GetCentChainSignerBackend() string {
+	return nc.CentChainSignerBackend
+}
+
+// GetCentChainSignerURL refer the interface
+func (nc *NodeConfig) GetCentChainSignerURL() string {
+	return nc.CentChainSignerURL
+}
+
+// GetCentChainConfirmationDepth refer the interface
+func (nc *NodeConfig) GetCentChainConfirmationDepth() int {
+	return nc.CentChainConfirmationDepth
+}
+
+// GetCentChainEventIndexerInterval refer the interface
+func (nc *NodeConfig) GetCentChainEventIndexerInterval() time.Duration {
+	return nc.CentChainEventIndexerInterval
+}
+
 // GetCentChainAnchorLifespan returns the default lifespan of an anchor.
 func (nc *NodeConfig) GetCentChainAnchorLifespan() time.Duration {
 	return nc.CentChainAnchorLifespan
@@ -299,6 +493,21 @@ func (nc *NodeConfig) GetLowEntropyNFTTokenEnabled() bool {
 	return nc.LowEntropyNFTTokenEnabled
 }
 
+// GetNFTRegistryAdapters refer the interface
+func (nc *NodeConfig) GetNFTRegistryAdapters() map[string]string {
+	return nc.NFTRegistryAdapters
+}
+
+// GetNFTTransferWatchInterval refer the interface
+func (nc *NodeConfig) GetNFTTransferWatchInterval() time.Duration {
+	return nc.NFTTransferWatchInterval
+}
+
+// GetNFTTransferPushDocuments refer the interface
+func (nc *NodeConfig) GetNFTTransferPushDocuments() bool {
+	return nc.NFTTransferPushDocuments
+}
+
 // IsPProfEnabled refer the interface
 func (nc *NodeConfig) IsPProfEnabled() bool {
 	return nc.PprofEnabled
@@ -363,15 +572,42 @@ func NewNodeConfig(c config.Configuration) config.Configuration {
 		P2PExternalIP:                  c.GetP2PExternalIP(),
 		P2PConnectionTimeout:           c.GetP2PConnectionTimeout(),
 		P2PResponseDelay:               c.GetP2PResponseDelay(),
+		P2PChunkSize:                   c.GetP2PChunkSize(),
+		P2PTransferPath:                c.GetP2PTransferPath(),
+		P2PMaxTransferSize:             c.GetP2PMaxTransferSize(),
+		P2PRateLimit:                   c.GetP2PRateLimit(),
+		P2PMaxConcurrentRequests:       c.GetP2PMaxConcurrentRequests(),
+		P2PBanThreshold:                c.GetP2PBanThreshold(),
+		P2PBanDuration:                 c.GetP2PBanDuration(),
+		SignatureCollectionMode:        c.GetSignatureCollectionMode(),
+		SignatureQuorum:                c.GetSignatureQuorum(),
+		SignatureRetryInterval:         c.GetSignatureRetryInterval(),
+		SignatureCollectionTimeout:     c.GetSignatureCollectionTimeout(),
 		ServerPort:                     c.GetServerPort(),
 		ServerAddress:                  c.GetServerAddress(),
 		NumWorkers:                     c.GetNumWorkers(),
 		WorkerWaitTimeMS:               c.GetWorkerWaitTimeMS(),
+		JobRetentionSuccess:            c.GetJobRetentionSuccess(),
+		JobRetentionFailed:             c.GetJobRetentionFailed(),
+		JobRetentionCancelled:          c.GetJobRetentionCancelled(),
+		JobPruneInterval:               c.GetJobPruneInterval(),
+		JobArchivePath:                 c.GetJobArchivePath(),
+		WebhookMaxAttempts:             c.GetWebhookMaxAttempts(),
+		WebhookRetryBackoff:            c.GetWebhookRetryBackoff(),
+		WebhookMaxRetryBackoff:         c.GetWebhookMaxRetryBackoff(),
+		WebhookTimeout:                 c.GetWebhookTimeout(),
+		StorageBackend:                 c.GetStorageBackend(),
 		EthereumNodeURL:                c.GetEthereumNodeURL(),
 		EthereumContextReadWaitTimeout: c.GetEthereumContextReadWaitTimeout(),
 		EthereumContextWaitTimeout:     c.GetEthereumContextWaitTimeout(),
 		EthereumIntervalRetry:          c.GetEthereumIntervalRetry(),
 		EthereumMaxRetries:             c.GetEthereumMaxRetries(),
+		EthereumTxCheckInterval:        c.GetEthereumTxCheckInterval(),
+		EthereumTxBumpTimeout:          c.GetEthereumTxBumpTimeout(),
+		EthereumGasPriceBump:           c.GetEthereumGasPriceBump(),
+		EthereumSignerBackend:          c.GetEthereumSignerBackend(),
+		EthereumSignerURL:              c.GetEthereumSignerURL(),
+		EthereumConfirmationDepth:      c.GetEthereumConfirmationDepth(),
 		EthereumMaxGasPrice:            c.GetEthereumMaxGasPrice(),
 		EthereumGasLimits:              extractGasLimits(c),
 		NetworkString:                  c.GetNetworkString(),
@@ -381,7 +617,14 @@ func NewNodeConfig(c config.Configuration) config.Configuration {
 		PprofEnabled:                   c.IsPProfEnabled(),
 		DebugLogEnabled:                c.IsDebugLogEnabled(),
 		LowEntropyNFTTokenEnabled:      c.GetLowEntropyNFTTokenEnabled(),
+		NFTRegistryAdapters:            c.GetNFTRegistryAdapters(),
+		NFTTransferWatchInterval:       c.GetNFTTransferWatchInterval(),
+		NFTTransferPushDocuments:       c.GetNFTTransferPushDocuments(),
 		CentChainMaxRetries:            c.GetCentChainMaxRetries(),
+		CentChainSignerBackend:         c.GetCentChainSignerBackend(),
+		CentChainSignerURL:             c.GetCentChainSignerURL(),
+		CentChainConfirmationDepth:     c.GetCentChainConfirmationDepth(),
+		CentChainEventIndexerInterval:  c.GetCentChainEventIndexerInterval(),
 		CentChainIntervalRetry:         c.GetCentChainIntervalRetry(),
 		CentChainAnchorLifespan:        c.GetCentChainAnchorLifespan(),
 		CentChainNodeURL:               c.GetCentChainNodeURL(),
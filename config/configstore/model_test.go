@@ -30,6 +30,21 @@ func (m *mockConfig) GetLowEntropyNFTTokenEnabled() bool {
 	return args.Get(0).(bool)
 }
 
+func (m *mockConfig) GetNFTRegistryAdapters() map[string]string {
+	args := m.Called()
+	return args.Get(0).(map[string]string)
+}
+
+func (m *mockConfig) GetNFTTransferWatchInterval() time.Duration {
+	args := m.Called()
+	return args.Get(0).(time.Duration)
+}
+
+func (m *mockConfig) GetNFTTransferPushDocuments() bool {
+	args := m.Called()
+	return args.Get(0).(bool)
+}
+
 func (m *mockConfig) GetPrecommitEnabled() bool {
 	args := m.Called()
 	return args.Get(0).(bool)
@@ -132,6 +147,61 @@ func (m *mockConfig) GetP2PResponseDelay() time.Duration {
 	return args.Get(0).(time.Duration)
 }
 
+func (m *mockConfig) GetP2PChunkSize() int {
+	args := m.Called()
+	return args.Get(0).(int)
+}
+
+func (m *mockConfig) GetP2PTransferPath() string {
+	args := m.Called()
+	return args.Get(0).(string)
+}
+
+func (m *mockConfig) GetP2PMaxTransferSize() int {
+	args := m.Called()
+	return args.Get(0).(int)
+}
+
+func (m *mockConfig) GetP2PRateLimit() int {
+	args := m.Called()
+	return args.Get(0).(int)
+}
+
+func (m *mockConfig) GetP2PMaxConcurrentRequests() int {
+	args := m.Called()
+	return args.Get(0).(int)
+}
+
+func (m *mockConfig) GetP2PBanThreshold() int {
+	args := m.Called()
+	return args.Get(0).(int)
+}
+
+func (m *mockConfig) GetP2PBanDuration() time.Duration {
+	args := m.Called()
+	return args.Get(0).(time.Duration)
+}
+
+func (m *mockConfig) GetSignatureCollectionMode() string {
+	args := m.Called()
+	return args.Get(0).(string)
+}
+
+func (m *mockConfig) GetSignatureQuorum() int {
+	args := m.Called()
+	return args.Get(0).(int)
+}
+
+func (m *mockConfig) GetSignatureRetryInterval() time.Duration {
+	args := m.Called()
+	return args.Get(0).(time.Duration)
+}
+
+func (m *mockConfig) GetSignatureCollectionTimeout() time.Duration {
+	args := m.Called()
+	return args.Get(0).(time.Duration)
+}
+
 func (m *mockConfig) GetReceiveEventNotificationEndpoint() string {
 	args := m.Called()
 	return args.Get(0).(string)
@@ -163,6 +233,61 @@ func (m *mockConfig) GetWorkerWaitTimeMS() int {
 	return args.Get(0).(int)
 }
 
+func (m *mockConfig) GetJobRetentionSuccess() time.Duration {
+	args := m.Called()
+	return args.Get(0).(time.Duration)
+}
+
+func (m *mockConfig) GetJobRetentionFailed() time.Duration {
+	args := m.Called()
+	return args.Get(0).(time.Duration)
+}
+
+func (m *mockConfig) GetJobRetentionCancelled() time.Duration {
+	args := m.Called()
+	return args.Get(0).(time.Duration)
+}
+
+func (m *mockConfig) GetJobPruneInterval() time.Duration {
+	args := m.Called()
+	return args.Get(0).(time.Duration)
+}
+
+func (m *mockConfig) GetJobArchivePath() string {
+	args := m.Called()
+	return args.Get(0).(string)
+}
+
+func (m *mockConfig) GetWebhookMaxAttempts() int {
+	args := m.Called()
+	return args.Get(0).(int)
+}
+
+func (m *mockConfig) GetWebhookRetryBackoff() time.Duration {
+	args := m.Called()
+	return args.Get(0).(time.Duration)
+}
+
+func (m *mockConfig) GetWebhookMaxRetryBackoff() time.Duration {
+	args := m.Called()
+	return args.Get(0).(time.Duration)
+}
+
+func (m *mockConfig) GetWebhookTimeout() time.Duration {
+	args := m.Called()
+	return args.Get(0).(time.Duration)
+}
+
+func (m *mockConfig) GetStorageBackend() string {
+	args := m.Called()
+	return args.Get(0).(string)
+}
+
+func (m *mockConfig) GetStoragePostgresDSN() string {
+	args := m.Called()
+	return args.Get(0).(string)
+}
+
 func (m *mockConfig) GetEthereumNodeURL() string {
 	args := m.Called()
 	return args.Get(0).(string)
@@ -188,6 +313,36 @@ func (m *mockConfig) GetEthereumMaxRetries() int {
 	return args.Get(0).(int)
 }
 
+func (m *mockConfig) GetEthereumTxCheckInterval() time.Duration {
+	args := m.Called()
+	return args.Get(0).(time.Duration)
+}
+
+func (m *mockConfig) GetEthereumTxBumpTimeout() time.Duration {
+	args := m.Called()
+	return args.Get(0).(time.Duration)
+}
+
+func (m *mockConfig) GetEthereumGasPriceBump() int {
+	args := m.Called()
+	return args.Get(0).(int)
+}
+
+func (m *mockConfig) GetEthereumSignerBackend() string {
+	args := m.Called()
+	return args.Get(0).(string)
+}
+
+func (m *mockConfig) GetEthereumSignerURL() string {
+	args := m.Called()
+	return args.Get(0).(string)
+}
+
+func (m *mockConfig) GetEthereumConfirmationDepth() int {
+	args := m.Called()
+	return args.Get(0).(int)
+}
+
 func (m *mockConfig) GetEthereumMaxGasPrice() *big.Int {
 	args := m.Called()
 	return args.Get(0).(*big.Int)
@@ -268,6 +423,26 @@ func (m *mockConfig) GetCentChainMaxRetries() int {
 	return args.Get(0).(int)
 }
 
+func (m *mockConfig) GetCentChainSignerBackend() string {
+	args := m.Called()
+	return args.Get(0).(string)
+}
+
+func (m *mockConfig) GetCentChainSignerURL() string {
+	args := m.Called()
+	return args.Get(0).(string)
+}
+
+func (m *mockConfig) GetCentChainConfirmationDepth() int {
+	args := m.Called()
+	return args.Get(0).(int)
+}
+
+func (m *mockConfig) GetCentChainEventIndexerInterval() time.Duration {
+	args := m.Called()
+	return args.Get(0).(time.Duration)
+}
+
 func (m *mockConfig) GetCentChainAnchorLifespan() time.Duration {
 	args := m.Called()
 	return args.Get(0).(time.Duration)
@@ -309,10 +484,31 @@ func createMockConfig() *mockConfig {
 	c.On("GetP2PExternalIP").Return("ip").Once()
 	c.On("GetP2PConnectionTimeout").Return(time.Second).Once()
 	c.On("GetP2PResponseDelay").Return(time.Millisecond).Once()
+	c.On("GetP2PChunkSize").Return(1024).Once()
+	c.On("GetP2PTransferPath").Return("dummyTransfers").Once()
+	c.On("GetP2PMaxTransferSize").Return(268435456).Once()
+	c.On("GetP2PRateLimit").Return(600).Once()
+	c.On("GetP2PMaxConcurrentRequests").Return(10).Once()
+	c.On("GetP2PBanThreshold").Return(10).Once()
+	c.On("GetP2PBanDuration").Return(10 * time.Minute).Once()
+	c.On("GetSignatureCollectionMode").Return("timeout").Once()
+	c.On("GetSignatureQuorum").Return(0).Once()
+	c.On("GetSignatureRetryInterval").Return(time.Minute).Once()
+	c.On("GetSignatureCollectionTimeout").Return(24 * time.Hour).Once()
 	c.On("GetServerPort").Return(8080).Once()
 	c.On("GetServerAddress").Return("dummyServer").Once()
 	c.On("GetNumWorkers").Return(2).Once()
 	c.On("GetWorkerWaitTimeMS").Return(1).Once()
+	c.On("GetJobRetentionSuccess").Return(168 * time.Hour).Once()
+	c.On("GetJobRetentionFailed").Return(2160 * time.Hour).Once()
+	c.On("GetJobRetentionCancelled").Return(168 * time.Hour).Once()
+	c.On("GetJobPruneInterval").Return(time.Hour).Once()
+	c.On("GetJobArchivePath").Return("").Once()
+	c.On("GetWebhookMaxAttempts").Return(8).Once()
+	c.On("GetWebhookRetryBackoff").Return(10 * time.Second).Once()
+	c.On("GetWebhookMaxRetryBackoff").Return(time.Hour).Once()
+	c.On("GetWebhookTimeout").Return(10 * time.Second).Once()
+	c.On("GetStorageBackend").Return("leveldb").Once()
 	c.On("GetEthereumNodeURL").Return("dummyNode").Once()
 	c.On("GetIdentityID").Return(utils.RandomSlice(identity.DIDLength), nil).Once()
 	c.On("GetP2PKeyPair").Return("pub", "priv").Once()
@@ -324,6 +520,12 @@ func createMockConfig() *mockConfig {
 	c.On("GetEthereumContextWaitTimeout").Return(time.Second).Once()
 	c.On("GetEthereumIntervalRetry").Return(time.Second).Once()
 	c.On("GetEthereumMaxRetries").Return(1).Once()
+	c.On("GetEthereumTxCheckInterval").Return(time.Second).Once()
+	c.On("GetEthereumTxBumpTimeout").Return(time.Minute).Once()
+	c.On("GetEthereumGasPriceBump").Return(20).Once()
+	c.On("GetEthereumSignerBackend").Return("keystore").Once()
+	c.On("GetEthereumSignerURL").Return("").Once()
+	c.On("GetEthereumConfirmationDepth").Return(6).Once()
 	c.On("GetEthereumMaxGasPrice").Return(big.NewInt(1)).Once()
 	c.On("GetEthereumGasLimit", mock.Anything).Return(uint64(100))
 	c.On("GetNetworkString").Return("somehill").Once()
@@ -333,10 +535,17 @@ func createMockConfig() *mockConfig {
 	c.On("IsPProfEnabled", mock.Anything).Return(true)
 	c.On("IsDebugLogEnabled", mock.Anything).Return(true)
 	c.On("GetLowEntropyNFTTokenEnabled", mock.Anything).Return(true)
+	c.On("GetNFTRegistryAdapters").Return(map[string]string{}).Once()
+	c.On("GetNFTTransferWatchInterval").Return(time.Second).Once()
+	c.On("GetNFTTransferPushDocuments").Return(false).Once()
 	c.On("GetCentChainAccount").Return(config.CentChainAccount{}, nil).Once()
 	c.On("GetCentChainIntervalRetry").Return(time.Second).Once()
 	c.On("GetCentChainAnchorLifespan").Return(time.Second).Once()
 	c.On("GetCentChainMaxRetries").Return(1).Once()
+	c.On("GetCentChainSignerBackend").Return("keystore").Once()
+	c.On("GetCentChainSignerURL").Return("").Once()
+	c.On("GetCentChainConfirmationDepth").Return(1).Once()
+	c.On("GetCentChainEventIndexerInterval").Return(6 * time.Second).Once()
 	c.On("GetCentChainNodeURL").Return("dummyNode").Once()
 	return c
 }
@@ -14,6 +14,7 @@ import (
 	"github.com/centrifuge/go-centrifuge/contextutil"
 	"github.com/centrifuge/go-centrifuge/errors"
 	"github.com/centrifuge/go-centrifuge/identity"
+	"github.com/centrifuge/go-centrifuge/signer"
 )
 
 const (
@@ -66,15 +67,17 @@ func (s service) CreateAccount(data config.Account) (config.Account, error) {
 }
 
 func (s service) GenerateAccount(cacc config.CentChainAccount) (config.Account, error) {
-	if cacc.ID == "" || cacc.Secret == "" || cacc.SS58Addr == "" {
-		return nil, errors.New("Centrifuge Chain account is required")
-	}
-
 	nc, err := s.GetConfig()
 	if err != nil {
 		return nil, err
 	}
 
+	// the secret is not kept on the node when the account is signed by a remote signer
+	remote := nc.GetCentChainSignerBackend() != "" && nc.GetCentChainSignerBackend() != signer.BackendKeystore
+	if cacc.ID == "" || (cacc.Secret == "" && !remote) || cacc.SS58Addr == "" {
+		return nil, errors.New("Centrifuge Chain account is required")
+	}
+
 	// copy the main account for basic settings
 	acc, err := NewAccount(nc.GetEthereumDefaultAccountName(), nc)
 	if nil != err {
@@ -112,16 +112,44 @@ type Configuration interface {
 	GetP2PExternalIP() string
 	GetP2PConnectionTimeout() time.Duration
 	GetP2PResponseDelay() time.Duration
+	GetP2PChunkSize() int
+	GetP2PTransferPath() string
+	GetP2PMaxTransferSize() int
+	GetP2PRateLimit() int
+	GetP2PMaxConcurrentRequests() int
+	GetP2PBanThreshold() int
+	GetP2PBanDuration() time.Duration
+	GetSignatureCollectionMode() string
+	GetSignatureQuorum() int
+	GetSignatureRetryInterval() time.Duration
+	GetSignatureCollectionTimeout() time.Duration
 	GetServerPort() int
 	GetServerAddress() string
 	GetNumWorkers() int
 	GetWorkerWaitTimeMS() int
+	GetJobRetentionSuccess() time.Duration
+	GetJobRetentionFailed() time.Duration
+	GetJobRetentionCancelled() time.Duration
+	GetJobPruneInterval() time.Duration
+	GetJobArchivePath() string
+	GetWebhookMaxAttempts() int
+	GetWebhookRetryBackoff() time.Duration
+	GetWebhookMaxRetryBackoff() time.Duration
+	GetWebhookTimeout() time.Duration
+	GetStorageBackend() string
+	GetStoragePostgresDSN() string
 	GetTaskRetries() int
 	GetEthereumNodeURL() string
 	GetEthereumContextReadWaitTimeout() time.Duration
 	GetEthereumContextWaitTimeout() time.Duration
 	GetEthereumIntervalRetry() time.Duration
 	GetEthereumMaxRetries() int
+	GetEthereumTxCheckInterval() time.Duration
+	GetEthereumTxBumpTimeout() time.Duration
+	GetEthereumGasPriceBump() int
+	GetEthereumSignerBackend() string
+	GetEthereumSignerURL() string
+	GetEthereumConfirmationDepth() int
 	GetEthereumMaxGasPrice() *big.Int
 	GetEthereumGasLimit(op ContractOp) uint64
 	GetNetworkString() string
@@ -147,6 +175,9 @@ type Configuration interface {
 	// same token id and minting it at the same time goes up and it theoretically could lead to a loss of an
 	// NFT with large enough NFTRegistries (>100'000 tokens). It is not recommended to use this option.
 	GetLowEntropyNFTTokenEnabled() bool
+	GetNFTRegistryAdapters() map[string]string
+	GetNFTTransferWatchInterval() time.Duration
+	GetNFTTransferPushDocuments() bool
 
 	// debug specific methods
 	IsPProfEnabled() bool
@@ -156,6 +187,10 @@ type Configuration interface {
 	GetCentChainAccount() (CentChainAccount, error)
 	GetCentChainIntervalRetry() time.Duration
 	GetCentChainMaxRetries() int
+	GetCentChainSignerBackend() string
+	GetCentChainSignerURL() string
+	GetCentChainConfirmationDepth() int
+	GetCentChainEventIndexerInterval() time.Duration
 	GetCentChainNodeURL() string
 	GetCentChainAnchorLifespan() time.Duration
 }
@@ -325,6 +360,61 @@ func (c *configuration) GetP2PResponseDelay() time.Duration {
 	return c.GetDuration("p2p.responseDelay")
 }
 
+// GetP2PChunkSize returns the maximum size of a P2P message before it is sent in chunks.
+func (c *configuration) GetP2PChunkSize() int {
+	return c.GetInt("p2p.chunkSize")
+}
+
+// GetP2PTransferPath returns the directory where chunked P2P transfers are spooled.
+func (c *configuration) GetP2PTransferPath() string {
+	return c.GetString("p2p.transferPath")
+}
+
+// GetP2PMaxTransferSize returns the maximum size in bytes of a chunked P2P transfer.
+func (c *configuration) GetP2PMaxTransferSize() int {
+	return c.GetInt("p2p.maxTransferSize")
+}
+
+// GetP2PRateLimit returns the number of P2P requests allowed per minute from a single peer or DID.
+func (c *configuration) GetP2PRateLimit() int {
+	return c.GetInt("p2p.rateLimit.requestsPerMinute")
+}
+
+// GetP2PMaxConcurrentRequests returns the number of P2P requests handled concurrently for a single peer or DID.
+func (c *configuration) GetP2PMaxConcurrentRequests() int {
+	return c.GetInt("p2p.rateLimit.maxConcurrent")
+}
+
+// GetP2PBanThreshold returns the number of failed validations after which a peer is banned.
+func (c *configuration) GetP2PBanThreshold() int {
+	return c.GetInt("p2p.rateLimit.banThreshold")
+}
+
+// GetP2PBanDuration returns how long a peer stays banned.
+func (c *configuration) GetP2PBanDuration() time.Duration {
+	return c.GetDuration("p2p.rateLimit.banDuration")
+}
+
+// GetSignatureCollectionMode returns how the signatures of the collaborators are collected.
+func (c *configuration) GetSignatureCollectionMode() string {
+	return c.GetString("documents.signatures.mode")
+}
+
+// GetSignatureQuorum returns the number of collaborator signatures required in quorum mode.
+func (c *configuration) GetSignatureQuorum() int {
+	return c.GetInt("documents.signatures.quorum")
+}
+
+// GetSignatureRetryInterval returns the time between requests to the collaborators that haven't signed yet.
+func (c *configuration) GetSignatureRetryInterval() time.Duration {
+	return c.GetDuration("documents.signatures.retryInterval")
+}
+
+// GetSignatureCollectionTimeout returns how long the signatures are collected before anchoring fails.
+func (c *configuration) GetSignatureCollectionTimeout() time.Duration {
+	return c.GetDuration("documents.signatures.timeout")
+}
+
 // GetReceiveEventNotificationEndpoint returns the webhook endpoint defined in the config.
 func (c *configuration) GetReceiveEventNotificationEndpoint() string {
 	return c.GetString("notifications.endpoint")
@@ -355,6 +445,61 @@ func (c *configuration) GetWorkerWaitTimeMS() int {
 	return c.GetInt("queue.workerWaitTimeMS")
 }
 
+// GetJobRetentionSuccess returns how long successful jobs are kept. Zero keeps them forever.
+func (c *configuration) GetJobRetentionSuccess() time.Duration {
+	return c.GetDuration("jobs.retention.success")
+}
+
+// GetJobRetentionFailed returns how long failed jobs are kept. Zero keeps them forever.
+func (c *configuration) GetJobRetentionFailed() time.Duration {
+	return c.GetDuration("jobs.retention.failed")
+}
+
+// GetJobRetentionCancelled returns how long cancelled jobs are kept. Zero keeps them forever.
+func (c *configuration) GetJobRetentionCancelled() time.Duration {
+	return c.GetDuration("jobs.retention.cancelled")
+}
+
+// GetJobPruneInterval returns the interval between two runs of the job pruner.
+func (c *configuration) GetJobPruneInterval() time.Duration {
+	return c.GetDuration("jobs.pruneInterval")
+}
+
+// GetJobArchivePath returns the directory the pruned jobs are archived to. Pruned jobs are deleted if empty.
+func (c *configuration) GetJobArchivePath() string {
+	return c.GetString("jobs.archivePath")
+}
+
+// GetWebhookMaxAttempts returns the number of times a webhook delivery is attempted before it is dead-lettered.
+func (c *configuration) GetWebhookMaxAttempts() int {
+	return c.GetInt("notifications.webhook.maxAttempts")
+}
+
+// GetWebhookRetryBackoff returns the wait before the first retry of a failed webhook delivery. The wait doubles with every attempt.
+func (c *configuration) GetWebhookRetryBackoff() time.Duration {
+	return c.GetDuration("notifications.webhook.retryBackoff")
+}
+
+// GetWebhookMaxRetryBackoff returns the maximum wait between two attempts of a webhook delivery.
+func (c *configuration) GetWebhookMaxRetryBackoff() time.Duration {
+	return c.GetDuration("notifications.webhook.maxRetryBackoff")
+}
+
+// GetWebhookTimeout returns the timeout of a webhook request.
+func (c *configuration) GetWebhookTimeout() time.Duration {
+	return c.GetDuration("notifications.webhook.timeout")
+}
+
+// GetStorageBackend returns the storage backend of the node databases, one of leveldb, bbolt and postgres.
+func (c *configuration) GetStorageBackend() string {
+	return c.GetString("storage.backend")
+}
+
+// GetStoragePostgresDSN returns the connection string of the PostgreSQL storage backend.
+func (c *configuration) GetStoragePostgresDSN() string {
+	return c.GetString("storage.postgres.dsn")
+}
+
 // GetEthereumNodeURL returns the URL of the Ethereum Node.
 func (c *configuration) GetEthereumNodeURL() string {
 	return c.GetString("ethereum.nodeURL")
@@ -380,6 +525,36 @@ func (c *configuration) GetEthereumMaxRetries() int {
 	return c.GetInt("ethereum.maxRetries")
 }
 
+// GetEthereumTxCheckInterval returns the interval at which the pending transactions are checked.
+func (c *configuration) GetEthereumTxCheckInterval() time.Duration {
+	return c.GetDuration("ethereum.txManager.checkInterval")
+}
+
+// GetEthereumTxBumpTimeout returns the time after which a pending transaction is replaced with a higher gas price.
+func (c *configuration) GetEthereumTxBumpTimeout() time.Duration {
+	return c.GetDuration("ethereum.txManager.bumpTimeout")
+}
+
+// GetEthereumGasPriceBump returns the percentage the gas price of a replaced transaction is raised by.
+func (c *configuration) GetEthereumGasPriceBump() int {
+	return c.GetInt("ethereum.txManager.gasPriceBump")
+}
+
+// GetEthereumSignerBackend returns the backend signing the ethereum transactions: keystore, clef or http.
+func (c *configuration) GetEthereumSignerBackend() string {
+	return c.GetString("ethereum.signer.backend")
+}
+
+// GetEthereumSignerURL returns the URL of the remote signer of the ethereum transactions.
+func (c *configuration) GetEthereumSignerURL() string {
+	return c.GetString("ethereum.signer.url")
+}
+
+// GetEthereumConfirmationDepth returns the number of blocks, including the block of a transaction, required before the transaction is confirmed.
+func (c *configuration) GetEthereumConfirmationDepth() int {
+	return c.GetInt("ethereum.confirmationDepth")
+}
+
 // GetEthereumMaxGasPrice returns the gas price to use for a ethereum transaction.
 func (c *configuration) GetEthereumMaxGasPrice() *big.Int {
 	n := new(big.Int)
@@ -449,6 +624,26 @@ func (c *configuration) GetCentChainMaxRetries() int {
 	return c.GetInt("centChain.maxRetries")
 }
 
+// GetCentChainSignerBackend returns the backend signing the centchain extrinsics: keystore or http.
+func (c *configuration) GetCentChainSignerBackend() string {
+	return c.GetString("centChain.signer.backend")
+}
+
+// GetCentChainSignerURL returns the URL of the remote signer of the centchain extrinsics.
+func (c *configuration) GetCentChainSignerURL() string {
+	return c.GetString("centChain.signer.url")
+}
+
+// GetCentChainConfirmationDepth returns the number of finalized blocks, including the block of an extrinsic, required before the extrinsic is confirmed.
+func (c *configuration) GetCentChainConfirmationDepth() int {
+	return c.GetInt("centChain.confirmationDepth")
+}
+
+// GetCentChainEventIndexerInterval returns the interval at which the centchain event indexer looks for new blocks.
+func (c *configuration) GetCentChainEventIndexerInterval() time.Duration {
+	return c.GetDuration("centChain.eventIndexer.interval")
+}
+
 // GetCentChainAnchorLifespan returns the default lifespan of an anchor.
 func (c *configuration) GetCentChainAnchorLifespan() time.Duration {
 	return c.GetDuration("centChain.anchorLifespan")
@@ -523,6 +718,21 @@ func (c *configuration) GetLowEntropyNFTTokenEnabled() bool {
 	return c.GetBool("nft.lowEntropyTokenIDEnabled")
 }
 
+// GetNFTRegistryAdapters returns the registry adapters of NFT registries, keyed by the registry address.
+func (c *configuration) GetNFTRegistryAdapters() map[string]string {
+	return cast.ToStringMapString(c.get("nft.registryAdapters"))
+}
+
+// GetNFTTransferWatchInterval returns the interval between two rounds of the NFT transfer watcher. Zero disables the watcher.
+func (c *configuration) GetNFTTransferWatchInterval() time.Duration {
+	return c.GetDuration("nft.transferWatcher.interval")
+}
+
+// GetNFTTransferPushDocuments returns true if documents are sent to the Centrifuge identities NFTs are transferred to.
+func (c *configuration) GetNFTTransferPushDocuments() bool {
+	return c.GetBool("nft.transferWatcher.pushDocuments")
+}
+
 // LoadConfiguration loads the configuration from the given file.
 func LoadConfiguration(configFile string) Configuration {
 	cfg := &configuration{configFile: configFile, mu: sync.RWMutex{}}
@@ -648,6 +858,7 @@ func CreateConfigFile(args map[string]interface{}) (*viper.Viper, error) {
 	v.Set("nodeHostname", apiHost)
 	v.Set("nodePort", apiPort)
 	v.Set("p2p.port", p2pPort)
+	v.Set("p2p.transferPath", targetDataDir+"/transfers")
 	v.Set("notifications.endpoint", webhookURL)
 	if p2pConnectTimeout != "" {
 		v.Set("p2p.connectTimeout", p2pConnectTimeout)
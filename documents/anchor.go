@@ -6,6 +6,7 @@ import (
 	"github.com/centrifuge/centrifuge-protobufs/gen/go/coredocument"
 	"github.com/centrifuge/go-centrifuge/errors"
 	"github.com/centrifuge/go-centrifuge/identity"
+	"github.com/centrifuge/go-centrifuge/jobs"
 )
 
 // AnchorProcessor identifies an implementation, which can do a bunch of things with a CoreDocument.
@@ -23,64 +24,193 @@ type AnchorProcessor interface {
 // updaterFunc is a wrapper that will be called to save the state of the model between processor steps
 type updaterFunc func(id []byte, model Model) error
 
-// AnchorDocument add signature, requests signatures, anchors document, and sends the anchored document
-// to collaborators
-func AnchorDocument(ctx context.Context, model Model, proc AnchorProcessor, updater updaterFunc, preAnchor bool) (Model, error) {
-	id := model.CurrentVersion()
-	err := proc.PrepareForSignatureRequests(ctx, model)
-	if err != nil {
-		return nil, errors.NewTypedError(ErrDocumentAnchoring, errors.New("failed to prepare document for signatures: %v", err))
-	}
+const (
+	anchorStepPrepare   = "Signature Preparation"
+	anchorStepPreCommit = "Pre-Commit"
+	anchorStepCommit    = "Anchor Commit"
+	anchorStepSend      = "Document Sending"
 
-	err = updater(id, model)
+	// signature collection is tracked with signatureCollectionTaskName
+)
+
+// anchorSteps tracks the anchoring steps completed for a job so that a retried job resumes after them.
+type anchorSteps interface {
+	// done returns true if the step completed in an earlier run of the job
+	done(step string) bool
+
+	// complete records that the step completed
+	complete(step string) error
+}
+
+// noSteps runs every anchoring step.
+type noSteps struct{}
+
+func (noSteps) done(string) bool {
+	return false
+}
+
+func (noSteps) complete(string) error {
+	return nil
+}
+
+// jobSteps tracks the anchoring steps in the task status of the job.
+type jobSteps struct {
+	jobMan    jobs.Manager
+	accountID identity.DID
+	jobID     jobs.JobID
+	status    map[string]jobs.Status
+}
+
+// newJobSteps loads the anchoring steps the job completed so far.
+func newJobSteps(jobMan jobs.Manager, accountID identity.DID, jobID jobs.JobID) (*jobSteps, error) {
+	job, err := jobMan.GetJob(accountID, jobID)
 	if err != nil {
 		return nil, err
 	}
 
-	if preAnchor {
-		err = proc.PreAnchorDocument(ctx, model)
-		if err != nil {
-			return nil, err
-		}
+	// the anchor task of a cancelled job doesn't start
+	if job.Status == jobs.Cancelled {
+		return nil, jobs.ErrJobCancelled
 	}
 
-	err = proc.RequestSignatures(ctx, model)
-	if err != nil {
-		return nil, errors.NewTypedError(ErrDocumentAnchoring, errors.New("failed to collect signatures: %v", err))
+	status := make(map[string]jobs.Status)
+	for task, st := range job.TaskStatus {
+		status[task] = st
 	}
 
-	err = updater(id, model)
+	return &jobSteps{jobMan: jobMan, accountID: accountID, jobID: jobID, status: status}, nil
+}
+
+func (s *jobSteps) done(step string) bool {
+	return s.status[step] == jobs.Success
+}
+
+// complete records the step and fails with ErrJobCancelled once the job is cancelled,
+// so that the anchoring stops before the next step.
+func (s *jobSteps) complete(step string) error {
+	s.status[step] = jobs.Success
+	err := s.jobMan.UpdateTaskStatus(s.accountID, s.jobID, jobs.Success, step, "")
 	if err != nil {
-		return nil, errors.NewTypedError(ErrDocumentAnchoring, err)
+		return err
 	}
 
-	err = proc.PrepareForAnchoring(model)
+	job, err := s.jobMan.GetJob(s.accountID, s.jobID)
 	if err != nil {
-		return nil, errors.NewTypedError(ErrDocumentAnchoring, errors.New("failed to prepare for anchoring: %v", err))
+		return err
 	}
 
-	err = updater(id, model)
-	if err != nil {
-		return nil, err
+	if job.Status == jobs.Cancelled {
+		return jobs.ErrJobCancelled
 	}
 
-	// TODO [TXManager] this function creates a child task in the queue which should be removed and called from the TxManger function
-	err = proc.AnchorDocument(ctx, model)
-	if err != nil {
-		return nil, errors.NewTypedError(ErrDocumentAnchoring, errors.New("failed to anchor document: %v", err))
+	return nil
+}
+
+// AnchorDocument add signature, requests signatures, anchors document, and sends the anchored document
+// to collaborators
+func AnchorDocument(ctx context.Context, model Model, proc AnchorProcessor, updater updaterFunc, preAnchor bool) (Model, error) {
+	return anchorDocument(ctx, model, proc, updater, preAnchor, noSteps{})
+}
+
+// anchorDocument runs the anchoring steps that are not done yet.
+func anchorDocument(ctx context.Context, model Model, proc AnchorProcessor, updater updaterFunc, preAnchor bool, steps anchorSteps) (Model, error) {
+	id := model.CurrentVersion()
+	if !steps.done(anchorStepPrepare) {
+		err := proc.PrepareForSignatureRequests(ctx, model)
+		if err != nil {
+			return nil, errors.NewTypedError(ErrDocumentAnchoring, errors.New("failed to prepare document for signatures: %v", err))
+		}
+
+		err = updater(id, model)
+		if err != nil {
+			return nil, err
+		}
+
+		err = steps.complete(anchorStepPrepare)
+		if err != nil {
+			return nil, err
+		}
 	}
 
-	// set the status to committed
-	if err = model.SetStatus(Committed); err != nil {
-		return nil, err
+	if preAnchor && !steps.done(anchorStepPreCommit) {
+		err := proc.PreAnchorDocument(ctx, model)
+		if err != nil {
+			return nil, err
+		}
+
+		err = steps.complete(anchorStepPreCommit)
+		if err != nil {
+			return nil, err
+		}
 	}
 
-	err = updater(id, model)
-	if err != nil {
-		return nil, errors.NewTypedError(ErrDocumentAnchoring, err)
+	if !steps.done(signatureCollectionTaskName) {
+		err := proc.RequestSignatures(ctx, model)
+		if err != nil {
+			// the signature collection anchors the document once the signatures are received
+			if errors.IsOfType(ErrSignaturesPending, err) {
+				return nil, err
+			}
+
+			return nil, errors.NewTypedError(ErrDocumentAnchoring, errors.New("failed to collect signatures: %v", err))
+		}
+
+		err = updater(id, model)
+		if err != nil {
+			return nil, errors.NewTypedError(ErrDocumentAnchoring, err)
+		}
+
+		err = steps.complete(signatureCollectionTaskName)
+		if err != nil {
+			return nil, err
+		}
 	}
 
-	err = proc.SendDocument(ctx, model)
+	return finishAnchoring(ctx, model, proc, updater, steps)
+}
+
+// finishAnchoring anchors the document with the collected signatures, and sends the anchored document to collaborators
+func finishAnchoring(ctx context.Context, model Model, proc AnchorProcessor, updater updaterFunc, steps anchorSteps) (Model, error) {
+	id := model.CurrentVersion()
+	if !steps.done(anchorStepCommit) {
+		err := updater(id, model)
+		if err != nil {
+			return nil, errors.NewTypedError(ErrDocumentAnchoring, err)
+		}
+
+		err = proc.PrepareForAnchoring(model)
+		if err != nil {
+			return nil, errors.NewTypedError(ErrDocumentAnchoring, errors.New("failed to prepare for anchoring: %v", err))
+		}
+
+		err = updater(id, model)
+		if err != nil {
+			return nil, err
+		}
+
+		// TODO [TXManager] this function creates a child task in the queue which should be removed and called from the TxManger function
+		err = proc.AnchorDocument(ctx, model)
+		if err != nil {
+			return nil, errors.NewTypedError(ErrDocumentAnchoring, errors.New("failed to anchor document: %v", err))
+		}
+
+		// set the status to committed
+		if err = model.SetStatus(Committed); err != nil {
+			return nil, err
+		}
+
+		err = updater(id, model)
+		if err != nil {
+			return nil, errors.NewTypedError(ErrDocumentAnchoring, err)
+		}
+
+		err = steps.complete(anchorStepCommit)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	err := proc.SendDocument(ctx, model)
 	if err != nil {
 		return nil, errors.NewTypedError(ErrDocumentAnchoring, errors.New("failed to send anchored document: %v", err))
 	}
@@ -90,5 +220,10 @@ func AnchorDocument(ctx context.Context, model Model, proc AnchorProcessor, upda
 		return nil, errors.NewTypedError(ErrDocumentAnchoring, err)
 	}
 
+	err = steps.complete(anchorStepSend)
+	if err != nil {
+		return nil, err
+	}
+
 	return model, nil
 }
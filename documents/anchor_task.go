@@ -2,6 +2,7 @@ package documents
 
 import (
 	"context"
+	"time"
 
 	"github.com/centrifuge/go-centrifuge/config"
 	"github.com/centrifuge/go-centrifuge/contextutil"
@@ -23,6 +24,15 @@ const (
 	AccountIDParam = "accountID"
 
 	documentAnchorTaskName = "Document Anchoring"
+
+	// AnchorVersionValueKey maps to the document version anchored by the job in the job values
+	AnchorVersionValueKey = "anchor_version"
+
+	// SignatureDeadlineValueKey maps to the deadline of the signature collection of the job in the job values
+	SignatureDeadlineValueKey = "signature_deadline"
+
+	// anchorTaskPollInterval is the interval at which the status of an anchor task waiting for signatures is checked
+	anchorTaskPollInterval = time.Second
 )
 
 var log = logging.Logger("anchor_task")
@@ -74,6 +84,11 @@ func (d *documentAnchorTask) ParseKwargs(kwargs map[string]interface{}) error {
 	return nil
 }
 
+// JobDetails returns the account and the job of the task.
+func (d *documentAnchorTask) JobDetails() (identity.DID, jobs.JobID) {
+	return d.accountID, d.JobID
+}
+
 // Copy returns a new task with state.
 func (d *documentAnchorTask) Copy() (gocelery.CeleryTask, error) {
 	return &documentAnchorTask{
@@ -86,9 +101,17 @@ func (d *documentAnchorTask) Copy() (gocelery.CeleryTask, error) {
 }
 
 // RunTask anchors the document.
+// The task is idempotent so that it can be delivered again after a restart: an already committed document is not anchored again.
+// The steps completed by an earlier run of the job are skipped so that a retried job resumes from the failed step.
 func (d *documentAnchorTask) RunTask() (res interface{}, err error) {
 	log.Infof("starting anchor task for transaction: %s\n", d.JobID)
 	defer func() {
+		// the task stays pending until the signature collection anchors the document
+		if err != nil && errors.IsOfType(ErrSignaturesPending, err) {
+			res, err = true, nil
+			return
+		}
+
 		err = d.UpdateJob(d.accountID, d.TaskTypeName(), err)
 	}()
 
@@ -108,9 +131,24 @@ func (d *documentAnchorTask) RunTask() (res interface{}, err error) {
 		return false, errors.New("failed to get model: %v", err)
 	}
 
-	if _, err = AnchorDocument(ctxh, model, d.processor, func(id []byte, model Model) error {
+	steps, err := newJobSteps(d.JobManager, d.accountID, d.JobID)
+	if err != nil {
+		return false, errors.New("failed to get job: %v", err)
+	}
+
+	// a committed document is only sent again if a retried job failed to send it
+	if model.GetStatus() == Committed && (!steps.done(anchorStepCommit) || steps.done(anchorStepSend)) {
+		log.Infof("document %s is already anchored", hexutil.Encode(d.id))
+		return true, nil
+	}
+
+	if _, err = anchorDocument(ctxh, model, d.processor, func(id []byte, model Model) error {
 		return d.modelSaveFunc(d.accountID[:], id, model)
-	}, tc.GetPrecommitEnabled()); err != nil {
+	}, tc.GetPrecommitEnabled(), steps); err != nil {
+		if errors.IsOfType(ErrSignaturesPending, err) {
+			return false, err
+		}
+
 		return false, errors.New("failed to anchor document: %v", err)
 	}
 
@@ -125,7 +163,12 @@ func initDocumentAnchorTask(jobMan jobs.Manager, tq queue.TaskQueuer, accountID
 		AccountIDParam:  accountID.String(),
 	}
 
-	err := jobMan.UpdateTaskStatus(accountID, jobID, jobs.Pending, documentAnchorTaskName, "init")
+	err := jobMan.UpdateJobWithValue(accountID, jobID, AnchorVersionValueKey, modelID)
+	if err != nil {
+		return nil, err
+	}
+
+	err = jobMan.UpdateTaskStatus(accountID, jobID, jobs.Pending, documentAnchorTaskName, "init")
 	if err != nil {
 		return nil, err
 	}
@@ -140,7 +183,12 @@ func initDocumentAnchorTask(jobMan jobs.Manager, tq queue.TaskQueuer, accountID
 
 // CreateAnchorJob creates a job for anchoring a document using jobs manager
 func CreateAnchorJob(parentCtx context.Context, jobsMan jobs.Manager, tq queue.TaskQueuer, self identity.DID, jobID jobs.JobID, documentID []byte) (jobs.JobID, chan error, error) {
-	jobID, done, err := jobsMan.ExecuteWithinJob(contextutil.Copy(parentCtx), self, jobID, "anchor document", func(accountID identity.DID, jobID jobs.JobID, jobsMan jobs.Manager, errChan chan<- error) {
+	return jobsMan.ExecuteWithinJob(contextutil.Copy(parentCtx), self, jobID, "anchor document", anchorJobWork(tq, documentID))
+}
+
+// anchorJobWork returns the work of an anchor job that anchors the document version.
+func anchorJobWork(tq queue.TaskQueuer, documentID []byte) func(ctx context.Context, accountID identity.DID, jobID jobs.JobID, jobsMan jobs.Manager, errChan chan<- error) {
+	return func(ctx context.Context, accountID identity.DID, jobID jobs.JobID, jobsMan jobs.Manager, errChan chan<- error) {
 		tr, err := initDocumentAnchorTask(jobsMan, tq, accountID, documentID, jobID)
 		if err != nil {
 			errChan <- err
@@ -151,7 +199,85 @@ func CreateAnchorJob(parentCtx context.Context, jobsMan jobs.Manager, tq queue.T
 			errChan <- err
 			return
 		}
-		errChan <- nil
-	})
-	return jobID, done, err
+		errChan <- waitForAnchorTask(ctx, jobsMan, accountID, jobID)
+	}
+}
+
+// retryAnchorJob returns the retrier of the failed anchor jobs.
+// The anchor task runs again and skips the anchoring steps that already succeeded.
+func retryAnchorJob(tq queue.TaskQueuer) jobs.Retrier {
+	return func(job *jobs.Job) (func(ctx context.Context, accountID identity.DID, jobID jobs.JobID, jobsMan jobs.Manager, errChan chan<- error), error) {
+		v, ok := job.Values[AnchorVersionValueKey]
+		if !ok || len(v.Value) == 0 {
+			return nil, errors.New("document version of the job is unknown")
+		}
+
+		return anchorJobWork(tq, v.Value), nil
+	}
+}
+
+// waitForAnchorTask waits until the anchor task waiting for signatures completes or the job is cancelled.
+// The anchor task is given a task timeout after the deadline of the signature collection to anchor the document,
+// so that the job fails if the collection doesn't finish, e.g. when it isn't resumed after a restart.
+func waitForAnchorTask(ctx context.Context, jobsMan jobs.Manager, accountID identity.DID, jobID jobs.JobID) error {
+	ticker := time.NewTicker(anchorTaskPollInterval)
+	defer ticker.Stop()
+	deadline := time.Now().UTC().Add(jobsMan.GetDefaultTaskTimeout())
+	for {
+		job, err := jobsMan.GetJob(accountID, jobID)
+		if err != nil {
+			return err
+		}
+
+		if job.Status == jobs.Cancelled {
+			return jobs.ErrJobCancelled
+		}
+
+		switch job.TaskStatus[documentAnchorTaskName] {
+		case jobs.Pending:
+			if d, ok := signatureDeadline(job); ok {
+				deadline = d.Add(jobsMan.GetDefaultTaskTimeout())
+			}
+
+			if time.Now().UTC().After(deadline) {
+				return errors.NewTypedError(ErrSignatureCollectionTimeout, errors.New("anchor task of job %s is still pending", jobID.String()))
+			}
+
+			select {
+			case <-ctx.Done():
+				return ctx.Err()
+			case <-ticker.C:
+			}
+		case jobs.Failed:
+			return errors.New("failed to anchor document: %s", lastTaskLog(job, documentAnchorTaskName))
+		default:
+			return nil
+		}
+	}
+}
+
+// signatureDeadline returns the deadline of the signature collection of the job, if any.
+func signatureDeadline(job *jobs.Job) (time.Time, bool) {
+	v, ok := job.Values[SignatureDeadlineValueKey]
+	if !ok {
+		return time.Time{}, false
+	}
+
+	d, err := time.Parse(time.RFC3339Nano, string(v.Value))
+	if err != nil {
+		return time.Time{}, false
+	}
+
+	return d, true
+}
+
+// lastTaskLog returns the last log message of the task.
+func lastTaskLog(job *jobs.Job, taskName string) string {
+	for i := len(job.Logs) - 1; i >= 0; i-- {
+		if job.Logs[i].Action == taskName {
+			return job.Logs[i].Message
+		}
+	}
+
+	return ""
 }
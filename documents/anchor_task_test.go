@@ -3,10 +3,14 @@
 package documents
 
 import (
+	"context"
 	"testing"
+	"time"
 
+	"github.com/centrifuge/go-centrifuge/errors"
 	"github.com/centrifuge/go-centrifuge/jobs"
 	"github.com/centrifuge/go-centrifuge/testingutils/identity"
+	"github.com/centrifuge/go-centrifuge/testingutils/testingjobs"
 	"github.com/centrifuge/go-centrifuge/utils"
 	"github.com/ethereum/go-ethereum/common/hexutil"
 	"github.com/stretchr/testify/assert"
@@ -84,3 +88,34 @@ func TestDocumentAnchorTask_ParseKwargs(t *testing.T) {
 		})
 	}
 }
+
+func TestWaitForAnchorTask(t *testing.T) {
+	did := testingidentity.GenerateRandomDID()
+	job := jobs.NewJob(did, "anchor document")
+	job.TaskStatus[documentAnchorTaskName] = jobs.Pending
+	jobMan := new(testingjobs.MockJobManager)
+	jobMan.On("GetJob", did, job.ID).Return(job, nil)
+	jobMan.On("GetDefaultTaskTimeout").Return(time.Minute)
+
+	// still pending after the deadline of the signature collection
+	job.Values[SignatureDeadlineValueKey] = jobs.JobValue{
+		Key:   SignatureDeadlineValueKey,
+		Value: []byte(time.Now().UTC().Add(-time.Hour).Format(time.RFC3339Nano)),
+	}
+	err := waitForAnchorTask(context.Background(), jobMan, did, job.ID)
+	assert.True(t, errors.IsOfType(ErrSignatureCollectionTimeout, err))
+
+	// job context closed
+	job.Values[SignatureDeadlineValueKey] = jobs.JobValue{
+		Key:   SignatureDeadlineValueKey,
+		Value: []byte(time.Now().UTC().Add(time.Hour).Format(time.RFC3339Nano)),
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	err = waitForAnchorTask(ctx, jobMan, did, job.ID)
+	assert.Equal(t, context.Canceled, err)
+
+	// anchored
+	job.TaskStatus[documentAnchorTaskName] = jobs.Success
+	assert.NoError(t, waitForAnchorTask(context.Background(), jobMan, did, job.ID))
+}
@@ -0,0 +1,64 @@
+// +build unit
+
+package documents
+
+import (
+	"context"
+	"testing"
+
+	"github.com/centrifuge/go-centrifuge/errors"
+	"github.com/centrifuge/go-centrifuge/jobs"
+	"github.com/centrifuge/go-centrifuge/testingutils/identity"
+	"github.com/centrifuge/go-centrifuge/testingutils/testingjobs"
+	"github.com/centrifuge/go-centrifuge/utils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestAnchorDocument_resume(t *testing.T) {
+	did := testingidentity.GenerateRandomDID()
+	job := jobs.NewJob(did, "anchor document")
+	job.TaskStatus[anchorStepPrepare] = jobs.Success
+	job.TaskStatus[anchorStepPreCommit] = jobs.Success
+	job.TaskStatus[signatureCollectionTaskName] = jobs.Success
+	job.TaskStatus[anchorStepCommit] = jobs.Failed
+	jobMan := new(testingjobs.MockJobManager)
+	jobMan.On("GetJob", did, job.ID).Return(job, nil)
+	steps, err := newJobSteps(jobMan, did, job.ID)
+	assert.NoError(t, err)
+	updater := func(id []byte, model Model) error {
+		return nil
+	}
+
+	// the completed steps are skipped
+	m := new(MockModel)
+	m.On("CurrentVersion").Return(utils.RandomSlice(32))
+	m.On("SetStatus", Committed).Return(nil).Once()
+	proc := new(MockAnchorProcessor)
+	proc.On("PrepareForAnchoring", m).Return(nil).Once()
+	proc.On("AnchorDocument", m).Return(nil).Once()
+	proc.On("SendDocument", mock.Anything, m).Return(errors.New("peer unreachable")).Once()
+	jobMan.On("UpdateTaskStatus", did, job.ID, jobs.Success, anchorStepCommit, "").Return(nil).Once()
+	_, err = anchorDocument(context.Background(), m, proc, updater, true, steps)
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "failed to send anchored document")
+	assert.True(t, steps.done(anchorStepCommit))
+	assert.False(t, steps.done(anchorStepSend))
+
+	// only sending is retried once the document is anchored
+	proc.On("SendDocument", mock.Anything, m).Return(nil).Once()
+	jobMan.On("UpdateTaskStatus", did, job.ID, jobs.Success, anchorStepSend, "").Return(nil).Once()
+	_, err = anchorDocument(context.Background(), m, proc, updater, true, steps)
+	assert.NoError(t, err)
+	assert.True(t, steps.done(anchorStepSend))
+
+	// anchoring stops once the job is cancelled
+	job.Status = jobs.Cancelled
+	jobMan.On("UpdateTaskStatus", did, job.ID, jobs.Success, anchorStepSend, "").Return(nil).Once()
+	assert.True(t, errors.IsOfType(jobs.ErrJobCancelled, steps.complete(anchorStepSend)))
+	_, err = newJobSteps(jobMan, did, job.ID)
+	assert.True(t, errors.IsOfType(jobs.ErrJobCancelled, err))
+	m.AssertExpectations(t)
+	proc.AssertExpectations(t)
+	jobMan.AssertExpectations(t)
+}
@@ -24,6 +24,12 @@ const (
 
 	// BootstrappedAnchorProcessor is the key to bootstrapped anchor processor
 	BootstrappedAnchorProcessor = "BootstrappedAnchorProcessor"
+
+	// BootstrappedSignaturePolicyService is the key to bootstrapped signature policy service
+	BootstrappedSignaturePolicyService = "BootstrappedSignaturePolicyService"
+
+	// BootstrappedSignatureCollector is the key to bootstrapped signature collector
+	BootstrappedSignatureCollector = "BootstrappedSignatureCollector"
 )
 
 // Bootstrapper implements bootstrap.Bootstrapper.
@@ -64,7 +70,21 @@ func (Bootstrapper) Bootstrap(ctx map[string]interface{}) error {
 		return errors.New("transaction service not initialised")
 	}
 
-	ctx[BootstrappedDocumentService] = DefaultService(cfg, repo, anchorSrv, registry, didService, queueSrv, jobManager)
+	cfgService, ok := ctx[config.BootstrappedConfigStorage].(config.Service)
+	if !ok {
+		return errors.New("config service not initialised")
+	}
+
+	// the p2p client and the anchor processor of the collector are set by the PostBootstrapper
+	collector := newSignatureCollector(ldb, repo, cfg, cfgService, jobManager, didService)
+
+	// held signature requests wait for a decision for half of the time the requester waits for the signature
+	policySrv := NewSignaturePolicyService(ldb, cfg.GetP2PConnectionTimeout()/2, collector.sendApproved)
+	docSrv := DefaultService(cfg, repo, anchorSrv, registry, didService, queueSrv, jobManager, policySrv)
+	collector.docSrv = docSrv
+	ctx[BootstrappedDocumentService] = docSrv
+	ctx[BootstrappedSignaturePolicyService] = policySrv
+	ctx[BootstrappedSignatureCollector] = collector
 	ctx[BootstrappedRegistry] = registry
 	ctx[BootstrappedDocumentRepository] = repo
 	return nil
@@ -110,7 +130,14 @@ func (PostBootstrapper) Bootstrap(ctx map[string]interface{}) error {
 		return errors.New("identity service not initialized")
 	}
 
-	dp := DefaultProcessor(didService, p2pClient, anchorSrv, cfg)
+	collector, ok := ctx[BootstrappedSignatureCollector].(*signatureCollector)
+	if !ok {
+		return errors.New("signature collector not initialised")
+	}
+
+	dp := DefaultProcessor(didService, p2pClient, anchorSrv, cfg, collector)
+	collector.client = p2pClient
+	collector.processor = dp
 	ctx[BootstrappedAnchorProcessor] = dp
 
 	jobManager := ctx[jobs.BootstrappedService].(jobs.Manager)
@@ -125,5 +152,6 @@ func (PostBootstrapper) Bootstrap(ctx map[string]interface{}) error {
 	}
 
 	queueSrv.RegisterTaskType(documentAnchorTaskName, anchorTask)
+	jobManager.RegisterRetrier(documentAnchorTaskName, retryAnchorJob(queueSrv))
 	return nil
 }
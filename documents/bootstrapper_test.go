@@ -4,9 +4,12 @@ package documents
 
 import (
 	"testing"
+	"time"
 
 	"github.com/centrifuge/go-centrifuge/anchors"
 	"github.com/centrifuge/go-centrifuge/bootstrap"
+	"github.com/centrifuge/go-centrifuge/config"
+	"github.com/centrifuge/go-centrifuge/config/configstore"
 	"github.com/centrifuge/go-centrifuge/identity"
 	"github.com/centrifuge/go-centrifuge/jobs"
 	"github.com/centrifuge/go-centrifuge/jobs/jobsv1"
@@ -26,9 +29,12 @@ func TestBootstrapper_Bootstrap(t *testing.T) {
 	db, err := leveldb.NewLevelDBStorage(randomPath)
 	assert.Nil(t, err)
 	repo := leveldb.NewLevelDBRepository(db)
-	ctx[bootstrap.BootstrappedConfig] = &testingconfig.MockConfig{}
+	cfg := new(testingconfig.MockConfig)
+	cfg.On("GetP2PConnectionTimeout").Return(time.Second).Once()
+	ctx[bootstrap.BootstrappedConfig] = cfg
+	ctx[config.BootstrappedConfigStorage] = new(configstore.MockService)
 	ctx[storage.BootstrappedDB] = repo
-	ctx[jobs.BootstrappedService] = jobsv1.NewManager(&testingconfig.MockConfig{}, jobsv1.NewRepository(repo))
+	ctx[jobs.BootstrappedService] = jobsv1.NewManager(&testingconfig.MockConfig{}, jobsv1.NewRepository(repo), nil)
 	ctx[anchors.BootstrappedAnchorService] = new(testinganchors.MockAnchorService)
 	ctx[identity.BootstrappedDIDService] = new(testingcommons.MockIdentityService)
 	ctx[jobs.BootstrappedService] = new(testingjobs.MockJobManager)
@@ -39,4 +45,7 @@ func TestBootstrapper_Bootstrap(t *testing.T) {
 	assert.NotNil(t, ctx[BootstrappedRegistry])
 	_, ok := ctx[BootstrappedRegistry].(*ServiceRegistry)
 	assert.True(t, ok)
+	_, ok = ctx[BootstrappedSignatureCollector].(SignatureCollector)
+	assert.True(t, ok)
+	cfg.AssertExpectations(t)
 }
@@ -52,8 +52,8 @@ func TestMain(m *testing.M) {
 		&testlogging.TestLoggingBootstrapper{},
 		&config.Bootstrapper{},
 		&leveldb.Bootstrapper{},
-		&configstore.Bootstrapper{},
 		jobsv1.Bootstrapper{},
+		&configstore.Bootstrapper{},
 		&queue.Bootstrapper{},
 		&anchors.Bootstrapper{},
 		&Bootstrapper{},
@@ -3,78 +3,15 @@
 package documents_test
 
 import (
-	"context"
 	"errors"
 	"testing"
 
-	"github.com/centrifuge/centrifuge-protobufs/gen/go/coredocument"
-	"github.com/centrifuge/centrifuge-protobufs/gen/go/p2p"
 	"github.com/centrifuge/go-centrifuge/documents"
-	"github.com/centrifuge/go-centrifuge/identity"
 	"github.com/centrifuge/go-centrifuge/testingutils/config"
 	"github.com/centrifuge/go-centrifuge/utils"
 	"github.com/stretchr/testify/assert"
-	"github.com/stretchr/testify/mock"
 )
 
-type mockAnchorProcessor struct {
-	mock.Mock
-}
-
-func (m *mockAnchorProcessor) PreAnchorDocument(ctx context.Context, model documents.Model) error {
-	args := m.Called(ctx, model)
-	return args.Error(0)
-}
-
-func (m *mockAnchorProcessor) Send(ctx context.Context, cd coredocumentpb.CoreDocument, recipient identity.DID) (err error) {
-	args := m.Called(ctx, cd, recipient)
-	return args.Error(0)
-}
-
-func (m *mockAnchorProcessor) Anchor(
-	ctx context.Context,
-	coreDocument *coredocumentpb.CoreDocument,
-	saveState func(*coredocumentpb.CoreDocument) error) (err error) {
-	args := m.Called(ctx, coreDocument, saveState)
-	if saveState != nil {
-		err := saveState(coreDocument)
-		if err != nil {
-			return err
-		}
-	}
-	return args.Error(0)
-}
-
-func (m *mockAnchorProcessor) PrepareForSignatureRequests(ctx context.Context, model documents.Model) error {
-	args := m.Called(model)
-	return args.Error(0)
-}
-
-func (m *mockAnchorProcessor) RequestSignatures(ctx context.Context, model documents.Model) error {
-	args := m.Called(ctx, model)
-	return args.Error(0)
-}
-
-func (m *mockAnchorProcessor) PrepareForAnchoring(model documents.Model) error {
-	args := m.Called(model)
-	return args.Error(0)
-}
-
-func (m *mockAnchorProcessor) AnchorDocument(ctx context.Context, model documents.Model) error {
-	args := m.Called(model)
-	return args.Error(0)
-}
-
-func (m *mockAnchorProcessor) SendDocument(ctx context.Context, model documents.Model) error {
-	args := m.Called(ctx, model)
-	return args.Error(0)
-}
-
-func (m *mockAnchorProcessor) RequestDocumentWithAccessToken(ctx context.Context, tokenIdentifier, entityIdentifier, entityRelationIdentifier []byte) (*p2ppb.GetDocumentResponse, error) {
-	args := m.Called(ctx, tokenIdentifier, entityIdentifier, entityRelationIdentifier)
-	return args.Get(0).(*p2ppb.GetDocumentResponse), args.Error(0)
-}
-
 func TestAnchorDocument(t *testing.T) {
 	ctxh := testingconfig.CreateAccountContext(t, cfg)
 	updater := func(id []byte, model documents.Model) error {
@@ -85,7 +22,7 @@ func TestAnchorDocument(t *testing.T) {
 	id := utils.RandomSlice(32)
 	m := &documents.MockModel{}
 	m.On("CurrentVersion").Return(id).Once()
-	proc := &mockAnchorProcessor{}
+	proc := &documents.MockAnchorProcessor{}
 	proc.On("PrepareForSignatureRequests", m).Return(errors.New("error")).Once()
 	model, err := documents.AnchorDocument(ctxh, m, proc, updater, false)
 	m.AssertExpectations(t)
@@ -97,7 +34,7 @@ func TestAnchorDocument(t *testing.T) {
 	// request signatures failed
 	m = &documents.MockModel{}
 	m.On("CurrentVersion").Return(id).Once()
-	proc = &mockAnchorProcessor{}
+	proc = &documents.MockAnchorProcessor{}
 	proc.On("PrepareForSignatureRequests", m).Return(nil).Once()
 	proc.On("RequestSignatures", ctxh, m).Return(errors.New("error")).Once()
 	proc.On("PreAnchorDocument", ctxh, m).Return(nil).Once()
@@ -111,7 +48,7 @@ func TestAnchorDocument(t *testing.T) {
 	// prepare for anchoring fails
 	m = &documents.MockModel{}
 	m.On("CurrentVersion").Return(id).Once()
-	proc = &mockAnchorProcessor{}
+	proc = &documents.MockAnchorProcessor{}
 	proc.On("PrepareForSignatureRequests", m).Return(nil).Once()
 	proc.On("RequestSignatures", ctxh, m).Return(nil).Once()
 	proc.On("PrepareForAnchoring", m).Return(errors.New("error")).Once()
@@ -126,7 +63,7 @@ func TestAnchorDocument(t *testing.T) {
 	// anchor fails
 	m = &documents.MockModel{}
 	m.On("CurrentVersion").Return(id).Once()
-	proc = &mockAnchorProcessor{}
+	proc = &documents.MockAnchorProcessor{}
 	proc.On("PrepareForSignatureRequests", m).Return(nil).Once()
 	proc.On("RequestSignatures", ctxh, m).Return(nil).Once()
 	proc.On("PrepareForAnchoring", m).Return(nil).Once()
@@ -143,7 +80,7 @@ func TestAnchorDocument(t *testing.T) {
 	m = &documents.MockModel{}
 	m.On("CurrentVersion").Return(id).Once()
 	m.On("SetStatus", documents.Committed).Return(nil)
-	proc = &mockAnchorProcessor{}
+	proc = &documents.MockAnchorProcessor{}
 	proc.On("PrepareForSignatureRequests", m).Return(nil).Once()
 	proc.On("RequestSignatures", ctxh, m).Return(nil).Once()
 	proc.On("PrepareForAnchoring", m).Return(nil).Once()
@@ -161,7 +98,7 @@ func TestAnchorDocument(t *testing.T) {
 	m = &documents.MockModel{}
 	m.On("CurrentVersion").Return(id).Once()
 	m.On("SetStatus", documents.Committed).Return(nil)
-	proc = &mockAnchorProcessor{}
+	proc = &documents.MockAnchorProcessor{}
 	proc.On("PrepareForSignatureRequests", m).Return(nil).Once()
 	proc.On("RequestSignatures", ctxh, m).Return(nil).Once()
 	proc.On("PrepareForAnchoring", m).Return(nil).Once()
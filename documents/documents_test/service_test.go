@@ -21,6 +21,7 @@ import (
 	"github.com/centrifuge/go-centrifuge/ethereum"
 	"github.com/centrifuge/go-centrifuge/identity"
 	"github.com/centrifuge/go-centrifuge/jobs"
+	"github.com/centrifuge/go-centrifuge/notification"
 	"github.com/centrifuge/go-centrifuge/storage/leveldb"
 	"github.com/centrifuge/go-centrifuge/testingutils/commons"
 	"github.com/centrifuge/go-centrifuge/testingutils/config"
@@ -57,7 +58,7 @@ func TestMain(m *testing.M) {
 }
 
 func TestService_ReceiveAnchoredDocument(t *testing.T) {
-	srv := documents.DefaultService(cfg, nil, nil, documents.NewServiceRegistry(), nil, nil, nil)
+	srv := documents.DefaultService(cfg, nil, nil, documents.NewServiceRegistry(), nil, nil, nil, nil)
 
 	// self failed
 	err := srv.ReceiveAnchoredDocument(context.Background(), nil, did)
@@ -88,7 +89,7 @@ func TestService_ReceiveAnchoredDocument(t *testing.T) {
 	nextAid, err := anchors.ToAnchorID(doc.NextVersion())
 	ar.On("GetAnchorData", nextAid).Return(zeroRoot, time.Now(), errors.New("missing"))
 	ar.On("GetAnchorData", mock.Anything).Return(dr, time.Now(), nil)
-	srv = documents.DefaultService(cfg, testRepo(), ar, documents.NewServiceRegistry(), idSrv, nil, nil)
+	srv = documents.DefaultService(cfg, testRepo(), ar, documents.NewServiceRegistry(), idSrv, nil, nil, nil)
 	err = srv.ReceiveAnchoredDocument(ctxh, doc, did)
 	assert.Error(t, err)
 	assert.True(t, errors.IsOfType(documents.ErrDocumentPersistence, err))
@@ -108,7 +109,7 @@ func TestService_ReceiveAnchoredDocument(t *testing.T) {
 	assert.NoError(t, err)
 	ar.On("GetAnchorData", nextAid).Return(zeroRoot, time.Now(), errors.New("missing"))
 	ar.On("GetAnchorData", mock.Anything).Return(dr, time.Now(), nil)
-	srv = documents.DefaultService(cfg, testRepo(), ar, documents.NewServiceRegistry(), idSrv, nil, nil)
+	srv = documents.DefaultService(cfg, testRepo(), ar, documents.NewServiceRegistry(), idSrv, nil, nil, nil)
 	err = srv.ReceiveAnchoredDocument(ctxh, doc, did)
 	assert.NoError(t, err)
 	ar.AssertExpectations(t)
@@ -147,7 +148,7 @@ func TestService_ReceiveAnchoredDocument(t *testing.T) {
 	ar.On("GetAnchorData", nextAid).Return(zeroRoot, time.Now(), errors.New("missing"))
 	ar.On("GetAnchorData", mock.Anything).Return(dr, time.Now(), nil)
 
-	srv = documents.DefaultService(cfg, testRepo(), ar, documents.NewServiceRegistry(), idSrv, nil, nil)
+	srv = documents.DefaultService(cfg, testRepo(), ar, documents.NewServiceRegistry(), idSrv, nil, nil, nil)
 	err = srv.ReceiveAnchoredDocument(ctxh, doc, id2)
 	assert.NoError(t, err)
 	ar.AssertExpectations(t)
@@ -159,7 +160,7 @@ func getServiceWithMockedLayers() (documents.Service, testingcommons.MockIdentit
 	idService := testingcommons.MockIdentityService{}
 	idService.On("ValidateSignature", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil).Once()
 	mockAnchor = &mockAnchorRepo{}
-	return documents.DefaultService(cfg, repo, mockAnchor, documents.NewServiceRegistry(), &idService, nil, nil), idService
+	return documents.DefaultService(cfg, repo, mockAnchor, documents.NewServiceRegistry(), &idService, nil, nil, nil), idService
 }
 
 type mockAnchorRepo struct {
@@ -257,18 +258,21 @@ func TestService_RequestDocumentSignature(t *testing.T) {
 	assert.True(t, errors.IsOfType(documents.ErrDocumentNil, err))
 
 	// missing previous version, transition not validated - success
+	sub := notification.GetBroker().Subscribe(did.String(), notification.Filter{})
+	defer notification.GetBroker().Unsubscribe(sub)
 	id := testingidentity.GenerateRandomDID()
 	doc, _ := createCDWithEmbeddedDocument(t, ctxh, []identity.DID{id}, true)
 	sigs, err := srv.RequestDocumentSignature(ctxh, doc, did)
 	assert.NoError(t, err)
 	assert.False(t, sigs[0].TransitionValidated)
+	assertEvents(t, sub, notification.SignatureRequested, notification.SignatureGiven)
 
 	// add doc to repo
 	id = testingidentity.GenerateRandomDID()
 	doc, _ = createCDWithEmbeddedDocument(t, ctxh, []identity.DID{id}, false)
 	idSrv := new(testingcommons.MockIdentityService)
 	idSrv.On("ValidateSignature", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil)
-	srv = documents.DefaultService(cfg, testRepo(), mockAnchor, documents.NewServiceRegistry(), idSrv, nil, nil)
+	srv = documents.DefaultService(cfg, testRepo(), mockAnchor, documents.NewServiceRegistry(), idSrv, nil, nil, nil)
 
 	// prepare a new version
 	err = doc.AddNFT(true, testingidentity.GenerateRandomDID().ToAddress(), utils.RandomSlice(32))
@@ -294,6 +298,29 @@ func TestService_RequestDocumentSignature(t *testing.T) {
 	sigs, err = srv.RequestDocumentSignature(ctxh, doc, did)
 	assert.NoError(t, err)
 	assert.True(t, sigs[0].TransitionValidated)
+	assertEvents(t, sub, notification.SignatureRequested, notification.DocumentChanged, notification.SignatureGiven)
+}
+
+// assertEvents asserts that the subscription receives the events of the types, in any order.
+func assertEvents(t *testing.T, sub *notification.Subscription, types ...notification.EventType) {
+	want := make(map[notification.EventType]int)
+	for _, et := range types {
+		want[et]++
+	}
+
+	for i := 0; i < len(types); i++ {
+		select {
+		case msg := <-sub.Events():
+			want[msg.EventType]--
+		case <-time.After(5 * time.Second):
+			assert.Fail(t, "timed out waiting for the events")
+			return
+		}
+	}
+
+	for et, n := range want {
+		assert.Equal(t, 0, n, et.String())
+	}
 }
 
 func TestService_CreateProofsForVersionDocumentDoesntExist(t *testing.T) {
@@ -485,7 +512,7 @@ func TestService_CreateModel(t *testing.T) {
 	invSrv.On("CreateModel", mock.Anything, mock.Anything).Return(m, jobs.NewJobID(), nil).Once()
 	err := reg.Register("generic", invSrv)
 	assert.NoError(t, err)
-	srv := documents.DefaultService(cfg, nil, nil, reg, nil, nil, nil)
+	srv := documents.DefaultService(cfg, nil, nil, reg, nil, nil, nil, nil)
 
 	// unknown scheme
 	payload := documents.CreatePayload{Scheme: "invalid_scheme"}
@@ -508,7 +535,7 @@ func TestService_UpdateModel(t *testing.T) {
 	invSrv.On("UpdateModel", mock.Anything, mock.Anything).Return(m, jobs.NewJobID(), nil).Once()
 	err := reg.Register("generic", invSrv)
 	assert.NoError(t, err)
-	srv := documents.DefaultService(cfg, nil, nil, reg, nil, nil, nil)
+	srv := documents.DefaultService(cfg, nil, nil, reg, nil, nil, nil, nil)
 
 	// unknown scheme
 	payload := documents.UpdatePayload{CreatePayload: documents.CreatePayload{Scheme: "unknown_service"}}
@@ -338,6 +338,31 @@ func (e *Entity) CollaboratorCanUpdate(updated documents.Model, collaborator ide
 	return documents.ValidateTransitions(rules, cf)
 }
 
+// ChangedFields returns the fields that are changed in the updated entity.
+func (e *Entity) ChangedFields(updated documents.Model) ([]documents.ChangedField, error) {
+	n, ok := updated.(*Entity)
+	if !ok {
+		return nil, errors.NewTypedError(documents.ErrDocumentInvalidType, errors.New("expecting an entity but got %T", updated))
+	}
+
+	cf, err := e.CoreDocument.ChangedCoreFields(n.CoreDocument, e.DocumentType())
+	if err != nil {
+		return nil, err
+	}
+
+	oldTree, err := e.getDocumentDataTree()
+	if err != nil {
+		return nil, err
+	}
+
+	newTree, err := n.getDocumentDataTree()
+	if err != nil {
+		return nil, err
+	}
+
+	return append(cf, documents.GetChangedFields(oldTree, newTree)...), nil
+}
+
 // AddAttributes adds attributes to the Entity model.
 func (e *Entity) AddAttributes(ca documents.CollaboratorsAccess, prepareNewVersion bool, attrs ...documents.Attribute) error {
 	ncd, err := e.CoreDocument.AddAttributes(ca, prepareNewVersion, compactPrefix(), attrs...)
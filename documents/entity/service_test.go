@@ -40,7 +40,7 @@ func getServiceWithMockedLayers() (testingcommons.MockIdentityService, *testingc
 	repo := testRepo()
 	anchorSrv := &testinganchors.MockAnchorService{}
 	anchorSrv.On("GetAnchorData", mock.Anything).Return(nil, errors.New("missing"))
-	docSrv := documents.DefaultService(cfg, repo, anchorSrv, documents.NewServiceRegistry(), &idService, nil, nil)
+	docSrv := documents.DefaultService(cfg, repo, anchorSrv, documents.NewServiceRegistry(), &idService, nil, nil, nil)
 	return idService, idFactory, DefaultService(
 		docSrv,
 		repo,
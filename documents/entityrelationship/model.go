@@ -235,6 +235,31 @@ func (e *EntityRelationship) CollaboratorCanUpdate(updated documents.Model, iden
 	return nil
 }
 
+// ChangedFields returns the fields that are changed in the updated entity relationship.
+func (e *EntityRelationship) ChangedFields(updated documents.Model) ([]documents.ChangedField, error) {
+	n, ok := updated.(*EntityRelationship)
+	if !ok {
+		return nil, errors.NewTypedError(documents.ErrDocumentInvalidType, errors.New("expecting an entity relationship but got %T", updated))
+	}
+
+	cf, err := e.CoreDocument.ChangedCoreFields(n.CoreDocument, e.DocumentType())
+	if err != nil {
+		return nil, err
+	}
+
+	oldTree, err := e.getDocumentDataTree()
+	if err != nil {
+		return nil, err
+	}
+
+	newTree, err := n.getDocumentDataTree()
+	if err != nil {
+		return nil, err
+	}
+
+	return append(cf, documents.GetChangedFields(oldTree, newTree)...), nil
+}
+
 // AddAttributes adds attributes to the EntityRelationship model.
 func (e *EntityRelationship) AddAttributes(ca documents.CollaboratorsAccess, prepareNewVersion bool, attrs ...documents.Attribute) error {
 	ncd, err := e.CoreDocument.AddAttributes(ca, prepareNewVersion, compactPrefix(), attrs...)
@@ -3,6 +3,7 @@ package entityrelationship
 import (
 	"context"
 	"encoding/json"
+	"time"
 
 	"github.com/centrifuge/centrifuge-protobufs/gen/go/coredocument"
 	"github.com/centrifuge/go-centrifuge/anchors"
@@ -11,7 +12,9 @@ import (
 	"github.com/centrifuge/go-centrifuge/errors"
 	"github.com/centrifuge/go-centrifuge/identity"
 	"github.com/centrifuge/go-centrifuge/jobs"
+	"github.com/centrifuge/go-centrifuge/notification"
 	"github.com/centrifuge/go-centrifuge/queue"
+	"github.com/ethereum/go-ethereum/common/hexutil"
 )
 
 // Service defines specific functions for entity
@@ -108,6 +111,8 @@ func (s service) Create(ctx context.Context, relationship documents.Model) (docu
 	if err != nil {
 		return nil, jobs.NilJobID(), nil, err
 	}
+
+	notifyAccessToken(ctx, notification.AccessTokenGranted, selfDID, relationship, jobID)
 	return relationship, jobID, done, nil
 }
 
@@ -134,9 +139,35 @@ func (s service) Update(ctx context.Context, updated documents.Model) (documents
 	if err != nil {
 		return nil, jobs.NilJobID(), nil, err
 	}
+
+	notifyAccessToken(ctx, notification.AccessTokenRevoked, selfDID, updated, jobID)
 	return updated, jobID, done, nil
 }
 
+// notifyAccessToken sends the access token event of the relationship in the background.
+func notifyAccessToken(ctx context.Context, eventType notification.EventType, selfDID identity.DID, model documents.Model, jobID jobs.JobID) {
+	er, ok := model.(*EntityRelationship)
+	if !ok || er.Data.TargetIdentity == nil {
+		return
+	}
+
+	notification.Notify(ctx, notification.Message{
+		EventType:    eventType,
+		AccountID:    selfDID.String(),
+		FromID:       selfDID.String(),
+		ToID:         er.Data.TargetIdentity.String(),
+		Recorded:     time.Now().UTC(),
+		DocumentType: er.DocumentType(),
+		DocumentID:   hexutil.Encode(er.ID()),
+		Scheme:       er.Scheme(),
+		Data: notification.AccessTokenData{
+			Grantee:  er.Data.TargetIdentity.String(),
+			EntityID: er.Data.EntityIdentifier.String(),
+			JobID:    jobID.String(),
+		},
+	})
+}
+
 // GetEntityRelationships returns the latest versions of the entity relationships that involve the entityID passed in
 func (s service) GetEntityRelationships(ctx context.Context, entityID []byte) ([]documents.Model, error) {
 	var relationships []documents.Model
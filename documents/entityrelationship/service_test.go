@@ -5,12 +5,15 @@ package entityrelationship
 import (
 	"context"
 	"testing"
+	"time"
 
 	"github.com/centrifuge/centrifuge-protobufs/gen/go/coredocument"
+	"github.com/centrifuge/go-centrifuge/contextutil"
 	"github.com/centrifuge/go-centrifuge/documents"
 	"github.com/centrifuge/go-centrifuge/errors"
 	"github.com/centrifuge/go-centrifuge/identity"
 	"github.com/centrifuge/go-centrifuge/jobs"
+	"github.com/centrifuge/go-centrifuge/notification"
 	"github.com/centrifuge/go-centrifuge/testingutils"
 	"github.com/centrifuge/go-centrifuge/testingutils/anchors"
 	"github.com/centrifuge/go-centrifuge/testingutils/commons"
@@ -35,7 +38,7 @@ func getServiceWithMockedLayers() (testingcommons.MockIdentityService, *testingc
 	entityRepo := testEntityRepo()
 	anchorSrv := &testinganchors.MockAnchorService{}
 	anchorSrv.On("GetAnchorData", mock.Anything).Return(nil, errors.New("missing"))
-	docSrv := documents.DefaultService(cfg, entityRepo, anchorSrv, documents.NewServiceRegistry(), &idService, nil, nil)
+	docSrv := documents.DefaultService(cfg, entityRepo, anchorSrv, documents.NewServiceRegistry(), &idService, nil, nil, nil)
 	return idService, idFactory, DefaultService(
 		docSrv,
 		entityRepo,
@@ -66,23 +69,47 @@ func TestService_Update(t *testing.T) {
 	// create
 	idFactory.On("IdentityExists", mock.Anything).Return(true, nil)
 	relationship := CreateRelationship(t, ctxh)
+	selfDID, err := contextutil.AccountDID(ctxh)
+	assert.NoError(t, err)
+	sub := notification.GetBroker().Subscribe(selfDID.String(), notification.Filter{
+		Types:      []notification.EventType{notification.AccessTokenGranted, notification.AccessTokenRevoked},
+		DocumentID: hexutil.Encode(relationship.ID()),
+	})
+	defer notification.GetBroker().Unsubscribe(sub)
 
-	old, _, _, err := eSrv.Create(ctxh, relationship)
+	old, jobID, _, err := eSrv.Create(ctxh, relationship)
 	assert.NoError(t, err)
 	assert.True(t, testEntityRepo().Exists(did[:], old.ID()))
 	assert.True(t, testEntityRepo().Exists(did[:], old.CurrentVersion()))
+	assertAccessTokenEvent(t, sub, notification.AccessTokenGranted, relationship, jobID)
 
 	// derive update payload
 	m := new(EntityRelationship)
 	err = m.revokeRelationship(old.(*EntityRelationship), *relationship.Data.TargetIdentity)
 	assert.NoError(t, err)
 
-	updated, _, _, err := eSrv.Update(ctxh, m)
+	updated, jobID, _, err := eSrv.Update(ctxh, m)
 	assert.NoError(t, err)
 	assert.Equal(t, updated.PreviousVersion(), old.CurrentVersion())
 	assert.True(t, testEntityRepo().Exists(did[:], updated.ID()))
 	assert.True(t, testEntityRepo().Exists(did[:], updated.CurrentVersion()))
 	assert.True(t, testEntityRepo().Exists(did[:], updated.PreviousVersion()))
+	assertAccessTokenEvent(t, sub, notification.AccessTokenRevoked, relationship, jobID)
+}
+
+// assertAccessTokenEvent asserts that the subscription receives the access token event of the relationship.
+func assertAccessTokenEvent(t *testing.T, sub *notification.Subscription, eventType notification.EventType, er *EntityRelationship, jobID jobs.JobID) {
+	select {
+	case msg := <-sub.Events():
+		assert.Equal(t, eventType, msg.EventType)
+		assert.Equal(t, notification.AccessTokenData{
+			Grantee:  er.Data.TargetIdentity.String(),
+			EntityID: er.Data.EntityIdentifier.String(),
+			JobID:    jobID.String(),
+		}, msg.Data)
+	case <-time.After(5 * time.Second):
+		assert.Fail(t, "timed out waiting for the access token event")
+	}
 }
 
 func TestService_GetEntityRelationships(t *testing.T) {
@@ -53,6 +53,33 @@ const (
 	// ErrNotPatcher must be used if an expected patcher model does not support patching
 	ErrNotPatcher = errors.Error("document doesn't support patching")
 
+	// ErrInvalidSignaturePolicy must be used when a signature policy is invalid
+	ErrInvalidSignaturePolicy = errors.Error("invalid signature policy")
+
+	// ErrSignatureRequestRejected must be used when the signature policy or the account rejected the signature request
+	ErrSignatureRequestRejected = errors.Error("signature request rejected")
+
+	// ErrSignatureRequestHeld must be used when the signature request is held for approval
+	ErrSignatureRequestHeld = errors.Error("signature request is held for approval")
+
+	// ErrSignatureRequestNotFound must be used when the held signature request is not found
+	ErrSignatureRequestNotFound = errors.Error("signature request not found")
+
+	// ErrSignatureRequestNotPending must be used when a decided signature request is approved or rejected again
+	ErrSignatureRequestNotPending = errors.Error("signature request is not pending")
+
+	// ErrSignaturesPending must be used when the signatures of a document are still collected in the background
+	ErrSignaturesPending = errors.Error("signatures are collected in the background")
+
+	// ErrSignatureCollectionTimeout must be used when the required signatures are not collected in time
+	ErrSignatureCollectionTimeout = errors.Error("signature collection timed out")
+
+	// ErrSignatureCollectionNotFound must be used when no signatures are collected for the document version
+	ErrSignatureCollectionNotFound = errors.Error("signature collection not found")
+
+	// ErrSignatureCollectionClosed must be used when signatures are received for a completed signature collection
+	ErrSignatureCollectionClosed = errors.Error("signature collection is closed")
+
 	// Coredoc errors
 
 	// ErrCDCreate must be used for coredoc creation/generation errors
@@ -81,6 +108,9 @@ const (
 	// ErrNftNotFound must be used when the NFT is not found in the document
 	ErrNftNotFound = errors.Error("nft not found in the Document")
 
+	// ErrNFTOwnerUnknown must be used when the registry doesn't track a single owner of its tokens
+	ErrNFTOwnerUnknown = errors.Error("registry doesn't track the owner of tokens")
+
 	// ErrNftByteLength must be used when there is a byte length mismatch
 	ErrNftByteLength = errors.Error("byte length mismatch")
 
@@ -235,6 +235,31 @@ func (g *Generic) CollaboratorCanUpdate(updated documents.Model, collaborator id
 	return documents.ValidateTransitions(rules, cf)
 }
 
+// ChangedFields returns the fields that are changed in the updated generic document.
+func (g *Generic) ChangedFields(updated documents.Model) ([]documents.ChangedField, error) {
+	n, ok := updated.(*Generic)
+	if !ok {
+		return nil, errors.NewTypedError(documents.ErrDocumentInvalidType, errors.New("expecting a generic document but got %T", updated))
+	}
+
+	cf, err := g.CoreDocument.ChangedCoreFields(n.CoreDocument, g.DocumentType())
+	if err != nil {
+		return nil, err
+	}
+
+	oldTree, err := g.getDocumentDataTree()
+	if err != nil {
+		return nil, err
+	}
+
+	newTree, err := n.getDocumentDataTree()
+	if err != nil {
+		return nil, err
+	}
+
+	return append(cf, documents.GetChangedFields(oldTree, newTree)...), nil
+}
+
 // AddAttributes adds attributes to the Generic model.
 func (g *Generic) AddAttributes(ca documents.CollaboratorsAccess, prepareNewVersion bool, attrs ...documents.Attribute) error {
 	ncd, err := g.CoreDocument.AddAttributes(ca, prepareNewVersion, compactPrefix(), attrs...)
@@ -110,6 +110,9 @@ type Model interface {
 	// CollaboratorCanUpdate returns an error if indicated identity does not have the capacity to update the document.
 	CollaboratorCanUpdate(updated Model, collaborator identity.DID) error
 
+	// ChangedFields returns the core document and document data fields that are changed in the updated version.
+	ChangedFields(updated Model) ([]ChangedField, error)
+
 	// IsDIDCollaborator returns true if the did is a collaborator of the document
 	IsDIDCollaborator(did identity.DID) (bool, error)
 
@@ -171,9 +174,13 @@ type Model interface {
 
 // TokenRegistry defines NFT related functions.
 type TokenRegistry interface {
-	// OwnerOf to retrieve owner of the tokenID
+	// OwnerOf to retrieve owner of the tokenID.
+	// Returns ErrNFTOwnerUnknown if the registry doesn't track single owners.
 	OwnerOf(registry common.Address, tokenID []byte) (common.Address, error)
 
+	// IsOwner returns true if the address owns the tokenID
+	IsOwner(registry, owner common.Address, tokenID []byte) (bool, error)
+
 	// CurrentIndexOfToken get the current index of the token
 	CurrentIndexOfToken(registry common.Address, tokenID []byte) (*big.Int, error)
 }
@@ -22,6 +22,10 @@ type Config interface {
 	GetIdentityID() ([]byte, error)
 	GetP2PConnectionTimeout() time.Duration
 	GetContractAddress(contractName config.ContractName) common.Address
+	GetSignatureCollectionMode() string
+	GetSignatureQuorum() int
+	GetSignatureRetryInterval() time.Duration
+	GetSignatureCollectionTimeout() time.Duration
 }
 
 // DocumentRequestProcessor offers methods to interact with the p2p layer to request documents.
@@ -35,6 +39,12 @@ type Client interface {
 	// GetSignaturesForDocument gets the signatures for document
 	GetSignaturesForDocument(ctx context.Context, model Model) ([]*coredocumentpb.Signature, []error, error)
 
+	// GetSignaturesFromCollaborators gets the signatures for document from the given collaborators
+	GetSignaturesFromCollaborators(ctx context.Context, model Model, collaborators []identity.DID) ([]*coredocumentpb.Signature, []error, error)
+
+	// SendSignatures sends the signatures of a document version to the collaborator that requested them earlier
+	SendSignatures(ctx context.Context, receiverID identity.DID, version []byte, signatures []*coredocumentpb.Signature) error
+
 	// after all signatures are collected the sender sends the document including the signatures
 	SendAnchoredDocument(ctx context.Context, receiverID identity.DID, in *p2ppb.AnchorDocumentRequest) (*p2ppb.AnchorDocumentResponse, error)
 
@@ -48,15 +58,18 @@ type defaultProcessor struct {
 	p2pClient       Client
 	anchorSrv       anchors.Service
 	config          Config
+	collector       SignatureCollector
 }
 
 // DefaultProcessor returns the default implementation of CoreDocument AnchorProcessor
-func DefaultProcessor(idService identity.Service, p2pClient Client, anchorSrv anchors.Service, config Config) AnchorProcessor {
+// Signatures are requested directly from the collaborators if the collector is nil.
+func DefaultProcessor(idService identity.Service, p2pClient Client, anchorSrv anchors.Service, config Config, collector SignatureCollector) AnchorProcessor {
 	return defaultProcessor{
 		identityService: idService,
 		p2pClient:       p2pClient,
 		anchorSrv:       anchorSrv,
 		config:          config,
+		collector:       collector,
 	}
 }
 
@@ -121,8 +134,17 @@ func (dp defaultProcessor) RequestSignatures(ctx context.Context, model Model) e
 	}
 
 	// we ignore signature collection errors and anchor anyways
-	signs, _, err := dp.p2pClient.GetSignaturesForDocument(ctx, model)
+	var signs []*coredocumentpb.Signature
+	if dp.collector != nil {
+		signs, err = dp.collector.Collect(ctx, model)
+	} else {
+		signs, _, err = dp.p2pClient.GetSignaturesForDocument(ctx, model)
+	}
 	if err != nil {
+		if errors.IsOfType(ErrSignaturesPending, err) {
+			return err
+		}
+
 		return errors.New("failed to collect signatures from the collaborators: %v", err)
 	}
 
@@ -171,7 +171,7 @@ func (m *mockModel) GetAttributes() []Attribute {
 
 func TestDefaultProcessor_PrepareForSignatureRequests(t *testing.T) {
 	srv := &testingcommons.MockIdentityService{}
-	dp := DefaultProcessor(srv, nil, nil, cfg).(defaultProcessor)
+	dp := DefaultProcessor(srv, nil, nil, cfg, nil).(defaultProcessor)
 
 	ctxh := testingconfig.CreateAccountContext(t, cfg)
 
@@ -221,6 +221,12 @@ func (p *p2pClient) GetSignaturesForDocument(ctx context.Context, model Model) (
 	return sigs, nil, args.Error(1)
 }
 
+func (p *p2pClient) GetSignaturesFromCollaborators(ctx context.Context, model Model, collaborators []identity.DID) ([]*coredocumentpb.Signature, []error, error) {
+	args := p.Called(ctx, model, collaborators)
+	sigs, _ := args.Get(0).([]*coredocumentpb.Signature)
+	return sigs, nil, args.Error(1)
+}
+
 func (p *p2pClient) SendAnchoredDocument(ctx context.Context, receiverID identity.DID, in *p2ppb.AnchorDocumentRequest) (*p2ppb.AnchorDocumentResponse, error) {
 	args := p.Called(ctx, receiverID, in)
 	resp, _ := args.Get(0).(*p2ppb.AnchorDocumentResponse)
@@ -229,7 +235,7 @@ func (p *p2pClient) SendAnchoredDocument(ctx context.Context, receiverID identit
 
 func TestDefaultProcessor_RequestSignatures(t *testing.T) {
 	srv := &testingcommons.MockIdentityService{}
-	dp := DefaultProcessor(srv, nil, nil, cfg).(defaultProcessor)
+	dp := DefaultProcessor(srv, nil, nil, cfg, nil).(defaultProcessor)
 	ctxh := testingconfig.CreateAccountContext(t, cfg)
 
 	self, err := contextutil.Account(ctxh)
@@ -322,7 +328,7 @@ func TestDefaultProcessor_RequestSignatures(t *testing.T) {
 
 func TestDefaultProcessor_PrepareForAnchoring(t *testing.T) {
 	srv := &testingcommons.MockIdentityService{}
-	dp := DefaultProcessor(srv, nil, nil, cfg).(defaultProcessor)
+	dp := DefaultProcessor(srv, nil, nil, cfg, nil).(defaultProcessor)
 
 	ctxh := testingconfig.CreateAccountContext(t, cfg)
 	self, err := contextutil.Account(ctxh)
@@ -400,7 +406,7 @@ func (m mockAnchorService) GetAnchorData(anchorID anchors.AnchorID) (docRoot anc
 
 func TestDefaultProcessor_AnchorDocument(t *testing.T) {
 	srv := &testingcommons.MockIdentityService{}
-	dp := DefaultProcessor(srv, nil, nil, cfg).(defaultProcessor)
+	dp := DefaultProcessor(srv, nil, nil, cfg, nil).(defaultProcessor)
 	ctxh := testingconfig.CreateAccountContext(t, cfg)
 	self, err := contextutil.Account(ctxh)
 	assert.NoError(t, err)
@@ -498,7 +504,7 @@ func TestDefaultProcessor_AnchorDocument(t *testing.T) {
 func TestDefaultProcessor_SendDocument(t *testing.T) {
 	srv := &testingcommons.MockIdentityService{}
 	srv.On("ValidateSignature", mock.Anything, mock.Anything).Return(nil).Once()
-	dp := DefaultProcessor(srv, nil, nil, cfg).(defaultProcessor)
+	dp := DefaultProcessor(srv, nil, nil, cfg, nil).(defaultProcessor)
 	ctxh := testingconfig.CreateAccountContext(t, cfg)
 	self, err := contextutil.Account(ctxh)
 	assert.NoError(t, err)
@@ -132,13 +132,13 @@ func (cd *CoreDocument) NFTOwnerCanRead(tokenRegistry TokenRegistry, registry co
 		return ErrNftNotFound
 	}
 
-	// get the owner of the NFT
-	owner, err := tokenRegistry.OwnerOf(registry, tokenID)
+	// check the owner of the NFT
+	owned, err := tokenRegistry.IsOwner(registry, account.ToAddress(), tokenID)
 	if err != nil {
 		return errors.New("failed to get NFT owner: %v", err)
 	}
 
-	if !bytes.Equal(owner.Bytes(), account[:]) {
+	if !owned {
 		return errors.New("account (%v) not owner of the NFT", account.String())
 	}
 
@@ -170,8 +170,28 @@ func (cd *CoreDocument) addNFTToReadRules(registry common.Address, tokenID []byt
 	return nil
 }
 
+// removeNFTFromReadRules removes the NFT token from the roles of core document.
+// The roles are shared with the previous version, so the roles holding the token are replaced by copies.
+func (cd *CoreDocument) removeNFTFromReadRules(registry common.Address, tokenID []byte) {
+	roles := make([]*coredocumentpb.Role, len(cd.Document.Roles))
+	for i, role := range cd.Document.Roles {
+		roles[i] = role
+		idx, found := isNFTInRole(role, registry, tokenID)
+		if !found {
+			continue
+		}
+
+		nrole := *role
+		nrole.Nfts = append(append([][]byte(nil), role.Nfts[:idx]...), role.Nfts[idx+1:]...)
+		roles[i] = &nrole
+		cd.Modified = true
+	}
+
+	cd.Document.Roles = roles
+}
+
 // AddNFT returns a new CoreDocument model with nft added to the Core document. If grantReadAccess is true, the nft is added
-// to the read rules.
+// to the read rules. The token previously stored for the registry is replaced and loses its read access.
 func (cd *CoreDocument) AddNFT(grantReadAccess bool, registry common.Address, tokenID []byte) (*CoreDocument, error) {
 	ncd, err := cd.PrepareNewVersion(nil, CollaboratorsAccess{}, nil)
 	if err != nil {
@@ -185,6 +205,8 @@ func (cd *CoreDocument) AddNFT(grantReadAccess bool, registry common.Address, to
 		eb := make([]byte, 12, 12)
 		nft.RegistryId = append(registry.Bytes(), eb...)
 		ncd.Document.Nfts = append(ncd.Document.Nfts, nft)
+	} else if !bytes.Equal(nft.TokenId, tokenID) {
+		ncd.removeNFTFromReadRules(registry, nft.TokenId)
 	}
 	nft.TokenId = tokenID
 
@@ -205,6 +227,7 @@ func (cd *CoreDocument) NFTs() []*coredocumentpb.NFT {
 }
 
 // IsNFTMinted checks if the there is an NFT that is minted against this document in the given registry.
+// The stored NFT of a registry that doesn't track the owners of its tokens is considered minted.
 func (cd *CoreDocument) IsNFTMinted(tokenRegistry TokenRegistry, registry common.Address) bool {
 	nft := getStoredNFT(cd.Document.Nfts, registry.Bytes())
 	if nft == nil {
@@ -212,7 +235,7 @@ func (cd *CoreDocument) IsNFTMinted(tokenRegistry TokenRegistry, registry common
 	}
 
 	_, err := tokenRegistry.OwnerOf(registry, nft.TokenId)
-	return err == nil
+	return err == nil || errors.IsOfType(ErrNFTOwnerUnknown, err)
 }
 
 // CreateNFTProofs generate proofs returns proofs for NFT minting.
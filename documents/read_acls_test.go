@@ -73,6 +73,11 @@ func (m mockRegistry) OwnerOf(registry common.Address, tokenID []byte) (common.A
 	return addr, args.Error(1)
 }
 
+func (m mockRegistry) IsOwner(registry, owner common.Address, tokenID []byte) (bool, error) {
+	args := m.Called(registry, owner, tokenID)
+	return args.Bool(0), args.Error(1)
+}
+
 func (m mockRegistry) CurrentIndexOfToken(registry common.Address, tokenID []byte) (*big.Int, error) {
 	args := m.Called(registry, tokenID)
 	addr, _ := args.Get(0).(*big.Int)
@@ -117,20 +122,19 @@ func TestCoreDocument_NFTOwnerCanRead(t *testing.T) {
 	assert.Error(t, cd.NFTOwnerCanRead(nil, registry, tokenID, account))
 
 	tr := mockRegistry{}
-	tr.On("OwnerOf", registry, tokenID).Return(nil, errors.New("failed to get owner of")).Once()
+	owner := account.ToAddress()
+	tr.On("IsOwner", registry, owner, tokenID).Return(false, errors.New("failed to get owner of")).Once()
 	assert.NoError(t, cd.addNFTToReadRules(registry, tokenID))
 	assert.Error(t, cd.NFTOwnerCanRead(tr, registry, tokenID, account))
 	tr.AssertExpectations(t)
 
-	// not the same owner
-	owner := common.BytesToAddress(utils.RandomSlice(20))
-	tr.On("OwnerOf", registry, tokenID).Return(owner, nil).Once()
+	// not the owner
+	tr.On("IsOwner", registry, owner, tokenID).Return(false, nil).Once()
 	assert.Error(t, cd.NFTOwnerCanRead(tr, registry, tokenID, account))
 	tr.AssertExpectations(t)
 
-	// same owner
-	owner = account.ToAddress()
-	tr.On("OwnerOf", registry, tokenID).Return(owner, nil).Once()
+	// owner, also of registries that don't track single owners
+	tr.On("IsOwner", registry, owner, tokenID).Return(true, nil).Once()
 	assert.NoError(t, cd.NFTOwnerCanRead(tr, registry, tokenID, account))
 	tr.AssertExpectations(t)
 }
@@ -155,6 +159,9 @@ func TestCoreDocumentModel_AddNFT(t *testing.T) {
 	assert.Len(t, cd.Document.Roles, 1)
 	assert.Len(t, cd.Document.Roles[0].Nfts, 1)
 
+	// the replaced token loses its read access, also in the new version only
+	old := cd
+	oldTokenID := tokenID
 	tokenID = utils.RandomSlice(32)
 	cd, err = cd.AddNFT(true, registry, tokenID)
 	assert.Nil(t, err)
@@ -164,7 +171,25 @@ func TestCoreDocumentModel_AddNFT(t *testing.T) {
 	assert.Nil(t, getStoredNFT(cd.Document.Nfts, registry2.Bytes()))
 	assert.Len(t, cd.Document.ReadRules, 2)
 	assert.Len(t, cd.Document.Roles, 2)
+	assert.Len(t, cd.Document.Roles[0].Nfts, 0)
 	assert.Len(t, cd.Document.Roles[1].Nfts, 1)
+	_, err = getReadAccessProofKeys(cd.Document, registry, oldTokenID)
+	assert.Error(t, err)
+	_, err = getReadAccessProofKeys(cd.Document, registry, tokenID)
+	assert.NoError(t, err)
+	_, err = getReadAccessProofKeys(old.Document, registry, oldTokenID)
+	assert.NoError(t, err)
+
+	// replaced without read access
+	oldTokenID = tokenID
+	tokenID = utils.RandomSlice(32)
+	cd, err = cd.AddNFT(false, registry, tokenID)
+	assert.Nil(t, err)
+	assert.Len(t, cd.Document.ReadRules, 2)
+	_, err = getReadAccessProofKeys(cd.Document, registry, oldTokenID)
+	assert.Error(t, err)
+	_, err = getReadAccessProofKeys(cd.Document, registry, tokenID)
+	assert.Error(t, err)
 }
 
 func TestCoreDocument_IsNFTMinted(t *testing.T) {
@@ -182,6 +207,16 @@ func TestCoreDocument_IsNFTMinted(t *testing.T) {
 	tr.On("OwnerOf", registry, tokenID).Return(owner, nil).Once()
 	assert.True(t, cd.IsNFTMinted(tr, registry))
 	tr.AssertExpectations(t)
+
+	// registry doesn't track the owner
+	tr.On("OwnerOf", registry, tokenID).Return(nil, ErrNFTOwnerUnknown).Once()
+	assert.True(t, cd.IsNFTMinted(tr, registry))
+	tr.AssertExpectations(t)
+
+	// token doesn't exist
+	tr.On("OwnerOf", registry, tokenID).Return(nil, errors.New("nonexistent token")).Once()
+	assert.False(t, cd.IsNFTMinted(tr, registry))
+	tr.AssertExpectations(t)
 }
 
 func TestCoreDocument_getReadAccessProofKeys(t *testing.T) {
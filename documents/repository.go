@@ -59,6 +59,9 @@ type Repository interface {
 	// should error out if the document exists.
 	Create(accountID, id []byte, model Model) error
 
+	// CreateWithBatch creates the model like Create, together with the writes of the batch, atomically.
+	CreateWithBatch(accountID, id []byte, model Model, batch *storage.Batch) error
+
 	// Update strictly updates the model.
 	// Will error out when the model doesn't exist in the DB.
 	Update(accountID, id []byte, model Model) error
@@ -114,23 +117,28 @@ func (r *repo) Get(accountID, id []byte) (Model, error) {
 // Create creates the model if not present in the DB.
 // should error out if the document exists.
 func (r *repo) Create(accountID, id []byte, model Model) error {
-	key := r.getKey(accountID, id)
-	if err := r.db.Create(key, model); err != nil {
-		return err
+	return r.CreateWithBatch(accountID, id, model, storage.NewBatch())
+}
+
+// CreateWithBatch creates the model like Create, together with the writes of the batch, atomically.
+// The writes of the model are added to the batch after the existing writes.
+func (r *repo) CreateWithBatch(accountID, id []byte, model Model, batch *storage.Batch) error {
+	if batch == nil {
+		batch = storage.NewBatch()
 	}
 
-	return r.updateLatestIndex(accountID, model)
+	batch.Create(r.getKey(accountID, id), model)
+	r.updateLatestIndex(batch, accountID, model)
+	return r.db.Write(batch)
 }
 
 // Update strictly updates the model.
 // Will error out when the model doesn't exist in the DB.
 func (r *repo) Update(accountID, id []byte, model Model) error {
-	key := r.getKey(accountID, id)
-	if err := r.db.Update(key, model); err != nil {
-		return err
-	}
-
-	return r.updateLatestIndex(accountID, model)
+	batch := storage.NewBatch()
+	batch.Update(r.getKey(accountID, id), model)
+	r.updateLatestIndex(batch, accountID, model)
+	return r.db.Write(batch)
 }
 
 // GetLatest returns thee latest version of the document.
@@ -171,10 +179,10 @@ func (r *repo) getLatestKey(accountID, docID []byte) []byte {
 	return append([]byte(LatestPrefix), []byte(hexKey)...)
 }
 
-// storeLatestIndex stores the latestVersion to db.
+// storeLatestIndex adds the write of the latestVersion to the batch.
 // If update is true, it is assumed that index is overwritten
 // else, index is created first time.
-func (r *repo) storeLatestIndex(key []byte, model Model, update bool) error {
+func (r *repo) storeLatestIndex(batch *storage.Batch, key []byte, model Model, update bool) {
 	lv := &latestVersion{
 		CurrentVersion: model.CurrentVersion(),
 		NextVersion:    model.NextVersion(),
@@ -188,13 +196,14 @@ func (r *repo) storeLatestIndex(key []byte, model Model, update bool) error {
 	lv.Timestamp = tm
 
 	if update {
-		return r.db.Update(key, lv)
+		batch.Update(key, lv)
+		return
 	}
 
-	return r.db.Create(key, lv)
+	batch.Create(key, lv)
 }
 
-// updateLatestIndex updates the latest version index.
+// updateLatestIndex adds the update of the latest version index to the batch.
 // We check if the latest index is present for a model.
 // If not found, create a latest index and return.
 // Note: anchor timestamp is not available immediately, so don't error out if the timestamp is empty
@@ -203,16 +212,18 @@ func (r *repo) storeLatestIndex(key []byte, model Model, update bool) error {
 // If not matches, check the model timestamp is greater than stored timestamp.
 // If greater update the latestVersion and return
 // If not, skip update and return.
-func (r *repo) updateLatestIndex(accID []byte, model Model) error {
+func (r *repo) updateLatestIndex(batch *storage.Batch, accID []byte, model Model) {
 	key := r.getLatestKey(accID, model.ID())
 	lv, err := r.getLatest(key)
 	if err != nil {
 		// no index is created yet. create one
-		return r.storeLatestIndex(key, model, false)
+		r.storeLatestIndex(batch, key, model, false)
+		return
 	}
 
 	if bytes.Equal(lv.NextVersion, model.CurrentVersion()) {
-		return r.storeLatestIndex(key, model, true)
+		r.storeLatestIndex(batch, key, model, true)
+		return
 	}
 
 	// compare timestamps
@@ -223,9 +234,8 @@ func (r *repo) updateLatestIndex(accID []byte, model Model) error {
 
 	if lv.Timestamp.Before(ts) {
 		// newer version found. so update
-		return r.storeLatestIndex(key, model, true)
+		r.storeLatestIndex(batch, key, model, true)
 	}
 
-	// must be an old version.
-	return nil
+	// otherwise must be an old version.
 }
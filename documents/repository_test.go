@@ -84,6 +84,32 @@ func TestLevelDBRepo_Create_Exists(t *testing.T) {
 	assert.Error(t, err, "Create: must not overwrite existing doc")
 }
 
+func TestRepo_CreateWithBatch(t *testing.T) {
+	r := getRepository(ctx)
+	db := ctx[storage.BootstrappedDB].(storage.Repository)
+	accountID, id := utils.RandomSlice(32), utils.RandomSlice(32)
+	d := &doc{SomeString: "Hello, World!", DocID: id, Current: id}
+	other := append([]byte("other_"), utils.RandomSlice(32)...)
+	assert.NoError(t, db.Create(other, d))
+
+	// failed batch, nothing written
+	batch := storage.NewBatch()
+	batch.Create(other, d)
+	err := r.CreateWithBatch(accountID, id, d, batch)
+	assert.True(t, errors.IsOfType(storage.ErrRepositoryModelCreateKeyExists, err))
+	assert.False(t, r.Exists(accountID, id))
+	assert.False(t, db.Exists(r.(*repo).getLatestKey(accountID, id)))
+
+	// document, index and batch written
+	batch = storage.NewBatch()
+	batch.Delete(other)
+	err = r.CreateWithBatch(accountID, id, d, batch)
+	assert.NoError(t, err)
+	assert.True(t, r.Exists(accountID, id))
+	assert.True(t, db.Exists(r.(*repo).getLatestKey(accountID, id)))
+	assert.False(t, db.Exists(other))
+}
+
 func TestLevelDBRepo_Update_Exists(t *testing.T) {
 	repo := getRepository(ctx)
 	accountID, id := utils.RandomSlice(32), utils.RandomSlice(32)
@@ -202,7 +228,12 @@ func TestRepo_updateLatestIndex(t *testing.T) {
 		Time:    tm,
 	}
 	assert.False(t, rr.db.Exists(rr.getLatestKey(acc, id)))
-	err := rr.updateLatestIndex(acc, d)
+	updateLatestIndex := func() error {
+		batch := storage.NewBatch()
+		rr.updateLatestIndex(batch, acc, d)
+		return rr.db.Write(batch)
+	}
+	err := updateLatestIndex()
 	assert.NoError(t, err)
 	assert.True(t, rr.db.Exists(rr.getLatestKey(acc, id)))
 	lv, err := rr.getLatest(rr.getLatestKey(acc, id))
@@ -216,7 +247,7 @@ func TestRepo_updateLatestIndex(t *testing.T) {
 	d.Current = next
 	d.Next = utils.RandomSlice(32)
 	d.Time = time.Now().UTC()
-	err = rr.updateLatestIndex(acc, d)
+	err = updateLatestIndex()
 	assert.NoError(t, err)
 	assert.True(t, rr.db.Exists(rr.getLatestKey(acc, id)))
 	lv, err = rr.getLatest(rr.getLatestKey(acc, id))
@@ -232,7 +263,7 @@ func TestRepo_updateLatestIndex(t *testing.T) {
 	tm = time.Now().UTC()
 	assert.False(t, d.Time.Equal(tm))
 	d.Time = tm
-	err = rr.updateLatestIndex(acc, d)
+	err = updateLatestIndex()
 	assert.NoError(t, err)
 	assert.True(t, rr.db.Exists(rr.getLatestKey(acc, id)))
 	lv, err = rr.getLatest(rr.getLatestKey(acc, id))
@@ -248,7 +279,7 @@ func TestRepo_updateLatestIndex(t *testing.T) {
 	oldN := d.Next
 	d.Current = utils.RandomSlice(32)
 	d.Next = utils.RandomSlice(32)
-	err = rr.updateLatestIndex(acc, d)
+	err = updateLatestIndex()
 	assert.NoError(t, err)
 	assert.True(t, rr.db.Exists(rr.getLatestKey(acc, id)))
 	lv, err = rr.getLatest(rr.getLatestKey(acc, id))
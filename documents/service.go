@@ -14,6 +14,7 @@ import (
 	"github.com/centrifuge/go-centrifuge/jobs"
 	"github.com/centrifuge/go-centrifuge/notification"
 	"github.com/centrifuge/go-centrifuge/queue"
+	"github.com/centrifuge/go-centrifuge/storage"
 	"github.com/centrifuge/go-centrifuge/utils"
 	"github.com/centrifuge/precise-proofs/proofs/proto"
 	"github.com/ethereum/go-ethereum/common/hexutil"
@@ -91,6 +92,9 @@ type Service interface {
 	// Commit triggers validations, state change and anchor job
 	Commit(ctx context.Context, model Model) (jobs.JobID, error)
 
+	// CommitWithBatch commits the model like Commit. The writes of the batch are stored atomically with the model.
+	CommitWithBatch(ctx context.Context, model Model, batch *storage.Batch) (jobs.JobID, error)
+
 	// Validate takes care of document validation
 	Validate(ctx context.Context, model Model, old Model) error
 
@@ -108,6 +112,7 @@ type service struct {
 	idService  identity.Service
 	queueSrv   queue.TaskQueuer
 	jobManager jobs.Manager
+	policySrv  SignaturePolicyService
 }
 
 var srvLog = logging.Logger("document-service")
@@ -120,7 +125,8 @@ func DefaultService(
 	registry *ServiceRegistry,
 	idService identity.Service,
 	queueSrv queue.TaskQueuer,
-	jobManager jobs.Manager) Service {
+	jobManager jobs.Manager,
+	policySrv SignaturePolicyService) Service {
 	return service{
 		config:     config,
 		repo:       repo,
@@ -130,6 +136,7 @@ func DefaultService(
 		idService:  idService,
 		queueSrv:   queueSrv,
 		jobManager: jobManager,
+		policySrv:  policySrv,
 	}
 }
 
@@ -218,6 +225,30 @@ func (s service) RequestDocumentSignature(ctx context.Context, model Model, coll
 	}
 
 	srvLog.Infof("document received %x with signing root %x", model.ID(), sr)
+	sigData := notification.SignatureData{
+		Collaborator: collaborator.String(),
+		VersionID:    hexutil.Encode(model.CurrentVersion()),
+		SigningRoot:  hexutil.Encode(sr),
+	}
+	if !isApprovedRequest(ctx) {
+		s.notify(ctx, notification.SignatureRequested, did, collaborator, model, sigData)
+		if old != nil {
+			s.notifyChanged(ctx, did, collaborator, old, model)
+		}
+	}
+
+	// the signature policy of the account decides if the document is signed
+	if s.policySrv != nil {
+		err = s.policySrv.Authorize(ctx, collaborator, model, old, sr)
+		if err != nil {
+			if errors.IsOfType(ErrSignatureRequestRejected, err) {
+				sigData.Reason = err.Error()
+				s.notify(ctx, notification.SignatureRefused, did, collaborator, model, sigData)
+			}
+
+			return nil, err
+		}
+	}
 
 	// If there is a previous version and we have successfully validated the transition then set the signature flag
 	sig, err := acc.SignMsg(ConsensusSignaturePayload(sr, old != nil))
@@ -247,9 +278,53 @@ func (s service) RequestDocumentSignature(ctx context.Context, model Model, coll
 	}
 
 	srvLog.Infof("signed document %x with version %x", model.ID(), model.CurrentVersion())
+	s.notify(ctx, notification.SignatureGiven, did, collaborator, model, sigData)
 	return []*coredocumentpb.Signature{sig}, nil
 }
 
+// notify sends the notification of the document event in the background.
+func (s service) notify(ctx context.Context, eventType notification.EventType, did, collaborator identity.DID, model Model, data interface{}) {
+	msg := notification.Message{
+		EventType:    eventType,
+		AccountID:    did.String(),
+		FromID:       hexutil.Encode(collaborator[:]),
+		ToID:         did.String(),
+		Recorded:     time.Now().UTC(),
+		DocumentType: model.DocumentType(),
+		DocumentID:   hexutil.Encode(model.ID()),
+		Scheme:       model.Scheme(),
+		Data:         data,
+	}
+
+	go func() {
+		_, err := s.notifier.Send(ctx, msg)
+		if err != nil {
+			log.Error(err)
+		}
+	}()
+}
+
+// notifyChanged sends the DocumentChanged notification of the new version received from the collaborator.
+func (s service) notifyChanged(ctx context.Context, did, collaborator identity.DID, old, model Model) {
+	changed, err := old.ChangedFields(model)
+	if err != nil {
+		log.Error(err)
+		return
+	}
+
+	fields := make([]string, 0, len(changed))
+	for _, cf := range changed {
+		fields = append(fields, cf.Name)
+	}
+
+	s.notify(ctx, notification.DocumentChanged, did, collaborator, model, notification.DocumentChangedData{
+		Collaborator:      collaborator.String(),
+		VersionID:         hexutil.Encode(model.CurrentVersion()),
+		PreviousVersionID: hexutil.Encode(old.CurrentVersion()),
+		ChangedFields:     fields,
+	})
+}
+
 func (s service) ReceiveAnchoredDocument(ctx context.Context, model Model, collaborator identity.DID) error {
 	acc, err := contextutil.Account(ctx)
 	if err != nil {
@@ -298,6 +373,7 @@ func (s service) ReceiveAnchoredDocument(ctx context.Context, model Model, colla
 		Recorded:     time.Now().UTC(),
 		DocumentType: model.DocumentType(),
 		DocumentID:   hexutil.Encode(model.ID()),
+		Scheme:       model.Scheme(),
 	}
 
 	// async so that we don't return an error as the p2p reply
@@ -454,6 +530,11 @@ func (s service) Validate(ctx context.Context, model Model, old Model) error {
 
 // Commit triggers validations, state change and anchor job
 func (s service) Commit(ctx context.Context, model Model) (jobs.JobID, error) {
+	return s.CommitWithBatch(ctx, model, nil)
+}
+
+// CommitWithBatch commits the model like Commit. The writes of the batch are stored atomically with the model.
+func (s service) CommitWithBatch(ctx context.Context, model Model, batch *storage.Batch) (jobs.JobID, error) {
 	did, err := contextutil.AccountDID(ctx)
 	if err != nil {
 		return jobs.NilJobID(), ErrDocumentConfigAccountID
@@ -473,7 +554,7 @@ func (s service) Commit(ctx context.Context, model Model) (jobs.JobID, error) {
 		return jobs.NilJobID(), err
 	}
 
-	err = s.repo.Create(did[:], model.CurrentVersion(), model)
+	err = s.repo.CreateWithBatch(did[:], model.CurrentVersion(), model, batch)
 	if err != nil {
 		return jobs.NilJobID(), errors.NewTypedError(ErrDocumentPersistence, err)
 	}
@@ -130,7 +130,7 @@ func TestService_Commit(t *testing.T) {
 	anchorSrv.On("GetAnchorData", mock.Anything).Return(nil, time.Now(), errors.New("anchor data missing"))
 	s.anchorSrv = anchorSrv
 	m.On("SetStatus", mock.Anything).Return(nil)
-	mr.On("Create", mock.Anything, mock.Anything, mock.Anything).Return(ErrDocumentPersistence)
+	mr.On("CreateWithBatch", mock.Anything, mock.Anything, mock.Anything).Return(ErrDocumentPersistence)
 	_, err = s.Commit(ctxh, m)
 	assert.Error(t, err)
 
@@ -140,7 +140,7 @@ func TestService_Commit(t *testing.T) {
 	s.jobManager = jobMan
 	mr = new(MockRepository)
 	mr.On("GetLatest", mock.Anything, mock.Anything).Return(nil, ErrDocumentVersionNotFound)
-	mr.On("Create", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+	mr.On("CreateWithBatch", mock.Anything, mock.Anything, mock.Anything).Return(nil)
 	s.repo = mr
 	_, err = s.Commit(ctxh, m)
 	assert.Error(t, err)
@@ -0,0 +1,625 @@
+package documents
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sync"
+	"time"
+
+	"github.com/centrifuge/centrifuge-protobufs/gen/go/coredocument"
+	"github.com/centrifuge/go-centrifuge/config"
+	"github.com/centrifuge/go-centrifuge/contextutil"
+	"github.com/centrifuge/go-centrifuge/errors"
+	"github.com/centrifuge/go-centrifuge/identity"
+	"github.com/centrifuge/go-centrifuge/jobs"
+	"github.com/centrifuge/go-centrifuge/storage"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/golang/protobuf/proto"
+)
+
+const (
+	// SignatureCollectionPrefix is the prefix of the signature collections in the DB
+	SignatureCollectionPrefix = "signature_collection_"
+
+	// SignatureCollectionModeTimeout anchors the document with the signatures received within one p2p timeout
+	SignatureCollectionModeTimeout = "timeout"
+
+	// SignatureCollectionModeAll waits until every collaborator has signed the document
+	SignatureCollectionModeAll = "all"
+
+	// SignatureCollectionModeQuorum waits until the configured number of collaborators have signed the document
+	SignatureCollectionModeQuorum = "quorum"
+
+	signatureCollectionTaskName = "Signature Collection"
+)
+
+// SignatureCollectionStatus is the status of a signature collection.
+type SignatureCollectionStatus string
+
+const (
+	// SignatureCollectionCollecting is the status of a collection waiting for signatures
+	SignatureCollectionCollecting SignatureCollectionStatus = "collecting"
+
+	// SignatureCollectionCompleted is the status of a collection whose document was anchored
+	SignatureCollectionCompleted SignatureCollectionStatus = "completed"
+
+	// SignatureCollectionFailed is the status of a collection that timed out or whose document failed to anchor
+	SignatureCollectionFailed SignatureCollectionStatus = "failed"
+)
+
+// SignatureCollection tracks the signatures collected for a document version being anchored.
+type SignatureCollection struct {
+	AccountID  identity.DID                `json:"account_id"`
+	DocumentID hexutil.Bytes               `json:"document_id"`
+	Version    hexutil.Bytes               `json:"version"`
+	JobID      string                      `json:"job_id"`
+	Signers    []identity.DID              `json:"signers"`
+	Required   int                         `json:"required"`
+	Signatures []*coredocumentpb.Signature `json:"signatures"`
+	Status     SignatureCollectionStatus   `json:"status"`
+	Attempts   int                         `json:"attempts"`
+	Error      string                      `json:"error,omitempty"`
+	CreatedAt  time.Time                   `json:"created_at"`
+	UpdatedAt  time.Time                   `json:"updated_at"`
+	Deadline   time.Time                   `json:"deadline"`
+}
+
+// JSON marshals the collection to json bytes.
+func (c *SignatureCollection) JSON() ([]byte, error) {
+	return json.Marshal(c)
+}
+
+// FromJSON loads the collection from json bytes.
+func (c *SignatureCollection) FromJSON(data []byte) error {
+	return json.Unmarshal(data, c)
+}
+
+// Type returns the type of the SignatureCollection.
+func (c *SignatureCollection) Type() reflect.Type {
+	return reflect.TypeOf(c)
+}
+
+// signed returns true if the collection has a signature of the signer.
+func (c *SignatureCollection) signed(signer identity.DID) bool {
+	for _, sig := range c.Signatures {
+		if identity.ValidateDIDBytes(sig.SignerId, signer) == nil {
+			return true
+		}
+	}
+
+	return false
+}
+
+// missing returns the signers that haven't signed yet.
+func (c *SignatureCollection) missing() []identity.DID {
+	var dids []identity.DID
+	for _, s := range c.Signers {
+		if !c.signed(s) {
+			dids = append(dids, s)
+		}
+	}
+
+	return dids
+}
+
+// complete returns true if the required number of signers have signed.
+func (c *SignatureCollection) complete() bool {
+	return len(c.Signers)-len(c.missing()) >= c.Required
+}
+
+// addSignatures adds the signatures of the signers that haven't signed yet.
+func (c *SignatureCollection) addSignatures(sigs []*coredocumentpb.Signature) {
+	for _, sig := range sigs {
+		signer, err := identity.NewDIDFromBytes(sig.SignerId)
+		if err != nil || !didsContain(c.Signers, signer) || c.signed(signer) {
+			continue
+		}
+
+		c.Signatures = append(c.Signatures, sig)
+	}
+}
+
+// SignatureCollector collects the signatures of the collaborators of the documents being anchored.
+type SignatureCollector interface {
+	// Collect collects the signatures of the signer collaborators of the model.
+	// In timeout mode, the signatures received within one p2p timeout are returned.
+	// Otherwise, ErrSignaturesPending is returned if the required signatures are not received in the first round.
+	// The collection then continues in the background and anchors the document once the signatures are received.
+	Collect(ctx context.Context, model Model) ([]*coredocumentpb.Signature, error)
+
+	// ReceiveSignatures accepts the signatures a collaborator sends after its signature request returned.
+	ReceiveSignatures(ctx context.Context, collaborator identity.DID, version []byte, signatures []*coredocumentpb.Signature) error
+
+	// Name returns the name of the collector server.
+	Name() string
+
+	// Start resumes the pending signature collections and stops them once the context is done.
+	Start(ctx context.Context, wg *sync.WaitGroup, startupErr chan<- error)
+}
+
+// signatureCollector implements SignatureCollector.
+type signatureCollector struct {
+	repo      storage.Repository
+	docRepo   Repository
+	config    Config
+	cfgSrv    config.Service
+	jobMan    jobs.Manager
+	idService identity.Service
+
+	// set once the services depending on the documents package are bootstrapped
+	docSrv    Service
+	client    Client
+	processor AnchorProcessor
+
+	mu      sync.Mutex
+	ctx     context.Context
+	waiters map[string]chan struct{}
+}
+
+// newSignatureCollector returns a signature collector. The document service, p2p client,
+// and the anchor processor must be set before the collector is used.
+func newSignatureCollector(
+	repo storage.Repository,
+	docRepo Repository,
+	config Config,
+	cfgSrv config.Service,
+	jobMan jobs.Manager,
+	idService identity.Service) *signatureCollector {
+	repo.Register(new(SignatureCollection))
+	return &signatureCollector{
+		repo:      repo,
+		docRepo:   docRepo,
+		config:    config,
+		cfgSrv:    cfgSrv,
+		jobMan:    jobMan,
+		idService: idService,
+		waiters:   make(map[string]chan struct{}),
+	}
+}
+
+func signatureCollectionKey(accountID identity.DID, version []byte) []byte {
+	return []byte(SignatureCollectionPrefix + hexutil.Encode(accountID[:]) + hexutil.Encode(version))
+}
+
+// Name returns the name of the collector server.
+func (c *signatureCollector) Name() string {
+	return "SignatureCollector"
+}
+
+// Start resumes the collections that were pending when the node stopped.
+func (c *signatureCollector) Start(ctx context.Context, wg *sync.WaitGroup, startupErr chan<- error) {
+	defer wg.Done()
+	c.mu.Lock()
+	c.ctx = ctx
+	c.mu.Unlock()
+
+	models, err := c.repo.GetAllByPrefix(SignatureCollectionPrefix)
+	if err != nil {
+		startupErr <- err
+		return
+	}
+
+	for _, m := range models {
+		col, ok := m.(*SignatureCollection)
+		if !ok || col.Status != SignatureCollectionCollecting {
+			continue
+		}
+
+		srvLog.Infof("resuming signature collection for document %s version %s", col.DocumentID, col.Version)
+		go c.run(col, true)
+	}
+
+	<-ctx.Done()
+	srvLog.Info("signature collector stopped")
+}
+
+// nodeContext returns the context of the running node. Background context is returned if the collector is not started.
+func (c *signatureCollector) nodeContext() context.Context {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.ctx == nil {
+		return context.Background()
+	}
+
+	return c.ctx
+}
+
+// accountContext returns the context of the account with the job.
+func (c *signatureCollector) accountContext(ctx context.Context, accountID identity.DID, jobID jobs.JobID) (context.Context, error) {
+	acc, err := c.cfgSrv.GetAccount(accountID[:])
+	if err != nil {
+		return nil, errors.New("failed to get account: %v", err)
+	}
+
+	return contextutil.New(contextutil.WithJob(ctx, jobID), acc)
+}
+
+func (c *signatureCollector) Collect(ctx context.Context, model Model) ([]*coredocumentpb.Signature, error) {
+	mode := c.config.GetSignatureCollectionMode()
+	if mode == "" || mode == SignatureCollectionModeTimeout {
+		signs, _, err := c.client.GetSignaturesForDocument(ctx, model)
+		return signs, err
+	}
+
+	if mode != SignatureCollectionModeAll && mode != SignatureCollectionModeQuorum {
+		return nil, errors.New("unknown signature collection mode %s", mode)
+	}
+
+	did, err := contextutil.AccountDID(ctx)
+	if err != nil {
+		return nil, ErrDocumentConfigAccountID
+	}
+
+	signers, err := model.GetSignerCollaborators(did)
+	if err != nil {
+		return nil, errors.New("failed to get external collaborators: %v", err)
+	}
+
+	required := len(signers)
+	if q := c.config.GetSignatureQuorum(); mode == SignatureCollectionModeQuorum && q > 0 && q < required {
+		required = q
+	}
+
+	now := time.Now().UTC()
+	col := &SignatureCollection{
+		AccountID:  did,
+		DocumentID: model.ID(),
+		Version:    model.CurrentVersion(),
+		JobID:      contextutil.Job(ctx).String(),
+		Signers:    signers,
+		Required:   required,
+		Status:     SignatureCollectionCollecting,
+		CreatedAt:  now,
+		UpdatedAt:  now,
+		Deadline:   now.Add(c.config.GetSignatureCollectionTimeout()),
+	}
+
+	signs, _, err := c.client.GetSignaturesFromCollaborators(ctx, model, signers)
+	if err != nil {
+		return nil, err
+	}
+
+	col.addSignatures(signs)
+	col.Attempts++
+	if col.complete() {
+		return col.Signatures, nil
+	}
+
+	key := signatureCollectionKey(did, col.Version)
+	c.mu.Lock()
+	if c.repo.Exists(key) {
+		err = c.repo.Update(key, col)
+	} else {
+		err = c.repo.Create(key, col)
+	}
+	c.mu.Unlock()
+	if err != nil {
+		return nil, err
+	}
+
+	// the anchor task stays pending until the collection anchors the document
+	jobID := contextutil.Job(ctx)
+	if !jobs.JobIDEqual(jobID, jobs.NilJobID()) {
+		err = c.jobMan.UpdateJobWithValue(did, jobID, SignatureDeadlineValueKey, []byte(col.Deadline.Format(time.RFC3339Nano)))
+		if err != nil {
+			return nil, err
+		}
+
+		err = c.jobMan.UpdateTaskStatus(did, jobID, jobs.Pending, documentAnchorTaskName, ErrSignaturesPending.Error())
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	c.log(col, "collected %d of %d required signatures", len(col.Signatures), col.Required)
+	go c.run(col, false)
+	return nil, ErrSignaturesPending
+}
+
+// update applies the change to the stored collection and saves it.
+func (c *signatureCollector) update(accountID identity.DID, version []byte, change func(col *SignatureCollection) error) (*SignatureCollection, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	key := signatureCollectionKey(accountID, version)
+	m, err := c.repo.Get(key)
+	if err != nil {
+		return nil, errors.NewTypedError(ErrSignatureCollectionNotFound, err)
+	}
+
+	col, ok := m.(*SignatureCollection)
+	if !ok {
+		return nil, errors.New("invalid signature collection type: %T", m)
+	}
+
+	err = change(col)
+	if err != nil {
+		return nil, err
+	}
+
+	col.UpdatedAt = time.Now().UTC()
+	return col, c.repo.Update(key, col)
+}
+
+// wait returns the channel notified when signatures are received for the collection.
+func (c *signatureCollector) wait(key []byte) chan struct{} {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	ch, ok := c.waiters[string(key)]
+	if !ok {
+		ch = make(chan struct{}, 1)
+		c.waiters[string(key)] = ch
+	}
+
+	return ch
+}
+
+// notify wakes up the collection waiting for the signatures.
+func (c *signatureCollector) notify(key []byte) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	ch, ok := c.waiters[string(key)]
+	if !ok {
+		return
+	}
+
+	select {
+	case ch <- struct{}{}:
+	default:
+	}
+}
+
+// run requests the missing signatures until the collection is complete and anchors the document.
+// resumed collections were started before the node restarted and update the job status themselves.
+func (c *signatureCollector) run(col *SignatureCollection, resumed bool) {
+	key := signatureCollectionKey(col.AccountID, col.Version)
+	ch := c.wait(key)
+	defer func() {
+		c.mu.Lock()
+		delete(c.waiters, string(key))
+		c.mu.Unlock()
+	}()
+
+	jobID, _ := jobs.FromString(col.JobID)
+	nodeCtx := c.nodeContext()
+	ctx, err := c.accountContext(nodeCtx, col.AccountID, jobID)
+	if err != nil {
+		c.finish(col, resumed, err)
+		return
+	}
+
+	model, err := c.docRepo.Get(col.AccountID[:], col.Version)
+	if err != nil {
+		c.finish(col, resumed, errors.New("failed to get model: %v", err))
+		return
+	}
+
+	for !col.complete() {
+		left := time.Until(col.Deadline)
+		if left <= 0 {
+			c.finish(col, resumed, errors.NewTypedError(ErrSignatureCollectionTimeout,
+				errors.New("collected %d of %d required signatures", len(col.Signatures), col.Required)))
+			return
+		}
+
+		wait := c.config.GetSignatureRetryInterval()
+		if wait <= 0 || wait > left {
+			wait = left
+		}
+
+		var signs []*coredocumentpb.Signature
+		select {
+		case <-nodeCtx.Done():
+			// the collection is resumed when the node starts again
+			return
+		case <-ch:
+			// late signatures are already stored
+		case <-time.After(wait):
+			signs, _, err = c.client.GetSignaturesFromCollaborators(ctx, model, col.missing())
+			if err != nil {
+				srvLog.Warningf("failed to request signatures for document %s: %v", col.DocumentID, err)
+			}
+		}
+
+		ncol, err := c.update(col.AccountID, col.Version, func(col *SignatureCollection) error {
+			if len(signs) > 0 {
+				col.addSignatures(signs)
+				col.Attempts++
+			}
+			return nil
+		})
+		if err != nil {
+			c.finish(col, resumed, err)
+			return
+		}
+
+		if len(ncol.Signatures) > len(col.Signatures) {
+			c.log(ncol, "collected %d of %d required signatures", len(ncol.Signatures), ncol.Required)
+		}
+
+		col = ncol
+	}
+
+	model.AppendSignatures(col.Signatures...)
+	updater := func(id []byte, model Model) error {
+		return c.docRepo.Update(col.AccountID[:], id, model)
+	}
+
+	var steps anchorSteps = noSteps{}
+	if !jobs.JobIDEqual(jobID, jobs.NilJobID()) {
+		steps, err = newJobSteps(c.jobMan, col.AccountID, jobID)
+		if err != nil {
+			c.finish(col, resumed, err)
+			return
+		}
+
+		// the signatures are stored with the model so that a retried job doesn't collect them again
+		err = updater(col.Version, model)
+		if err == nil {
+			err = steps.complete(signatureCollectionTaskName)
+		}
+
+		if err != nil {
+			c.finish(col, resumed, err)
+			return
+		}
+	}
+
+	_, err = finishAnchoring(ctx, model, c.processor, updater, steps)
+	c.finish(col, resumed, err)
+}
+
+// finish stores the result of the collection and updates the job.
+func (c *signatureCollector) finish(col *SignatureCollection, resumed bool, err error) {
+	status, jobStatus, msg := SignatureCollectionCompleted, jobs.Success, ""
+	if err != nil {
+		status, jobStatus, msg = SignatureCollectionFailed, jobs.Failed, err.Error()
+		srvLog.Errorf("failed to anchor document %s with collected signatures: %v", col.DocumentID, err)
+	}
+
+	_, errs := c.update(col.AccountID, col.Version, func(col *SignatureCollection) error {
+		col.Status = status
+		col.Error = msg
+		return nil
+	})
+	if errs != nil {
+		srvLog.Error(errs)
+	}
+
+	jobID, errs := jobs.FromString(col.JobID)
+	if errs != nil || jobs.JobIDEqual(jobID, jobs.NilJobID()) {
+		return
+	}
+
+	errs = c.jobMan.UpdateTaskStatus(col.AccountID, jobID, jobStatus, documentAnchorTaskName, msg)
+	if errs != nil {
+		srvLog.Error(errs)
+	}
+
+	// the job was started before the node restarted so no one else completes it
+	if resumed {
+		errs = c.jobMan.UpdateJobStatus(col.AccountID, jobID, jobStatus, msg)
+		if errs != nil {
+			srvLog.Error(errs)
+		}
+	}
+}
+
+// log adds the progress of the collection to the job.
+func (c *signatureCollector) log(col *SignatureCollection, format string, args ...interface{}) {
+	jobID, err := jobs.FromString(col.JobID)
+	if err != nil || jobs.JobIDEqual(jobID, jobs.NilJobID()) {
+		return
+	}
+
+	err = c.jobMan.UpdateTaskStatus(col.AccountID, jobID, jobs.Pending, signatureCollectionTaskName, fmt.Sprintf(format, args...))
+	if err != nil {
+		srvLog.Error(err)
+	}
+}
+
+func (c *signatureCollector) ReceiveSignatures(ctx context.Context, collaborator identity.DID, version []byte, signatures []*coredocumentpb.Signature) error {
+	did, err := contextutil.AccountDID(ctx)
+	if err != nil {
+		return ErrDocumentConfigAccountID
+	}
+
+	if !c.repo.Exists(signatureCollectionKey(did, version)) {
+		return ErrSignatureCollectionNotFound
+	}
+
+	model, err := c.docRepo.Get(did[:], version)
+	if err != nil {
+		return errors.NewTypedError(ErrDocumentNotFound, err)
+	}
+
+	err = ValidateCollaboratorSignatures(c.idService, model, collaborator, signatures)
+	if err != nil {
+		return errors.NewTypedError(ErrDocumentInvalid, err)
+	}
+
+	col, err := c.update(did, version, func(col *SignatureCollection) error {
+		if col.Status != SignatureCollectionCollecting {
+			return ErrSignatureCollectionClosed
+		}
+
+		if !didsContain(col.Signers, collaborator) {
+			return errors.NewTypedError(ErrDocumentInvalid, errors.New("%s is not a signer of the document", collaborator))
+		}
+
+		col.addSignatures(signatures)
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	c.log(col, "received signature from %s", collaborator)
+	c.notify(signatureCollectionKey(did, version))
+	return nil
+}
+
+// sendApproved signs the document of an approved signature request no peer was waiting for
+// and sends the signature to the requester.
+func (c *signatureCollector) sendApproved(accountID identity.DID, req *SignatureRequest) {
+	go func() {
+		err := c.signAndSend(accountID, req)
+		if err != nil {
+			srvLog.Errorf("failed to send signature of approved request %s: %v", req.ID, err)
+		}
+	}()
+}
+
+func (c *signatureCollector) signAndSend(accountID identity.DID, req *SignatureRequest) error {
+	ctx, err := c.accountContext(c.nodeContext(), accountID, jobs.NilJobID())
+	if err != nil {
+		return err
+	}
+
+	ctx = withApprovedRequest(ctx)
+	cd := new(coredocumentpb.CoreDocument)
+	err = proto.Unmarshal(req.Document, cd)
+	if err != nil {
+		return err
+	}
+
+	model, err := c.docSrv.DeriveFromCoreDocument(*cd)
+	if err != nil {
+		return err
+	}
+
+	sigs, err := c.docSrv.RequestDocumentSignature(ctx, model, req.Collaborator)
+	if err != nil {
+		return err
+	}
+
+	return c.client.SendSignatures(ctx, req.Collaborator, model.CurrentVersion(), sigs)
+}
+
+// ValidateCollaboratorSignatures validates the signatures of the collaborator against the signing root of the model.
+func ValidateCollaboratorSignatures(idService identity.Service, model Model, collaborator identity.DID, signatures []*coredocumentpb.Signature) error {
+	tm, err := model.Timestamp()
+	if err != nil {
+		return errors.New("cannot get model timestamp : %s", err.Error())
+	}
+
+	signingRoot, err := model.CalculateSigningRoot()
+	if err != nil {
+		return errors.New("failed to calculate signing root: %s", err.Error())
+	}
+
+	for _, sig := range signatures {
+		err = identity.ValidateDIDBytes(sig.SignerId, collaborator)
+		if err != nil {
+			return errors.New("signature invalid with err: %s", err.Error())
+		}
+
+		err = idService.ValidateSignature(collaborator, sig.PublicKey, sig.Signature, ConsensusSignaturePayload(signingRoot, sig.TransitionValidated), tm)
+		if err != nil {
+			return errors.New("signature invalid with err: %s", err.Error())
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,134 @@
+// +build unit
+
+package documents
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/centrifuge/centrifuge-protobufs/gen/go/coredocument"
+	"github.com/centrifuge/go-centrifuge/contextutil"
+	"github.com/centrifuge/go-centrifuge/errors"
+	"github.com/centrifuge/go-centrifuge/identity"
+	"github.com/centrifuge/go-centrifuge/storage"
+	"github.com/centrifuge/go-centrifuge/testingutils/config"
+	"github.com/centrifuge/go-centrifuge/testingutils/identity"
+	"github.com/centrifuge/go-centrifuge/testingutils/testingjobs"
+	"github.com/centrifuge/go-centrifuge/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+func newTestCollector(mode string, quorum int) (*signatureCollector, *p2pClient) {
+	c := new(testingconfig.MockConfig)
+	c.On("GetSignatureCollectionMode").Return(mode)
+	c.On("GetSignatureQuorum").Return(quorum)
+	c.On("GetSignatureCollectionTimeout").Return(time.Hour)
+	c.On("GetSignatureRetryInterval").Return(time.Hour)
+	repo := ctx[storage.BootstrappedDB].(storage.Repository)
+	collector := newSignatureCollector(repo, NewDBRepository(repo), c, nil, new(testingjobs.MockJobManager), nil)
+	client := new(p2pClient)
+	collector.client = client
+	return collector, client
+}
+
+func TestSignatureCollection_signatures(t *testing.T) {
+	s1, s2, other := testingidentity.GenerateRandomDID(), testingidentity.GenerateRandomDID(), testingidentity.GenerateRandomDID()
+	col := &SignatureCollection{Signers: []identity.DID{s1, s2}, Required: 2}
+	assert.False(t, col.complete())
+	assert.Equal(t, []identity.DID{s1, s2}, col.missing())
+
+	// signatures of unknown signers and duplicates are ignored
+	col.addSignatures([]*coredocumentpb.Signature{{SignerId: s1[:]}, {SignerId: s1[:]}, {SignerId: other[:]}})
+	assert.Len(t, col.Signatures, 1)
+	assert.True(t, col.signed(s1))
+	assert.Equal(t, []identity.DID{s2}, col.missing())
+	assert.False(t, col.complete())
+
+	col.Required = 1
+	assert.True(t, col.complete())
+}
+
+func TestSignatureCollector_Collect_timeout(t *testing.T) {
+	collector, client := newTestCollector(SignatureCollectionModeTimeout, 0)
+	cctx := testingconfig.CreateAccountContext(t, cfg)
+	model := new(MockModel)
+	sigs := []*coredocumentpb.Signature{{SignerId: utils.RandomSlice(identity.DIDLength)}}
+	client.On("GetSignaturesForDocument", cctx, model).Return(sigs, nil).Once()
+	res, err := collector.Collect(cctx, model)
+	assert.NoError(t, err)
+	assert.Equal(t, sigs, res)
+	client.AssertExpectations(t)
+
+	// unknown mode
+	collector, _ = newTestCollector("unknown", 0)
+	_, err = collector.Collect(cctx, model)
+	assert.Error(t, err)
+}
+
+func TestSignatureCollector_Collect_quorum(t *testing.T) {
+	collector, client := newTestCollector(SignatureCollectionModeQuorum, 1)
+	cctx := testingconfig.CreateAccountContext(t, cfg)
+	did, err := contextutil.AccountDID(cctx)
+	assert.NoError(t, err)
+	s1, s2 := testingidentity.GenerateRandomDID(), testingidentity.GenerateRandomDID()
+	model := new(MockModel)
+	model.On("GetSignerCollaborators", []identity.DID{did}).Return([]identity.DID{s1, s2}, nil)
+	model.On("ID").Return(utils.RandomSlice(32))
+	model.On("CurrentVersion").Return(utils.RandomSlice(32))
+	sigs := []*coredocumentpb.Signature{{SignerId: s2[:]}}
+	client.On("GetSignaturesFromCollaborators", cctx, model, []identity.DID{s1, s2}).Return(sigs, nil).Once()
+	res, err := collector.Collect(cctx, model)
+	assert.NoError(t, err)
+	assert.Equal(t, sigs, res)
+	assert.False(t, collector.repo.Exists(signatureCollectionKey(did, model.CurrentVersion())))
+
+	// the first round fails
+	client.On("GetSignaturesFromCollaborators", cctx, model, []identity.DID{s1, s2}).Return(nil, errors.New("failed")).Once()
+	_, err = collector.Collect(cctx, model)
+	assert.Error(t, err)
+	client.AssertExpectations(t)
+}
+
+func TestSignatureCollector_ReceiveSignatures(t *testing.T) {
+	collector, _ := newTestCollector(SignatureCollectionModeAll, 0)
+	cctx := testingconfig.CreateAccountContext(t, cfg)
+	did, err := contextutil.AccountDID(cctx)
+	assert.NoError(t, err)
+	collaborator := testingidentity.GenerateRandomDID()
+	version := utils.RandomSlice(32)
+	sigs := []*coredocumentpb.Signature{{SignerId: collaborator[:]}}
+
+	// missing account
+	err = collector.ReceiveSignatures(context.Background(), collaborator, version, sigs)
+	assert.True(t, errors.IsOfType(ErrDocumentConfigAccountID, err))
+
+	// unknown collection
+	err = collector.ReceiveSignatures(cctx, collaborator, version, sigs)
+	assert.True(t, errors.IsOfType(ErrSignatureCollectionNotFound, err))
+
+	// document of the collection is missing
+	col := &SignatureCollection{AccountID: did, Version: version, Signers: []identity.DID{collaborator}, Status: SignatureCollectionCompleted}
+	assert.NoError(t, collector.repo.Create(signatureCollectionKey(did, version), col))
+	err = collector.ReceiveSignatures(cctx, collaborator, version, sigs)
+	assert.True(t, errors.IsOfType(ErrDocumentNotFound, err))
+}
+
+func TestSignatureCollector_wait(t *testing.T) {
+	collector, _ := newTestCollector(SignatureCollectionModeAll, 0)
+	key := signatureCollectionKey(testingidentity.GenerateRandomDID(), utils.RandomSlice(32))
+
+	// no one waiting
+	collector.notify(key)
+
+	ch := collector.wait(key)
+	collector.notify(key)
+	collector.notify(key)
+	select {
+	case <-ch:
+	default:
+		t.Fatal("collection was not notified")
+	}
+
+	assert.Equal(t, ch, collector.wait(key))
+}
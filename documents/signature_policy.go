@@ -0,0 +1,201 @@
+package documents
+
+import (
+	"encoding/json"
+	"reflect"
+	"strings"
+
+	"github.com/centrifuge/go-centrifuge/errors"
+	"github.com/centrifuge/go-centrifuge/identity"
+	"github.com/centrifuge/go-centrifuge/utils"
+	"github.com/shopspring/decimal"
+)
+
+// PolicyAction is the decision a signature policy makes about a signature request.
+type PolicyAction string
+
+const (
+	// PolicyActionSign signs the document right away
+	PolicyActionSign PolicyAction = "sign"
+
+	// PolicyActionReject rejects the signature request
+	PolicyActionReject PolicyAction = "reject"
+
+	// PolicyActionHold holds the signature request until it is approved or rejected manually
+	PolicyActionHold PolicyAction = "hold"
+)
+
+// isValid returns true if the action is known.
+func (a PolicyAction) isValid() bool {
+	switch a {
+	case PolicyActionSign, PolicyActionReject, PolicyActionHold:
+		return true
+	default:
+		return false
+	}
+}
+
+// AttributeLimit matches when the numeric value of the attribute with the label exceeds Max.
+// Integer, decimal and monetary attributes are supported.
+type AttributeLimit struct {
+	Label string          `json:"label"`
+	Max   decimal.Decimal `json:"max"`
+}
+
+// SignaturePolicyRule applies its action to the signature requests matching all of its conditions.
+// An empty condition matches every request.
+type SignaturePolicyRule struct {
+	Action PolicyAction `json:"action"`
+
+	// Senders are the DIDs requesting the signature
+	Senders []identity.DID `json:"senders,omitempty"`
+
+	// Schemes are the document schemes
+	Schemes []string `json:"schemes,omitempty"`
+
+	// Fields are prefixes of the readable names of the changed fields. Matches if any changed field has one of the prefixes.
+	Fields []string `json:"fields,omitempty"`
+
+	// AttributeLimits matches if any of the attributes exceed its limit.
+	AttributeLimits []AttributeLimit `json:"attribute_limits,omitempty"`
+}
+
+// SignaturePolicy decides how the signature requests of an account are handled.
+// Rules are evaluated in order and the first matching rule decides. DefaultAction is used if no rule matches.
+type SignaturePolicy struct {
+	Rules         []SignaturePolicyRule `json:"rules"`
+	DefaultAction PolicyAction          `json:"default_action"`
+}
+
+// DefaultSignaturePolicy returns the policy of accounts without one, which signs every valid request.
+func DefaultSignaturePolicy() *SignaturePolicy {
+	return &SignaturePolicy{DefaultAction: PolicyActionSign}
+}
+
+// JSON marshals the policy to json bytes.
+func (p *SignaturePolicy) JSON() ([]byte, error) {
+	return json.Marshal(p)
+}
+
+// FromJSON loads the policy from json bytes.
+func (p *SignaturePolicy) FromJSON(data []byte) error {
+	return json.Unmarshal(data, p)
+}
+
+// Type returns the type of the SignaturePolicy.
+func (p *SignaturePolicy) Type() reflect.Type {
+	return reflect.TypeOf(p)
+}
+
+// Validate checks that the actions of the policy are known.
+func (p *SignaturePolicy) Validate() error {
+	if !p.DefaultAction.isValid() {
+		return errors.NewTypedError(ErrInvalidSignaturePolicy, errors.New("unknown default action %s", p.DefaultAction))
+	}
+
+	for i, r := range p.Rules {
+		if !r.Action.isValid() {
+			return errors.NewTypedError(ErrInvalidSignaturePolicy, errors.New("unknown action %s in rule %d", r.Action, i))
+		}
+
+		for _, l := range r.AttributeLimits {
+			if l.Label == "" {
+				return errors.NewTypedError(ErrInvalidSignaturePolicy, errors.New("empty attribute label in rule %d", i))
+			}
+		}
+	}
+
+	return nil
+}
+
+// Evaluate returns the action for the signature request of the sender for the model with the changed fields.
+func (p *SignaturePolicy) Evaluate(sender identity.DID, model Model, changed []ChangedField) PolicyAction {
+	for _, r := range p.Rules {
+		if r.matches(sender, model, changed) {
+			return r.Action
+		}
+	}
+
+	return p.DefaultAction
+}
+
+func (r SignaturePolicyRule) matches(sender identity.DID, model Model, changed []ChangedField) bool {
+	if len(r.Senders) > 0 && !didsContain(r.Senders, sender) {
+		return false
+	}
+
+	if len(r.Schemes) > 0 && !utils.ContainsString(r.Schemes, model.Scheme()) {
+		return false
+	}
+
+	if len(r.Fields) > 0 && !fieldsChanged(r.Fields, changed) {
+		return false
+	}
+
+	if len(r.AttributeLimits) > 0 && !limitsExceeded(r.AttributeLimits, model) {
+		return false
+	}
+
+	return true
+}
+
+func didsContain(dids []identity.DID, did identity.DID) bool {
+	for _, d := range dids {
+		if d.Equal(did) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func fieldsChanged(prefixes []string, changed []ChangedField) bool {
+	for _, cf := range changed {
+		for _, p := range prefixes {
+			if strings.HasPrefix(cf.Name, p) {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+func limitsExceeded(limits []AttributeLimit, model Model) bool {
+	for _, l := range limits {
+		key, err := AttrKeyFromLabel(l.Label)
+		if err != nil || !model.AttributeExists(key) {
+			continue
+		}
+
+		attr, err := model.GetAttribute(key)
+		if err != nil {
+			continue
+		}
+
+		v, ok := numericValue(attr.Value)
+		if ok && v.GreaterThan(l.Max) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// numericValue returns the value of integer, decimal and monetary attributes.
+func numericValue(v AttrVal) (decimal.Decimal, bool) {
+	var s string
+	switch {
+	case v.Type == AttrInt256 && v.Int256 != nil:
+		s = v.Int256.String()
+	case v.Type == AttrDecimal && v.Decimal != nil:
+		s = v.Decimal.String()
+	case v.Type == AttrMonetary && v.Monetary.Value != nil:
+		s = v.Monetary.Value.String()
+	default:
+		return decimal.Decimal{}, false
+	}
+
+	d, err := decimal.NewFromString(s)
+	return d, err == nil
+}
@@ -0,0 +1,224 @@
+// +build unit
+
+package documents
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/centrifuge/centrifuge-protobufs/gen/go/coredocument"
+	"github.com/centrifuge/go-centrifuge/contextutil"
+	"github.com/centrifuge/go-centrifuge/errors"
+	"github.com/centrifuge/go-centrifuge/identity"
+	"github.com/centrifuge/go-centrifuge/notification"
+	"github.com/centrifuge/go-centrifuge/storage"
+	"github.com/centrifuge/go-centrifuge/testingutils/config"
+	"github.com/centrifuge/go-centrifuge/testingutils/identity"
+	"github.com/centrifuge/go-centrifuge/utils"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSignaturePolicy_Validate(t *testing.T) {
+	p := new(SignaturePolicy)
+	err := p.Validate()
+	assert.Error(t, err)
+	assert.True(t, errors.IsOfType(ErrInvalidSignaturePolicy, err))
+
+	p = DefaultSignaturePolicy()
+	assert.NoError(t, p.Validate())
+
+	p.Rules = []SignaturePolicyRule{{Action: "unknown"}}
+	err = p.Validate()
+	assert.Error(t, err)
+	assert.True(t, errors.IsOfType(ErrInvalidSignaturePolicy, err))
+
+	p.Rules = []SignaturePolicyRule{{Action: PolicyActionHold, AttributeLimits: []AttributeLimit{{}}}}
+	err = p.Validate()
+	assert.Error(t, err)
+	assert.True(t, errors.IsOfType(ErrInvalidSignaturePolicy, err))
+
+	p.Rules[0].AttributeLimits[0].Label = "amount"
+	assert.NoError(t, p.Validate())
+}
+
+func TestSignaturePolicy_Evaluate(t *testing.T) {
+	sender := testingidentity.GenerateRandomDID()
+	other := testingidentity.GenerateRandomDID()
+	amount, err := NewStringAttribute("amount", AttrDecimal, "1500.5")
+	assert.NoError(t, err)
+	model := new(MockModel)
+	model.On("Scheme").Return("generic")
+	model.On("AttributeExists", amount.Key).Return(true)
+	model.On("GetAttribute", amount.Key).Return(amount, nil)
+	changed := []ChangedField{{Name: "cd_tree.attributes"}}
+
+	p := &SignaturePolicy{
+		Rules: []SignaturePolicyRule{
+			{Action: PolicyActionReject, Senders: []identity.DID{other}},
+			{Action: PolicyActionHold, Schemes: []string{"entity"}},
+			{Action: PolicyActionHold, Fields: []string{"cd_tree.roles"}},
+			{Action: PolicyActionHold, AttributeLimits: []AttributeLimit{{Label: "amount", Max: decimal.New(1000, 0)}}},
+		},
+		DefaultAction: PolicyActionSign,
+	}
+
+	assert.Equal(t, PolicyActionReject, p.Evaluate(other, model, nil))
+	assert.Equal(t, PolicyActionHold, p.Evaluate(sender, model, changed))
+
+	// within limit
+	p.Rules[3].AttributeLimits[0].Max = decimal.New(2000, 0)
+	assert.Equal(t, PolicyActionSign, p.Evaluate(sender, model, changed))
+
+	// changed roles
+	assert.Equal(t, PolicyActionHold, p.Evaluate(sender, model, []ChangedField{{Name: "cd_tree.roles[0].collaborators"}}))
+}
+
+func newTestPolicyService(holdWait time.Duration) SignaturePolicyService {
+	return NewSignaturePolicyService(ctx[storage.BootstrappedDB].(storage.Repository), holdWait, nil)
+}
+
+func heldModel() *MockModel {
+	model := new(MockModel)
+	model.On("Scheme").Return("generic")
+	model.On("ID").Return(utils.RandomSlice(32))
+	model.On("CurrentVersion").Return(utils.RandomSlice(32))
+	model.On("PackCoreDocument").Return(coredocumentpb.CoreDocument{}, nil)
+	return model
+}
+
+func TestPolicyService_Policy(t *testing.T) {
+	srv := newTestPolicyService(time.Millisecond)
+	_, err := srv.GetPolicy(context.Background())
+	assert.Error(t, err)
+
+	// accounts without a policy
+	p, err := srv.(*policyService).getPolicy(testingidentity.GenerateRandomDID())
+	assert.NoError(t, err)
+	assert.Equal(t, DefaultSignaturePolicy(), p)
+
+	cctx := testingconfig.CreateAccountContext(t, cfg)
+	err = srv.UpdatePolicy(cctx, new(SignaturePolicy))
+	assert.Error(t, err)
+
+	np := &SignaturePolicy{DefaultAction: PolicyActionHold}
+	assert.NoError(t, srv.UpdatePolicy(cctx, np))
+	p, err = srv.GetPolicy(cctx)
+	assert.NoError(t, err)
+	assert.Equal(t, np, p)
+
+	np.DefaultAction = PolicyActionSign
+	assert.NoError(t, srv.UpdatePolicy(cctx, np))
+	p, err = srv.GetPolicy(cctx)
+	assert.NoError(t, err)
+	assert.Equal(t, PolicyActionSign, p.DefaultAction)
+}
+
+func TestPolicyService_Authorize(t *testing.T) {
+	srv := newTestPolicyService(10 * time.Millisecond)
+	cctx := testingconfig.CreateAccountContext(t, cfg)
+	collaborator := testingidentity.GenerateRandomDID()
+	sr := utils.RandomSlice(32)
+
+	// sign
+	assert.NoError(t, srv.UpdatePolicy(cctx, DefaultSignaturePolicy()))
+	assert.NoError(t, srv.Authorize(cctx, collaborator, heldModel(), nil, sr))
+
+	// reject
+	assert.NoError(t, srv.UpdatePolicy(cctx, &SignaturePolicy{DefaultAction: PolicyActionReject}))
+	err := srv.Authorize(cctx, collaborator, heldModel(), nil, sr)
+	assert.True(t, errors.IsOfType(ErrSignatureRequestRejected, err))
+
+	// hold times out and the later retry is signed once approved
+	assert.NoError(t, srv.UpdatePolicy(cctx, &SignaturePolicy{DefaultAction: PolicyActionHold}))
+	model := heldModel()
+	err = srv.Authorize(cctx, collaborator, model, nil, sr)
+	assert.True(t, errors.IsOfType(ErrSignatureRequestHeld, err))
+	reqs, err := srv.GetSignatureRequests(cctx)
+	assert.NoError(t, err)
+	var req *SignatureRequest
+	for _, r := range reqs {
+		if utils.IsSameByteSlice(r.ID, model.CurrentVersion()) {
+			req = r
+		}
+	}
+	assert.NotNil(t, req)
+	assert.Equal(t, SignatureRequestPending, req.Status)
+	assert.Equal(t, collaborator, req.Collaborator)
+
+	req, err = srv.ApproveSignatureRequest(cctx, model.CurrentVersion())
+	assert.NoError(t, err)
+	assert.Equal(t, SignatureRequestApproved, req.Status)
+	assert.NoError(t, srv.Authorize(cctx, collaborator, model, nil, sr))
+
+	// decided requests cannot be decided again
+	_, err = srv.RejectSignatureRequest(cctx, model.CurrentVersion())
+	assert.True(t, errors.IsOfType(ErrSignatureRequestNotPending, err))
+
+	// a different signing root needs a new approval
+	err = srv.Authorize(cctx, collaborator, model, nil, utils.RandomSlice(32))
+	assert.True(t, errors.IsOfType(ErrSignatureRequestHeld, err))
+
+	// rejecting the request nobody waits for sends the refusal
+	did, err := contextutil.AccountDID(cctx)
+	assert.NoError(t, err)
+	sub := notification.GetBroker().Subscribe(did.String(), notification.Filter{
+		Types:      []notification.EventType{notification.SignatureRefused},
+		DocumentID: hexutil.Encode(model.ID()),
+	})
+	defer notification.GetBroker().Unsubscribe(sub)
+	_, err = srv.RejectSignatureRequest(cctx, model.CurrentVersion())
+	assert.NoError(t, err)
+	select {
+	case msg := <-sub.Events():
+		data := msg.Data.(notification.SignatureData)
+		assert.Equal(t, collaborator.String(), data.Collaborator)
+		assert.Equal(t, hexutil.Encode(model.CurrentVersion()), data.VersionID)
+		assert.Equal(t, ErrSignatureRequestRejected.Error(), data.Reason)
+	case <-time.After(5 * time.Second):
+		assert.Fail(t, "timed out waiting for the refusal")
+	}
+
+	// missing request
+	_, err = srv.ApproveSignatureRequest(cctx, utils.RandomSlice(32))
+	assert.True(t, errors.IsOfType(ErrSignatureRequestNotFound, err))
+}
+
+func TestPolicyService_Authorize_waiting(t *testing.T) {
+	srv := newTestPolicyService(time.Minute)
+	cctx := testingconfig.CreateAccountContext(t, cfg)
+	assert.NoError(t, srv.UpdatePolicy(cctx, &SignaturePolicy{DefaultAction: PolicyActionHold}))
+	collaborator := testingidentity.GenerateRandomDID()
+
+	for _, approve := range []bool{true, false} {
+		model := heldModel()
+		errc := make(chan error)
+		go func() {
+			errc <- srv.Authorize(cctx, collaborator, model, nil, utils.RandomSlice(32))
+		}()
+
+		// wait for the request to be held
+		var err error
+		for i := 0; i < 100; i++ {
+			if approve {
+				_, err = srv.ApproveSignatureRequest(cctx, model.CurrentVersion())
+			} else {
+				_, err = srv.RejectSignatureRequest(cctx, model.CurrentVersion())
+			}
+			if err == nil {
+				break
+			}
+			time.Sleep(10 * time.Millisecond)
+		}
+		assert.NoError(t, err)
+
+		err = <-errc
+		if approve {
+			assert.NoError(t, err)
+		} else {
+			assert.True(t, errors.IsOfType(ErrSignatureRequestRejected, err))
+		}
+	}
+}
@@ -0,0 +1,468 @@
+package documents
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"reflect"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/centrifuge/go-centrifuge/contextutil"
+	"github.com/centrifuge/go-centrifuge/errors"
+	"github.com/centrifuge/go-centrifuge/identity"
+	"github.com/centrifuge/go-centrifuge/notification"
+	"github.com/centrifuge/go-centrifuge/storage"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/golang/protobuf/proto"
+)
+
+const (
+	// SignaturePolicyPrefix is the prefix of the signature policies in the DB
+	SignaturePolicyPrefix = "signature_policy_"
+
+	// SignatureRequestPrefix is the prefix of the held signature requests in the DB
+	SignatureRequestPrefix = "signature_request_"
+)
+
+// SignatureRequestStatus is the status of a held signature request.
+type SignatureRequestStatus string
+
+const (
+	// SignatureRequestPending is the status of a request waiting for approval
+	SignatureRequestPending SignatureRequestStatus = "pending"
+
+	// SignatureRequestApproved is the status of an approved request. The document is signed when the request is received.
+	SignatureRequestApproved SignatureRequestStatus = "approved"
+
+	// SignatureRequestRejected is the status of a rejected request
+	SignatureRequestRejected SignatureRequestStatus = "rejected"
+)
+
+type contextKey string
+
+// approvedRequest marks the context of the signature of a held request approved after the requester stopped waiting.
+const approvedRequest = contextKey("approved_request")
+
+// withApprovedRequest returns a context marking the signature of an approved request.
+func withApprovedRequest(ctx context.Context) context.Context {
+	return context.WithValue(ctx, approvedRequest, true)
+}
+
+// isApprovedRequest returns true if the context signs an approved request.
+// The events of the request were sent already when it was received.
+func isApprovedRequest(ctx context.Context) bool {
+	approved, _ := ctx.Value(approvedRequest).(bool)
+	return approved
+}
+
+// SignatureRequest is a signature request held by the signature policy of the account.
+// A request is identified by the document version it asks to sign.
+type SignatureRequest struct {
+	ID            hexutil.Bytes          `json:"id"`
+	AccountID     identity.DID           `json:"account_id"`
+	Collaborator  identity.DID           `json:"collaborator"`
+	DocumentID    hexutil.Bytes          `json:"document_id"`
+	Scheme        string                 `json:"scheme"`
+	SigningRoot   hexutil.Bytes          `json:"signing_root"`
+	ChangedFields []string               `json:"changed_fields"`
+	Status        SignatureRequestStatus `json:"status"`
+	CreatedAt     time.Time              `json:"created_at"`
+	UpdatedAt     time.Time              `json:"updated_at"`
+
+	// Document is the serialised core document to sign once the request is approved. Not returned by the service.
+	Document hexutil.Bytes `json:"document,omitempty"`
+}
+
+// JSON marshals the request to json bytes.
+func (r *SignatureRequest) JSON() ([]byte, error) {
+	return json.Marshal(r)
+}
+
+// FromJSON loads the request from json bytes.
+func (r *SignatureRequest) FromJSON(data []byte) error {
+	return json.Unmarshal(data, r)
+}
+
+// Type returns the type of the SignatureRequest.
+func (r *SignatureRequest) Type() reflect.Type {
+	return reflect.TypeOf(r)
+}
+
+// SignaturePolicyService manages the signature policies of the accounts and the signature requests held by them.
+type SignaturePolicyService interface {
+	// GetPolicy returns the signature policy of the account.
+	GetPolicy(ctx context.Context) (*SignaturePolicy, error)
+
+	// UpdatePolicy validates and replaces the signature policy of the account.
+	UpdatePolicy(ctx context.Context, policy *SignaturePolicy) error
+
+	// GetSignatureRequests returns the signature requests held for the account.
+	GetSignatureRequests(ctx context.Context) ([]*SignatureRequest, error)
+
+	// ApproveSignatureRequest approves a pending signature request.
+	// The signature is returned to the peer if it is still waiting or when it requests the signature again.
+	ApproveSignatureRequest(ctx context.Context, id []byte) (*SignatureRequest, error)
+
+	// RejectSignatureRequest rejects a pending signature request.
+	RejectSignatureRequest(ctx context.Context, id []byte) (*SignatureRequest, error)
+
+	// Authorize applies the signature policy of the account to the signature request of the collaborator.
+	// Returns nil if the document can be signed. Held requests wait for a decision for a while
+	// and ErrSignatureRequestHeld is returned if the request is still pending.
+	Authorize(ctx context.Context, collaborator identity.DID, model, old Model, signingRoot []byte) error
+}
+
+// ApprovedFunc is called with an approved signature request that no peer was waiting for.
+type ApprovedFunc func(accountID identity.DID, req *SignatureRequest)
+
+// policyService implements SignaturePolicyService.
+type policyService struct {
+	repo       storage.Repository
+	holdWait   time.Duration
+	onApproved ApprovedFunc
+
+	mu      sync.Mutex
+	waiters map[string][]chan SignatureRequestStatus
+}
+
+// NewSignaturePolicyService returns the default implementation of SignaturePolicyService.
+// holdWait is the time a held request waits for a decision before the peer is told that it is pending.
+// onApproved, if not nil, is called with the approved requests that no peer was waiting for.
+func NewSignaturePolicyService(repo storage.Repository, holdWait time.Duration, onApproved ApprovedFunc) SignaturePolicyService {
+	repo.Register(new(SignaturePolicy))
+	repo.Register(new(SignatureRequest))
+	return &policyService{
+		repo:       repo,
+		holdWait:   holdWait,
+		onApproved: onApproved,
+		waiters:    make(map[string][]chan SignatureRequestStatus),
+	}
+}
+
+func policyKey(accountID identity.DID) []byte {
+	return append([]byte(SignaturePolicyPrefix), []byte(hexutil.Encode(accountID[:]))...)
+}
+
+func signatureRequestPrefix(accountID identity.DID) string {
+	return SignatureRequestPrefix + hexutil.Encode(accountID[:])
+}
+
+func signatureRequestKey(accountID identity.DID, id []byte) []byte {
+	return []byte(signatureRequestPrefix(accountID) + hexutil.Encode(id))
+}
+
+func (s *policyService) GetPolicy(ctx context.Context) (*SignaturePolicy, error) {
+	did, err := contextutil.AccountDID(ctx)
+	if err != nil {
+		return nil, ErrDocumentConfigAccountID
+	}
+
+	return s.getPolicy(did)
+}
+
+func (s *policyService) getPolicy(did identity.DID) (*SignaturePolicy, error) {
+	key := policyKey(did)
+	if !s.repo.Exists(key) {
+		return DefaultSignaturePolicy(), nil
+	}
+
+	m, err := s.repo.Get(key)
+	if err != nil {
+		return nil, err
+	}
+
+	p, ok := m.(*SignaturePolicy)
+	if !ok {
+		return nil, errors.New("invalid signature policy type: %T", m)
+	}
+
+	return p, nil
+}
+
+func (s *policyService) UpdatePolicy(ctx context.Context, policy *SignaturePolicy) error {
+	did, err := contextutil.AccountDID(ctx)
+	if err != nil {
+		return ErrDocumentConfigAccountID
+	}
+
+	if policy == nil {
+		return ErrPayloadNil
+	}
+
+	err = policy.Validate()
+	if err != nil {
+		return err
+	}
+
+	key := policyKey(did)
+	if s.repo.Exists(key) {
+		return s.repo.Update(key, policy)
+	}
+
+	return s.repo.Create(key, policy)
+}
+
+func (s *policyService) GetSignatureRequests(ctx context.Context) ([]*SignatureRequest, error) {
+	did, err := contextutil.AccountDID(ctx)
+	if err != nil {
+		return nil, ErrDocumentConfigAccountID
+	}
+
+	models, err := s.repo.GetAllByPrefix(signatureRequestPrefix(did))
+	if err != nil {
+		return nil, err
+	}
+
+	var reqs []*SignatureRequest
+	for _, m := range models {
+		req, ok := m.(*SignatureRequest)
+		if !ok {
+			continue
+		}
+
+		req.Document = nil
+		reqs = append(reqs, req)
+	}
+
+	sort.Slice(reqs, func(i, j int) bool {
+		return reqs[i].CreatedAt.Before(reqs[j].CreatedAt)
+	})
+	return reqs, nil
+}
+
+func (s *policyService) getSignatureRequest(did identity.DID, id []byte) (*SignatureRequest, error) {
+	m, err := s.repo.Get(signatureRequestKey(did, id))
+	if err != nil {
+		return nil, errors.NewTypedError(ErrSignatureRequestNotFound, err)
+	}
+
+	req, ok := m.(*SignatureRequest)
+	if !ok {
+		return nil, errors.New("invalid signature request type: %T", m)
+	}
+
+	return req, nil
+}
+
+func (s *policyService) ApproveSignatureRequest(ctx context.Context, id []byte) (*SignatureRequest, error) {
+	return s.decide(ctx, id, SignatureRequestApproved)
+}
+
+func (s *policyService) RejectSignatureRequest(ctx context.Context, id []byte) (*SignatureRequest, error) {
+	return s.decide(ctx, id, SignatureRequestRejected)
+}
+
+// decide updates the status of a pending request and wakes up the peer waiting for it.
+func (s *policyService) decide(ctx context.Context, id []byte, status SignatureRequestStatus) (*SignatureRequest, error) {
+	did, err := contextutil.AccountDID(ctx)
+	if err != nil {
+		return nil, ErrDocumentConfigAccountID
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	req, err := s.getSignatureRequest(did, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.Status != SignatureRequestPending {
+		return nil, errors.NewTypedError(ErrSignatureRequestNotPending, errors.New("request is %s", req.Status))
+	}
+
+	req.Status = status
+	req.UpdatedAt = time.Now().UTC()
+	key := signatureRequestKey(did, id)
+	err = s.repo.Update(key, req)
+	if err != nil {
+		return nil, err
+	}
+
+	waiting := len(s.waiters[string(key)]) > 0
+	for _, ch := range s.waiters[string(key)] {
+		ch <- status
+	}
+	delete(s.waiters, string(key))
+
+	// the requester stopped waiting so the signature is sent to it
+	if status == SignatureRequestApproved && !waiting && s.onApproved != nil {
+		r := *req
+		s.onApproved(did, &r)
+	}
+
+	// the waiting requester sends the event of the rejection
+	if status == SignatureRequestRejected && !waiting {
+		notifyRefused(ctx, did, req)
+	}
+
+	req.Document = nil
+	return req, nil
+}
+
+func (s *policyService) Authorize(ctx context.Context, collaborator identity.DID, model, old Model, signingRoot []byte) error {
+	did, err := contextutil.AccountDID(ctx)
+	if err != nil {
+		return ErrDocumentConfigAccountID
+	}
+
+	policy, err := s.getPolicy(did)
+	if err != nil {
+		return err
+	}
+
+	var changed []ChangedField
+	if old != nil {
+		changed, err = old.ChangedFields(model)
+		if err != nil {
+			return err
+		}
+	}
+
+	switch policy.Evaluate(collaborator, model, changed) {
+	case PolicyActionSign:
+		return nil
+	case PolicyActionReject:
+		return ErrSignatureRequestRejected
+	}
+
+	ch, err := s.hold(did, collaborator, model, changed, signingRoot)
+	if err != nil {
+		return err
+	}
+
+	// the request was decided already
+	if ch == nil {
+		return nil
+	}
+
+	srvLog.Infof("signature request for document %x held for approval", model.ID())
+	select {
+	case status := <-ch:
+		if status == SignatureRequestApproved {
+			return nil
+		}
+
+		return ErrSignatureRequestRejected
+	case <-time.After(s.holdWait):
+	case <-ctx.Done():
+	}
+
+	s.stopWaiting(signatureRequestKey(did, model.CurrentVersion()), ch)
+	return ErrSignatureRequestHeld
+}
+
+// hold stores the signature request as pending unless it was decided already for the same signing root.
+// Returns nil channel if the request is approved, the channel to wait on otherwise.
+func (s *policyService) hold(did, collaborator identity.DID, model Model, changed []ChangedField, signingRoot []byte) (chan SignatureRequestStatus, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	key := signatureRequestKey(did, model.CurrentVersion())
+	exists := s.repo.Exists(key)
+	if exists {
+		req, err := s.getSignatureRequest(did, model.CurrentVersion())
+		if err != nil {
+			return nil, err
+		}
+
+		// a decision only holds for the same signing root
+		if bytes.Equal(req.SigningRoot, signingRoot) && req.Collaborator.Equal(collaborator) {
+			switch req.Status {
+			case SignatureRequestApproved:
+				return nil, nil
+			case SignatureRequestRejected:
+				return nil, ErrSignatureRequestRejected
+			default:
+				return s.wait(key), nil
+			}
+		}
+	}
+
+	var fields []string
+	for _, cf := range changed {
+		fields = append(fields, cf.Name)
+	}
+
+	cd, err := model.PackCoreDocument()
+	if err != nil {
+		return nil, err
+	}
+
+	doc, err := proto.Marshal(&cd)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now().UTC()
+	req := &SignatureRequest{
+		ID:            model.CurrentVersion(),
+		AccountID:     did,
+		Collaborator:  collaborator,
+		DocumentID:    model.ID(),
+		Scheme:        model.Scheme(),
+		SigningRoot:   signingRoot,
+		ChangedFields: fields,
+		Status:        SignatureRequestPending,
+		CreatedAt:     now,
+		UpdatedAt:     now,
+		Document:      doc,
+	}
+
+	if exists {
+		err = s.repo.Update(key, req)
+	} else {
+		err = s.repo.Create(key, req)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return s.wait(key), nil
+}
+
+// wait registers a channel that receives the decision on the request. Must be called with the lock held.
+func (s *policyService) wait(key []byte) chan SignatureRequestStatus {
+	ch := make(chan SignatureRequestStatus, 1)
+	s.waiters[string(key)] = append(s.waiters[string(key)], ch)
+	return ch
+}
+
+// stopWaiting removes the channel from the waiters of the request.
+func (s *policyService) stopWaiting(key []byte, ch chan SignatureRequestStatus) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	chs := s.waiters[string(key)]
+	for i, c := range chs {
+		if c == ch {
+			chs = append(chs[:i], chs[i+1:]...)
+			break
+		}
+	}
+
+	if len(chs) == 0 {
+		delete(s.waiters, string(key))
+		return
+	}
+
+	s.waiters[string(key)] = chs
+}
+
+// notifyRefused sends the SignatureRefused notification of the rejected request in the background.
+func notifyRefused(ctx context.Context, did identity.DID, req *SignatureRequest) {
+	notification.Notify(ctx, notification.Message{
+		EventType:  notification.SignatureRefused,
+		AccountID:  did.String(),
+		FromID:     hexutil.Encode(req.Collaborator[:]),
+		ToID:       did.String(),
+		Recorded:   time.Now().UTC(),
+		DocumentID: req.DocumentID.String(),
+		Scheme:     req.Scheme,
+		Data: notification.SignatureData{
+			Collaborator: req.Collaborator.String(),
+			VersionID:    req.ID.String(),
+			SigningRoot:  req.SigningRoot.String(),
+			Reason:       ErrSignatureRequestRejected.Error(),
+		},
+	})
+}
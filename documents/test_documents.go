@@ -7,6 +7,7 @@ import (
 	"time"
 
 	"github.com/centrifuge/centrifuge-protobufs/gen/go/coredocument"
+	"github.com/centrifuge/centrifuge-protobufs/gen/go/p2p"
 	"github.com/centrifuge/go-centrifuge/errors"
 	"github.com/centrifuge/go-centrifuge/identity"
 	"github.com/centrifuge/go-centrifuge/storage"
@@ -172,6 +173,12 @@ func (m *MockModel) GetCollaborators(filterIDs ...identity.DID) (CollaboratorsAc
 	return cas, args.Error(1)
 }
 
+func (m *MockModel) GetSignerCollaborators(filterIDs ...identity.DID) ([]identity.DID, error) {
+	args := m.Called(filterIDs)
+	dids, _ := args.Get(0).([]identity.DID)
+	return dids, args.Error(1)
+}
+
 func (m *MockModel) GetAttributes() []Attribute {
 	args := m.Called()
 	attrs, _ := args.Get(0).([]Attribute)
@@ -252,6 +259,11 @@ func (m *MockRepository) Create(accountID, id []byte, model Model) error {
 	return args.Error(0)
 }
 
+func (m *MockRepository) CreateWithBatch(accountID, id []byte, model Model, batch *storage.Batch) error {
+	args := m.Called(accountID, id, batch)
+	return args.Error(0)
+}
+
 func (m *MockRepository) Update(accountID, id []byte, model Model) error {
 	args := m.Called(accountID, id)
 	return args.Error(0)
@@ -286,3 +298,116 @@ func (b PostBootstrapper) TestBootstrap(ctx map[string]interface{}) error {
 func (PostBootstrapper) TestTearDown() error {
 	return nil
 }
+
+type MockSignaturePolicyService struct {
+	SignaturePolicyService
+	mock.Mock
+}
+
+func (m *MockSignaturePolicyService) GetPolicy(ctx context.Context) (*SignaturePolicy, error) {
+	args := m.Called(ctx)
+	p, _ := args.Get(0).(*SignaturePolicy)
+	return p, args.Error(1)
+}
+
+func (m *MockSignaturePolicyService) UpdatePolicy(ctx context.Context, policy *SignaturePolicy) error {
+	args := m.Called(ctx, policy)
+	return args.Error(0)
+}
+
+func (m *MockSignaturePolicyService) GetSignatureRequests(ctx context.Context) ([]*SignatureRequest, error) {
+	args := m.Called(ctx)
+	reqs, _ := args.Get(0).([]*SignatureRequest)
+	return reqs, args.Error(1)
+}
+
+func (m *MockSignaturePolicyService) ApproveSignatureRequest(ctx context.Context, id []byte) (*SignatureRequest, error) {
+	args := m.Called(ctx, id)
+	req, _ := args.Get(0).(*SignatureRequest)
+	return req, args.Error(1)
+}
+
+func (m *MockSignaturePolicyService) RejectSignatureRequest(ctx context.Context, id []byte) (*SignatureRequest, error) {
+	args := m.Called(ctx, id)
+	req, _ := args.Get(0).(*SignatureRequest)
+	return req, args.Error(1)
+}
+
+func (m *MockSignaturePolicyService) Authorize(ctx context.Context, collaborator identity.DID, model, old Model, signingRoot []byte) error {
+	args := m.Called(ctx, collaborator, model, old, signingRoot)
+	return args.Error(0)
+}
+
+type MockSignatureCollector struct {
+	SignatureCollector
+	mock.Mock
+}
+
+func (m *MockSignatureCollector) Collect(ctx context.Context, model Model) ([]*coredocumentpb.Signature, error) {
+	args := m.Called(ctx, model)
+	sigs, _ := args.Get(0).([]*coredocumentpb.Signature)
+	return sigs, args.Error(1)
+}
+
+func (m *MockSignatureCollector) ReceiveSignatures(ctx context.Context, collaborator identity.DID, version []byte, signatures []*coredocumentpb.Signature) error {
+	args := m.Called(ctx, collaborator, version, signatures)
+	return args.Error(0)
+}
+
+type MockAnchorProcessor struct {
+	mock.Mock
+}
+
+func (m *MockAnchorProcessor) PreAnchorDocument(ctx context.Context, model Model) error {
+	args := m.Called(ctx, model)
+	return args.Error(0)
+}
+
+func (m *MockAnchorProcessor) Send(ctx context.Context, cd coredocumentpb.CoreDocument, recipient identity.DID) (err error) {
+	args := m.Called(ctx, cd, recipient)
+	return args.Error(0)
+}
+
+func (m *MockAnchorProcessor) Anchor(
+	ctx context.Context,
+	coreDocument *coredocumentpb.CoreDocument,
+	saveState func(*coredocumentpb.CoreDocument) error) (err error) {
+	args := m.Called(ctx, coreDocument, saveState)
+	if saveState != nil {
+		err := saveState(coreDocument)
+		if err != nil {
+			return err
+		}
+	}
+	return args.Error(0)
+}
+
+func (m *MockAnchorProcessor) PrepareForSignatureRequests(ctx context.Context, model Model) error {
+	args := m.Called(model)
+	return args.Error(0)
+}
+
+func (m *MockAnchorProcessor) RequestSignatures(ctx context.Context, model Model) error {
+	args := m.Called(ctx, model)
+	return args.Error(0)
+}
+
+func (m *MockAnchorProcessor) PrepareForAnchoring(model Model) error {
+	args := m.Called(model)
+	return args.Error(0)
+}
+
+func (m *MockAnchorProcessor) AnchorDocument(ctx context.Context, model Model) error {
+	args := m.Called(model)
+	return args.Error(0)
+}
+
+func (m *MockAnchorProcessor) SendDocument(ctx context.Context, model Model) error {
+	args := m.Called(ctx, model)
+	return args.Error(0)
+}
+
+func (m *MockAnchorProcessor) RequestDocumentWithAccessToken(ctx context.Context, tokenIdentifier, entityIdentifier, entityRelationIdentifier []byte) (*p2ppb.GetDocumentResponse, error) {
+	args := m.Called(ctx, tokenIdentifier, entityIdentifier, entityRelationIdentifier)
+	return args.Get(0).(*p2ppb.GetDocumentResponse), args.Error(0)
+}
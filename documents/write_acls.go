@@ -209,6 +209,21 @@ func (cd *CoreDocument) CollaboratorCanUpdate(ncd *CoreDocument, collaborator id
 	return ValidateTransitions(rules, cf)
 }
 
+// ChangedCoreFields returns the fields of the core document that are changed in the new core document.
+func (cd *CoreDocument) ChangedCoreFields(ncd *CoreDocument, docType string) ([]ChangedField, error) {
+	oldTree, err := cd.coredocTree(docType)
+	if err != nil {
+		return nil, err
+	}
+
+	newTree, err := ncd.coredocTree(docType)
+	if err != nil {
+		return nil, err
+	}
+
+	return GetChangedFields(oldTree, newTree), nil
+}
+
 // initTransitionRules initiates the transition rules for a given Core document.
 // Collaborators are given default edit capability over all fields of the CoreDocument and underlying documents such as invoices or purchase orders.
 // if the rules are created already, this is a no-op.
@@ -8,6 +8,7 @@ import (
 	"github.com/centrifuge/go-centrifuge/errors"
 	"github.com/centrifuge/go-centrifuge/jobs"
 	"github.com/centrifuge/go-centrifuge/queue"
+	"github.com/centrifuge/go-centrifuge/storage"
 )
 
 // BootstrappedEthereumClient is a key to mapped client in bootstrap context.
@@ -23,7 +24,7 @@ func (Bootstrapper) Bootstrap(ctx map[string]interface{}) error {
 		return err
 	}
 
-	txManager, ok := ctx[jobs.BootstrappedService].(jobs.Manager)
+	jobsMan, ok := ctx[jobs.BootstrappedService].(jobs.Manager)
 	if !ok {
 		return errors.New("transactions repository not initialised")
 	}
@@ -33,18 +34,28 @@ func (Bootstrapper) Bootstrap(ctx map[string]interface{}) error {
 	}
 	queueSrv := ctx[bootstrap.BootstrappedQueueServer].(*queue.Server)
 
-	client, err := NewGethClient(cfg)
+	repo, ok := ctx[storage.BootstrappedDB].(storage.Repository)
+	if !ok {
+		return errors.New("storage not initialised")
+	}
+
+	client, err := newGethClient(cfg)
 	if err != nil {
 		return err
 	}
 
+	// the transaction status task follows the transactions replaced by the transaction manager
+	client.txMan = newTxManager(cfg, repo, client.GetEthClient, client.signer)
 	SetClient(client)
-	ethTransTask := NewTransactionStatusTask(cfg.GetEthereumContextWaitTimeout(), txManager, client.TransactionByHash, client.TransactionReceipt, DefaultWaitForTransactionMiningContext)
+	ethTransTask := NewTransactionStatusTask(
+		cfg.GetEthereumContextWaitTimeout(), jobsMan, client.txMan.TransactionByHash, client.txMan.TransactionReceipt,
+		DefaultWaitForTransactionMiningContext, cfg.GetEthereumConfirmationDepth(), client.GetEthClient().HeaderByNumber)
 	queueSrv.RegisterTaskType(ethTransTask.TaskTypeName(), ethTransTask)
-	waitEventTask := NewWaitEventTask(txManager, func() (ctx context.Context, cancelFunc context.CancelFunc) {
+	waitEventTask := NewWaitEventTask(jobsMan, func() (ctx context.Context, cancelFunc context.CancelFunc) {
 		return DefaultWaitForTransactionMiningContext(cfg.GetEthereumContextReadWaitTimeout())
 	}, client.GetEthClient().FilterLogs)
 	queueSrv.RegisterTaskType(waitEventTask.TaskTypeName(), waitEventTask)
 	ctx[BootstrappedEthereumClient] = client
+	ctx[BootstrappedTxManager] = client.txMan
 	return nil
 }
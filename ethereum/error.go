@@ -20,4 +20,16 @@ const (
 
 	// ErrEthKeyNotProvided holds specific error when ethereum key is not provided
 	ErrEthKeyNotProvided = errors.Error("Ethereum Key not provided")
+
+	// ErrTransactionNotFound must be used when the transaction manager doesn't track a transaction with the hash
+	ErrTransactionNotFound = errors.Error("transaction not found")
+
+	// ErrTransactionNotPending must be used when a transaction can't be replaced because it is mined or being cancelled
+	ErrTransactionNotPending = errors.Error("transaction is not pending")
+
+	// ErrGasPriceCapReached must be used when a transaction can't be replaced because its gas price is at the max gas price
+	ErrGasPriceCapReached = errors.Error("gas price of the transaction can't be raised above the max gas price")
+
+	// ErrUnknownSender must be used when the sender of a transaction is not an ethereum account of the node
+	ErrUnknownSender = errors.Error("transaction sender is not an ethereum account of the node")
 )
@@ -5,7 +5,6 @@ import (
 	"math/big"
 	"net/url"
 	"reflect"
-	"strings"
 	"sync"
 	"time"
 
@@ -14,6 +13,7 @@ import (
 	"github.com/centrifuge/go-centrifuge/identity"
 	"github.com/centrifuge/go-centrifuge/jobs"
 	"github.com/centrifuge/go-centrifuge/queue"
+	"github.com/centrifuge/go-centrifuge/signer"
 	"github.com/ethereum/go-ethereum"
 	"github.com/ethereum/go-ethereum/accounts/abi"
 	"github.com/ethereum/go-ethereum/accounts/abi/bind"
@@ -36,6 +36,9 @@ type Config interface {
 	GetEthereumIntervalRetry() time.Duration
 	GetEthereumMaxRetries() int
 	GetEthereumContextReadWaitTimeout() time.Duration
+	GetEthereumDefaultAccountName() string
+	GetEthereumSignerBackend() string
+	GetEthereumSignerURL() string
 }
 
 // DefaultWaitForTransactionMiningContext returns context with timeout for write operations
@@ -52,6 +55,7 @@ type EthClient interface {
 	bind.ContractBackend
 
 	NonceAt(ctx context.Context, account common.Address, blockNumber *big.Int) (uint64, error)
+	BalanceAt(ctx context.Context, account common.Address, blockNumber *big.Int) (*big.Int, error)
 }
 
 // Client can be implemented by any chain client
@@ -99,20 +103,40 @@ type gethClient struct {
 
 	// txMu to ensure one transaction at a time per client
 	txMu sync.Mutex
+
+	// txMan assigns the nonces and tracks the sent transactions, if set
+	txMan *txManager
 }
 
 // NewGethClient returns an gethClient which implements Client
 func NewGethClient(config Config) (Client, error) {
+	gc, err := newGethClient(config)
+	if err != nil {
+		return nil, err
+	}
+
+	return gc, nil
+}
+
+func newGethClient(config Config) (*gethClient, error) {
 	// This might be removed as soon as we support multiple ethereum keys per account, the error might not be thrown at startup
 	acc, err := config.GetEthereumAccount("main")
 	if err != nil {
 		return nil, err
 	}
-	if acc.Key == "" {
-		return nil, ErrEthKeyNotProvided
-	}
-	if acc.Password == "" {
-		log.Warningf("Main Ethereum Password not provided")
+	switch config.GetEthereumSignerBackend() {
+	case "", signer.BackendKeystore:
+		if acc.Key == "" {
+			return nil, ErrEthKeyNotProvided
+		}
+		if acc.Password == "" {
+			log.Warningf("Main Ethereum Password not provided")
+		}
+	default:
+		// the key is held by the remote signer
+		if !common.IsHexAddress(acc.Address) {
+			return nil, errors.NewTypedError(ErrEthKeyNotProvided, errors.New("main ethereum address required by the remote signer"))
+		}
 	}
 
 	log.Info("Opening connection to Ethereum:", config.GetEthereumNodeURL())
@@ -245,11 +269,37 @@ func (gc *gethClient) getGethTxOpts(accountName string) (*bind.TransactOpts, err
 		return nil, errors.NewTypedError(ErrEthTransaction, errors.New("failed to get ethereum account: %v", err))
 	}
 
-	opts, err := bind.NewTransactor(strings.NewReader(account.Key), account.Password)
+	s, err := signer.NewEthereumSigner(gc.config.GetEthereumSignerBackend(), gc.config.GetEthereumSignerURL(), account)
 	if err != nil {
 		return nil, errors.NewTypedError(ErrEthTransaction, errors.New("failed to create new transaction opts: %v", err))
 	}
-	return opts, nil
+	return signer.TransactOpts(s), nil
+}
+
+// signer returns the signer of the node ethereum account with the address.
+func (gc *gethClient) signer(from common.Address) (bind.SignerFn, error) {
+	gc.accMu.Lock()
+	defer gc.accMu.Unlock()
+
+	for _, opts := range gc.accounts {
+		if opts.From == from {
+			return opts.Signer, nil
+		}
+	}
+
+	// accounts are loaded on their first transaction, so the default one might not be loaded yet after a restart
+	name := gc.config.GetEthereumDefaultAccountName()
+	opts, err := gc.getGethTxOpts(name)
+	if err != nil {
+		return nil, err
+	}
+
+	if opts.From != from {
+		return nil, errors.NewTypedError(ErrUnknownSender, errors.New("sender %s", from.Hex()))
+	}
+
+	gc.accounts[name] = opts
+	return opts.Signer, nil
 }
 
 // getOptimalGasPrice get the optimal current gas price from eth client
@@ -348,6 +398,7 @@ func (gc *gethClient) SubmitTransactionWithRetries(contractMethod interface{}, o
 		}
 
 		if err == nil {
+			gc.trackTransaction(opts.From, tx)
 			return tx, nil
 		}
 
@@ -371,8 +422,31 @@ func (gc *gethClient) GetGethCallOpts(pending bool) (*bind.CallOpts, context.Can
 	return &bind.CallOpts{Pending: pending, Context: ctx}, cancel
 }
 
+// trackTransaction passes the sent transaction to the transaction manager, if set.
+func (gc *gethClient) trackTransaction(from common.Address, tx *types.Transaction) {
+	if gc.txMan == nil || tx == nil {
+		return
+	}
+
+	// the transaction is sent already, so the error is only logged. An untracked transaction is not replaced if it gets stuck.
+	err := gc.txMan.track(from, tx)
+	if err != nil {
+		log.Errorf("failed to track ethereum transaction %s: %v", tx.Hash().Hex(), err)
+	}
+}
+
 // setNonce updates the opts.Nonce to next valid nonce
 func (gc *gethClient) setNonce(opts *bind.TransactOpts) error {
+	if gc.txMan != nil {
+		n, err := gc.txMan.nextNonce(opts.From)
+		if err != nil {
+			return err
+		}
+
+		opts.Nonce = new(big.Int).SetUint64(n)
+		return nil
+	}
+
 	ctx, cancel := gc.defaultReadContext()
 	defer cancel()
 
@@ -26,6 +26,10 @@ func (m *MockEthClient) GetGethCallOpts(pending bool) (*bind.CallOpts, context.C
 
 func (m *MockEthClient) GetEthClient() EthClient {
 	args := m.Called()
+	if c, ok := args.Get(0).(EthClient); ok {
+		return c
+	}
+
 	c, _ := args.Get(0).(*ethclient.Client)
 	return c
 }
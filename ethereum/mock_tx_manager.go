@@ -0,0 +1,25 @@
+// +build integration unit
+
+package ethereum
+
+import (
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/mock"
+)
+
+// MockTransactionManager implements TransactionManager.
+type MockTransactionManager struct {
+	mock.Mock
+}
+
+func (m *MockTransactionManager) PendingTransactions() ([]*Transaction, error) {
+	args := m.Called()
+	txs, _ := args.Get(0).([]*Transaction)
+	return txs, args.Error(1)
+}
+
+func (m *MockTransactionManager) CancelTransaction(hash common.Hash) (*Transaction, error) {
+	args := m.Called(hash)
+	tx, _ := args.Get(0).(*Transaction)
+	return tx, args.Error(1)
+}
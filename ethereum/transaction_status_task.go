@@ -2,6 +2,7 @@ package ethereum
 
 import (
 	"context"
+	"math/big"
 	"time"
 
 	"github.com/centrifuge/go-centrifuge/errors"
@@ -35,6 +36,9 @@ const (
 
 	// TransactionStatusSuccess contains the flag for a successful receipt.status
 	TransactionStatusSuccess uint64 = 1
+
+	// InclusionChain is the chain recorded in the job inclusions of ethereum transactions
+	InclusionChain = "ethereum"
 )
 
 // WatchTransaction holds the transaction status received form chain event
@@ -53,6 +57,10 @@ type TransactionStatusTask struct {
 	ethContextInitializer func(d time.Duration) (ctx context.Context, cancelFunc context.CancelFunc)
 	transactionByHash     func(ctx context.Context, hash common.Hash) (tx *types.Transaction, isPending bool, err error)
 	transactionReceipt    func(ctx context.Context, txHash common.Hash) (*types.Receipt, error)
+	headerByNumber        func(ctx context.Context, number *big.Int) (*types.Header, error)
+
+	// confirmationDepth is the number of blocks, including the block of the transaction, required to confirm it
+	confirmationDepth int
 
 	//txHash is the id of an Ethereum transaction
 	txHash    string
@@ -70,6 +78,8 @@ func NewTransactionStatusTask(
 	transactionByHash func(ctx context.Context, hash common.Hash) (tx *types.Transaction, isPending bool, err error),
 	transactionReceipt func(ctx context.Context, txHash common.Hash) (*types.Receipt, error),
 	ethContextInitializer func(d time.Duration) (ctx context.Context, cancelFunc context.CancelFunc),
+	confirmationDepth int,
+	headerByNumber func(ctx context.Context, number *big.Int) (*types.Header, error),
 
 ) *TransactionStatusTask {
 	return &TransactionStatusTask{
@@ -78,6 +88,8 @@ func NewTransactionStatusTask(
 		ethContextInitializer: ethContextInitializer,
 		transactionByHash:     transactionByHash,
 		transactionReceipt:    transactionReceipt,
+		confirmationDepth:     confirmationDepth,
+		headerByNumber:        headerByNumber,
 	}
 }
 
@@ -95,10 +107,17 @@ func (tst *TransactionStatusTask) Copy() (gocelery.CeleryTask, error) {
 		transactionByHash:     tst.transactionByHash,
 		transactionReceipt:    tst.transactionReceipt,
 		ethContextInitializer: tst.ethContextInitializer,
+		confirmationDepth:     tst.confirmationDepth,
+		headerByNumber:        tst.headerByNumber,
 		BaseTask:              jobsv1.BaseTask{JobManager: tst.JobManager},
 	}, nil
 }
 
+// JobDetails returns the account and the job of the task.
+func (tst *TransactionStatusTask) JobDetails() (identity.DID, jobs.JobID) {
+	return tst.accountID, tst.JobID
+}
+
 // ParseKwargs - define a method to parse CentID
 func (tst *TransactionStatusTask) ParseKwargs(kwargs map[string]interface{}) (err error) {
 	err = tst.ParseJobID(tst.TaskTypeName(), kwargs)
@@ -182,20 +201,69 @@ func (tst *TransactionStatusTask) getEventValueFromTransactionReceipt(ctx contex
 	return nil, errors.NewTypedError(ErrEthTransaction, errors.New("Event [%s] with value idx [%d] not found", event, idxValue))
 }
 
-func (tst *TransactionStatusTask) isTransactionSuccessful(ctx context.Context, txHash string) error {
+func (tst *TransactionStatusTask) isTransactionSuccessful(ctx context.Context, txHash string) (*types.Receipt, error) {
 	receipt, err := tst.transactionReceipt(ctx, common.HexToHash(txHash))
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	if receipt.Status != TransactionStatusSuccess {
-		return ErrTransactionFailed
+		return nil, ErrTransactionFailed
 	}
 
-	return nil
+	return receipt, nil
+}
+
+// confirmInclusion returns the inclusion of the transaction once its block reached the confirmation depth.
+// The block of the receipt must still be in the canonical chain, otherwise the transaction was reorged and
+// the task waits for its new inclusion.
+func (tst *TransactionStatusTask) confirmInclusion(ctx context.Context, receipt *types.Receipt) (*jobs.Inclusion, error) {
+	confirmations := uint64(1)
+	if tst.confirmationDepth > 0 {
+		head, err := tst.headerByNumber(ctx, nil)
+		if err != nil {
+			return nil, err
+		}
+
+		confirmations = 0
+		if head.Number.Cmp(receipt.BlockNumber) >= 0 {
+			confirmations = new(big.Int).Sub(head.Number, receipt.BlockNumber).Uint64() + 1
+		}
+
+		if confirmations < uint64(tst.confirmationDepth) {
+			log.Infof("Transaction %s has %d/%d confirmations", tst.txHash, confirmations, tst.confirmationDepth)
+			return nil, gocelery.ErrTaskRetryable
+		}
+
+		header, err := tst.headerByNumber(ctx, receipt.BlockNumber)
+		if err != nil {
+			return nil, err
+		}
+
+		if header.Hash() != receipt.BlockHash {
+			log.Warningf("Block %s of transaction %s was reorged, waiting for its new inclusion", receipt.BlockHash.Hex(), tst.txHash)
+			return nil, gocelery.ErrTaskRetryable
+		}
+	}
+
+	var bn uint64
+	if receipt.BlockNumber != nil {
+		bn = receipt.BlockNumber.Uint64()
+	}
+
+	return &jobs.Inclusion{
+		Chain:         InclusionChain,
+		TxHash:        receipt.TxHash.Hex(),
+		BlockNumber:   bn,
+		BlockHash:     receipt.BlockHash.Hex(),
+		Confirmations: confirmations,
+		ConfirmedAt:   time.Now().UTC(),
+	}, nil
 }
 
 // RunTask calls listens to events from geth related to MintingConfirmationTask#TokenID and records result.
+// The task only reads the chain state, so running it again after a restart is safe.
+// The transaction is confirmed once its block reached the confirmation depth and is still in the canonical chain.
 func (tst *TransactionStatusTask) RunTask() (resp interface{}, err error) {
 	var jobValue *jobs.JobValue
 	ctx, cancelF := tst.ethContextInitializer(tst.timeout)
@@ -218,14 +286,24 @@ func (tst *TransactionStatusTask) RunTask() (resp interface{}, err error) {
 		return nil, gocelery.ErrTaskRetryable
 	}
 
-	err = tst.isTransactionSuccessful(ctx, tst.txHash)
+	receipt, err := tst.isTransactionSuccessful(ctx, tst.txHash)
 	if err != nil {
-		if err != ErrTransactionFailed {
+		if !errors.IsOfType(ErrTransactionFailed, err) {
 			err = gocelery.ErrTaskRetryable
 		}
 		return nil, err
 	}
 
+	inclusion, err := tst.confirmInclusion(ctx, receipt)
+	if err != nil {
+		return nil, gocelery.ErrTaskRetryable
+	}
+
+	err = tst.JobManager.UpdateJobInclusion(tst.accountID, tst.JobID, *inclusion)
+	if err != nil {
+		return nil, err
+	}
+
 	if tst.eventName != "" {
 		v, err := tst.getEventValueFromTransactionReceipt(ctx, tst.txHash, tst.eventName, tst.eventValueIdx)
 		if err != nil {
@@ -20,7 +20,7 @@ func enqueueJob(t *testing.T, txHash string) (jobs.Manager, identity.DID, jobs.J
 	jobManager := ctx[jobs.BootstrappedService].(jobs.Manager)
 
 	cid := testingidentity.GenerateRandomDID()
-	tx, done, err := jobManager.ExecuteWithinJob(context.Background(), cid, jobs.NilJobID(), "Check TX status", func(accountID identity.DID, jobID jobs.JobID, txMan jobs.Manager, errChan chan<- error) {
+	tx, done, err := jobManager.ExecuteWithinJob(context.Background(), cid, jobs.NilJobID(), "Check TX status", func(ctx context.Context, accountID identity.DID, jobID jobs.JobID, txMan jobs.Manager, errChan chan<- error) {
 		result, err := queueSrv.EnqueueJob(ethereum.EthTXStatusTaskName, map[string]interface{}{
 			jobs.JobIDParam:                  jobID.String(),
 			ethereum.TransactionAccountParam: cid.String(),
@@ -4,12 +4,14 @@ package ethereum
 
 import (
 	"context"
+	"math/big"
 	"testing"
 	"time"
 
 	"github.com/centrifuge/go-centrifuge/jobs"
 	"github.com/centrifuge/go-centrifuge/testingutils/identity"
 	"github.com/centrifuge/go-centrifuge/utils"
+	"github.com/centrifuge/gocelery"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/crypto"
@@ -130,7 +132,7 @@ func TestGetEventValueFromTransactionReceipt(t *testing.T) {
 
 	// Empty event list error
 	mockClient.On("TransactionReceipt", mock.Anything, common.HexToHash("0x1")).Return(&types.Receipt{Status: 1}, nil).Once()
-	ethTransTask := NewTransactionStatusTask(200*time.Millisecond, nil, nil, mockClient.TransactionReceipt, nil)
+	ethTransTask := NewTransactionStatusTask(200*time.Millisecond, nil, nil, mockClient.TransactionReceipt, nil, 0, nil)
 	v, err := ethTransTask.getEventValueFromTransactionReceipt(context.Background(), "0x1", eventName, eventIdx)
 	assert.Error(t, err)
 	assert.Nil(t, v)
@@ -138,7 +140,7 @@ func TestGetEventValueFromTransactionReceipt(t *testing.T) {
 	// Logs missing topics error
 	receiptLog := &types.Log{}
 	mockClient.On("TransactionReceipt", mock.Anything, common.HexToHash("0x1")).Return(&types.Receipt{Status: 1, Logs: []*types.Log{receiptLog}}, nil).Once()
-	ethTransTask = NewTransactionStatusTask(200*time.Millisecond, nil, nil, mockClient.TransactionReceipt, nil)
+	ethTransTask = NewTransactionStatusTask(200*time.Millisecond, nil, nil, mockClient.TransactionReceipt, nil, 0, nil)
 	v, err = ethTransTask.getEventValueFromTransactionReceipt(context.Background(), "0x1", eventName, eventIdx)
 	assert.Error(t, err)
 	assert.Nil(t, v)
@@ -150,7 +152,7 @@ func TestGetEventValueFromTransactionReceipt(t *testing.T) {
 		},
 	}
 	mockClient.On("TransactionReceipt", mock.Anything, common.HexToHash("0x1")).Return(&types.Receipt{Status: 1, Logs: []*types.Log{receiptLog}}, nil).Once()
-	ethTransTask = NewTransactionStatusTask(200*time.Millisecond, nil, nil, mockClient.TransactionReceipt, nil)
+	ethTransTask = NewTransactionStatusTask(200*time.Millisecond, nil, nil, mockClient.TransactionReceipt, nil, 0, nil)
 	v, err = ethTransTask.getEventValueFromTransactionReceipt(context.Background(), "0x1", wrongEvent, eventIdx)
 	assert.Error(t, err)
 	assert.Nil(t, v)
@@ -163,7 +165,7 @@ func TestGetEventValueFromTransactionReceipt(t *testing.T) {
 		},
 	}
 	mockClient.On("TransactionReceipt", mock.Anything, common.HexToHash("0x1")).Return(&types.Receipt{Status: 1, Logs: []*types.Log{receiptLog}}, nil).Once()
-	ethTransTask = NewTransactionStatusTask(200*time.Millisecond, nil, nil, mockClient.TransactionReceipt, nil)
+	ethTransTask = NewTransactionStatusTask(200*time.Millisecond, nil, nil, mockClient.TransactionReceipt, nil, 0, nil)
 	v, err = ethTransTask.getEventValueFromTransactionReceipt(context.Background(), "0x1", eventName, 2)
 	assert.Error(t, err)
 	assert.Nil(t, v)
@@ -176,9 +178,48 @@ func TestGetEventValueFromTransactionReceipt(t *testing.T) {
 		},
 	}
 	mockClient.On("TransactionReceipt", mock.Anything, common.HexToHash("0x1")).Return(&types.Receipt{Status: 1, Logs: []*types.Log{receiptLog}}, nil).Once()
-	ethTransTask = NewTransactionStatusTask(200*time.Millisecond, nil, nil, mockClient.TransactionReceipt, nil)
+	ethTransTask = NewTransactionStatusTask(200*time.Millisecond, nil, nil, mockClient.TransactionReceipt, nil, 0, nil)
 	v, err = ethTransTask.getEventValueFromTransactionReceipt(context.Background(), "0x1", eventName, eventIdx)
 	assert.NoError(t, err)
 	assert.Equal(t, []byte{0x0, 0x0, 0x0, 0x0, 0x0, 0x0, 0x0, 0x0, 0x0, 0x0, 0x0, 0x0, 0x0, 0x0, 0x0, 0x0, 0x0, 0x0, 0x0, 0x0, 0x0, 0x0, 0x0, 0x0, 0x0, 0x0, 0x0, 0x0, 0x1, 0x2, 0x3, 0x4}, v)
 
 }
+
+func TestTransactionStatusTask_confirmInclusion(t *testing.T) {
+	head := big.NewInt(12)
+	canonical := &types.Header{Number: big.NewInt(10)}
+	headerByNumber := func(ctx context.Context, number *big.Int) (*types.Header, error) {
+		if number == nil {
+			return &types.Header{Number: head}, nil
+		}
+
+		return canonical, nil
+	}
+	receipt := &types.Receipt{Status: 1, TxHash: common.HexToHash("0x1"), BlockNumber: big.NewInt(10), BlockHash: canonical.Hash()}
+
+	// no confirmation depth
+	task := NewTransactionStatusTask(200*time.Millisecond, nil, nil, nil, nil, 0, nil)
+	inclusion, err := task.confirmInclusion(context.Background(), receipt)
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(10), inclusion.BlockNumber)
+	assert.Equal(t, uint64(1), inclusion.Confirmations)
+
+	// depth not reached
+	task = NewTransactionStatusTask(200*time.Millisecond, nil, nil, nil, nil, 6, headerByNumber)
+	_, err = task.confirmInclusion(context.Background(), receipt)
+	assert.Equal(t, gocelery.ErrTaskRetryable, err)
+
+	// depth reached
+	head = big.NewInt(15)
+	inclusion, err = task.confirmInclusion(context.Background(), receipt)
+	assert.NoError(t, err)
+	assert.Equal(t, InclusionChain, inclusion.Chain)
+	assert.Equal(t, receipt.TxHash.Hex(), inclusion.TxHash)
+	assert.Equal(t, canonical.Hash().Hex(), inclusion.BlockHash)
+	assert.Equal(t, uint64(6), inclusion.Confirmations)
+
+	// block of the receipt reorged
+	canonical = &types.Header{Number: big.NewInt(10), Extra: []byte{1}}
+	_, err = task.confirmInclusion(context.Background(), receipt)
+	assert.Equal(t, gocelery.ErrTaskRetryable, err)
+}
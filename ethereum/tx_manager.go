@@ -0,0 +1,543 @@
+package ethereum
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"reflect"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/centrifuge/go-centrifuge/errors"
+	"github.com/centrifuge/go-centrifuge/storage"
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+const (
+	// BootstrappedTxManager is the key to the TransactionManager in the bootstrap context.
+	BootstrappedTxManager = "BootstrappedTxManager"
+
+	txPrefix = "ethereum_tx_"
+
+	// minGasPriceBump is the minimum percentage ethereum nodes require to replace a pending transaction
+	minGasPriceBump = 10
+
+	// cancelGasLimit is the gas of the empty transfer replacing a cancelled transaction
+	cancelGasLimit = 21000
+
+	// finishedTxRetention is how long mined and cancelled transactions are kept to resolve the hashes of their replaced attempts
+	finishedTxRetention = 24 * time.Hour
+)
+
+// TxStatus is the status of a transaction tracked by the transaction manager.
+type TxStatus string
+
+const (
+	// TxPending is the status of a transaction waiting to be mined
+	TxPending TxStatus = "pending"
+
+	// TxCancelling is the status of a transaction replaced by an empty transfer that is not mined yet
+	TxCancelling TxStatus = "cancelling"
+
+	// TxMined is the status of a transaction mined with one of its attempts
+	TxMined TxStatus = "mined"
+
+	// TxCancelled is the status of a transaction whose nonce was used by the empty transfer replacing it
+	TxCancelled TxStatus = "cancelled"
+
+	// TxDropped is the status of a transaction whose nonce was used by a transaction not sent by the node
+	TxDropped TxStatus = "dropped"
+)
+
+// TxAttempt is a signed version of a transaction sent to the ethereum node.
+type TxAttempt struct {
+	Hash     common.Hash `json:"hash"`
+	GasPrice *big.Int    `json:"gas_price"`
+	SentAt   time.Time   `json:"sent_at"`
+
+	// Cancel is true if the attempt is the empty transfer cancelling the transaction
+	Cancel bool `json:"cancel"`
+}
+
+// Transaction is a transaction of a node account, tracked from its submission until one of its attempts is mined.
+type Transaction struct {
+	From      common.Address  `json:"from"`
+	Nonce     uint64          `json:"nonce"`
+	To        *common.Address `json:"to"`
+	Value     *big.Int        `json:"value"`
+	Data      hexutil.Bytes   `json:"data"`
+	GasLimit  uint64          `json:"gas_limit"`
+	Status    TxStatus        `json:"status"`
+	Attempts  []TxAttempt     `json:"attempts"`
+	MinedHash common.Hash     `json:"mined_hash"`
+	CreatedAt time.Time       `json:"created_at"`
+	UpdatedAt time.Time       `json:"updated_at"`
+}
+
+// JSON marshals the transaction to json bytes.
+func (tx *Transaction) JSON() ([]byte, error) {
+	return json.Marshal(tx)
+}
+
+// FromJSON loads the transaction from json bytes.
+func (tx *Transaction) FromJSON(data []byte) error {
+	return json.Unmarshal(data, tx)
+}
+
+// Type returns the type of the Transaction.
+func (tx *Transaction) Type() reflect.Type {
+	return reflect.TypeOf(tx)
+}
+
+// LastAttempt returns the latest attempt sent for the transaction.
+func (tx *Transaction) LastAttempt() TxAttempt {
+	return tx.Attempts[len(tx.Attempts)-1]
+}
+
+// InFlight returns true if none of the attempts of the transaction is mined yet.
+func (tx *Transaction) InFlight() bool {
+	return tx.Status == TxPending || tx.Status == TxCancelling
+}
+
+func (tx *Transaction) hasAttempt(hash common.Hash) bool {
+	for _, a := range tx.Attempts {
+		if a.Hash == hash {
+			return true
+		}
+	}
+
+	return false
+}
+
+func txKey(from common.Address, nonce uint64) []byte {
+	return []byte(fmt.Sprintf("%s%s_%020d", txPrefix, strings.ToLower(from.Hex()), nonce))
+}
+
+// TransactionManager assigns the nonces of the node's ethereum accounts and tracks their transactions until they are mined.
+// Transactions pending for longer than the bump timeout are sent again with a higher gas price.
+type TransactionManager interface {
+	// PendingTransactions returns the in-flight transactions ordered by sender and nonce.
+	PendingTransactions() ([]*Transaction, error)
+
+	// CancelTransaction replaces the pending transaction having an attempt with the hash by an empty transfer to its sender.
+	CancelTransaction(hash common.Hash) (*Transaction, error)
+}
+
+// TxManagerConfig is the config of the transaction manager.
+type TxManagerConfig interface {
+	GetEthereumMaxGasPrice() *big.Int
+	GetEthereumContextReadWaitTimeout() time.Duration
+	GetEthereumTxCheckInterval() time.Duration
+	GetEthereumTxBumpTimeout() time.Duration
+	GetEthereumGasPriceBump() int
+}
+
+// txManager implements TransactionManager and runs as a node server replacing the stuck transactions.
+type txManager struct {
+	config TxManagerConfig
+	repo   storage.Repository
+	client func() EthClient
+	signer func(from common.Address) (bind.SignerFn, error)
+
+	// mu serialises the nonce assignment with the updates of the tracked transactions
+	mu sync.Mutex
+}
+
+// newTxManager returns a transaction manager persisting the transactions in the repository.
+func newTxManager(config TxManagerConfig, repo storage.Repository, client func() EthClient, signer func(from common.Address) (bind.SignerFn, error)) *txManager {
+	repo.Register(new(Transaction))
+	return &txManager{config: config, repo: repo, client: client, signer: signer}
+}
+
+// Name returns the name of the transaction manager server.
+func (m *txManager) Name() string {
+	return "EthereumTransactionManager"
+}
+
+// Start checks the pending transactions every check interval until the context is done.
+func (m *txManager) Start(ctx context.Context, wg *sync.WaitGroup, startupErr chan<- error) {
+	defer wg.Done()
+	interval := m.config.GetEthereumTxCheckInterval()
+	if interval <= 0 {
+		log.Info("ethereum transaction manager disabled")
+		<-ctx.Done()
+		return
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			log.Info("ethereum transaction manager stopped")
+			return
+		case <-ticker.C:
+			if err := m.check(time.Now().UTC()); err != nil {
+				log.Errorf("failed to check pending ethereum transactions: %v", err)
+			}
+		}
+	}
+}
+
+func (m *txManager) readContext() (context.Context, context.CancelFunc) {
+	return context.WithTimeout(context.Background(), m.config.GetEthereumContextReadWaitTimeout())
+}
+
+// transactions returns all the tracked transactions ordered by sender and nonce.
+func (m *txManager) transactions() ([]*Transaction, error) {
+	models, err := m.repo.GetAllByPrefix(txPrefix)
+	if err != nil {
+		return nil, err
+	}
+
+	txs := make([]*Transaction, 0, len(models))
+	for _, model := range models {
+		txs = append(txs, model.(*Transaction))
+	}
+
+	sort.Slice(txs, func(i, j int) bool {
+		if txs[i].From != txs[j].From {
+			return txs[i].From.Hex() < txs[j].From.Hex()
+		}
+
+		return txs[i].Nonce < txs[j].Nonce
+	})
+
+	return txs, nil
+}
+
+func (m *txManager) save(tx *Transaction) error {
+	batch := storage.NewBatch()
+	batch.Put(txKey(tx.From, tx.Nonce), tx)
+	return m.repo.Write(batch)
+}
+
+// nextNonce returns the nonce of the next transaction of the account.
+// Nonces are assigned after the in-flight transactions of the account, so a transaction dropped by the ethereum node
+// doesn't get its nonce reused while it is sent again.
+func (m *txManager) nextNonce(from common.Address) (uint64, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	ctx, cancel := m.readContext()
+	defer cancel()
+	nonce, err := m.client().PendingNonceAt(ctx, from)
+	if err != nil {
+		return 0, errors.NewTypedError(ErrEthTransaction, errors.New("failed to get chain nonce for %s: %v", from.String(), err))
+	}
+
+	txs, err := m.transactions()
+	if err != nil {
+		return 0, err
+	}
+
+	for _, tx := range txs {
+		if tx.From == from && tx.InFlight() && tx.Nonce >= nonce {
+			nonce = tx.Nonce + 1
+		}
+	}
+
+	return nonce, nil
+}
+
+// track starts tracking the transaction sent by the account.
+func (m *txManager) track(from common.Address, sent *types.Transaction) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	now := time.Now().UTC()
+	return m.save(&Transaction{
+		From:      from,
+		Nonce:     sent.Nonce(),
+		To:        sent.To(),
+		Value:     sent.Value(),
+		Data:      sent.Data(),
+		GasLimit:  sent.Gas(),
+		Status:    TxPending,
+		Attempts:  []TxAttempt{{Hash: sent.Hash(), GasPrice: sent.GasPrice(), SentAt: now}},
+		CreatedAt: now,
+		UpdatedAt: now,
+	})
+}
+
+// PendingTransactions returns the in-flight transactions ordered by sender and nonce.
+func (m *txManager) PendingTransactions() ([]*Transaction, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	txs, err := m.transactions()
+	if err != nil {
+		return nil, err
+	}
+
+	var pending []*Transaction
+	for _, tx := range txs {
+		if tx.InFlight() {
+			pending = append(pending, tx)
+		}
+	}
+
+	return pending, nil
+}
+
+// CancelTransaction replaces the pending transaction having an attempt with the hash by an empty transfer to its sender.
+// The transaction is cancelled once the transfer is mined; it can still be mined if one of its attempts is mined first.
+func (m *txManager) CancelTransaction(hash common.Hash) (*Transaction, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	tx, err := m.find(hash)
+	if err != nil {
+		return nil, err
+	}
+
+	if tx.Status != TxPending {
+		return nil, errors.NewTypedError(ErrTransactionNotPending, errors.New("transaction %s is %s", hash.Hex(), tx.Status))
+	}
+
+	err = m.replace(tx, true, time.Now().UTC())
+	if err != nil {
+		return nil, err
+	}
+
+	return tx, nil
+}
+
+func (m *txManager) find(hash common.Hash) (*Transaction, error) {
+	txs, err := m.transactions()
+	if err != nil {
+		return nil, err
+	}
+
+	for _, tx := range txs {
+		if tx.hasAttempt(hash) {
+			return tx, nil
+		}
+	}
+
+	return nil, errors.NewTypedError(ErrTransactionNotFound, errors.New("transaction %s", hash.Hex()))
+}
+
+// replacementGasPrice returns the gas price of the next attempt of the transaction,
+// raised by the gas price bump or to the suggested gas price and capped by the max gas price.
+func (m *txManager) replacementGasPrice(ctx context.Context, tx *Transaction) (*big.Int, error) {
+	bump := m.config.GetEthereumGasPriceBump()
+	if bump < minGasPriceBump {
+		bump = minGasPriceBump
+	}
+
+	last := tx.LastAttempt().GasPrice
+	price := new(big.Int).Mul(last, big.NewInt(int64(100+bump)))
+	price.Div(price, big.NewInt(100))
+	suggested, err := m.client().SuggestGasPrice(ctx)
+	if err != nil {
+		return nil, errors.NewTypedError(ErrEthTransaction, errors.New("failed to get suggested gas price: %v", err))
+	}
+
+	if suggested.Cmp(price) > 0 {
+		price = suggested
+	}
+
+	maxPrice := m.config.GetEthereumMaxGasPrice()
+	if price.Cmp(maxPrice) > 0 {
+		price = new(big.Int).Set(maxPrice)
+	}
+
+	if price.Cmp(last) <= 0 {
+		return nil, errors.NewTypedError(ErrGasPriceCapReached, errors.New("transaction %s has gas price %s", tx.LastAttempt().Hash.Hex(), last.String()))
+	}
+
+	return price, nil
+}
+
+// replace sends a new attempt of the transaction with the same nonce and a higher gas price.
+// Once a transaction is being cancelled, all its new attempts are empty transfers to the sender.
+func (m *txManager) replace(tx *Transaction, cancel bool, now time.Time) error {
+	ctx, cancelCtx := m.readContext()
+	defer cancelCtx()
+
+	price, err := m.replacementGasPrice(ctx, tx)
+	if err != nil {
+		return err
+	}
+
+	cancel = cancel || tx.Status == TxCancelling
+	var raw *types.Transaction
+	if cancel {
+		raw = types.NewTransaction(tx.Nonce, tx.From, big.NewInt(0), cancelGasLimit, price, nil)
+	} else if tx.To == nil {
+		raw = types.NewContractCreation(tx.Nonce, tx.Value, tx.GasLimit, price, tx.Data)
+	} else {
+		raw = types.NewTransaction(tx.Nonce, *tx.To, tx.Value, tx.GasLimit, price, tx.Data)
+	}
+
+	sign, err := m.signer(tx.From)
+	if err != nil {
+		return err
+	}
+
+	// same signer as the contract bindings
+	signed, err := sign(types.HomesteadSigner{}, tx.From, raw)
+	if err != nil {
+		return errors.NewTypedError(ErrEthTransaction, errors.New("failed to sign transaction: %v", err))
+	}
+
+	err = m.client().SendTransaction(ctx, signed)
+	if err != nil {
+		return errors.NewTypedError(ErrEthTransaction, errors.New("failed to send replacement of transaction %s: %v", tx.LastAttempt().Hash.Hex(), err))
+	}
+
+	log.Infof("Replaced ethereum transaction %s with %s, nonce [%d], gas price [%s], cancel [%t]",
+		tx.LastAttempt().Hash.Hex(), signed.Hash().Hex(), tx.Nonce, price.String(), cancel)
+	tx.Attempts = append(tx.Attempts, TxAttempt{Hash: signed.Hash(), GasPrice: price, SentAt: now, Cancel: cancel})
+	if cancel {
+		tx.Status = TxCancelling
+	}
+
+	tx.UpdatedAt = now
+	return m.save(tx)
+}
+
+// check settles the transactions whose nonce is mined, replaces the ones pending for longer than the bump timeout
+// and deletes the finished transactions older than their retention.
+func (m *txManager) check(now time.Time) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	txs, err := m.transactions()
+	if err != nil {
+		return err
+	}
+
+	batch := storage.NewBatch()
+	mined := make(map[common.Address]uint64)
+	for _, tx := range txs {
+		if !tx.InFlight() {
+			if now.Sub(tx.UpdatedAt) > finishedTxRetention {
+				batch.Delete(txKey(tx.From, tx.Nonce))
+			}
+
+			continue
+		}
+
+		nonce, ok := mined[tx.From]
+		if !ok {
+			nonce, err = m.minedNonce(tx.From)
+			if err != nil {
+				log.Errorf("failed to get mined nonce of %s: %v", tx.From.Hex(), err)
+				continue
+			}
+
+			mined[tx.From] = nonce
+		}
+
+		if tx.Nonce < nonce {
+			err = m.settle(tx, now)
+			if err != nil {
+				log.Errorf("failed to settle transaction %s: %v", tx.LastAttempt().Hash.Hex(), err)
+			}
+
+			continue
+		}
+
+		if now.Sub(tx.LastAttempt().SentAt) < m.config.GetEthereumTxBumpTimeout() {
+			continue
+		}
+
+		err = m.replace(tx, false, now)
+		if err != nil {
+			log.Warningf("failed to replace stuck transaction %s: %v", tx.LastAttempt().Hash.Hex(), err)
+		}
+	}
+
+	if batch.Len() == 0 {
+		return nil
+	}
+
+	return m.repo.Write(batch)
+}
+
+func (m *txManager) minedNonce(from common.Address) (uint64, error) {
+	ctx, cancel := m.readContext()
+	defer cancel()
+	return m.client().NonceAt(ctx, from, nil)
+}
+
+// settle records which attempt of the transaction was mined.
+// The transaction is only dropped once the ethereum node reported every attempt as not found,
+// other receipt errors leave it in flight to be settled on the next check.
+func (m *txManager) settle(tx *Transaction, now time.Time) error {
+	ctx, cancel := m.readContext()
+	defer cancel()
+
+	for i := len(tx.Attempts) - 1; i >= 0; i-- {
+		receipt, err := m.client().TransactionReceipt(ctx, tx.Attempts[i].Hash)
+		if err == ethereum.NotFound || (err == nil && receipt == nil) {
+			continue
+		}
+
+		if err != nil {
+			return errors.New("failed to get receipt of %s: %v", tx.Attempts[i].Hash.Hex(), err)
+		}
+
+		tx.MinedHash = tx.Attempts[i].Hash
+		tx.Status = TxMined
+		if tx.Attempts[i].Cancel {
+			tx.Status = TxCancelled
+		}
+
+		break
+	}
+
+	if tx.InFlight() {
+		tx.Status = TxDropped
+	}
+
+	log.Infof("Ethereum transaction of %s with nonce [%d] is %s", tx.From.Hex(), tx.Nonce, tx.Status)
+	tx.UpdatedAt = now
+	return m.save(tx)
+}
+
+// resolveHash returns the hash of the mined attempt of the transaction having an attempt with the hash,
+// or of its latest attempt if none is mined yet, with the status of the transaction.
+// Unknown hashes are returned as is with an empty status.
+func (m *txManager) resolveHash(hash common.Hash) (common.Hash, TxStatus) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	tx, err := m.find(hash)
+	if err != nil {
+		return hash, ""
+	}
+
+	if tx.MinedHash != (common.Hash{}) {
+		return tx.MinedHash, tx.Status
+	}
+
+	return tx.LastAttempt().Hash, tx.Status
+}
+
+// TransactionByHash returns the latest attempt of the transaction so that the replaced transactions are followed.
+func (m *txManager) TransactionByHash(ctx context.Context, hash common.Hash) (tx *types.Transaction, isPending bool, err error) {
+	resolved, _ := m.resolveHash(hash)
+	return m.client().TransactionByHash(ctx, resolved)
+}
+
+// TransactionReceipt returns the receipt of the mined attempt of the transaction.
+// Returns ErrTransactionFailed if the nonce was used by the cancelling transfer or by a transaction not sent by the node,
+// the receipts of those don't tell whether the original transaction ran.
+func (m *txManager) TransactionReceipt(ctx context.Context, hash common.Hash) (*types.Receipt, error) {
+	resolved, status := m.resolveHash(hash)
+	if status == TxCancelled || status == TxDropped {
+		return nil, errors.NewTypedError(ErrTransactionFailed, errors.New("transaction %s was %s", hash.Hex(), status))
+	}
+
+	return m.client().TransactionReceipt(ctx, resolved)
+}
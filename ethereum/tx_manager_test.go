@@ -0,0 +1,217 @@
+// +build unit
+
+package ethereum
+
+import (
+	"context"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/centrifuge/go-centrifuge/errors"
+	"github.com/centrifuge/go-centrifuge/storage/leveldb"
+	"github.com/centrifuge/go-centrifuge/testingutils"
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func (m *MockEthCl) NonceAt(ctx context.Context, account common.Address, blockNumber *big.Int) (uint64, error) {
+	args := m.Called(ctx, account, blockNumber)
+	return args.Get(0).(uint64), args.Error(1)
+}
+
+func (m *MockEthCl) SuggestGasPrice(ctx context.Context) (*big.Int, error) {
+	args := m.Called(ctx)
+	return args.Get(0).(*big.Int), args.Error(1)
+}
+
+func (m *MockEthCl) SendTransaction(ctx context.Context, tx *types.Transaction) error {
+	args := m.Called(ctx, tx)
+	return args.Error(0)
+}
+
+func (m *MockEthCl) TransactionReceipt(ctx context.Context, txHash common.Hash) (*types.Receipt, error) {
+	args := m.Called(ctx, txHash)
+	r, _ := args.Get(0).(*types.Receipt)
+	return r, args.Error(1)
+}
+
+func newTestTxManager(t *testing.T) (*txManager, *MockEthCl, *bind.TransactOpts) {
+	key, err := crypto.GenerateKey()
+	assert.NoError(t, err)
+	opts := bind.NewKeyedTransactor(key)
+	db, err := leveldb.NewLevelDBStorage(leveldb.GetRandomTestStoragePath())
+	assert.NoError(t, err)
+	client := new(MockEthCl)
+	m := newTxManager(cfg, leveldb.NewLevelDBRepository(db), func() EthClient {
+		return client
+	}, func(from common.Address) (bind.SignerFn, error) {
+		if from != opts.From {
+			return nil, ErrUnknownSender
+		}
+
+		return opts.Signer, nil
+	})
+	return m, client, opts
+}
+
+func sendTestTx(t *testing.T, opts *bind.TransactOpts, nonce uint64, gasPrice int64) *types.Transaction {
+	tx, err := opts.Signer(types.HomesteadSigner{}, opts.From,
+		types.NewTransaction(nonce, common.Address{1}, big.NewInt(0), 100000, big.NewInt(gasPrice), []byte{1, 2}))
+	assert.NoError(t, err)
+	return tx
+}
+
+func TestTxManager_nonces(t *testing.T) {
+	m, client, opts := newTestTxManager(t)
+	gc := &gethClient{config: cfg, client: client, txMan: m}
+
+	// chain nonce without tracked transactions
+	client.On("PendingNonceAt", mock.Anything, opts.From).Return(uint64(5), nil)
+	var sent []uint64
+	submit := func(opts *bind.TransactOpts, gasPrice int64) (*types.Transaction, error) {
+		sent = append(sent, opts.Nonce.Uint64())
+		return sendTestTx(t, opts, opts.Nonce.Uint64(), gasPrice), nil
+	}
+	tx, err := gc.SubmitTransactionWithRetries(submit, opts, int64(10))
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(5), tx.Nonce())
+
+	// nonces are assigned after the tracked transactions, even if the node dropped them
+	tx, err = gc.SubmitTransactionWithRetries(submit, opts, int64(10))
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(6), tx.Nonce())
+	assert.Equal(t, []uint64{5, 6}, sent)
+
+	pending, err := m.PendingTransactions()
+	assert.NoError(t, err)
+	assert.Len(t, pending, 2)
+	assert.Equal(t, uint64(6), pending[1].Nonce)
+	assert.Equal(t, tx.Hash(), pending[1].LastAttempt().Hash)
+	assert.Equal(t, TxPending, pending[1].Status)
+
+	// other accounts
+	client.On("PendingNonceAt", mock.Anything, common.Address{2}).Return(uint64(1), nil)
+	n, err := m.nextNonce(common.Address{2})
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(1), n)
+}
+
+func TestTxManager_check(t *testing.T) {
+	defer testingutils.MockConfigOption(cfg, "ethereum.txManager.bumpTimeout", "5m")()
+	defer testingutils.MockConfigOption(cfg, "ethereum.txManager.gasPriceBump", 20)()
+	defer testingutils.MockConfigOption(cfg, "ethereum.maxGasPrice", "100")()
+	m, client, opts := newTestTxManager(t)
+	tx := sendTestTx(t, opts, 5, 10)
+	assert.NoError(t, m.track(opts.From, tx))
+	sentAt := time.Now().UTC()
+
+	// pending within the bump timeout
+	client.On("NonceAt", mock.Anything, opts.From, mock.Anything).Return(uint64(5), nil).Times(3)
+	assert.NoError(t, m.check(sentAt.Add(time.Minute)))
+	client.AssertNotCalled(t, "SendTransaction", mock.Anything, mock.Anything)
+
+	// stuck transaction replaced with a higher gas price
+	var replacements []*types.Transaction
+	client.On("SuggestGasPrice", mock.Anything).Return(big.NewInt(1), nil)
+	client.On("SendTransaction", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+		replacements = append(replacements, args.Get(1).(*types.Transaction))
+	}).Return(nil)
+	assert.NoError(t, m.check(sentAt.Add(6*time.Minute)))
+	assert.Len(t, replacements, 1)
+	assert.Equal(t, uint64(5), replacements[0].Nonce())
+	assert.Equal(t, big.NewInt(12), replacements[0].GasPrice())
+	assert.Equal(t, tx.Data(), replacements[0].Data())
+	resolved, status := m.resolveHash(tx.Hash())
+	assert.Equal(t, replacements[0].Hash(), resolved)
+	assert.Equal(t, TxPending, status)
+
+	// cancelled with an empty transfer to the sender
+	cancelled, err := m.CancelTransaction(tx.Hash())
+	assert.NoError(t, err)
+	assert.Equal(t, TxCancelling, cancelled.Status)
+	assert.Len(t, cancelled.Attempts, 3)
+	assert.True(t, cancelled.LastAttempt().Cancel)
+	assert.Len(t, replacements, 2)
+	assert.Equal(t, opts.From, *replacements[1].To())
+	assert.Equal(t, big.NewInt(14), replacements[1].GasPrice())
+	assert.Len(t, replacements[1].Data(), 0)
+	_, err = m.CancelTransaction(tx.Hash())
+	assert.True(t, errors.IsOfType(ErrTransactionNotPending, err))
+	_, err = m.CancelTransaction(common.Hash{1})
+	assert.True(t, errors.IsOfType(ErrTransactionNotFound, err))
+
+	// gas price can't be raised above the max gas price
+	defer testingutils.MockConfigOption(cfg, "ethereum.maxGasPrice", "14")()
+	assert.NoError(t, m.check(sentAt.Add(20*time.Minute)))
+	assert.Len(t, replacements, 2)
+
+	// receipt errors other than not found keep the transaction in flight
+	client.On("NonceAt", mock.Anything, opts.From, mock.Anything).Return(uint64(6), nil).Once()
+	client.On("TransactionReceipt", mock.Anything, replacements[1].Hash()).Return(nil, errors.New("connection refused")).Once()
+	assert.NoError(t, m.check(sentAt.Add(20*time.Minute)))
+	pending, err := m.PendingTransactions()
+	assert.NoError(t, err)
+	assert.Len(t, pending, 1)
+	assert.Equal(t, TxCancelling, pending[0].Status)
+
+	// the nonce is mined with the cancelling transfer
+	client.On("NonceAt", mock.Anything, opts.From, mock.Anything).Return(uint64(6), nil).Once()
+	client.On("TransactionReceipt", mock.Anything, replacements[1].Hash()).Return(&types.Receipt{Status: 1}, nil).Once()
+	assert.NoError(t, m.check(sentAt.Add(21*time.Minute)))
+	pending, err = m.PendingTransactions()
+	assert.NoError(t, err)
+	assert.Len(t, pending, 0)
+	cancelled, err = m.find(tx.Hash())
+	assert.NoError(t, err)
+	assert.Equal(t, TxCancelled, cancelled.Status)
+	resolved, status = m.resolveHash(tx.Hash())
+	assert.Equal(t, replacements[1].Hash(), resolved)
+	assert.Equal(t, TxCancelled, status)
+
+	// the receipt of the cancelling transfer isn't returned for the transaction
+	_, err = m.TransactionReceipt(context.Background(), tx.Hash())
+	assert.True(t, errors.IsOfType(ErrTransactionFailed, err))
+
+	// finished transactions are deleted after their retention
+	assert.NoError(t, m.check(time.Now().UTC().Add(finishedTxRetention+time.Hour)))
+	_, err = m.find(tx.Hash())
+	assert.True(t, errors.IsOfType(ErrTransactionNotFound, err))
+	resolved, status = m.resolveHash(tx.Hash())
+	assert.Equal(t, tx.Hash(), resolved)
+	assert.Equal(t, TxStatus(""), status)
+	client.AssertExpectations(t)
+}
+
+func TestTxManager_settle(t *testing.T) {
+	m, client, opts := newTestTxManager(t)
+	tx := sendTestTx(t, opts, 5, 10)
+	assert.NoError(t, m.track(opts.From, tx))
+	tracked, err := m.find(tx.Hash())
+	assert.NoError(t, err)
+
+	// failed receipt request
+	client.On("TransactionReceipt", mock.Anything, tx.Hash()).Return(nil, errors.New("connection refused")).Once()
+	assert.Error(t, m.settle(tracked, time.Now().UTC()))
+	assert.Equal(t, TxPending, tracked.Status)
+
+	// not found for every attempt
+	client.On("TransactionReceipt", mock.Anything, tx.Hash()).Return(nil, ethereum.NotFound).Once()
+	assert.NoError(t, m.settle(tracked, time.Now().UTC()))
+	assert.Equal(t, TxDropped, tracked.Status)
+	_, err = m.TransactionReceipt(context.Background(), tx.Hash())
+	assert.True(t, errors.IsOfType(ErrTransactionFailed, err))
+
+	// mined
+	tracked.Status = TxPending
+	client.On("TransactionReceipt", mock.Anything, tx.Hash()).Return(&types.Receipt{Status: 1}, nil).Once()
+	assert.NoError(t, m.settle(tracked, time.Now().UTC()))
+	assert.Equal(t, TxMined, tracked.Status)
+	assert.Equal(t, tx.Hash(), tracked.MinedHash)
+	client.AssertExpectations(t)
+}
@@ -54,6 +54,11 @@ func (t *WaitForEventTask) Copy() (gocelery.CeleryTask, error) {
 	}, nil
 }
 
+// JobDetails returns the account and the job of the task.
+func (t *WaitForEventTask) JobDetails() (identity.DID, jobs.JobID) {
+	return t.accountID, t.JobID
+}
+
 // ParseKwargs parses the kwargs into a query.
 func (t *WaitForEventTask) ParseKwargs(kwargs map[string]interface{}) error {
 	err := t.ParseJobID(t.TaskTypeName(), kwargs)
@@ -103,6 +108,7 @@ func (t *WaitForEventTask) ParseKwargs(kwargs map[string]interface{}) error {
 }
 
 // RunTask runs the task of fetching the logs.
+// The task only reads the chain logs, so running it again after a restart is safe.
 func (t *WaitForEventTask) RunTask() (res interface{}, err error) {
 	var jobValue *jobs.JobValue
 	ctx, cancelFunc := t.ethContextInitializer()
@@ -174,7 +180,7 @@ func CreateWaitForEventJob(
 	jobID jobs.JobID,
 	eventSignature string,
 	fromBlock *big.Int, address common.Address, topic common.Hash) (jobs.JobID, chan error, error) {
-	jobID, done, err := jobsMan.ExecuteWithinJob(contextutil.Copy(parentCtx), self, jobID, "Waiting for Event from Ethereum", func(accountID identity.DID, jobID jobs.JobID, jobsMan jobs.Manager, errChan chan<- error) {
+	jobID, done, err := jobsMan.ExecuteWithinJob(contextutil.Copy(parentCtx), self, jobID, "Waiting for Event from Ethereum", func(ctx context.Context, accountID identity.DID, jobID jobs.JobID, jobsMan jobs.Manager, errChan chan<- error) {
 		tr, err := initWaitForEventTask(tq, accountID, jobID, eventSignature, fromBlock, address, topic)
 		if err != nil {
 			errChan <- err
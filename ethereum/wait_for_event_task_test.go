@@ -95,6 +95,7 @@ func TestWaitForEventTask_RunTask(t *testing.T) {
 	task := new(WaitForEventTask)
 	jm := testingjobs.MockJobManager{}
 	jm.On("UpdateTaskStatus", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil)
+	jm.On("GetJob", mock.Anything, mock.Anything).Return(jobs.NewJob(identity.DID{}, "wait for event"), nil)
 	task.BaseTask = jobsv1.BaseTask{
 		JobManager: jm,
 	}
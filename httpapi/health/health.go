@@ -1,6 +1,8 @@
 package health
 
 import (
+	"expvar"
+	"fmt"
 	"net/http"
 
 	"github.com/centrifuge/go-centrifuge/version"
@@ -41,8 +43,35 @@ func (h handler) Ping(w http.ResponseWriter, r *http.Request) {
 	})
 }
 
+// p2pMetricsVar is the expvar map holding the p2p receiver limiter counters.
+const p2pMetricsVar = "p2p_receiver"
+
+// P2PMetrics responds with the p2p receiver limiter counters.
+// Only the limiter counters are served, the rest of the expvar dump(cmdline, memstats) is not exposed.
+// @summary Returns the p2p receiver limiter counters
+// @description returns the throttled and banned p2p peers and DIDs
+// @id get_p2p_metrics
+// @tags Health
+// @param authorization header string true "Hex encoded centrifuge ID of the account for the intended API action"
+// @produce json
+// @Failure 403 {object} httputils.HTTPError
+// @success 200 {object} object
+// @router /debug/p2p [get]
+func (h handler) P2PMetrics(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	v := expvar.Get(p2pMetricsVar)
+	if v == nil {
+		fmt.Fprint(w, "{}")
+		return
+	}
+
+	fmt.Fprint(w, v.String())
+}
+
 // Register registers the health APIs to the router
 func Register(r chi.Router, config config) {
 	h := handler{c: config}
 	r.Get("/ping", h.Ping)
+	// p2p receiver metrics such as throttled and banned peers, requires the authorization header
+	r.Get("/debug/p2p", h.P2PMetrics)
 }
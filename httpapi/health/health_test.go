@@ -4,6 +4,7 @@ package health
 
 import (
 	"encoding/json"
+	"expvar"
 	"net/http"
 	"net/http/httptest"
 	"testing"
@@ -30,9 +31,32 @@ func TestHandler_Ping(t *testing.T) {
 	assert.Equal(t, pong.Network, "test network")
 }
 
+func TestHandler_P2PMetrics(t *testing.T) {
+	h := handler{mockConfig{}}
+
+	// no limiter yet
+	res := httptest.NewRecorder()
+	h.P2PMetrics(res, httptest.NewRequest("GET", "/debug/p2p", nil))
+	assert.Equal(t, http.StatusOK, res.Code)
+	assert.Equal(t, "{}", res.Body.String())
+
+	// only the limiter counters are served
+	m := expvar.NewMap(p2pMetricsVar)
+	m.Add("throttled", 2)
+	res = httptest.NewRecorder()
+	h.P2PMetrics(res, httptest.NewRequest("GET", "/debug/p2p", nil))
+	assert.Equal(t, http.StatusOK, res.Code)
+	var metrics map[string]interface{}
+	assert.NoError(t, json.Unmarshal(res.Body.Bytes(), &metrics))
+	assert.Equal(t, float64(2), metrics["throttled"])
+	assert.NotContains(t, res.Body.String(), "memstats")
+	assert.NotContains(t, res.Body.String(), "cmdline")
+}
+
 func TestRegister(t *testing.T) {
 	r := chi.NewRouter()
 	Register(r, mockConfig{})
-	assert.Len(t, r.Routes(), 1)
-	assert.Equal(t, r.Routes()[0].Pattern, "/ping")
+	assert.Len(t, r.Routes(), 2)
+	assert.Equal(t, r.Routes()[0].Pattern, "/debug/p2p")
+	assert.Equal(t, r.Routes()[1].Pattern, "/ping")
 }
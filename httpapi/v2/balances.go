@@ -0,0 +1,82 @@
+package v2
+
+import (
+	"net/http"
+
+	"github.com/centrifuge/go-centrifuge/contextutil"
+	"github.com/centrifuge/go-centrifuge/identity"
+	"github.com/centrifuge/go-centrifuge/utils/httputils"
+	"github.com/go-chi/chi"
+	"github.com/go-chi/render"
+)
+
+// CentChainBalance is the balance of the Centrifuge chain account in the smallest CFG unit.
+type CentChainBalance struct {
+	AccountID  string `json:"account_id"`
+	Free       string `json:"free"`
+	Reserved   string `json:"reserved"`
+	MiscFrozen string `json:"misc_frozen"`
+	FeeFrozen  string `json:"fee_frozen"`
+
+	// Spendable is the part of the free balance that can pay the extrinsic fees.
+	Spendable string `json:"spendable"`
+}
+
+// EthereumBalance is the ETH balance of the ethereum account in wei.
+type EthereumBalance struct {
+	Address string `json:"address"`
+	Balance string `json:"balance"`
+}
+
+// AccountBalances holds the balances of the chain accounts configured for the account.
+// Accounts that are not configured are omitted.
+type AccountBalances struct {
+	CentChain *CentChainBalance `json:"centrifuge_chain,omitempty"`
+	Ethereum  *EthereumBalance  `json:"ethereum,omitempty"`
+}
+
+// GetAccountBalances returns the balances of the chain accounts of the account.
+// @summary Returns the CFG and ETH balances of the account.
+// @description Returns the CFG balance of the Centrifuge chain account and the ETH balance of the ethereum account configured for the account. Extrinsics are rejected before submission when the spendable CFG balance doesn't cover the estimated fee.
+// @id get_account_balances
+// @tags Accounts
+// @param authorization header string true "Hex encoded centrifuge ID of the account for the intended API action"
+// @param account_id path string true "Account DID"
+// @produce json
+// @Failure 403 {object} httputils.HTTPError
+// @Failure 400 {object} httputils.HTTPError
+// @Failure 500 {object} httputils.HTTPError
+// @success 200 {object} v2.AccountBalances
+// @router /v2/accounts/{account_id}/balances [get]
+func (h handler) GetAccountBalances(w http.ResponseWriter, r *http.Request) {
+	var err error
+	var code int
+	defer httputils.RespondIfError(&code, &err, w, r)
+
+	did, err := identity.NewDIDFromString(chi.URLParam(r, AccountIDParam))
+	if err != nil {
+		code = http.StatusBadRequest
+		log.Error(err)
+		err = ErrInvalidAccountID
+		return
+	}
+
+	ctx := r.Context()
+	self, err := contextutil.AccountDID(ctx)
+	if err != nil || !self.Equal(did) {
+		code = http.StatusForbidden
+		log.Error(err)
+		err = ErrAccountMismatch
+		return
+	}
+
+	resp, err := h.srv.GetAccountBalances(ctx)
+	if err != nil {
+		code = http.StatusInternalServerError
+		log.Error(err)
+		return
+	}
+
+	render.Status(r, http.StatusOK)
+	render.JSON(w, r, resp)
+}
@@ -0,0 +1,126 @@
+// +build unit
+
+package v2
+
+import (
+	"context"
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/centrifuge/go-centrifuge/centchain"
+	"github.com/centrifuge/go-centrifuge/config"
+	"github.com/centrifuge/go-centrifuge/config/configstore"
+	"github.com/centrifuge/go-centrifuge/contextutil"
+	"github.com/centrifuge/go-centrifuge/errors"
+	"github.com/centrifuge/go-centrifuge/ethereum"
+	testingidentity "github.com/centrifuge/go-centrifuge/testingutils/identity"
+	"github.com/centrifuge/go-substrate-rpc-client/types"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/go-chi/chi"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type mockEthCl struct {
+	ethereum.EthClient
+	mock.Mock
+}
+
+func (m *mockEthCl) BalanceAt(ctx context.Context, account common.Address, blockNumber *big.Int) (*big.Int, error) {
+	args := m.Called(ctx, account, blockNumber)
+	b, _ := args.Get(0).(*big.Int)
+	return b, args.Error(1)
+}
+
+func TestHandler_GetAccountBalances(t *testing.T) {
+	getHTTPReqAndResp := func(ctx context.Context) (*httptest.ResponseRecorder, *http.Request) {
+		return httptest.NewRecorder(), httptest.NewRequest("GET", "/accounts/{account_id}/balances", nil).WithContext(ctx)
+	}
+
+	did := testingidentity.GenerateRandomDID()
+	rctx := chi.NewRouteContext()
+	rctx.URLParams.Keys = []string{AccountIDParam}
+	rctx.URLParams.Values = []string{"some invalid id"}
+	ctx := context.WithValue(context.Background(), chi.RouteCtxKey, rctx)
+
+	// invalid account ID
+	centAPI := new(centchain.MockAPI)
+	ethCl := new(mockEthCl)
+	ethClient := new(ethereum.MockEthClient)
+	ethClient.On("GetEthClient").Return(ethCl)
+	h := handler{srv: Service{centAPI: centAPI, ethClient: ethClient}}
+	w, r := getHTTPReqAndResp(ctx)
+	h.GetAccountBalances(w, r)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	assert.Contains(t, w.Body.String(), ErrInvalidAccountID.Error())
+
+	// not the authorized account
+	rctx.URLParams.Values[0] = did.String()
+	ctx, err := contextutil.New(ctx, &configstore.Account{IdentityID: testingidentity.GenerateRandomDID().ToAddress().Bytes()})
+	assert.NoError(t, err)
+	w, r = getHTTPReqAndResp(ctx)
+	h.GetAccountBalances(w, r)
+	assert.Equal(t, http.StatusForbidden, w.Code)
+	assert.Contains(t, w.Body.String(), ErrAccountMismatch.Error())
+
+	// no chain accounts
+	acc := &configstore.Account{IdentityID: did[:]}
+	ctx, err = contextutil.New(ctx, acc)
+	assert.NoError(t, err)
+	w, r = getHTTPReqAndResp(ctx)
+	h.GetAccountBalances(w, r)
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "{}\n", w.Body.String())
+
+	// failed to get CFG balance
+	accountID := hexutil.Encode(common.Hash{1}.Bytes())
+	addr := common.Address{2}
+	acc.CentChainAccount = config.CentChainAccount{ID: accountID}
+	acc.EthereumAccount = &config.AccountConfig{Address: addr.Hex()}
+	meta := types.NewMetadataV8()
+	centAPI.On("GetMetadataLatest").Return(meta, nil)
+	centAPI.On("GetBalance", meta, common.Hash{1}.Bytes()).Return(nil, errors.New("failed to get balance")).Once()
+	w, r = getHTTPReqAndResp(ctx)
+	h.GetAccountBalances(w, r)
+	assert.Equal(t, http.StatusInternalServerError, w.Code)
+	assert.Contains(t, w.Body.String(), "failed to get balance")
+
+	// failed to get ETH balance
+	balance := &centchain.Balance{
+		Free:       big.NewInt(100),
+		Reserved:   big.NewInt(5),
+		MiscFrozen: big.NewInt(0),
+		FeeFrozen:  big.NewInt(30),
+	}
+	centAPI.On("GetBalance", meta, common.Hash{1}.Bytes()).Return(balance, nil)
+	ethCl.On("BalanceAt", mock.Anything, addr, (*big.Int)(nil)).Return(nil, errors.New("failed to get eth balance")).Once()
+	w, r = getHTTPReqAndResp(ctx)
+	h.GetAccountBalances(w, r)
+	assert.Equal(t, http.StatusInternalServerError, w.Code)
+	assert.Contains(t, w.Body.String(), "failed to get eth balance")
+
+	// success
+	ethCl.On("BalanceAt", mock.Anything, addr, (*big.Int)(nil)).Return(big.NewInt(1000000000), nil).Once()
+	w, r = getHTTPReqAndResp(ctx)
+	h.GetAccountBalances(w, r)
+	assert.Equal(t, http.StatusOK, w.Code)
+	var resp AccountBalances
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+	assert.Equal(t, AccountBalances{
+		CentChain: &CentChainBalance{
+			AccountID:  accountID,
+			Free:       "100",
+			Reserved:   "5",
+			MiscFrozen: "0",
+			FeeFrozen:  "30",
+			Spendable:  "70",
+		},
+		Ethereum: &EthereumBalance{Address: addr.Hex(), Balance: "1000000000"},
+	}, resp)
+	centAPI.AssertExpectations(t)
+	ethCl.AssertExpectations(t)
+}
@@ -2,8 +2,14 @@ package v2
 
 import (
 	"github.com/centrifuge/go-centrifuge/bootstrap"
+	"github.com/centrifuge/go-centrifuge/centchain"
+	"github.com/centrifuge/go-centrifuge/config/configstore"
 	"github.com/centrifuge/go-centrifuge/documents"
 	"github.com/centrifuge/go-centrifuge/errors"
+	"github.com/centrifuge/go-centrifuge/ethereum"
+	"github.com/centrifuge/go-centrifuge/jobs"
+	"github.com/centrifuge/go-centrifuge/nft"
+	"github.com/centrifuge/go-centrifuge/notification"
 	"github.com/centrifuge/go-centrifuge/pending"
 )
 
@@ -20,14 +26,57 @@ func (b Bootstrapper) Bootstrap(ctx map[string]interface{}) error {
 		return errors.New("failed to get %s", pending.BootstrappedPendingDocumentService)
 	}
 
-	nftSrv, ok := ctx[bootstrap.BootstrappedNFTService].(documents.TokenRegistry)
+	nftSrv, ok := ctx[bootstrap.BootstrappedNFTService].(nft.Service)
 	if !ok {
 		return errors.New("failed to get %s", bootstrap.BootstrappedNFTService)
 	}
 
+	policySrv, ok := ctx[documents.BootstrappedSignaturePolicyService].(documents.SignaturePolicyService)
+	if !ok {
+		return errors.New("failed to get %s", documents.BootstrappedSignaturePolicyService)
+	}
+
+	keyRotator, ok := ctx[configstore.BootstrappedKeyRotator].(configstore.KeyRotator)
+	if !ok {
+		return errors.New("failed to get %s", configstore.BootstrappedKeyRotator)
+	}
+
+	jobsMan, ok := ctx[jobs.BootstrappedService].(jobs.Manager)
+	if !ok {
+		return errors.New("failed to get %s", jobs.BootstrappedService)
+	}
+
+	dispatcher, ok := ctx[notification.BootstrappedDispatcher].(notification.Dispatcher)
+	if !ok {
+		return errors.New("failed to get %s", notification.BootstrappedDispatcher)
+	}
+
+	txMan, ok := ctx[ethereum.BootstrappedTxManager].(ethereum.TransactionManager)
+	if !ok {
+		return errors.New("failed to get %s", ethereum.BootstrappedTxManager)
+	}
+
+	centAPI, ok := ctx[centchain.BootstrappedCentChainClient].(centchain.API)
+	if !ok {
+		return errors.New("failed to get %s", centchain.BootstrappedCentChainClient)
+	}
+
+	ethClient, ok := ctx[ethereum.BootstrappedEthereumClient].(ethereum.Client)
+	if !ok {
+		return errors.New("failed to get %s", ethereum.BootstrappedEthereumClient)
+	}
+
 	ctx[BootstrappedService] = Service{
 		pendingDocSrv: pendingDocSrv,
 		tokenRegistry: nftSrv,
+		policySrv:     policySrv,
+		keyRotator:    keyRotator,
+		jobsMan:       jobsMan,
+		dispatcher:    dispatcher,
+		nftSrv:        nftSrv,
+		txMan:         txMan,
+		centAPI:       centAPI,
+		ethClient:     ethClient,
 	}
 	return nil
 }
@@ -6,8 +6,15 @@ import (
 	"testing"
 
 	"github.com/centrifuge/go-centrifuge/bootstrap"
+	"github.com/centrifuge/go-centrifuge/centchain"
+	"github.com/centrifuge/go-centrifuge/config/configstore"
+	"github.com/centrifuge/go-centrifuge/documents"
+	"github.com/centrifuge/go-centrifuge/ethereum"
+	"github.com/centrifuge/go-centrifuge/jobs"
+	"github.com/centrifuge/go-centrifuge/notification"
 	"github.com/centrifuge/go-centrifuge/pending"
 	testingnfts "github.com/centrifuge/go-centrifuge/testingutils/nfts"
+	"github.com/centrifuge/go-centrifuge/testingutils/testingjobs"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -26,9 +33,51 @@ func TestBootstrapper_Bootstrap(t *testing.T) {
 	assert.Error(t, err)
 	assert.Contains(t, err.Error(), bootstrap.BootstrappedNFTService)
 
-	// success
+	// missing signature policy service
 	ctx[bootstrap.BootstrappedNFTService] = new(testingnfts.MockNFTService)
 	err = b.Bootstrap(ctx)
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), documents.BootstrappedSignaturePolicyService)
+
+	// missing key rotator
+	ctx[documents.BootstrappedSignaturePolicyService] = new(documents.MockSignaturePolicyService)
+	err = b.Bootstrap(ctx)
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), configstore.BootstrappedKeyRotator)
+
+	// missing jobs manager
+	ctx[configstore.BootstrappedKeyRotator] = new(configstore.MockKeyRotator)
+	err = b.Bootstrap(ctx)
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), jobs.BootstrappedService)
+
+	// missing webhook dispatcher
+	ctx[jobs.BootstrappedService] = new(testingjobs.MockJobManager)
+	err = b.Bootstrap(ctx)
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), notification.BootstrappedDispatcher)
+
+	// missing ethereum transaction manager
+	ctx[notification.BootstrappedDispatcher] = new(notification.MockDispatcher)
+	err = b.Bootstrap(ctx)
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), ethereum.BootstrappedTxManager)
+
+	// missing centchain client
+	ctx[ethereum.BootstrappedTxManager] = new(ethereum.MockTransactionManager)
+	err = b.Bootstrap(ctx)
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), centchain.BootstrappedCentChainClient)
+
+	// missing ethereum client
+	ctx[centchain.BootstrappedCentChainClient] = new(centchain.MockAPI)
+	err = b.Bootstrap(ctx)
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), ethereum.BootstrappedEthereumClient)
+
+	// success
+	ctx[ethereum.BootstrappedEthereumClient] = new(ethereum.MockEthClient)
+	err = b.Bootstrap(ctx)
 	assert.NoError(t, b.Bootstrap(ctx))
 	assert.NotNil(t, ctx[BootstrappedService])
 }
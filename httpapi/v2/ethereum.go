@@ -0,0 +1,144 @@
+package v2
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/centrifuge/go-centrifuge/errors"
+	"github.com/centrifuge/go-centrifuge/ethereum"
+	"github.com/centrifuge/go-centrifuge/utils/httputils"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/go-chi/chi"
+	"github.com/go-chi/render"
+)
+
+// TxHashParam is the key for the ethereum transaction hash in the API path.
+const TxHashParam = "tx_hash"
+
+// ErrInvalidTxHash for an invalid ethereum transaction hash in the API path.
+const ErrInvalidTxHash = errors.Error("Invalid transaction hash")
+
+// EthereumTxAttempt is a signed version of an ethereum transaction sent to the ethereum node.
+type EthereumTxAttempt struct {
+	Hash     common.Hash `json:"hash" swaggertype:"primitive,string"`
+	GasPrice string      `json:"gas_price"`
+	SentAt   time.Time   `json:"sent_at" swaggertype:"primitive,string"`
+	Cancel   bool        `json:"cancel"`
+}
+
+// EthereumTransaction is an ethereum transaction of the node accounts tracked until it is mined.
+type EthereumTransaction struct {
+	From      common.Address      `json:"from" swaggertype:"primitive,string"`
+	Nonce     uint64              `json:"nonce"`
+	To        *common.Address     `json:"to,omitempty" swaggertype:"primitive,string"`
+	Value     string              `json:"value"`
+	Data      hexutil.Bytes       `json:"data" swaggertype:"primitive,string"`
+	GasLimit  uint64              `json:"gas_limit"`
+	Status    ethereum.TxStatus   `json:"status" enums:"pending,cancelling,mined,cancelled,dropped"`
+	Attempts  []EthereumTxAttempt `json:"attempts"`
+	CreatedAt time.Time           `json:"created_at" swaggertype:"primitive,string"`
+	UpdatedAt time.Time           `json:"updated_at" swaggertype:"primitive,string"`
+}
+
+// EthereumTransactionsResponse holds the in-flight ethereum transactions of the node accounts.
+type EthereumTransactionsResponse struct {
+	Transactions []EthereumTransaction `json:"transactions"`
+}
+
+func toEthereumTransaction(tx *ethereum.Transaction) EthereumTransaction {
+	attempts := make([]EthereumTxAttempt, 0, len(tx.Attempts))
+	for _, a := range tx.Attempts {
+		attempts = append(attempts, EthereumTxAttempt{Hash: a.Hash, GasPrice: a.GasPrice.String(), SentAt: a.SentAt, Cancel: a.Cancel})
+	}
+
+	value := "0"
+	if tx.Value != nil {
+		value = tx.Value.String()
+	}
+
+	return EthereumTransaction{
+		From:      tx.From,
+		Nonce:     tx.Nonce,
+		To:        tx.To,
+		Value:     value,
+		Data:      tx.Data,
+		GasLimit:  tx.GasLimit,
+		Status:    tx.Status,
+		Attempts:  attempts,
+		CreatedAt: tx.CreatedAt,
+		UpdatedAt: tx.UpdatedAt,
+	}
+}
+
+// GetEthereumTransactions returns the in-flight ethereum transactions of the node accounts.
+// @summary Returns the in-flight ethereum transactions of the node accounts.
+// @description Returns the ethereum transactions sent by the node accounts that are not mined yet, ordered by sender and nonce, with every attempt sent at a higher gas price.
+// @id get_ethereum_transactions
+// @tags Ethereum
+// @param authorization header string true "Hex encoded centrifuge ID of the account for the intended API action"
+// @produce json
+// @Failure 403 {object} httputils.HTTPError
+// @Failure 500 {object} httputils.HTTPError
+// @success 200 {object} v2.EthereumTransactionsResponse
+// @router /v2/ethereum/transactions [get]
+func (h handler) GetEthereumTransactions(w http.ResponseWriter, r *http.Request) {
+	var err error
+	var code int
+	defer httputils.RespondIfError(&code, &err, w, r)
+
+	txs, err := h.srv.GetEthereumTransactions()
+	if err != nil {
+		code = http.StatusInternalServerError
+		log.Error(err)
+		return
+	}
+
+	resp := EthereumTransactionsResponse{Transactions: []EthereumTransaction{}}
+	for _, tx := range txs {
+		resp.Transactions = append(resp.Transactions, toEthereumTransaction(tx))
+	}
+
+	render.Status(r, http.StatusOK)
+	render.JSON(w, r, resp)
+}
+
+// CancelEthereumTransaction cancels an in-flight ethereum transaction.
+// @summary Cancels an in-flight ethereum transaction.
+// @description Replaces the pending transaction with an empty transfer to its sender at a higher gas price. The hash can be of any attempt of the transaction. The transaction can still be mined if one of its attempts is mined before the transfer.
+// @id cancel_ethereum_transaction
+// @tags Ethereum
+// @param authorization header string true "Hex encoded centrifuge ID of the account for the intended API action"
+// @param tx_hash path string true "Hex encoded transaction hash"
+// @produce json
+// @Failure 403 {object} httputils.HTTPError
+// @Failure 400 {object} httputils.HTTPError
+// @Failure 404 {object} httputils.HTTPError
+// @success 202 {object} v2.EthereumTransaction
+// @router /v2/ethereum/transactions/{tx_hash}/cancel [post]
+func (h handler) CancelEthereumTransaction(w http.ResponseWriter, r *http.Request) {
+	var err error
+	var code int
+	defer httputils.RespondIfError(&code, &err, w, r)
+
+	hash, err := hexutil.Decode(chi.URLParam(r, TxHashParam))
+	if err != nil || len(hash) != common.HashLength {
+		code = http.StatusBadRequest
+		err = ErrInvalidTxHash
+		log.Error(err)
+		return
+	}
+
+	tx, err := h.srv.CancelEthereumTransaction(common.BytesToHash(hash))
+	if err != nil {
+		log.Error(err)
+		code = http.StatusBadRequest
+		if errors.IsOfType(ethereum.ErrTransactionNotFound, err) {
+			code = http.StatusNotFound
+		}
+		return
+	}
+
+	render.Status(r, http.StatusAccepted)
+	render.JSON(w, r, toEthereumTransaction(tx))
+}
@@ -0,0 +1,114 @@
+// +build unit
+
+package v2
+
+import (
+	"context"
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/centrifuge/go-centrifuge/errors"
+	"github.com/centrifuge/go-centrifuge/ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/go-chi/chi"
+	"github.com/stretchr/testify/assert"
+)
+
+func testEthereumTransaction() *ethereum.Transaction {
+	to := common.Address{2}
+	return &ethereum.Transaction{
+		From:     common.Address{1},
+		Nonce:    5,
+		To:       &to,
+		Value:    big.NewInt(0),
+		Data:     []byte{1},
+		GasLimit: 100000,
+		Status:   ethereum.TxPending,
+		Attempts: []ethereum.TxAttempt{
+			{Hash: common.Hash{1}, GasPrice: big.NewInt(10), SentAt: time.Now()},
+			{Hash: common.Hash{2}, GasPrice: big.NewInt(12), SentAt: time.Now()},
+		},
+	}
+}
+
+func TestHandler_GetEthereumTransactions(t *testing.T) {
+	txMan := new(ethereum.MockTransactionManager)
+	h := handler{srv: Service{txMan: txMan}}
+	getHTTPReqAndResp := func() (*httptest.ResponseRecorder, *http.Request) {
+		return httptest.NewRecorder(), httptest.NewRequest("GET", "/ethereum/transactions", nil)
+	}
+
+	// failed to list
+	txMan.On("PendingTransactions").Return(nil, errors.New("failed to list")).Once()
+	w, r := getHTTPReqAndResp()
+	h.GetEthereumTransactions(w, r)
+	assert.Equal(t, http.StatusInternalServerError, w.Code)
+
+	// no transactions
+	txMan.On("PendingTransactions").Return(nil, nil).Once()
+	w, r = getHTTPReqAndResp()
+	h.GetEthereumTransactions(w, r)
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), `"transactions":[]`)
+
+	// success
+	txMan.On("PendingTransactions").Return([]*ethereum.Transaction{testEthereumTransaction()}, nil).Once()
+	w, r = getHTTPReqAndResp()
+	h.GetEthereumTransactions(w, r)
+	assert.Equal(t, http.StatusOK, w.Code)
+	var resp EthereumTransactionsResponse
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+	assert.Len(t, resp.Transactions, 1)
+	assert.Equal(t, uint64(5), resp.Transactions[0].Nonce)
+	assert.Equal(t, ethereum.TxPending, resp.Transactions[0].Status)
+	assert.Len(t, resp.Transactions[0].Attempts, 2)
+	assert.Equal(t, "12", resp.Transactions[0].Attempts[1].GasPrice)
+	txMan.AssertExpectations(t)
+}
+
+func TestHandler_CancelEthereumTransaction(t *testing.T) {
+	rctx := chi.NewRouteContext()
+	rctx.URLParams.Keys = []string{TxHashParam}
+	rctx.URLParams.Values = []string{"0x01"}
+	ctx := context.WithValue(context.Background(), chi.RouteCtxKey, rctx)
+	txMan := new(ethereum.MockTransactionManager)
+	h := handler{srv: Service{txMan: txMan}}
+	getHTTPReqAndResp := func() (*httptest.ResponseRecorder, *http.Request) {
+		return httptest.NewRecorder(), httptest.NewRequest("POST", "/ethereum/transactions/{tx_hash}/cancel", nil).WithContext(ctx)
+	}
+
+	// invalid hash
+	w, r := getHTTPReqAndResp()
+	h.CancelEthereumTransaction(w, r)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	assert.Contains(t, w.Body.String(), ErrInvalidTxHash.Error())
+
+	// missing transaction
+	hash := common.Hash{1}
+	rctx.URLParams.Values = []string{hash.Hex()}
+	txMan.On("CancelTransaction", hash).Return(nil, ethereum.ErrTransactionNotFound).Once()
+	w, r = getHTTPReqAndResp()
+	h.CancelEthereumTransaction(w, r)
+	assert.Equal(t, http.StatusNotFound, w.Code)
+
+	// not pending
+	txMan.On("CancelTransaction", hash).Return(nil, ethereum.ErrTransactionNotPending).Once()
+	w, r = getHTTPReqAndResp()
+	h.CancelEthereumTransaction(w, r)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	assert.Contains(t, w.Body.String(), ethereum.ErrTransactionNotPending.Error())
+
+	// success
+	tx := testEthereumTransaction()
+	tx.Status = ethereum.TxCancelling
+	txMan.On("CancelTransaction", hash).Return(tx, nil).Once()
+	w, r = getHTTPReqAndResp()
+	h.CancelEthereumTransaction(w, r)
+	assert.Equal(t, http.StatusAccepted, w.Code)
+	assert.Contains(t, w.Body.String(), `"status":"cancelling"`)
+	txMan.AssertExpectations(t)
+}
@@ -0,0 +1,132 @@
+package v2
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/centrifuge/go-centrifuge/contextutil"
+	"github.com/centrifuge/go-centrifuge/errors"
+	"github.com/centrifuge/go-centrifuge/notification"
+	"github.com/centrifuge/go-centrifuge/utils/httputils"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+// ErrInvalidEventsFilter for invalid query parameters of the event stream.
+const ErrInvalidEventsFilter = errors.Error("Invalid Events filter")
+
+// eventsKeepAlive is the interval of the comments sent to keep idle streams open.
+const eventsKeepAlive = 15 * time.Second
+
+// toEventsFilter converts the query parameters to the events filter.
+func toEventsFilter(r *http.Request) (notification.Filter, error) {
+	q := r.URL.Query()
+	var filter notification.Filter
+	for _, v := range q["type"] {
+		for _, name := range strings.Split(v, ",") {
+			t, err := notification.EventTypeFromString(strings.TrimSpace(name))
+			if err != nil {
+				return filter, err
+			}
+
+			filter.Types = append(filter.Types, t)
+		}
+	}
+
+	if v := q.Get("document_id"); v != "" {
+		if _, err := hexutil.Decode(v); err != nil {
+			return filter, errors.New("invalid document_id: %v", err)
+		}
+
+		filter.DocumentID = v
+	}
+
+	return filter, nil
+}
+
+// StreamEvents streams the events of the account as Server-Sent Events.
+// @summary Streams the events of the account.
+// @description Streams job status changes, task log lines, received documents, signature, NFT, access token and identity key events as Server-Sent Events.
+// @description The event name is the event type and the data is the JSON encoded notification message.
+// @id stream_events
+// @tags Events
+// @param authorization header string true "Hex encoded centrifuge ID of the account for the intended API action"
+// @param type query string false "Comma separated event types" Enums(received_payload, job_completed, task_updated, nft_minted, signature_requested, signature_given, signature_refused, document_changed, nft_transferred, access_token_granted, access_token_revoked, key_added, key_revoked)
+// @param document_id query string false "Hex encoded document ID, or job ID for job events"
+// @produce text/event-stream
+// @Failure 403 {object} httputils.HTTPError
+// @Failure 400 {object} httputils.HTTPError
+// @Failure 500 {object} httputils.HTTPError
+// @success 200 {object} notification.Message
+// @router /v2/events [get]
+func (h handler) StreamEvents(w http.ResponseWriter, r *http.Request) {
+	var err error
+	var code int
+	defer httputils.RespondIfError(&code, &err, w, r)
+
+	did, err := contextutil.AccountDID(r.Context())
+	if err != nil {
+		code = http.StatusBadRequest
+		log.Error(err)
+		return
+	}
+
+	filter, err := toEventsFilter(r)
+	if err != nil {
+		code = http.StatusBadRequest
+		log.Error(err)
+		err = errors.NewTypedError(ErrInvalidEventsFilter, err)
+		return
+	}
+
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		code = http.StatusInternalServerError
+		err = errors.New("streaming is not supported")
+		log.Error(err)
+		return
+	}
+
+	broker := notification.GetBroker()
+	sub := broker.Subscribe(did.String(), filter)
+	defer broker.Unsubscribe(sub)
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+	w.WriteHeader(http.StatusOK)
+	flusher.Flush()
+
+	keepAlive := time.NewTicker(eventsKeepAlive)
+	defer keepAlive.Stop()
+	for {
+		select {
+		case <-r.Context().Done():
+			return
+		case <-keepAlive.C:
+			_, werr := fmt.Fprint(w, ": keep-alive\n\n")
+			if werr != nil {
+				return
+			}
+		case msg, ok := <-sub.Events():
+			if !ok {
+				return
+			}
+
+			data, merr := json.Marshal(msg)
+			if merr != nil {
+				log.Error(merr)
+				continue
+			}
+
+			_, werr := fmt.Fprintf(w, "event: %s\ndata: %s\n\n", msg.EventType, data)
+			if werr != nil {
+				return
+			}
+		}
+
+		flusher.Flush()
+	}
+}
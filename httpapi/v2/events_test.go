@@ -0,0 +1,61 @@
+// +build unit
+
+package v2
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/centrifuge/go-centrifuge/config/configstore"
+	"github.com/centrifuge/go-centrifuge/contextutil"
+	"github.com/centrifuge/go-centrifuge/notification"
+	testingidentity "github.com/centrifuge/go-centrifuge/testingutils/identity"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHandler_StreamEvents(t *testing.T) {
+	did := testingidentity.GenerateRandomDID()
+	ctx, err := contextutil.New(context.Background(), &configstore.Account{IdentityID: did[:]})
+	assert.NoError(t, err)
+	h := handler{}
+
+	// invalid filters
+	for _, q := range []string{"type=unknown", "type=task_updated,unknown", "document_id=xyz"} {
+		w, r := httptest.NewRecorder(), httptest.NewRequest("GET", "/events?"+q, nil).WithContext(ctx)
+		h.StreamEvents(w, r)
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		assert.Contains(t, w.Body.String(), ErrInvalidEventsFilter.Error())
+	}
+
+	// stream
+	broker := notification.NewBroker()
+	notification.SetBroker(broker)
+	defer notification.SetBroker(notification.NewBroker())
+	w := httptest.NewRecorder()
+	r := httptest.NewRequest("GET", "/events?type=task_updated,nft_minted&document_id=0x01", nil).WithContext(ctx)
+	done := make(chan struct{})
+	go func() {
+		h.StreamEvents(w, r)
+		close(done)
+	}()
+
+	// wait for the subscription
+	time.Sleep(100 * time.Millisecond)
+	broker.Publish(notification.Message{EventType: notification.TaskUpdated, AccountID: did.String(), DocumentID: "0x01", TaskName: "anchor"})
+	broker.Publish(notification.Message{EventType: notification.JobCompleted, AccountID: did.String(), DocumentID: "0x01"})
+	broker.Publish(notification.Message{EventType: notification.NFTMinted, AccountID: did.String(), DocumentID: "0x02"})
+	time.Sleep(100 * time.Millisecond)
+	broker.CloseSubscriptions()
+	<-done
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "text/event-stream", w.Header().Get("Content-Type"))
+	body := w.Body.String()
+	assert.Equal(t, 1, strings.Count(body, "event: "))
+	assert.Contains(t, body, "event: task_updated\ndata: {")
+	assert.Contains(t, body, `"task_name":"anchor"`)
+}
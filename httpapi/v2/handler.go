@@ -32,4 +32,30 @@ func Register(ctx map[string]interface{}, r chi.Router) {
 	r.Post("/documents/{"+coreapi.DocumentIDParam+"}/transition_rules", h.AddTransitionRules)
 	r.Get("/documents/{"+coreapi.DocumentIDParam+"}/transition_rules/{"+RuleIDParam+"}", h.GetTransitionRule)
 	r.Delete("/documents/{"+coreapi.DocumentIDParam+"}/transition_rules/{"+RuleIDParam+"}", h.DeleteTransitionRule)
+	r.Get("/documents/{"+coreapi.DocumentIDParam+"}/nfts", h.GetDocumentNFTs)
+	r.Get("/nfts", h.ListNFTs)
+	r.Post("/nfts/registries/{"+RegistryAddressParam+"}/mint/preview", h.PreviewMintNFT)
+	r.Get("/nfts/registries/{"+RegistryAddressParam+"}/tokens/{"+TokenIDParam+"}/metadata", h.GetNFTMetadata)
+	r.Get("/nfts/registries/{"+RegistryAddressParam+"}/tokens/{"+TokenIDParam+"}/transfers", h.GetNFTTransfers)
+	r.Post("/nfts/registries/{"+RegistryAddressParam+"}/tokens/{"+TokenIDParam+"}/remint", h.RemintNFT)
+	r.Get("/signature_policy", h.GetSignaturePolicy)
+	r.Put("/signature_policy", h.UpdateSignaturePolicy)
+	r.Get("/signature_requests", h.GetSignatureRequests)
+	r.Post("/signature_requests/{"+SignatureRequestIDParam+"}/approve", h.ApproveSignatureRequest)
+	r.Post("/signature_requests/{"+SignatureRequestIDParam+"}/reject", h.RejectSignatureRequest)
+	r.Post("/accounts/{"+AccountIDParam+"}/keys/rotate", h.RotateKeys)
+	r.Get("/accounts/{"+AccountIDParam+"}/balances", h.GetAccountBalances)
+	r.Get("/jobs", h.ListJobs)
+	r.Post("/jobs/{"+JobIDParam+"}/cancel", h.CancelJob)
+	r.Post("/jobs/{"+JobIDParam+"}/retry", h.RetryJob)
+	r.Get("/events", h.StreamEvents)
+	r.Get("/ethereum/transactions", h.GetEthereumTransactions)
+	r.Post("/ethereum/transactions/{"+TxHashParam+"}/cancel", h.CancelEthereumTransaction)
+	r.Get("/webhooks/dead_letters", h.GetDeadLetters)
+	r.Post("/webhooks/dead_letters/{"+DeliveryIDParam+"}/replay", h.ReplayDeadLetter)
+	r.Post("/webhooks/secret/rotate", h.RotateWebhookSecret)
+	r.Post("/webhooks/subscriptions", h.CreateWebhookSubscription)
+	r.Get("/webhooks/subscriptions", h.GetWebhookSubscriptions)
+	r.Get("/webhooks/subscriptions/{"+SubscriptionIDParam+"}", h.GetWebhookSubscription)
+	r.Delete("/webhooks/subscriptions/{"+SubscriptionIDParam+"}", h.DeleteWebhookSubscription)
 }
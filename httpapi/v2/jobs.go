@@ -0,0 +1,269 @@
+package v2
+
+import (
+	"net/http"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/centrifuge/go-centrifuge/errors"
+	"github.com/centrifuge/go-centrifuge/httpapi/coreapi"
+	"github.com/centrifuge/go-centrifuge/identity"
+	"github.com/centrifuge/go-centrifuge/jobs"
+	"github.com/centrifuge/go-centrifuge/utils/httputils"
+	"github.com/go-chi/chi"
+	"github.com/go-chi/render"
+)
+
+// JobIDParam is the key for the job ID in the API path.
+const JobIDParam = "job_id"
+
+const (
+	// defaultJobsLimit is the number of jobs returned when the limit is not set.
+	defaultJobsLimit = 20
+
+	// maxJobsLimit is the maximum number of jobs returned in a page.
+	maxJobsLimit = 100
+)
+
+// ErrInvalidJobsFilter for invalid query parameters of the jobs listing.
+const ErrInvalidJobsFilter = errors.Error("Invalid Jobs filter")
+
+// JobLog is a log message of a job.
+type JobLog struct {
+	Action    string    `json:"action"`
+	Message   string    `json:"message"`
+	CreatedAt time.Time `json:"created_at" swaggertype:"primitive,string"`
+}
+
+// JobInclusion is the block a transaction of a job was confirmed in.
+type JobInclusion struct {
+	Chain         string    `json:"chain" enums:"ethereum,centchain"`
+	TxHash        string    `json:"tx_hash"`
+	BlockNumber   uint64    `json:"block_number"`
+	BlockHash     string    `json:"block_hash"`
+	Confirmations uint64    `json:"confirmations"`
+	ConfirmedAt   time.Time `json:"confirmed_at" swaggertype:"primitive,string"`
+}
+
+// Job holds the details of a job.
+type Job struct {
+	JobID       string                 `json:"job_id"`
+	AccountID   identity.DID           `json:"account_id" swaggertype:"primitive,string"`
+	Description string                 `json:"description"`
+	Status      jobs.Status            `json:"status" enums:"pending,success,failed,cancelled"`
+	TaskStatus  map[string]jobs.Status `json:"task_status"`
+	Logs        []JobLog               `json:"logs"`
+	Inclusions  []JobInclusion         `json:"inclusions"`
+	CreatedAt   time.Time              `json:"created_at" swaggertype:"primitive,string"`
+}
+
+// JobsResponse is a page of jobs.
+type JobsResponse struct {
+	Jobs   []Job `json:"jobs"`
+	Total  int   `json:"total"`
+	Offset int   `json:"offset"`
+	Limit  int   `json:"limit"`
+}
+
+func toJob(job *jobs.Job) Job {
+	logs := make([]JobLog, 0, len(job.Logs))
+	for _, l := range job.Logs {
+		logs = append(logs, JobLog{Action: l.Action, Message: l.Message, CreatedAt: l.CreatedAt})
+	}
+
+	inclusions := make([]JobInclusion, 0, len(job.Inclusions))
+	for _, i := range job.Inclusions {
+		inclusions = append(inclusions, JobInclusion(i))
+	}
+	sort.Slice(inclusions, func(i, j int) bool {
+		return inclusions[i].ConfirmedAt.Before(inclusions[j].ConfirmedAt)
+	})
+
+	return Job{
+		JobID:       job.ID.String(),
+		AccountID:   job.DID,
+		Description: job.Description,
+		Status:      job.Status,
+		TaskStatus:  job.TaskStatus,
+		Logs:        logs,
+		Inclusions:  inclusions,
+		CreatedAt:   job.CreatedAt,
+	}
+}
+
+// toJobsFilter converts the query parameters to the jobs filter.
+func toJobsFilter(r *http.Request) (jobs.Filter, error) {
+	q := r.URL.Query()
+	filter := jobs.Filter{
+		Status:      jobs.Status(q.Get("status")),
+		Description: q.Get("description"),
+		Limit:       defaultJobsLimit,
+	}
+
+	switch filter.Status {
+	case "", jobs.Pending, jobs.Success, jobs.Failed, jobs.Cancelled:
+	default:
+		return filter, errors.New("invalid status: %s", filter.Status)
+	}
+
+	var err error
+	if v := q.Get("from"); v != "" {
+		filter.From, err = time.Parse(time.RFC3339, v)
+		if err != nil {
+			return filter, errors.New("invalid from: %v", err)
+		}
+	}
+
+	if v := q.Get("to"); v != "" {
+		filter.To, err = time.Parse(time.RFC3339, v)
+		if err != nil {
+			return filter, errors.New("invalid to: %v", err)
+		}
+	}
+
+	if v := q.Get("offset"); v != "" {
+		filter.Offset, err = strconv.Atoi(v)
+		if err != nil || filter.Offset < 0 {
+			return filter, errors.New("invalid offset: %s", v)
+		}
+	}
+
+	if v := q.Get("limit"); v != "" {
+		filter.Limit, err = strconv.Atoi(v)
+		if err != nil || filter.Limit < 1 || filter.Limit > maxJobsLimit {
+			return filter, errors.New("invalid limit: %s", v)
+		}
+	}
+
+	return filter, nil
+}
+
+// ListJobs returns the jobs of the account.
+// @summary Returns the jobs of the account.
+// @description Returns the jobs of the account, newest first, filtered by status, description and creation time.
+// @id list_jobs
+// @tags Jobs
+// @param authorization header string true "Hex encoded centrifuge ID of the account for the intended API action"
+// @param status query string false "Job status" Enums(pending, success, failed, cancelled)
+// @param description query string false "Text the job description contains"
+// @param from query string false "Jobs created at or after the time in RFC3339"
+// @param to query string false "Jobs created at or before the time in RFC3339"
+// @param offset query int false "Number of jobs to skip"
+// @param limit query int false "Number of jobs to return, 20 by default, at most 100"
+// @produce json
+// @Failure 403 {object} httputils.HTTPError
+// @Failure 400 {object} httputils.HTTPError
+// @Failure 500 {object} httputils.HTTPError
+// @success 200 {object} v2.JobsResponse
+// @router /v2/jobs [get]
+func (h handler) ListJobs(w http.ResponseWriter, r *http.Request) {
+	var err error
+	var code int
+	defer httputils.RespondIfError(&code, &err, w, r)
+
+	filter, err := toJobsFilter(r)
+	if err != nil {
+		code = http.StatusBadRequest
+		log.Error(err)
+		err = errors.NewTypedError(ErrInvalidJobsFilter, err)
+		return
+	}
+
+	list, total, err := h.srv.ListJobs(r.Context(), filter)
+	if err != nil {
+		code = http.StatusInternalServerError
+		log.Error(err)
+		return
+	}
+
+	resp := JobsResponse{Jobs: []Job{}, Total: total, Offset: filter.Offset, Limit: filter.Limit}
+	for _, job := range list {
+		resp.Jobs = append(resp.Jobs, toJob(job))
+	}
+
+	render.Status(r, http.StatusOK)
+	render.JSON(w, r, resp)
+}
+
+// CancelJob cancels a pending job.
+// @summary Cancels a pending job.
+// @description Cancels the context of a pending job so that its work stops, and marks the job cancelled.
+// @id cancel_job
+// @tags Jobs
+// @param authorization header string true "Hex encoded centrifuge ID of the account for the intended API action"
+// @param job_id path string true "Job ID"
+// @produce json
+// @Failure 403 {object} httputils.HTTPError
+// @Failure 400 {object} httputils.HTTPError
+// @Failure 404 {object} httputils.HTTPError
+// @success 200 {object} v2.Job
+// @router /v2/jobs/{job_id}/cancel [post]
+func (h handler) CancelJob(w http.ResponseWriter, r *http.Request) {
+	var err error
+	var code int
+	defer httputils.RespondIfError(&code, &err, w, r)
+
+	jobID, err := jobs.FromString(chi.URLParam(r, JobIDParam))
+	if err != nil {
+		code = http.StatusBadRequest
+		log.Error(err)
+		err = errors.NewTypedError(coreapi.ErrInvalidJobID, err)
+		return
+	}
+
+	job, err := h.srv.CancelJob(r.Context(), jobID)
+	if err != nil {
+		log.Error(err)
+		code = http.StatusBadRequest
+		if errors.IsOfType(jobs.ErrJobsMissing, err) {
+			code = http.StatusNotFound
+			err = coreapi.ErrJobNotFound
+		}
+		return
+	}
+
+	render.Status(r, http.StatusOK)
+	render.JSON(w, r, toJob(job))
+}
+
+// RetryJob retries a failed job.
+// @summary Retries a failed job.
+// @description Resumes a failed job from its first failed task, skipping the tasks that already succeeded. For document anchoring, the completed signature collection and pre-commit are not repeated.
+// @id retry_job
+// @tags Jobs
+// @param authorization header string true "Hex encoded centrifuge ID of the account for the intended API action"
+// @param job_id path string true "Job ID"
+// @produce json
+// @Failure 403 {object} httputils.HTTPError
+// @Failure 400 {object} httputils.HTTPError
+// @Failure 404 {object} httputils.HTTPError
+// @success 202 {object} v2.Job
+// @router /v2/jobs/{job_id}/retry [post]
+func (h handler) RetryJob(w http.ResponseWriter, r *http.Request) {
+	var err error
+	var code int
+	defer httputils.RespondIfError(&code, &err, w, r)
+
+	jobID, err := jobs.FromString(chi.URLParam(r, JobIDParam))
+	if err != nil {
+		code = http.StatusBadRequest
+		log.Error(err)
+		err = errors.NewTypedError(coreapi.ErrInvalidJobID, err)
+		return
+	}
+
+	job, err := h.srv.RetryJob(r.Context(), jobID)
+	if err != nil {
+		log.Error(err)
+		code = http.StatusBadRequest
+		if errors.IsOfType(jobs.ErrJobsMissing, err) {
+			code = http.StatusNotFound
+			err = coreapi.ErrJobNotFound
+		}
+		return
+	}
+
+	render.Status(r, http.StatusAccepted)
+	render.JSON(w, r, toJob(job))
+}
@@ -0,0 +1,163 @@
+// +build unit
+
+package v2
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/centrifuge/go-centrifuge/config/configstore"
+	"github.com/centrifuge/go-centrifuge/contextutil"
+	"github.com/centrifuge/go-centrifuge/httpapi/coreapi"
+	"github.com/centrifuge/go-centrifuge/jobs"
+	testingidentity "github.com/centrifuge/go-centrifuge/testingutils/identity"
+	"github.com/centrifuge/go-centrifuge/testingutils/testingjobs"
+	"github.com/go-chi/chi"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestHandler_ListJobs(t *testing.T) {
+	did := testingidentity.GenerateRandomDID()
+	ctx, err := contextutil.New(context.Background(), &configstore.Account{IdentityID: did[:]})
+	assert.NoError(t, err)
+	jobMan := new(testingjobs.MockJobManager)
+	h := handler{srv: Service{jobsMan: jobMan}}
+	getHTTPReqAndResp := func(query string) (*httptest.ResponseRecorder, *http.Request) {
+		return httptest.NewRecorder(), httptest.NewRequest("GET", "/jobs?"+query, nil).WithContext(ctx)
+	}
+
+	// invalid filters
+	for _, q := range []string{"status=unknown", "from=yesterday", "to=1", "offset=-1", "limit=0", "limit=101"} {
+		w, r := getHTTPReqAndResp(q)
+		h.ListJobs(w, r)
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		assert.Contains(t, w.Body.String(), ErrInvalidJobsFilter.Error())
+	}
+
+	// success
+	from := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	filter := jobs.Filter{Status: jobs.Failed, Description: "anchor", From: from, Offset: 1, Limit: 1}
+	job := jobs.NewJob(did, "anchor document")
+	job.Inclusions["0x1"] = jobs.Inclusion{Chain: "centchain", TxHash: "0x1", BlockNumber: 10, Confirmations: 1}
+	jobMan.On("ListJobs", did, filter).Return([]*jobs.Job{job}, 2, nil).Once()
+	w, r := getHTTPReqAndResp("status=failed&description=anchor&from=2020-01-01T00:00:00Z&offset=1&limit=1")
+	h.ListJobs(w, r)
+	assert.Equal(t, http.StatusOK, w.Code)
+	var resp JobsResponse
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+	assert.Equal(t, 2, resp.Total)
+	assert.Len(t, resp.Jobs, 1)
+	assert.Equal(t, job.ID.String(), resp.Jobs[0].JobID)
+	assert.Equal(t, did, resp.Jobs[0].AccountID)
+	assert.Equal(t, []JobInclusion{{Chain: "centchain", TxHash: "0x1", BlockNumber: 10, Confirmations: 1}}, resp.Jobs[0].Inclusions)
+
+	// defaults
+	jobMan.On("ListJobs", did, jobs.Filter{Limit: defaultJobsLimit}).Return(nil, 0, nil).Once()
+	w, r = getHTTPReqAndResp("")
+	h.ListJobs(w, r)
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), `"jobs":[]`)
+	jobMan.AssertExpectations(t)
+}
+
+func TestHandler_CancelJob(t *testing.T) {
+	did := testingidentity.GenerateRandomDID()
+	rctx := chi.NewRouteContext()
+	rctx.URLParams.Keys = []string{JobIDParam}
+	rctx.URLParams.Values = []string{"some invalid id"}
+	ctx := context.WithValue(context.Background(), chi.RouteCtxKey, rctx)
+	ctx, err := contextutil.New(ctx, &configstore.Account{IdentityID: did[:]})
+	assert.NoError(t, err)
+	jobMan := new(testingjobs.MockJobManager)
+	h := handler{srv: Service{jobsMan: jobMan}}
+	getHTTPReqAndResp := func() (*httptest.ResponseRecorder, *http.Request) {
+		return httptest.NewRecorder(), httptest.NewRequest("POST", "/jobs/{job_id}/cancel", nil).WithContext(ctx)
+	}
+
+	// invalid job ID
+	w, r := getHTTPReqAndResp()
+	h.CancelJob(w, r)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	assert.Contains(t, w.Body.String(), coreapi.ErrInvalidJobID.Error())
+
+	// missing job
+	job := jobs.NewJob(did, "anchor document")
+	rctx.URLParams.Values[0] = job.ID.String()
+	jobMan.On("CancelJob", did, job.ID).Return(jobs.ErrJobsMissing).Once()
+	w, r = getHTTPReqAndResp()
+	h.CancelJob(w, r)
+	assert.Equal(t, http.StatusNotFound, w.Code)
+	assert.Contains(t, w.Body.String(), coreapi.ErrJobNotFound.Error())
+
+	// job not pending
+	jobMan.On("CancelJob", did, job.ID).Return(jobs.ErrJobNotPending).Once()
+	w, r = getHTTPReqAndResp()
+	h.CancelJob(w, r)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	assert.Contains(t, w.Body.String(), jobs.ErrJobNotPending.Error())
+
+	// success
+	job.Status = jobs.Cancelled
+	jobMan.On("CancelJob", did, job.ID).Return(nil).Once()
+	jobMan.On("GetJob", did, job.ID).Return(job, nil).Once()
+	w, r = getHTTPReqAndResp()
+	h.CancelJob(w, r)
+	assert.Equal(t, http.StatusOK, w.Code)
+	var resp Job
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+	assert.Equal(t, jobs.Cancelled, resp.Status)
+	jobMan.AssertExpectations(t)
+}
+
+func TestHandler_RetryJob(t *testing.T) {
+	did := testingidentity.GenerateRandomDID()
+	rctx := chi.NewRouteContext()
+	rctx.URLParams.Keys = []string{JobIDParam}
+	rctx.URLParams.Values = []string{"some invalid id"}
+	ctx := context.WithValue(context.Background(), chi.RouteCtxKey, rctx)
+	ctx, err := contextutil.New(ctx, &configstore.Account{IdentityID: did[:]})
+	assert.NoError(t, err)
+	jobMan := new(testingjobs.MockJobManager)
+	h := handler{srv: Service{jobsMan: jobMan}}
+	getHTTPReqAndResp := func() (*httptest.ResponseRecorder, *http.Request) {
+		return httptest.NewRecorder(), httptest.NewRequest("POST", "/jobs/{job_id}/retry", nil).WithContext(ctx)
+	}
+
+	// invalid job ID
+	w, r := getHTTPReqAndResp()
+	h.RetryJob(w, r)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	assert.Contains(t, w.Body.String(), coreapi.ErrInvalidJobID.Error())
+
+	// missing job
+	job := jobs.NewJob(did, "anchor document")
+	rctx.URLParams.Values[0] = job.ID.String()
+	jobMan.On("RetryJob", mock.Anything, did, job.ID).Return(nil, jobs.ErrJobsMissing).Once()
+	w, r = getHTTPReqAndResp()
+	h.RetryJob(w, r)
+	assert.Equal(t, http.StatusNotFound, w.Code)
+	assert.Contains(t, w.Body.String(), coreapi.ErrJobNotFound.Error())
+
+	// job not failed
+	jobMan.On("RetryJob", mock.Anything, did, job.ID).Return(nil, jobs.ErrJobNotFailed).Once()
+	w, r = getHTTPReqAndResp()
+	h.RetryJob(w, r)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	assert.Contains(t, w.Body.String(), jobs.ErrJobNotFailed.Error())
+
+	// success
+	jobMan.On("RetryJob", mock.Anything, did, job.ID).Return(make(chan error), nil).Once()
+	jobMan.On("GetJob", did, job.ID).Return(job, nil).Once()
+	w, r = getHTTPReqAndResp()
+	h.RetryJob(w, r)
+	assert.Equal(t, http.StatusAccepted, w.Code)
+	var resp Job
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+	assert.Equal(t, jobs.Pending, resp.Status)
+	jobMan.AssertExpectations(t)
+}
@@ -0,0 +1,126 @@
+package v2
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/centrifuge/go-centrifuge/config/configstore"
+	"github.com/centrifuge/go-centrifuge/contextutil"
+	"github.com/centrifuge/go-centrifuge/errors"
+	"github.com/centrifuge/go-centrifuge/identity"
+	"github.com/centrifuge/go-centrifuge/utils/httputils"
+	"github.com/go-chi/chi"
+	"github.com/go-chi/render"
+)
+
+// AccountIDParam is the key for the account DID in the API path.
+const AccountIDParam = "account_id"
+
+const (
+	// ErrInvalidAccountID for invalid account DID in the api path.
+	ErrInvalidAccountID = errors.Error("Invalid Account ID")
+
+	// ErrAccountMismatch is returned when the account in the path is not the authorized account.
+	ErrAccountMismatch = errors.Error("account doesn't match the authorized account")
+)
+
+// RotateKeysRequest selects the keys to rotate.
+type RotateKeysRequest struct {
+	// Signing rotates the signing key of the account.
+	Signing bool `json:"signing"`
+
+	// P2P rotates the p2p key of the node. The new key is added to every account of the node.
+	P2P bool `json:"p2p"`
+
+	// RevokeOld revokes the old keys once the new keys are added.
+	RevokeOld bool `json:"revoke_old"`
+
+	// RevokeAfter delays the revocation of the old keys, e.g. "24h".
+	RevokeAfter string `json:"revoke_after,omitempty"`
+}
+
+// RotateKeysResponse holds the new keys and the job that adds them to the identities.
+type RotateKeysResponse struct {
+	JobID string                   `json:"job_id"`
+	Keys  []configstore.RotatedKey `json:"keys"`
+}
+
+// RotateKeys rotates the keys of the account.
+// @summary Rotates the keys of the account.
+// @description Generates new keys, adds them to the identity for the right purposes, updates the account, and switches the p2p host key without a restart. Old keys are optionally revoked after a delay. Delayed revocations don't survive a node restart.
+// @id rotate_keys
+// @tags Accounts
+// @accept json
+// @param authorization header string true "Hex encoded centrifuge ID of the account for the intended API action"
+// @param account_id path string true "Account DID"
+// @param body body v2.RotateKeysRequest true "Rotate Keys Request"
+// @produce json
+// @Failure 403 {object} httputils.HTTPError
+// @Failure 400 {object} httputils.HTTPError
+// @success 202 {object} v2.RotateKeysResponse
+// @router /v2/accounts/{account_id}/keys/rotate [post]
+func (h handler) RotateKeys(w http.ResponseWriter, r *http.Request) {
+	var err error
+	var code int
+	defer httputils.RespondIfError(&code, &err, w, r)
+
+	did, err := identity.NewDIDFromString(chi.URLParam(r, AccountIDParam))
+	if err != nil {
+		code = http.StatusBadRequest
+		log.Error(err)
+		err = ErrInvalidAccountID
+		return
+	}
+
+	ctx := r.Context()
+	self, err := contextutil.AccountDID(ctx)
+	if err != nil || !self.Equal(did) {
+		code = http.StatusForbidden
+		log.Error(err)
+		err = ErrAccountMismatch
+		return
+	}
+
+	var req RotateKeysRequest
+	err = unmarshalBody(r, &req)
+	if err != nil {
+		code = http.StatusBadRequest
+		log.Error(err)
+		return
+	}
+
+	rreq, err := toRotateKeysRequest(req)
+	if err != nil {
+		code = http.StatusBadRequest
+		log.Error(err)
+		return
+	}
+
+	resp, err := h.srv.RotateKeys(ctx, rreq)
+	if err != nil {
+		code = http.StatusBadRequest
+		log.Error(err)
+		return
+	}
+
+	render.Status(r, http.StatusAccepted)
+	render.JSON(w, r, resp)
+}
+
+func toRotateKeysRequest(req RotateKeysRequest) (configstore.RotateKeysRequest, error) {
+	var after time.Duration
+	if req.RevokeAfter != "" {
+		var err error
+		after, err = time.ParseDuration(req.RevokeAfter)
+		if err != nil || after < 0 {
+			return configstore.RotateKeysRequest{}, errors.New("invalid revoke_after: %s", req.RevokeAfter)
+		}
+	}
+
+	return configstore.RotateKeysRequest{
+		Signing:     req.Signing,
+		P2P:         req.P2P,
+		RevokeOld:   req.RevokeOld,
+		RevokeAfter: after,
+	}, nil
+}
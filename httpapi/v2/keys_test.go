@@ -0,0 +1,91 @@
+// +build unit
+
+package v2
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/centrifuge/go-centrifuge/config/configstore"
+	"github.com/centrifuge/go-centrifuge/contextutil"
+	"github.com/centrifuge/go-centrifuge/identity"
+	"github.com/centrifuge/go-centrifuge/jobs"
+	testingidentity "github.com/centrifuge/go-centrifuge/testingutils/identity"
+	"github.com/centrifuge/go-centrifuge/utils"
+	"github.com/go-chi/chi"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestHandler_RotateKeys(t *testing.T) {
+	getHTTPReqAndResp := func(ctx context.Context, body []byte) (*httptest.ResponseRecorder, *http.Request) {
+		return httptest.NewRecorder(), httptest.NewRequest("POST", "/accounts/{account_id}/keys/rotate", bytes.NewReader(body)).WithContext(ctx)
+	}
+
+	did := testingidentity.GenerateRandomDID()
+	rctx := chi.NewRouteContext()
+	rctx.URLParams.Keys = []string{AccountIDParam}
+	rctx.URLParams.Values = []string{"some invalid id"}
+	ctx := context.WithValue(context.Background(), chi.RouteCtxKey, rctx)
+
+	// invalid account ID
+	h := handler{}
+	w, r := getHTTPReqAndResp(ctx, nil)
+	h.RotateKeys(w, r)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	assert.Contains(t, w.Body.String(), ErrInvalidAccountID.Error())
+
+	// not the authorized account
+	rctx.URLParams.Values[0] = did.String()
+	ctx, err := contextutil.New(ctx, &configstore.Account{IdentityID: testingidentity.GenerateRandomDID().ToAddress().Bytes()})
+	assert.NoError(t, err)
+	w, r = getHTTPReqAndResp(ctx, nil)
+	h.RotateKeys(w, r)
+	assert.Equal(t, http.StatusForbidden, w.Code)
+	assert.Contains(t, w.Body.String(), ErrAccountMismatch.Error())
+
+	// invalid delay
+	ctx, err = contextutil.New(ctx, &configstore.Account{IdentityID: did[:]})
+	assert.NoError(t, err)
+	body, err := json.Marshal(RotateKeysRequest{Signing: true, RevokeOld: true, RevokeAfter: "tomorrow"})
+	assert.NoError(t, err)
+	w, r = getHTTPReqAndResp(ctx, body)
+	h.RotateKeys(w, r)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	assert.Contains(t, w.Body.String(), "invalid revoke_after")
+
+	// rotation fails
+	rotator := new(configstore.MockKeyRotator)
+	h.srv.keyRotator = rotator
+	req := configstore.RotateKeysRequest{Signing: true, RevokeOld: true, RevokeAfter: 24 * time.Hour}
+	rotator.On("RotateKeys", mock.Anything, req).Return(nil, nil, nil, configstore.ErrKeyRotationInProgress).Once()
+	body, err = json.Marshal(RotateKeysRequest{Signing: true, RevokeOld: true, RevokeAfter: "24h"})
+	assert.NoError(t, err)
+	w, r = getHTTPReqAndResp(ctx, body)
+	h.RotateKeys(w, r)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	assert.Contains(t, w.Body.String(), configstore.ErrKeyRotationInProgress.Error())
+
+	// success
+	jobID := jobs.NewJobID()
+	keys := []configstore.RotatedKey{{
+		Purpose:  identity.KeyPurposeSigning.Name,
+		OldKey:   utils.RandomSlice(32),
+		NewKey:   utils.RandomSlice(32),
+		Accounts: []identity.DID{did},
+	}}
+	rotator.On("RotateKeys", mock.Anything, req).Return(keys, jobID, make(chan error), nil).Once()
+	w, r = getHTTPReqAndResp(ctx, body)
+	h.RotateKeys(w, r)
+	assert.Equal(t, http.StatusAccepted, w.Code)
+	var resp RotateKeysResponse
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+	assert.Equal(t, jobID.String(), resp.JobID)
+	assert.Equal(t, keys, resp.Keys)
+	rotator.AssertExpectations(t)
+}
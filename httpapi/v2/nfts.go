@@ -0,0 +1,539 @@
+package v2
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/centrifuge/go-centrifuge/errors"
+	"github.com/centrifuge/go-centrifuge/httpapi/coreapi"
+	"github.com/centrifuge/go-centrifuge/identity"
+	"github.com/centrifuge/go-centrifuge/nft"
+	"github.com/centrifuge/go-centrifuge/utils/byteutils"
+	"github.com/centrifuge/go-centrifuge/utils/httputils"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/go-chi/chi"
+	"github.com/go-chi/render"
+)
+
+const (
+	// RegistryAddressParam is the key for the NFT registry address in the API path.
+	RegistryAddressParam = "registry_address"
+
+	// TokenIDParam is the key for the NFT token ID in the API path.
+	TokenIDParam = "token_id"
+)
+
+// NFT holds the details of an NFT minted from a document and its current state on chain.
+type NFT struct {
+	Registry       common.Address  `json:"registry" swaggertype:"primitive,string"`
+	TokenID        hexutil.Bytes   `json:"token_id" swaggertype:"primitive,string"`
+	Owner          common.Address  `json:"owner" swaggertype:"primitive,string"`
+	CurrentIndex   string          `json:"current_index"`
+	AccountID      *identity.DID   `json:"account_id,omitempty" swaggertype:"primitive,string"`
+	DocumentID     hexutil.Bytes   `json:"document_id" swaggertype:"primitive,string"`
+	VersionID      hexutil.Bytes   `json:"version_id,omitempty" swaggertype:"primitive,string"`
+	AnchorID       hexutil.Bytes   `json:"anchor_id,omitempty" swaggertype:"primitive,string"`
+	DepositAddress *common.Address `json:"deposit_address,omitempty" swaggertype:"primitive,string"`
+	ProofFields    []string        `json:"proof_fields"`
+	JobID          string          `json:"job_id,omitempty"`
+	MintedAt       *time.Time      `json:"minted_at,omitempty" swaggertype:"primitive,string"`
+	Replaces       hexutil.Bytes   `json:"replaces,omitempty" swaggertype:"primitive,string"`
+	ReplacedBy     hexutil.Bytes   `json:"replaced_by,omitempty" swaggertype:"primitive,string"`
+	BurnedAt       *time.Time      `json:"burned_at,omitempty" swaggertype:"primitive,string"`
+}
+
+// RemintNFTRequest holds the fields for minting the NFT replacing the burned NFT.
+type RemintNFTRequest struct {
+	DocumentID               byteutils.HexBytes    `json:"document_id" swaggertype:"primitive,string"`
+	DepositAddress           common.Address        `json:"deposit_address" swaggertype:"primitive,string"`
+	AssetManagerAddress      byteutils.OptionalHex `json:"asset_manager_address" swaggertype:"primitive,string"`
+	ProofFields              []string              `json:"proof_fields"`
+	GrantNFTReadAccess       bool                  `json:"grant_nft_read_access"`
+	SubmitNFTReadAccessProof bool                  `json:"submit_nft_read_access_proof"`
+}
+
+// RemintNFTResponse holds the re-minted NFT and the job burning the NFT and minting its replacement.
+type RemintNFTResponse struct {
+	JobID           string             `json:"job_id"`
+	DocumentID      byteutils.HexBytes `json:"document_id" swaggertype:"primitive,string"`
+	TokenID         string             `json:"token_id"`
+	Replaces        string             `json:"replaces"`
+	RegistryAddress common.Address     `json:"registry_address" swaggertype:"primitive,string"`
+	DepositAddress  common.Address     `json:"deposit_address" swaggertype:"primitive,string"`
+}
+
+// MintPreviewRequest holds the fields for building the mint of an NFT against the latest version of a document.
+type MintPreviewRequest struct {
+	DocumentID               byteutils.HexBytes    `json:"document_id" swaggertype:"primitive,string"`
+	DepositAddress           common.Address        `json:"deposit_address" swaggertype:"primitive,string"`
+	AssetManagerAddress      byteutils.OptionalHex `json:"asset_manager_address" swaggertype:"primitive,string"`
+	ProofFields              []string              `json:"proof_fields"`
+	GrantNFTReadAccess       bool                  `json:"grant_nft_read_access"`
+	SubmitTokenProof         bool                  `json:"submit_token_proof"`
+	SubmitNFTReadAccessProof bool                  `json:"submit_nft_read_access_proof"`
+}
+
+// MintProof is a proof of a document field passed to the mint method of the registry.
+type MintProof struct {
+	Property     hexutil.Bytes   `json:"property" swaggertype:"primitive,string"`
+	Value        hexutil.Bytes   `json:"value" swaggertype:"primitive,string"`
+	Salt         hexutil.Bytes   `json:"salt" swaggertype:"primitive,string"`
+	SortedHashes []hexutil.Bytes `json:"sorted_hashes" swaggertype:"array,string"`
+}
+
+// ValidateNFTProof is a proof of the ValidateNFT call on centchain.
+type ValidateNFTProof struct {
+	LeafHash     hexutil.Bytes   `json:"leaf_hash" swaggertype:"primitive,string"`
+	SortedHashes []hexutil.Bytes `json:"sorted_hashes" swaggertype:"array,string"`
+}
+
+// ValidateNFTPayload holds the arguments of the ValidateNFT call on centchain.
+type ValidateNFTPayload struct {
+	AnchorID       hexutil.Bytes      `json:"anchor_id" swaggertype:"primitive,string"`
+	DepositAddress common.Address     `json:"deposit_address" swaggertype:"primitive,string"`
+	Proofs         []ValidateNFTProof `json:"proofs"`
+	StaticProofs   []hexutil.Bytes    `json:"static_proofs" swaggertype:"array,string"`
+}
+
+// MintPreviewResponse holds the mint of an NFT built without sending any transaction.
+type MintPreviewResponse struct {
+	TokenID         string             `json:"token_id"`
+	RegistryAddress common.Address     `json:"registry_address" swaggertype:"primitive,string"`
+	DocumentID      byteutils.HexBytes `json:"document_id" swaggertype:"primitive,string"`
+	DocumentRoot    hexutil.Bytes      `json:"document_root" swaggertype:"primitive,string"`
+	AnchorID        hexutil.Bytes      `json:"anchor_id" swaggertype:"primitive,string"`
+	NextAnchorID    hexutil.Bytes      `json:"next_anchor_id" swaggertype:"primitive,string"`
+	SigningRoot     hexutil.Bytes      `json:"signing_root" swaggertype:"primitive,string"`
+	SignaturesRoot  hexutil.Bytes      `json:"signatures_root" swaggertype:"primitive,string"`
+	LeftDataRoot    hexutil.Bytes      `json:"left_data_root" swaggertype:"primitive,string"`
+	RightDataRoot   hexutil.Bytes      `json:"right_data_root" swaggertype:"primitive,string"`
+	BundledHash     hexutil.Bytes      `json:"bundled_hash" swaggertype:"primitive,string"`
+	Proofs          []MintProof        `json:"proofs"`
+
+	// Calldata is the ABI encoded call of the mint method of the registry
+	Calldata    hexutil.Bytes      `json:"calldata" swaggertype:"primitive,string"`
+	ValidateNFT ValidateNFTPayload `json:"validate_nft"`
+}
+
+func toHexBytes32(hashes [][32]byte) []hexutil.Bytes {
+	res := make([]hexutil.Bytes, 0, len(hashes))
+	for _, h := range hashes {
+		res = append(res, append([]byte(nil), h[:]...))
+	}
+
+	return res
+}
+
+func toMintPreviewResponse(registry common.Address, docID []byte, preview *nft.MintPreview) MintPreviewResponse {
+	mreq := preview.Request
+	resp := MintPreviewResponse{
+		TokenID:         preview.TokenID.String(),
+		RegistryAddress: registry,
+		DocumentID:      docID,
+		DocumentRoot:    preview.DocumentRoot,
+		AnchorID:        mreq.AnchorID[:],
+		SigningRoot:     mreq.SigningRoot[:],
+		SignaturesRoot:  mreq.SignaturesRoot[:],
+		LeftDataRoot:    mreq.LeftDataRoot[:],
+		RightDataRoot:   mreq.RightDataRoot[:],
+		BundledHash:     mreq.BundledHash[:],
+		Proofs:          []MintProof{},
+		Calldata:        preview.Calldata,
+		ValidateNFT: ValidateNFTPayload{
+			AnchorID:       mreq.AnchorID[:],
+			DepositAddress: mreq.To,
+			Proofs:         []ValidateNFTProof{},
+			StaticProofs:   toHexBytes32(preview.StaticProofs[:]),
+		},
+	}
+
+	if mreq.NextAnchorID != nil {
+		resp.NextAnchorID = common.LeftPadBytes(mreq.NextAnchorID.Bytes(), 32)
+	}
+
+	for i := range mreq.Props {
+		resp.Proofs = append(resp.Proofs, MintProof{
+			Property:     mreq.Props[i],
+			Value:        mreq.Values[i],
+			Salt:         mreq.Salts[i][:],
+			SortedHashes: toHexBytes32(mreq.Proofs[i]),
+		})
+	}
+
+	for _, p := range preview.Proofs {
+		resp.ValidateNFT.Proofs = append(resp.ValidateNFT.Proofs, ValidateNFTProof{
+			LeafHash:     p.LeafHash[:],
+			SortedHashes: toHexBytes32(p.SortedHashes),
+		})
+	}
+
+	return resp
+}
+
+// NFTTransfer is a transfer of an NFT observed on chain.
+type NFTTransfer struct {
+	From         common.Address `json:"from" swaggertype:"primitive,string"`
+	To           common.Address `json:"to" swaggertype:"primitive,string"`
+	BlockNumber  uint64         `json:"block_number"`
+	TxHash       common.Hash    `json:"tx_hash" swaggertype:"primitive,string"`
+	LogIndex     uint           `json:"log_index"`
+	ObservedAt   time.Time      `json:"observed_at" swaggertype:"primitive,string"`
+	Kind         string         `json:"kind" enums:"transfer,mint,burn"`
+	DocumentSent bool           `json:"document_sent"`
+}
+
+func toNFTTransfers(transfers []nft.Transfer) []NFTTransfer {
+	res := make([]NFTTransfer, 0, len(transfers))
+	for _, t := range transfers {
+		res = append(res, NFTTransfer{
+			From:         t.From,
+			To:           t.To,
+			BlockNumber:  t.BlockNumber,
+			TxHash:       t.TxHash,
+			LogIndex:     t.LogIndex,
+			ObservedAt:   t.ObservedAt,
+			Kind:         t.Kind,
+			DocumentSent: t.DocumentSent,
+		})
+	}
+
+	return res
+}
+
+func toNFTs(tokens []nft.TokenInfo) []NFT {
+	resp := make([]NFT, 0, len(tokens))
+	for _, t := range tokens {
+		n := NFT{
+			Registry:    t.Registry,
+			TokenID:     t.TokenID,
+			Owner:       t.Owner,
+			DocumentID:  t.DocumentID,
+			VersionID:   t.VersionID,
+			AnchorID:    t.AnchorID,
+			ProofFields: t.ProofFields,
+			JobID:       t.JobID,
+			Replaces:    t.Replaces,
+			ReplacedBy:  t.ReplacedBy,
+		}
+
+		if t.ProofFields == nil {
+			n.ProofFields = []string{}
+		}
+
+		if t.CurrentIndex != nil {
+			n.CurrentIndex = t.CurrentIndex.String()
+		}
+
+		// tokens not minted by the account have no inventory record
+		if !t.MintedAt.IsZero() {
+			accountID, depositAddress, mintedAt := t.AccountID, t.DepositAddress, t.MintedAt
+			n.AccountID, n.DepositAddress, n.MintedAt = &accountID, &depositAddress, &mintedAt
+		}
+
+		if !t.BurnedAt.IsZero() {
+			burnedAt := t.BurnedAt
+			n.BurnedAt = &burnedAt
+		}
+
+		resp = append(resp, n)
+	}
+
+	return resp
+}
+
+// ListNFTs returns the NFTs minted by the account.
+// @summary Returns the NFTs minted by the account.
+// @description Returns the NFTs minted by the account with the documents they were minted from, and their current owner and index in the registry.
+// @id list_nfts
+// @tags NFTs
+// @param authorization header string true "Hex encoded centrifuge ID of the account for the intended API action"
+// @produce json
+// @Failure 403 {object} httputils.HTTPError
+// @Failure 500 {object} httputils.HTTPError
+// @success 200 {array} v2.NFT
+// @router /v2/nfts [get]
+func (h handler) ListNFTs(w http.ResponseWriter, r *http.Request) {
+	var err error
+	var code int
+	defer httputils.RespondIfError(&code, &err, w, r)
+
+	tokens, err := h.srv.GetAccountNFTs(r.Context())
+	if err != nil {
+		code = http.StatusInternalServerError
+		log.Error(err)
+		return
+	}
+
+	render.Status(r, http.StatusOK)
+	render.JSON(w, r, toNFTs(tokens))
+}
+
+// GetDocumentNFTs returns the NFTs of the document.
+// @summary Returns the NFTs of the latest version of the document.
+// @description Returns the NFTs of the latest version of the document with their current owner and index in the registry. NFTs minted by the account include the minting details.
+// @id get_document_nfts
+// @tags NFTs
+// @param authorization header string true "Hex encoded centrifuge ID of the account for the intended API action"
+// @param document_id path string true "Document Identifier"
+// @produce json
+// @Failure 403 {object} httputils.HTTPError
+// @Failure 400 {object} httputils.HTTPError
+// @Failure 404 {object} httputils.HTTPError
+// @success 200 {array} v2.NFT
+// @router /v2/documents/{document_id}/nfts [get]
+func (h handler) GetDocumentNFTs(w http.ResponseWriter, r *http.Request) {
+	var err error
+	var code int
+	defer httputils.RespondIfError(&code, &err, w, r)
+
+	docID, err := hexutil.Decode(chi.URLParam(r, coreapi.DocumentIDParam))
+	if err != nil {
+		code = http.StatusBadRequest
+		log.Error(err)
+		err = coreapi.ErrInvalidDocumentID
+		return
+	}
+
+	tokens, err := h.srv.GetDocumentNFTs(r.Context(), docID)
+	if err != nil {
+		code = http.StatusNotFound
+		log.Error(err)
+		err = coreapi.ErrDocumentNotFound
+		return
+	}
+
+	render.Status(r, http.StatusOK)
+	render.JSON(w, r, toNFTs(tokens))
+}
+
+// GetNFTMetadata returns the ERC-721 metadata of the NFT.
+// @summary Returns the ERC-721 metadata of the NFT.
+// @description Returns the ERC-721 JSON metadata of an NFT minted by the account, built from the document version it was minted from.
+// @id get_nft_metadata
+// @tags NFTs
+// @param authorization header string true "Hex encoded centrifuge ID of the account for the intended API action"
+// @param registry_address path string true "Registry address in hex"
+// @param token_id path string true "NFT token ID in hex"
+// @produce json
+// @Failure 403 {object} httputils.HTTPError
+// @Failure 400 {object} httputils.HTTPError
+// @Failure 404 {object} httputils.HTTPError
+// @success 200 {object} nft.Metadata
+// @router /v2/nfts/registries/{registry_address}/tokens/{token_id}/metadata [get]
+func (h handler) GetNFTMetadata(w http.ResponseWriter, r *http.Request) {
+	var err error
+	var code int
+	defer httputils.RespondIfError(&code, &err, w, r)
+
+	if !common.IsHexAddress(chi.URLParam(r, RegistryAddressParam)) {
+		code = http.StatusBadRequest
+		err = coreapi.ErrInvalidRegistryAddress
+		log.Error(err)
+		return
+	}
+
+	tokenID, err := nft.TokenIDFromString(chi.URLParam(r, TokenIDParam))
+	if err != nil {
+		code = http.StatusBadRequest
+		log.Error(err)
+		err = coreapi.ErrInvalidTokenID
+		return
+	}
+
+	registry := common.HexToAddress(chi.URLParam(r, RegistryAddressParam))
+	md, err := h.srv.GetNFTMetadata(r.Context(), registry, tokenID)
+	if err != nil {
+		log.Error(err)
+		code = http.StatusBadRequest
+		if errors.IsOfType(nft.ErrTokenNotFound, err) {
+			code = http.StatusNotFound
+			err = nft.ErrTokenNotFound
+		}
+		return
+	}
+
+	render.Status(r, http.StatusOK)
+	render.JSON(w, r, md)
+}
+
+// GetNFTTransfers returns the ownership history of the NFT.
+// @summary Returns the ownership history of the NFT.
+// @description Returns the transfers of an NFT minted by the account or linked to its documents observed on chain by the node, oldest first. Transfers are observed while the NFT transfer watcher is enabled. Mints and burns are labelled by the kind of the transfer.
+// @id get_nft_transfers
+// @tags NFTs
+// @param authorization header string true "Hex encoded centrifuge ID of the account for the intended API action"
+// @param registry_address path string true "Registry address in hex"
+// @param token_id path string true "NFT token ID in hex"
+// @produce json
+// @Failure 403 {object} httputils.HTTPError
+// @Failure 400 {object} httputils.HTTPError
+// @Failure 404 {object} httputils.HTTPError
+// @success 200 {array} v2.NFTTransfer
+// @router /v2/nfts/registries/{registry_address}/tokens/{token_id}/transfers [get]
+func (h handler) GetNFTTransfers(w http.ResponseWriter, r *http.Request) {
+	var err error
+	var code int
+	defer httputils.RespondIfError(&code, &err, w, r)
+
+	if !common.IsHexAddress(chi.URLParam(r, RegistryAddressParam)) {
+		code = http.StatusBadRequest
+		err = coreapi.ErrInvalidRegistryAddress
+		log.Error(err)
+		return
+	}
+
+	tokenID, err := nft.TokenIDFromString(chi.URLParam(r, TokenIDParam))
+	if err != nil {
+		code = http.StatusBadRequest
+		log.Error(err)
+		err = coreapi.ErrInvalidTokenID
+		return
+	}
+
+	registry := common.HexToAddress(chi.URLParam(r, RegistryAddressParam))
+	transfers, err := h.srv.GetNFTTransfers(r.Context(), registry, tokenID)
+	if err != nil {
+		log.Error(err)
+		code = http.StatusBadRequest
+		if errors.IsOfType(nft.ErrTokenNotFound, err) {
+			code = http.StatusNotFound
+			err = nft.ErrTokenNotFound
+		}
+		return
+	}
+
+	render.Status(r, http.StatusOK)
+	render.JSON(w, r, toNFTTransfers(transfers))
+}
+
+// RemintNFT burns the NFT and mints a new NFT against the latest version of the document.
+// @summary Burns the NFT and mints a new NFT against the latest version of the document.
+// @description Burns the NFT of the document through the registry and mints a new NFT in the same registry against the latest anchored version of the document. The identity of the account must own the NFT. The new NFT replaces the burned NFT in the NFTs of the document, and the burned NFT loses its read access to the document. Read access of the new NFT is granted with grant_nft_read_access.
+// @id remint_nft
+// @tags NFTs
+// @accept json
+// @param authorization header string true "Hex encoded centrifuge ID of the account for the intended API action"
+// @param registry_address path string true "Registry address in hex"
+// @param token_id path string true "NFT token ID in hex"
+// @param body body v2.RemintNFTRequest true "Remint NFT request"
+// @produce json
+// @Failure 403 {object} httputils.HTTPError
+// @Failure 400 {object} httputils.HTTPError
+// @Failure 500 {object} httputils.HTTPError
+// @success 202 {object} v2.RemintNFTResponse
+// @router /v2/nfts/registries/{registry_address}/tokens/{token_id}/remint [post]
+func (h handler) RemintNFT(w http.ResponseWriter, r *http.Request) {
+	var err error
+	var code int
+	defer httputils.RespondIfError(&code, &err, w, r)
+
+	if !common.IsHexAddress(chi.URLParam(r, RegistryAddressParam)) {
+		code = http.StatusBadRequest
+		err = coreapi.ErrInvalidRegistryAddress
+		log.Error(err)
+		return
+	}
+
+	tokenID, err := nft.TokenIDFromString(chi.URLParam(r, TokenIDParam))
+	if err != nil {
+		code = http.StatusBadRequest
+		log.Error(err)
+		err = coreapi.ErrInvalidTokenID
+		return
+	}
+
+	var req RemintNFTRequest
+	err = unmarshalBody(r, &req)
+	if err != nil {
+		code = http.StatusBadRequest
+		log.Error(err)
+		return
+	}
+
+	registry := common.HexToAddress(chi.URLParam(r, RegistryAddressParam))
+	resp, err := h.srv.RemintNFT(r.Context(), nft.RemintNFTRequest{
+		MintNFTRequest: nft.MintNFTRequest{
+			DocumentID:               req.DocumentID,
+			RegistryAddress:          registry,
+			DepositAddress:           req.DepositAddress,
+			AssetManagerAddress:      common.HexToAddress(req.AssetManagerAddress.String()),
+			ProofFields:              req.ProofFields,
+			GrantNFTReadAccess:       req.GrantNFTReadAccess,
+			SubmitTokenProof:         true,
+			SubmitNFTReadAccessProof: req.SubmitNFTReadAccessProof,
+		},
+		TokenID: tokenID,
+	})
+	if err != nil {
+		code = http.StatusBadRequest
+		log.Error(err)
+		return
+	}
+
+	render.Status(r, http.StatusAccepted)
+	render.JSON(w, r, RemintNFTResponse{
+		JobID:           resp.JobID,
+		DocumentID:      req.DocumentID,
+		TokenID:         resp.TokenID,
+		Replaces:        tokenID.String(),
+		RegistryAddress: registry,
+		DepositAddress:  req.DepositAddress,
+	})
+}
+
+// PreviewMintNFT builds the mint of an NFT without sending it.
+// @summary Builds the mint of an NFT against the latest version of the document without sending it.
+// @description Builds the mint request and the optimized proofs of an NFT against the latest version of the document, and checks the proofs against the document root. Returns the ABI encoded calldata of the mint method of the registry and the payload of the ValidateNFT call on centchain. Like the mint, the new NFT is added to a new version of the document, but the version is only built to create the proofs: it isn't signed, anchored or stored, so the proofs and anchor IDs are those of the unsigned version. Nothing is sent and the document isn't updated.
+// @id preview_mint_nft
+// @tags NFTs
+// @accept json
+// @param authorization header string true "Hex encoded centrifuge ID of the account for the intended API action"
+// @param registry_address path string true "Registry address in hex"
+// @param body body v2.MintPreviewRequest true "Mint preview request"
+// @produce json
+// @Failure 403 {object} httputils.HTTPError
+// @Failure 400 {object} httputils.HTTPError
+// @Failure 500 {object} httputils.HTTPError
+// @success 200 {object} v2.MintPreviewResponse
+// @router /v2/nfts/registries/{registry_address}/mint/preview [post]
+func (h handler) PreviewMintNFT(w http.ResponseWriter, r *http.Request) {
+	var err error
+	var code int
+	defer httputils.RespondIfError(&code, &err, w, r)
+
+	if !common.IsHexAddress(chi.URLParam(r, RegistryAddressParam)) {
+		code = http.StatusBadRequest
+		err = coreapi.ErrInvalidRegistryAddress
+		log.Error(err)
+		return
+	}
+
+	var req MintPreviewRequest
+	err = unmarshalBody(r, &req)
+	if err != nil {
+		code = http.StatusBadRequest
+		log.Error(err)
+		return
+	}
+
+	registry := common.HexToAddress(chi.URLParam(r, RegistryAddressParam))
+	preview, err := h.srv.PreviewMintNFT(r.Context(), nft.MintNFTRequest{
+		DocumentID:               req.DocumentID,
+		RegistryAddress:          registry,
+		DepositAddress:           req.DepositAddress,
+		AssetManagerAddress:      common.HexToAddress(req.AssetManagerAddress.String()),
+		ProofFields:              req.ProofFields,
+		GrantNFTReadAccess:       req.GrantNFTReadAccess,
+		SubmitTokenProof:         req.SubmitTokenProof,
+		SubmitNFTReadAccessProof: req.SubmitNFTReadAccessProof,
+	})
+	if err != nil {
+		code = http.StatusBadRequest
+		log.Error(err)
+		return
+	}
+
+	render.Status(r, http.StatusOK)
+	render.JSON(w, r, toMintPreviewResponse(registry, req.DocumentID, preview))
+}
@@ -0,0 +1,400 @@
+// +build unit
+
+package v2
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/centrifuge/go-centrifuge/errors"
+	"github.com/centrifuge/go-centrifuge/httpapi/coreapi"
+	"github.com/centrifuge/go-centrifuge/nft"
+	"github.com/centrifuge/go-centrifuge/testingutils/identity"
+	"github.com/centrifuge/go-centrifuge/testingutils/nfts"
+	"github.com/centrifuge/go-centrifuge/utils"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/go-chi/chi"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestHandler_ListNFTs(t *testing.T) {
+	nftSrv := new(testingnfts.MockNFTService)
+	h := handler{srv: Service{nftSrv: nftSrv}}
+	getHTTPReqAndResp := func() (*httptest.ResponseRecorder, *http.Request) {
+		return httptest.NewRecorder(), httptest.NewRequest("GET", "/nfts", nil)
+	}
+
+	// failed
+	nftSrv.On("GetAccountTokens", mock.Anything).Return(nil, errors.New("failed")).Once()
+	w, r := getHTTPReqAndResp()
+	h.ListNFTs(w, r)
+	assert.Equal(t, http.StatusInternalServerError, w.Code)
+
+	// empty
+	nftSrv.On("GetAccountTokens", mock.Anything).Return(nil, nil).Once()
+	w, r = getHTTPReqAndResp()
+	h.ListNFTs(w, r)
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "[]\n", w.Body.String())
+
+	// success
+	token := nft.TokenInfo{
+		Token: nft.Token{
+			Registry:    common.HexToAddress("0x111855759a39fb75fc7341139f5d7a3974d4da08"),
+			TokenID:     utils.RandomSlice(32),
+			AccountID:   testingidentity.GenerateRandomDID(),
+			DocumentID:  utils.RandomSlice(32),
+			VersionID:   utils.RandomSlice(32),
+			AnchorID:    utils.RandomSlice(32),
+			ProofFields: []string{"generic.data"},
+			JobID:       "job",
+			MintedAt:    time.Now().UTC(),
+		},
+		Owner:        common.HexToAddress("0x222855759a39fb75fc7341139f5d7a3974d4da08"),
+		CurrentIndex: big.NewInt(2),
+	}
+	nftSrv.On("GetAccountTokens", mock.Anything).Return([]nft.TokenInfo{token}, nil).Once()
+	w, r = getHTTPReqAndResp()
+	h.ListNFTs(w, r)
+	assert.Equal(t, http.StatusOK, w.Code)
+	var resp []NFT
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+	assert.Len(t, resp, 1)
+	assert.Equal(t, token.Registry, resp[0].Registry)
+	assert.Equal(t, token.TokenID, resp[0].TokenID)
+	assert.Equal(t, token.Owner, resp[0].Owner)
+	assert.Equal(t, "2", resp[0].CurrentIndex)
+	assert.Equal(t, token.AccountID, *resp[0].AccountID)
+	assert.Equal(t, token.AnchorID, resp[0].AnchorID)
+	assert.Equal(t, token.ProofFields, resp[0].ProofFields)
+	assert.Equal(t, "job", resp[0].JobID)
+	nftSrv.AssertExpectations(t)
+}
+
+func TestHandler_GetDocumentNFTs(t *testing.T) {
+	rctx := chi.NewRouteContext()
+	rctx.URLParams.Keys = []string{coreapi.DocumentIDParam}
+	rctx.URLParams.Values = []string{"some invalid id"}
+	ctx := context.WithValue(context.Background(), chi.RouteCtxKey, rctx)
+	nftSrv := new(testingnfts.MockNFTService)
+	h := handler{srv: Service{nftSrv: nftSrv}}
+	getHTTPReqAndResp := func() (*httptest.ResponseRecorder, *http.Request) {
+		return httptest.NewRecorder(), httptest.NewRequest("GET", "/documents/{document_id}/nfts", nil).WithContext(ctx)
+	}
+
+	// invalid document ID
+	w, r := getHTTPReqAndResp()
+	h.GetDocumentNFTs(w, r)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	assert.Contains(t, w.Body.String(), coreapi.ErrInvalidDocumentID.Error())
+
+	// missing document
+	docID := utils.RandomSlice(32)
+	rctx.URLParams.Values[0] = hexutil.Encode(docID)
+	nftSrv.On("GetDocumentTokens", mock.Anything, docID).Return(nil, errors.New("missing")).Once()
+	w, r = getHTTPReqAndResp()
+	h.GetDocumentNFTs(w, r)
+	assert.Equal(t, http.StatusNotFound, w.Code)
+	assert.Contains(t, w.Body.String(), coreapi.ErrDocumentNotFound.Error())
+
+	// token not minted by the account
+	token := nft.TokenInfo{Token: nft.Token{
+		Registry:   common.HexToAddress("0x111855759a39fb75fc7341139f5d7a3974d4da08"),
+		TokenID:    utils.RandomSlice(32),
+		DocumentID: docID,
+	}}
+	nftSrv.On("GetDocumentTokens", mock.Anything, docID).Return([]nft.TokenInfo{token}, nil).Once()
+	w, r = getHTTPReqAndResp()
+	h.GetDocumentNFTs(w, r)
+	assert.Equal(t, http.StatusOK, w.Code)
+	var resp []NFT
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+	assert.Len(t, resp, 1)
+	assert.Equal(t, token.TokenID, resp[0].TokenID)
+	assert.Nil(t, resp[0].AccountID)
+	assert.Nil(t, resp[0].MintedAt)
+	assert.Empty(t, resp[0].CurrentIndex)
+	assert.Equal(t, []string{}, resp[0].ProofFields)
+	nftSrv.AssertExpectations(t)
+}
+
+func TestHandler_GetNFTMetadata(t *testing.T) {
+	rctx := chi.NewRouteContext()
+	rctx.URLParams.Keys = []string{RegistryAddressParam, TokenIDParam}
+	rctx.URLParams.Values = []string{"some invalid address", "some invalid token"}
+	ctx := context.WithValue(context.Background(), chi.RouteCtxKey, rctx)
+	nftSrv := new(testingnfts.MockNFTService)
+	h := handler{srv: Service{nftSrv: nftSrv}}
+	getHTTPReqAndResp := func() (*httptest.ResponseRecorder, *http.Request) {
+		return httptest.NewRecorder(), httptest.NewRequest("GET", "/nfts/registries/{registry_address}/tokens/{token_id}/metadata", nil).WithContext(ctx)
+	}
+
+	// invalid registry
+	w, r := getHTTPReqAndResp()
+	h.GetNFTMetadata(w, r)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	assert.Contains(t, w.Body.String(), coreapi.ErrInvalidRegistryAddress.Error())
+
+	// invalid token
+	registry := common.HexToAddress("0x111855759a39fb75fc7341139f5d7a3974d4da08")
+	rctx.URLParams.Values[0] = registry.Hex()
+	w, r = getHTTPReqAndResp()
+	h.GetNFTMetadata(w, r)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	assert.Contains(t, w.Body.String(), coreapi.ErrInvalidTokenID.Error())
+
+	// missing token
+	tokenID := nft.NewTokenID()
+	rctx.URLParams.Values[1] = tokenID.String()
+	nftSrv.On("GetTokenMetadata", mock.Anything, registry, tokenID).Return(
+		nil, errors.NewTypedError(nft.ErrTokenNotFound, errors.New("missing"))).Once()
+	w, r = getHTTPReqAndResp()
+	h.GetNFTMetadata(w, r)
+	assert.Equal(t, http.StatusNotFound, w.Code)
+	assert.Contains(t, w.Body.String(), nft.ErrTokenNotFound.Error())
+
+	// success
+	md := &nft.Metadata{
+		Name:        "name",
+		Description: "description",
+		Attributes:  []nft.MetadataAttribute{{TraitType: "scheme", Value: "generic"}},
+	}
+	nftSrv.On("GetTokenMetadata", mock.Anything, registry, tokenID).Return(md, nil).Once()
+	w, r = getHTTPReqAndResp()
+	h.GetNFTMetadata(w, r)
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), `"trait_type":"scheme"`)
+	nftSrv.AssertExpectations(t)
+}
+
+func TestHandler_RemintNFT(t *testing.T) {
+	rctx := chi.NewRouteContext()
+	rctx.URLParams.Keys = []string{RegistryAddressParam, TokenIDParam}
+	rctx.URLParams.Values = []string{"some invalid address", "some invalid token"}
+	ctx := context.WithValue(context.Background(), chi.RouteCtxKey, rctx)
+	nftSrv := new(testingnfts.MockNFTService)
+	h := handler{srv: Service{nftSrv: nftSrv}}
+	getHTTPReqAndResp := func(body io.Reader) (*httptest.ResponseRecorder, *http.Request) {
+		return httptest.NewRecorder(), httptest.NewRequest("POST", "/nfts/registries/{registry_address}/tokens/{token_id}/remint", body).WithContext(ctx)
+	}
+
+	// invalid registry
+	w, r := getHTTPReqAndResp(nil)
+	h.RemintNFT(w, r)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	assert.Contains(t, w.Body.String(), coreapi.ErrInvalidRegistryAddress.Error())
+
+	// invalid token
+	registry := common.HexToAddress("0x111855759a39fb75fc7341139f5d7a3974d4da08")
+	rctx.URLParams.Values[0] = registry.Hex()
+	w, r = getHTTPReqAndResp(nil)
+	h.RemintNFT(w, r)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	assert.Contains(t, w.Body.String(), coreapi.ErrInvalidTokenID.Error())
+
+	// invalid body
+	tokenID := nft.NewTokenID()
+	rctx.URLParams.Values[1] = tokenID.String()
+	w, r = getHTTPReqAndResp(bytes.NewReader([]byte("invalid json")))
+	h.RemintNFT(w, r)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+
+	docID := utils.RandomSlice(32)
+	deposit := common.HexToAddress("0x222855759a39fb75fc7341139f5d7a3974d4da08")
+	body, err := json.Marshal(map[string]interface{}{
+		"document_id":                  hexutil.Encode(docID),
+		"deposit_address":              deposit.Hex(),
+		"proof_fields":                 []string{"generic.data"},
+		"grant_nft_read_access":        true,
+		"submit_nft_read_access_proof": true,
+	})
+	assert.NoError(t, err)
+	req := nft.RemintNFTRequest{
+		MintNFTRequest: nft.MintNFTRequest{
+			DocumentID:               docID,
+			RegistryAddress:          registry,
+			DepositAddress:           deposit,
+			ProofFields:              []string{"generic.data"},
+			GrantNFTReadAccess:       true,
+			SubmitTokenProof:         true,
+			SubmitNFTReadAccessProof: true,
+		},
+		TokenID: tokenID,
+	}
+
+	// failed
+	nftSrv.On("RemintNFT", mock.Anything, req).Return(nil, nil, errors.New("nft not found")).Once()
+	w, r = getHTTPReqAndResp(bytes.NewReader(body))
+	h.RemintNFT(w, r)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+
+	// success
+	newTokenID := nft.NewTokenID()
+	nftSrv.On("RemintNFT", mock.Anything, req).Return(
+		&nft.TokenResponse{JobID: "job", TokenID: newTokenID.String()}, make(chan error), nil).Once()
+	w, r = getHTTPReqAndResp(bytes.NewReader(body))
+	h.RemintNFT(w, r)
+	assert.Equal(t, http.StatusAccepted, w.Code)
+	var resp RemintNFTResponse
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+	assert.Equal(t, "job", resp.JobID)
+	assert.Equal(t, newTokenID.String(), resp.TokenID)
+	assert.Equal(t, tokenID.String(), resp.Replaces)
+	nftSrv.AssertExpectations(t)
+}
+
+func TestHandler_PreviewMintNFT(t *testing.T) {
+	rctx := chi.NewRouteContext()
+	rctx.URLParams.Keys = []string{RegistryAddressParam}
+	rctx.URLParams.Values = []string{"some invalid address"}
+	ctx := context.WithValue(context.Background(), chi.RouteCtxKey, rctx)
+	nftSrv := new(testingnfts.MockNFTService)
+	h := handler{srv: Service{nftSrv: nftSrv}}
+	getHTTPReqAndResp := func(body io.Reader) (*httptest.ResponseRecorder, *http.Request) {
+		return httptest.NewRecorder(), httptest.NewRequest("POST", "/nfts/registries/{registry_address}/mint/preview", body).WithContext(ctx)
+	}
+
+	// invalid registry
+	w, r := getHTTPReqAndResp(nil)
+	h.PreviewMintNFT(w, r)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	assert.Contains(t, w.Body.String(), coreapi.ErrInvalidRegistryAddress.Error())
+
+	// invalid body
+	registry := common.HexToAddress("0x111855759a39fb75fc7341139f5d7a3974d4da08")
+	rctx.URLParams.Values[0] = registry.Hex()
+	w, r = getHTTPReqAndResp(bytes.NewReader([]byte("invalid json")))
+	h.PreviewMintNFT(w, r)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+
+	docID := utils.RandomSlice(32)
+	deposit := common.HexToAddress("0x222855759a39fb75fc7341139f5d7a3974d4da08")
+	body, err := json.Marshal(map[string]interface{}{
+		"document_id":        hexutil.Encode(docID),
+		"deposit_address":    deposit.Hex(),
+		"proof_fields":       []string{"generic.data"},
+		"submit_token_proof": true,
+	})
+	assert.NoError(t, err)
+	req := nft.MintNFTRequest{
+		DocumentID:       docID,
+		RegistryAddress:  registry,
+		DepositAddress:   deposit,
+		ProofFields:      []string{"generic.data"},
+		SubmitTokenProof: true,
+	}
+
+	// invalid proofs
+	nftSrv.On("PreviewMint", mock.Anything, req).Return(
+		nil, errors.NewTypedError(nft.ErrInvalidMintProofs, errors.New("proof of 0x01"))).Once()
+	w, r = getHTTPReqAndResp(bytes.NewReader(body))
+	h.PreviewMintNFT(w, r)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	assert.Contains(t, w.Body.String(), nft.ErrInvalidMintProofs.Error())
+
+	// success
+	tokenID := nft.NewTokenID()
+	var salt, hash [32]byte
+	copy(salt[:], utils.RandomSlice(32))
+	copy(hash[:], utils.RandomSlice(32))
+	preview := &nft.MintPreview{
+		TokenID: tokenID,
+		Request: nft.MintRequest{
+			To:           deposit,
+			TokenID:      tokenID.BigInt(),
+			NextAnchorID: big.NewInt(1),
+			Props:        [][]byte{{1}},
+			Values:       [][]byte{{2}},
+			Salts:        [][32]byte{salt},
+			Proofs:       [][][32]byte{{hash}},
+		},
+		DocumentRoot: utils.RandomSlice(32),
+		Calldata:     utils.RandomSlice(100),
+		Proofs:       []nft.SubstrateProof{{LeafHash: hash, SortedHashes: [][32]byte{hash}}},
+	}
+	nftSrv.On("PreviewMint", mock.Anything, req).Return(preview, nil).Once()
+	w, r = getHTTPReqAndResp(bytes.NewReader(body))
+	h.PreviewMintNFT(w, r)
+	assert.Equal(t, http.StatusOK, w.Code)
+	var resp MintPreviewResponse
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+	assert.Equal(t, tokenID.String(), resp.TokenID)
+	assert.Equal(t, registry, resp.RegistryAddress)
+	assert.Equal(t, preview.Calldata, []byte(resp.Calldata))
+	assert.Equal(t, preview.DocumentRoot, []byte(resp.DocumentRoot))
+	assert.Len(t, resp.NextAnchorID, 32)
+	assert.Len(t, resp.Proofs, 1)
+	assert.Equal(t, salt[:], []byte(resp.Proofs[0].Salt))
+	assert.Equal(t, deposit, resp.ValidateNFT.DepositAddress)
+	assert.Len(t, resp.ValidateNFT.Proofs, 1)
+	assert.Len(t, resp.ValidateNFT.StaticProofs, 3)
+	nftSrv.AssertExpectations(t)
+}
+
+func TestHandler_GetNFTTransfers(t *testing.T) {
+	rctx := chi.NewRouteContext()
+	rctx.URLParams.Keys = []string{RegistryAddressParam, TokenIDParam}
+	rctx.URLParams.Values = []string{"some invalid address", "some invalid token"}
+	ctx := context.WithValue(context.Background(), chi.RouteCtxKey, rctx)
+	nftSrv := new(testingnfts.MockNFTService)
+	h := handler{srv: Service{nftSrv: nftSrv}}
+	getHTTPReqAndResp := func() (*httptest.ResponseRecorder, *http.Request) {
+		return httptest.NewRecorder(), httptest.NewRequest("GET", "/nfts/registries/{registry_address}/tokens/{token_id}/transfers", nil).WithContext(ctx)
+	}
+
+	// invalid registry
+	w, r := getHTTPReqAndResp()
+	h.GetNFTTransfers(w, r)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	assert.Contains(t, w.Body.String(), coreapi.ErrInvalidRegistryAddress.Error())
+
+	// invalid token
+	registry := common.HexToAddress("0x111855759a39fb75fc7341139f5d7a3974d4da08")
+	rctx.URLParams.Values[0] = registry.Hex()
+	w, r = getHTTPReqAndResp()
+	h.GetNFTTransfers(w, r)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	assert.Contains(t, w.Body.String(), coreapi.ErrInvalidTokenID.Error())
+
+	// missing token
+	tokenID := nft.NewTokenID()
+	rctx.URLParams.Values[1] = tokenID.String()
+	nftSrv.On("GetTokenTransfers", mock.Anything, registry, tokenID).Return(
+		nil, errors.NewTypedError(nft.ErrTokenNotFound, errors.New("missing"))).Once()
+	w, r = getHTTPReqAndResp()
+	h.GetNFTTransfers(w, r)
+	assert.Equal(t, http.StatusNotFound, w.Code)
+
+	// success
+	transfer := nft.Transfer{
+		Registry:     registry,
+		TokenID:      tokenID[:],
+		From:         common.HexToAddress("0x222855759a39fb75fc7341139f5d7a3974d4da08"),
+		To:           common.HexToAddress("0x333855759a39fb75fc7341139f5d7a3974d4da08"),
+		BlockNumber:  10,
+		Kind:         nft.TransferKindTransfer,
+		DocumentSent: true,
+	}
+	nftSrv.On("GetTokenTransfers", mock.Anything, registry, tokenID).Return([]nft.Transfer{transfer}, nil).Once()
+	w, r = getHTTPReqAndResp()
+	h.GetNFTTransfers(w, r)
+	assert.Equal(t, http.StatusOK, w.Code)
+	var resp []NFTTransfer
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+	assert.Len(t, resp, 1)
+	assert.Equal(t, transfer.From, resp[0].From)
+	assert.Equal(t, transfer.To, resp[0].To)
+	assert.Equal(t, uint64(10), resp[0].BlockNumber)
+	assert.Equal(t, nft.TransferKindTransfer, resp[0].Kind)
+	assert.True(t, resp[0].DocumentSent)
+	nftSrv.AssertExpectations(t)
+}
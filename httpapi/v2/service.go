@@ -4,16 +4,33 @@ import (
 	"context"
 
 	coredocumentpb "github.com/centrifuge/centrifuge-protobufs/gen/go/coredocument"
+	"github.com/centrifuge/go-centrifuge/centchain"
+	"github.com/centrifuge/go-centrifuge/config/configstore"
+	"github.com/centrifuge/go-centrifuge/contextutil"
 	"github.com/centrifuge/go-centrifuge/documents"
+	"github.com/centrifuge/go-centrifuge/errors"
+	"github.com/centrifuge/go-centrifuge/ethereum"
 	"github.com/centrifuge/go-centrifuge/identity"
 	"github.com/centrifuge/go-centrifuge/jobs"
+	"github.com/centrifuge/go-centrifuge/nft"
+	"github.com/centrifuge/go-centrifuge/notification"
 	"github.com/centrifuge/go-centrifuge/pending"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
 )
 
 // Service is the entry point for all the V2 APIs.
 type Service struct {
 	pendingDocSrv pending.Service
 	tokenRegistry documents.TokenRegistry
+	policySrv     documents.SignaturePolicyService
+	keyRotator    configstore.KeyRotator
+	jobsMan       jobs.Manager
+	dispatcher    notification.Dispatcher
+	nftSrv        nft.Service
+	txMan         ethereum.TransactionManager
+	centAPI       centchain.API
+	ethClient     ethereum.Client
 }
 
 // CreateDocument creates a pending document from the given payload.
@@ -82,3 +99,243 @@ func (s Service) GetTransitionRule(ctx context.Context, docID, ruleID []byte) (*
 func (s Service) DeleteTransitionRule(ctx context.Context, docID, ruleID []byte) error {
 	return s.pendingDocSrv.DeleteTransitionRule(ctx, docID, ruleID)
 }
+
+// decideFunc approves or rejects a held signature request.
+type decideFunc func(ctx context.Context, id []byte) (*documents.SignatureRequest, error)
+
+// GetSignaturePolicy returns the signature policy of the account.
+func (s Service) GetSignaturePolicy(ctx context.Context) (*documents.SignaturePolicy, error) {
+	return s.policySrv.GetPolicy(ctx)
+}
+
+// UpdateSignaturePolicy replaces the signature policy of the account.
+func (s Service) UpdateSignaturePolicy(ctx context.Context, policy *documents.SignaturePolicy) error {
+	return s.policySrv.UpdatePolicy(ctx, policy)
+}
+
+// GetSignatureRequests returns the signature requests held for the account.
+func (s Service) GetSignatureRequests(ctx context.Context) ([]*documents.SignatureRequest, error) {
+	return s.policySrv.GetSignatureRequests(ctx)
+}
+
+// ApproveSignatureRequest approves a held signature request.
+func (s Service) ApproveSignatureRequest(ctx context.Context, id []byte) (*documents.SignatureRequest, error) {
+	return s.policySrv.ApproveSignatureRequest(ctx, id)
+}
+
+// RejectSignatureRequest rejects a held signature request.
+func (s Service) RejectSignatureRequest(ctx context.Context, id []byte) (*documents.SignatureRequest, error) {
+	return s.policySrv.RejectSignatureRequest(ctx, id)
+}
+
+// RotateKeys rotates the keys of the account and returns the new keys with the job adding them to the identities.
+func (s Service) RotateKeys(ctx context.Context, req configstore.RotateKeysRequest) (RotateKeysResponse, error) {
+	keys, jobID, _, err := s.keyRotator.RotateKeys(ctx, req)
+	if err != nil {
+		return RotateKeysResponse{}, err
+	}
+
+	return RotateKeysResponse{JobID: jobID.String(), Keys: keys}, nil
+}
+
+// ListJobs returns the jobs of the account matching the filter and the total number of matches.
+func (s Service) ListJobs(ctx context.Context, filter jobs.Filter) ([]*jobs.Job, int, error) {
+	did, err := contextutil.AccountDID(ctx)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return s.jobsMan.ListJobs(did, filter)
+}
+
+// CancelJob cancels the pending job of the account and returns the cancelled job.
+func (s Service) CancelJob(ctx context.Context, jobID jobs.JobID) (*jobs.Job, error) {
+	did, err := contextutil.AccountDID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	err = s.jobsMan.CancelJob(did, jobID)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.jobsMan.GetJob(did, jobID)
+}
+
+// RetryJob resumes the failed job of the account from its first failed task and returns the pending job.
+func (s Service) RetryJob(ctx context.Context, jobID jobs.JobID) (*jobs.Job, error) {
+	did, err := contextutil.AccountDID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = s.jobsMan.RetryJob(contextutil.Copy(ctx), did, jobID)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.jobsMan.GetJob(did, jobID)
+}
+
+// GetDeadLetters returns the dead-lettered webhook deliveries of the account.
+func (s Service) GetDeadLetters(ctx context.Context) ([]*notification.Delivery, error) {
+	did, err := contextutil.AccountDID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.dispatcher.DeadLetters(did)
+}
+
+// ReplayDeadLetter schedules the dead-lettered webhook delivery of the account to be delivered again.
+func (s Service) ReplayDeadLetter(ctx context.Context, id string) (*notification.Delivery, error) {
+	did, err := contextutil.AccountDID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.dispatcher.Replay(did, id)
+}
+
+// RotateWebhookSecret generates a new secret for signing the webhooks of the account.
+func (s Service) RotateWebhookSecret(ctx context.Context) (string, error) {
+	did, err := contextutil.AccountDID(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	return s.dispatcher.RotateSecret(did)
+}
+
+// CreateWebhookSubscription creates a new webhook subscription for the account.
+func (s Service) CreateWebhookSubscription(ctx context.Context, sub *notification.WebhookSubscription) (*notification.WebhookSubscription, error) {
+	did, err := contextutil.AccountDID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.dispatcher.CreateSubscription(did, sub)
+}
+
+// GetWebhookSubscriptions returns the webhook subscriptions of the account.
+func (s Service) GetWebhookSubscriptions(ctx context.Context) ([]*notification.WebhookSubscription, error) {
+	did, err := contextutil.AccountDID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.dispatcher.GetSubscriptions(did)
+}
+
+// GetWebhookSubscription returns the webhook subscription of the account.
+func (s Service) GetWebhookSubscription(ctx context.Context, id string) (*notification.WebhookSubscription, error) {
+	did, err := contextutil.AccountDID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.dispatcher.GetSubscription(did, id)
+}
+
+// DeleteWebhookSubscription deletes the webhook subscription of the account.
+func (s Service) DeleteWebhookSubscription(ctx context.Context, id string) error {
+	did, err := contextutil.AccountDID(ctx)
+	if err != nil {
+		return err
+	}
+
+	return s.dispatcher.DeleteSubscription(did, id)
+}
+
+// GetAccountNFTs returns the NFTs minted by the account.
+func (s Service) GetAccountNFTs(ctx context.Context) ([]nft.TokenInfo, error) {
+	return s.nftSrv.GetAccountTokens(ctx)
+}
+
+// GetDocumentNFTs returns the NFTs of the latest version of the document.
+func (s Service) GetDocumentNFTs(ctx context.Context, docID []byte) ([]nft.TokenInfo, error) {
+	return s.nftSrv.GetDocumentTokens(ctx, docID)
+}
+
+// GetNFTMetadata returns the ERC-721 metadata of the NFT minted by the account.
+func (s Service) GetNFTMetadata(ctx context.Context, registry common.Address, tokenID nft.TokenID) (*nft.Metadata, error) {
+	return s.nftSrv.GetTokenMetadata(ctx, registry, tokenID)
+}
+
+// GetNFTTransfers returns the observed transfers of the NFT minted by the account or linked to its documents.
+func (s Service) GetNFTTransfers(ctx context.Context, registry common.Address, tokenID nft.TokenID) ([]nft.Transfer, error) {
+	return s.nftSrv.GetTokenTransfers(ctx, registry, tokenID)
+}
+
+// RemintNFT burns the NFT of the document and mints a new NFT against the latest version of the document.
+func (s Service) RemintNFT(ctx context.Context, req nft.RemintNFTRequest) (*nft.TokenResponse, error) {
+	resp, _, err := s.nftSrv.RemintNFT(ctx, req)
+	return resp, err
+}
+
+// PreviewMintNFT builds the mint of an NFT against the latest version of the document without sending it.
+func (s Service) PreviewMintNFT(ctx context.Context, req nft.MintNFTRequest) (*nft.MintPreview, error) {
+	return s.nftSrv.PreviewMint(ctx, req)
+}
+
+// GetEthereumTransactions returns the in-flight ethereum transactions of the node accounts.
+func (s Service) GetEthereumTransactions() ([]*ethereum.Transaction, error) {
+	return s.txMan.PendingTransactions()
+}
+
+// CancelEthereumTransaction replaces the in-flight ethereum transaction with an empty transfer to its sender.
+func (s Service) CancelEthereumTransaction(hash common.Hash) (*ethereum.Transaction, error) {
+	return s.txMan.CancelTransaction(hash)
+}
+
+// GetAccountBalances returns the CFG and ETH balances of the chain accounts of the account.
+func (s Service) GetAccountBalances(ctx context.Context) (*AccountBalances, error) {
+	acc, err := contextutil.Account(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var resp AccountBalances
+	if cacc := acc.GetCentChainAccount(); cacc.ID != "" {
+		accountID, err := hexutil.Decode(cacc.ID)
+		if err != nil {
+			return nil, errors.New("invalid centchain account ID: %v", err)
+		}
+
+		meta, err := s.centAPI.GetMetadataLatest()
+		if err != nil {
+			return nil, err
+		}
+
+		b, err := s.centAPI.GetBalance(meta, accountID)
+		if err != nil {
+			return nil, err
+		}
+
+		resp.CentChain = &CentChainBalance{
+			AccountID:  cacc.ID,
+			Free:       b.Free.String(),
+			Reserved:   b.Reserved.String(),
+			MiscFrozen: b.MiscFrozen.String(),
+			FeeFrozen:  b.FeeFrozen.String(),
+			Spendable:  b.Spendable().String(),
+		}
+	}
+
+	if eacc := acc.GetEthereumAccount(); eacc != nil && eacc.Address != "" {
+		if !common.IsHexAddress(eacc.Address) {
+			return nil, errors.New("invalid ethereum address: %s", eacc.Address)
+		}
+
+		addr := common.HexToAddress(eacc.Address)
+		balance, err := s.ethClient.GetEthClient().BalanceAt(ctx, addr, nil)
+		if err != nil {
+			return nil, err
+		}
+
+		resp.Ethereum = &EthereumBalance{Address: addr.Hex(), Balance: balance.String()}
+	}
+
+	return &resp, nil
+}
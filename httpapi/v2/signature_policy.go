@@ -0,0 +1,172 @@
+package v2
+
+import (
+	"net/http"
+
+	"github.com/centrifuge/go-centrifuge/documents"
+	"github.com/centrifuge/go-centrifuge/errors"
+	"github.com/centrifuge/go-centrifuge/utils/httputils"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/go-chi/chi"
+	"github.com/go-chi/render"
+)
+
+// SignatureRequestIDParam is the key for the signature request ID in the API path.
+const SignatureRequestIDParam = "request_id"
+
+// ErrInvalidSignatureRequestID for invalid signature request ID in the api path.
+const ErrInvalidSignatureRequestID = errors.Error("Invalid Signature Request ID")
+
+// GetSignaturePolicy returns the signature policy of the account.
+// @summary Returns the signature policy of the account.
+// @description Returns the signature policy that decides how the signature requests of the account are handled.
+// @id get_signature_policy
+// @tags Signatures
+// @param authorization header string true "Hex encoded centrifuge ID of the account for the intended API action"
+// @produce json
+// @Failure 403 {object} httputils.HTTPError
+// @Failure 500 {object} httputils.HTTPError
+// @success 200 {object} documents.SignaturePolicy
+// @router /v2/signature_policy [get]
+func (h handler) GetSignaturePolicy(w http.ResponseWriter, r *http.Request) {
+	var err error
+	var code int
+	defer httputils.RespondIfError(&code, &err, w, r)
+
+	p, err := h.srv.GetSignaturePolicy(r.Context())
+	if err != nil {
+		code = http.StatusInternalServerError
+		log.Error(err)
+		return
+	}
+
+	render.Status(r, http.StatusOK)
+	render.JSON(w, r, p)
+}
+
+// UpdateSignaturePolicy replaces the signature policy of the account.
+// @summary Replaces the signature policy of the account.
+// @description Replaces the signature policy of the account. Rules are evaluated in order and the first matching rule decides between sign, reject and hold.
+// @id update_signature_policy
+// @tags Signatures
+// @accept json
+// @param authorization header string true "Hex encoded centrifuge ID of the account for the intended API action"
+// @param body body documents.SignaturePolicy true "Signature Policy"
+// @produce json
+// @Failure 403 {object} httputils.HTTPError
+// @Failure 400 {object} httputils.HTTPError
+// @success 200 {object} documents.SignaturePolicy
+// @router /v2/signature_policy [put]
+func (h handler) UpdateSignaturePolicy(w http.ResponseWriter, r *http.Request) {
+	var err error
+	var code int
+	defer httputils.RespondIfError(&code, &err, w, r)
+
+	p := new(documents.SignaturePolicy)
+	err = unmarshalBody(r, p)
+	if err != nil {
+		code = http.StatusBadRequest
+		log.Error(err)
+		return
+	}
+
+	err = h.srv.UpdateSignaturePolicy(r.Context(), p)
+	if err != nil {
+		code = http.StatusBadRequest
+		log.Error(err)
+		return
+	}
+
+	render.Status(r, http.StatusOK)
+	render.JSON(w, r, p)
+}
+
+// GetSignatureRequests returns the signature requests held by the signature policy of the account.
+// @summary Returns the held signature requests.
+// @description Returns the signature requests held by the signature policy of the account with their status.
+// @id get_signature_requests
+// @tags Signatures
+// @param authorization header string true "Hex encoded centrifuge ID of the account for the intended API action"
+// @produce json
+// @Failure 403 {object} httputils.HTTPError
+// @Failure 500 {object} httputils.HTTPError
+// @success 200 {array} documents.SignatureRequest
+// @router /v2/signature_requests [get]
+func (h handler) GetSignatureRequests(w http.ResponseWriter, r *http.Request) {
+	var err error
+	var code int
+	defer httputils.RespondIfError(&code, &err, w, r)
+
+	reqs, err := h.srv.GetSignatureRequests(r.Context())
+	if err != nil {
+		code = http.StatusInternalServerError
+		log.Error(err)
+		return
+	}
+
+	if reqs == nil {
+		reqs = []*documents.SignatureRequest{}
+	}
+
+	render.Status(r, http.StatusOK)
+	render.JSON(w, r, reqs)
+}
+
+// ApproveSignatureRequest approves a held signature request.
+// @summary Approves a held signature request.
+// @description Approves a held signature request. The signature is returned to the requester if it is still waiting or when it requests the signature again.
+// @id approve_signature_request
+// @tags Signatures
+// @param authorization header string true "Hex encoded centrifuge ID of the account for the intended API action"
+// @param request_id path string true "Signature Request ID"
+// @produce json
+// @Failure 403 {object} httputils.HTTPError
+// @Failure 400 {object} httputils.HTTPError
+// @success 200 {object} documents.SignatureRequest
+// @router /v2/signature_requests/{request_id}/approve [post]
+func (h handler) ApproveSignatureRequest(w http.ResponseWriter, r *http.Request) {
+	h.decideSignatureRequest(w, r, h.srv.ApproveSignatureRequest)
+}
+
+// RejectSignatureRequest rejects a held signature request.
+// @summary Rejects a held signature request.
+// @description Rejects a held signature request.
+// @id reject_signature_request
+// @tags Signatures
+// @param authorization header string true "Hex encoded centrifuge ID of the account for the intended API action"
+// @param request_id path string true "Signature Request ID"
+// @produce json
+// @Failure 403 {object} httputils.HTTPError
+// @Failure 400 {object} httputils.HTTPError
+// @success 200 {object} documents.SignatureRequest
+// @router /v2/signature_requests/{request_id}/reject [post]
+func (h handler) RejectSignatureRequest(w http.ResponseWriter, r *http.Request) {
+	h.decideSignatureRequest(w, r, h.srv.RejectSignatureRequest)
+}
+
+func (h handler) decideSignatureRequest(w http.ResponseWriter, r *http.Request, decide decideFunc) {
+	var err error
+	var code int
+	defer httputils.RespondIfError(&code, &err, w, r)
+
+	id, err := hexutil.Decode(chi.URLParam(r, SignatureRequestIDParam))
+	if err != nil {
+		code = http.StatusBadRequest
+		log.Error(err)
+		err = ErrInvalidSignatureRequestID
+		return
+	}
+
+	req, err := decide(r.Context(), id)
+	if err != nil {
+		code = http.StatusBadRequest
+		if errors.IsOfType(documents.ErrSignatureRequestNotFound, err) {
+			code = http.StatusNotFound
+		}
+		log.Error(err)
+		return
+	}
+
+	render.Status(r, http.StatusOK)
+	render.JSON(w, r, req)
+}
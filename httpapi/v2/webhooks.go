@@ -0,0 +1,318 @@
+package v2
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/centrifuge/go-centrifuge/errors"
+	"github.com/centrifuge/go-centrifuge/identity"
+	"github.com/centrifuge/go-centrifuge/notification"
+	"github.com/centrifuge/go-centrifuge/utils/httputils"
+	"github.com/go-chi/chi"
+	"github.com/go-chi/render"
+)
+
+// DeliveryIDParam is the key for the webhook delivery ID in the API path.
+const DeliveryIDParam = "delivery_id"
+
+// SubscriptionIDParam is the key for the webhook subscription ID in the API path.
+const SubscriptionIDParam = "subscription_id"
+
+// DeadLettersResponse holds the dead-lettered webhook deliveries.
+type DeadLettersResponse struct {
+	Deliveries []*notification.Delivery `json:"deliveries"`
+}
+
+// WebhookSecretResponse holds the new secret the webhooks are signed with.
+type WebhookSecretResponse struct {
+	Secret string `json:"secret"`
+}
+
+// WebhookSubscriptionRequest holds the webhook and the filters of a new subscription.
+// Empty filters match all the notifications.
+type WebhookSubscriptionRequest struct {
+	URL        string         `json:"url"`
+	EventTypes []string       `json:"event_types" enums:"received_payload,job_completed,task_updated,nft_minted,signature_requested,signature_given,signature_refused,document_changed,nft_transferred,access_token_granted,access_token_revoked,key_added,key_revoked"`
+	Schemes    []string       `json:"schemes"`
+	SenderIDs  []identity.DID `json:"sender_ids" swaggertype:"array,string"`
+}
+
+// WebhookSubscription holds a webhook subscription and its delivery state.
+type WebhookSubscription struct {
+	WebhookSubscriptionRequest
+	ID              string    `json:"id"`
+	CreatedAt       time.Time `json:"created_at" swaggertype:"primitive,string"`
+	Delivered       int       `json:"delivered"`
+	Failed          int       `json:"failed"`
+	LastDeliveredAt time.Time `json:"last_delivered_at" swaggertype:"primitive,string"`
+	LastFailedAt    time.Time `json:"last_failed_at" swaggertype:"primitive,string"`
+	LastError       string    `json:"last_error"`
+}
+
+// WebhookSubscriptionsResponse holds the webhook subscriptions of the account.
+type WebhookSubscriptionsResponse struct {
+	Subscriptions []WebhookSubscription `json:"subscriptions"`
+}
+
+func toWebhookSubscription(sub *notification.WebhookSubscription) WebhookSubscription {
+	types := make([]string, 0, len(sub.EventTypes))
+	for _, t := range sub.EventTypes {
+		types = append(types, t.String())
+	}
+
+	return WebhookSubscription{
+		WebhookSubscriptionRequest: WebhookSubscriptionRequest{
+			URL:        sub.URL,
+			EventTypes: types,
+			Schemes:    sub.Schemes,
+			SenderIDs:  sub.SenderIDs,
+		},
+		ID:              sub.ID,
+		CreatedAt:       sub.CreatedAt,
+		Delivered:       sub.Delivered,
+		Failed:          sub.Failed,
+		LastDeliveredAt: sub.LastDeliveredAt,
+		LastFailedAt:    sub.LastFailedAt,
+		LastError:       sub.LastError,
+	}
+}
+
+func toNotificationSubscription(req WebhookSubscriptionRequest) (*notification.WebhookSubscription, error) {
+	sub := &notification.WebhookSubscription{
+		URL:       req.URL,
+		Schemes:   req.Schemes,
+		SenderIDs: req.SenderIDs,
+	}
+
+	for _, name := range req.EventTypes {
+		t, err := notification.EventTypeFromString(name)
+		if err != nil {
+			return nil, err
+		}
+
+		sub.EventTypes = append(sub.EventTypes, t)
+	}
+
+	return sub, nil
+}
+
+// CreateWebhookSubscription creates a new webhook subscription for the account.
+// @summary Creates a new webhook subscription.
+// @description Creates a webhook subscription that receives the notifications of the account matching the event types, document schemes and sender DIDs. Empty filters match all the notifications.
+// @id create_webhook_subscription
+// @tags Webhooks
+// @accept json
+// @param authorization header string true "Hex encoded centrifuge ID of the account for the intended API action"
+// @param body body v2.WebhookSubscriptionRequest true "Webhook Subscription"
+// @produce json
+// @Failure 403 {object} httputils.HTTPError
+// @Failure 400 {object} httputils.HTTPError
+// @success 201 {object} v2.WebhookSubscription
+// @router /v2/webhooks/subscriptions [post]
+func (h handler) CreateWebhookSubscription(w http.ResponseWriter, r *http.Request) {
+	var err error
+	var code int
+	defer httputils.RespondIfError(&code, &err, w, r)
+
+	var req WebhookSubscriptionRequest
+	err = unmarshalBody(r, &req)
+	if err != nil {
+		code = http.StatusBadRequest
+		log.Error(err)
+		return
+	}
+
+	sub, err := toNotificationSubscription(req)
+	if err != nil {
+		code = http.StatusBadRequest
+		log.Error(err)
+		err = errors.NewTypedError(notification.ErrInvalidSubscription, err)
+		return
+	}
+
+	sub, err = h.srv.CreateWebhookSubscription(r.Context(), sub)
+	if err != nil {
+		code = http.StatusBadRequest
+		log.Error(err)
+		return
+	}
+
+	render.Status(r, http.StatusCreated)
+	render.JSON(w, r, toWebhookSubscription(sub))
+}
+
+// GetWebhookSubscriptions returns the webhook subscriptions of the account.
+// @summary Returns the webhook subscriptions of the account.
+// @description Returns the webhook subscriptions of the account with their delivery state, oldest first.
+// @id get_webhook_subscriptions
+// @tags Webhooks
+// @param authorization header string true "Hex encoded centrifuge ID of the account for the intended API action"
+// @produce json
+// @Failure 403 {object} httputils.HTTPError
+// @Failure 500 {object} httputils.HTTPError
+// @success 200 {object} v2.WebhookSubscriptionsResponse
+// @router /v2/webhooks/subscriptions [get]
+func (h handler) GetWebhookSubscriptions(w http.ResponseWriter, r *http.Request) {
+	var err error
+	var code int
+	defer httputils.RespondIfError(&code, &err, w, r)
+
+	list, err := h.srv.GetWebhookSubscriptions(r.Context())
+	if err != nil {
+		code = http.StatusInternalServerError
+		log.Error(err)
+		return
+	}
+
+	resp := WebhookSubscriptionsResponse{Subscriptions: []WebhookSubscription{}}
+	for _, sub := range list {
+		resp.Subscriptions = append(resp.Subscriptions, toWebhookSubscription(sub))
+	}
+
+	render.Status(r, http.StatusOK)
+	render.JSON(w, r, resp)
+}
+
+// GetWebhookSubscription returns the webhook subscription of the account.
+// @summary Returns the webhook subscription.
+// @description Returns the webhook subscription of the account with its delivery state.
+// @id get_webhook_subscription
+// @tags Webhooks
+// @param authorization header string true "Hex encoded centrifuge ID of the account for the intended API action"
+// @param subscription_id path string true "Subscription ID"
+// @produce json
+// @Failure 403 {object} httputils.HTTPError
+// @Failure 404 {object} httputils.HTTPError
+// @success 200 {object} v2.WebhookSubscription
+// @router /v2/webhooks/subscriptions/{subscription_id} [get]
+func (h handler) GetWebhookSubscription(w http.ResponseWriter, r *http.Request) {
+	var err error
+	var code int
+	defer httputils.RespondIfError(&code, &err, w, r)
+
+	sub, err := h.srv.GetWebhookSubscription(r.Context(), chi.URLParam(r, SubscriptionIDParam))
+	if err != nil {
+		code = http.StatusNotFound
+		log.Error(err)
+		return
+	}
+
+	render.Status(r, http.StatusOK)
+	render.JSON(w, r, toWebhookSubscription(sub))
+}
+
+// DeleteWebhookSubscription deletes the webhook subscription of the account.
+// @summary Deletes the webhook subscription.
+// @description Deletes the webhook subscription of the account. The pending deliveries of the subscription are dropped.
+// @id delete_webhook_subscription
+// @tags Webhooks
+// @param authorization header string true "Hex encoded centrifuge ID of the account for the intended API action"
+// @param subscription_id path string true "Subscription ID"
+// @produce json
+// @Failure 403 {object} httputils.HTTPError
+// @Failure 404 {object} httputils.HTTPError
+// @success 204
+// @router /v2/webhooks/subscriptions/{subscription_id} [delete]
+func (h handler) DeleteWebhookSubscription(w http.ResponseWriter, r *http.Request) {
+	var err error
+	var code int
+	defer httputils.RespondIfError(&code, &err, w, r)
+
+	err = h.srv.DeleteWebhookSubscription(r.Context(), chi.URLParam(r, SubscriptionIDParam))
+	if err != nil {
+		code = http.StatusNotFound
+		log.Error(err)
+		return
+	}
+
+	render.NoContent(w, r)
+}
+
+// GetDeadLetters returns the dead-lettered webhook deliveries of the account.
+// @summary Returns the dead-lettered webhook deliveries of the account.
+// @description Returns the webhook deliveries of the account that failed all the attempts, oldest first.
+// @id get_dead_letters
+// @tags Webhooks
+// @param authorization header string true "Hex encoded centrifuge ID of the account for the intended API action"
+// @produce json
+// @Failure 403 {object} httputils.HTTPError
+// @Failure 500 {object} httputils.HTTPError
+// @success 200 {object} v2.DeadLettersResponse
+// @router /v2/webhooks/dead_letters [get]
+func (h handler) GetDeadLetters(w http.ResponseWriter, r *http.Request) {
+	var err error
+	var code int
+	defer httputils.RespondIfError(&code, &err, w, r)
+
+	list, err := h.srv.GetDeadLetters(r.Context())
+	if err != nil {
+		code = http.StatusInternalServerError
+		log.Error(err)
+		return
+	}
+
+	resp := DeadLettersResponse{Deliveries: []*notification.Delivery{}}
+	resp.Deliveries = append(resp.Deliveries, list...)
+	render.Status(r, http.StatusOK)
+	render.JSON(w, r, resp)
+}
+
+// ReplayDeadLetter schedules a dead-lettered webhook delivery to be delivered again.
+// @summary Replays a dead-lettered webhook delivery.
+// @description Moves the dead-lettered webhook delivery back to the delivery queue with a fresh set of attempts. The delivery keeps its idempotency key.
+// @id replay_dead_letter
+// @tags Webhooks
+// @param authorization header string true "Hex encoded centrifuge ID of the account for the intended API action"
+// @param delivery_id path string true "Delivery ID"
+// @produce json
+// @Failure 403 {object} httputils.HTTPError
+// @Failure 400 {object} httputils.HTTPError
+// @Failure 404 {object} httputils.HTTPError
+// @success 202 {object} notification.Delivery
+// @router /v2/webhooks/dead_letters/{delivery_id}/replay [post]
+func (h handler) ReplayDeadLetter(w http.ResponseWriter, r *http.Request) {
+	var err error
+	var code int
+	defer httputils.RespondIfError(&code, &err, w, r)
+
+	del, err := h.srv.ReplayDeadLetter(r.Context(), chi.URLParam(r, DeliveryIDParam))
+	if err != nil {
+		log.Error(err)
+		code = http.StatusBadRequest
+		if errors.IsOfType(notification.ErrDeliveryNotFound, err) {
+			code = http.StatusNotFound
+		}
+		return
+	}
+
+	render.Status(r, http.StatusAccepted)
+	render.JSON(w, r, del)
+}
+
+// RotateWebhookSecret generates a new secret for signing the webhooks of the account.
+// @summary Rotates the webhook secret of the account.
+// @description Generates a new secret for the webhooks of the account. The webhooks carry the hex encoded HMAC-SHA256 of the body, keyed with the secret, in the X-Centrifuge-Signature header.
+// @description The secret is only returned once.
+// @id rotate_webhook_secret
+// @tags Webhooks
+// @param authorization header string true "Hex encoded centrifuge ID of the account for the intended API action"
+// @produce json
+// @Failure 403 {object} httputils.HTTPError
+// @Failure 500 {object} httputils.HTTPError
+// @success 200 {object} v2.WebhookSecretResponse
+// @router /v2/webhooks/secret/rotate [post]
+func (h handler) RotateWebhookSecret(w http.ResponseWriter, r *http.Request) {
+	var err error
+	var code int
+	defer httputils.RespondIfError(&code, &err, w, r)
+
+	secret, err := h.srv.RotateWebhookSecret(r.Context())
+	if err != nil {
+		code = http.StatusInternalServerError
+		log.Error(err)
+		return
+	}
+
+	render.Status(r, http.StatusOK)
+	render.JSON(w, r, WebhookSecretResponse{Secret: secret})
+}
@@ -0,0 +1,191 @@
+// +build unit
+
+package v2
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/centrifuge/go-centrifuge/config/configstore"
+	"github.com/centrifuge/go-centrifuge/contextutil"
+	"github.com/centrifuge/go-centrifuge/errors"
+	"github.com/centrifuge/go-centrifuge/identity"
+	"github.com/centrifuge/go-centrifuge/notification"
+	testingidentity "github.com/centrifuge/go-centrifuge/testingutils/identity"
+	"github.com/go-chi/chi"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHandler_GetDeadLetters(t *testing.T) {
+	did := testingidentity.GenerateRandomDID()
+	ctx, err := contextutil.New(context.Background(), &configstore.Account{IdentityID: did[:]})
+	assert.NoError(t, err)
+	dispatcher := new(notification.MockDispatcher)
+	h := handler{srv: Service{dispatcher: dispatcher}}
+	getHTTPReqAndResp := func() (*httptest.ResponseRecorder, *http.Request) {
+		return httptest.NewRecorder(), httptest.NewRequest("GET", "/webhooks/dead_letters", nil).WithContext(ctx)
+	}
+
+	// failed
+	dispatcher.On("DeadLetters", did).Return(nil, errors.New("failed")).Once()
+	w, r := getHTTPReqAndResp()
+	h.GetDeadLetters(w, r)
+	assert.Equal(t, http.StatusInternalServerError, w.Code)
+
+	// empty
+	dispatcher.On("DeadLetters", did).Return(nil, nil).Once()
+	w, r = getHTTPReqAndResp()
+	h.GetDeadLetters(w, r)
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), `"deliveries":[]`)
+
+	// success
+	del := &notification.Delivery{ID: "0x01", AccountID: did, Status: notification.DeliveryDead, Payload: []byte(`{}`)}
+	dispatcher.On("DeadLetters", did).Return([]*notification.Delivery{del}, nil).Once()
+	w, r = getHTTPReqAndResp()
+	h.GetDeadLetters(w, r)
+	assert.Equal(t, http.StatusOK, w.Code)
+	var resp DeadLettersResponse
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+	assert.Len(t, resp.Deliveries, 1)
+	assert.Equal(t, del.ID, resp.Deliveries[0].ID)
+	dispatcher.AssertExpectations(t)
+}
+
+func TestHandler_ReplayDeadLetter(t *testing.T) {
+	did := testingidentity.GenerateRandomDID()
+	rctx := chi.NewRouteContext()
+	rctx.URLParams.Keys = []string{DeliveryIDParam}
+	rctx.URLParams.Values = []string{"0x01"}
+	ctx := context.WithValue(context.Background(), chi.RouteCtxKey, rctx)
+	ctx, err := contextutil.New(ctx, &configstore.Account{IdentityID: did[:]})
+	assert.NoError(t, err)
+	dispatcher := new(notification.MockDispatcher)
+	h := handler{srv: Service{dispatcher: dispatcher}}
+	getHTTPReqAndResp := func() (*httptest.ResponseRecorder, *http.Request) {
+		return httptest.NewRecorder(), httptest.NewRequest("POST", "/webhooks/dead_letters/{delivery_id}/replay", nil).WithContext(ctx)
+	}
+
+	// missing delivery
+	dispatcher.On("Replay", did, "0x01").Return(nil, notification.ErrDeliveryNotFound).Once()
+	w, r := getHTTPReqAndResp()
+	h.ReplayDeadLetter(w, r)
+	assert.Equal(t, http.StatusNotFound, w.Code)
+
+	// not dead
+	dispatcher.On("Replay", did, "0x01").Return(nil, notification.ErrDeliveryNotDead).Once()
+	w, r = getHTTPReqAndResp()
+	h.ReplayDeadLetter(w, r)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	assert.Contains(t, w.Body.String(), notification.ErrDeliveryNotDead.Error())
+
+	// success
+	del := &notification.Delivery{ID: "0x01", AccountID: did, Status: notification.DeliveryPending, Payload: []byte(`{}`)}
+	dispatcher.On("Replay", did, "0x01").Return(del, nil).Once()
+	w, r = getHTTPReqAndResp()
+	h.ReplayDeadLetter(w, r)
+	assert.Equal(t, http.StatusAccepted, w.Code)
+	assert.Contains(t, w.Body.String(), `"status":"pending"`)
+	dispatcher.AssertExpectations(t)
+}
+
+func TestHandler_RotateWebhookSecret(t *testing.T) {
+	did := testingidentity.GenerateRandomDID()
+	ctx, err := contextutil.New(context.Background(), &configstore.Account{IdentityID: did[:]})
+	assert.NoError(t, err)
+	dispatcher := new(notification.MockDispatcher)
+	h := handler{srv: Service{dispatcher: dispatcher}}
+	dispatcher.On("RotateSecret", did).Return("0xsecret", nil).Once()
+	w := httptest.NewRecorder()
+	r := httptest.NewRequest("POST", "/webhooks/secret/rotate", nil).WithContext(ctx)
+	h.RotateWebhookSecret(w, r)
+	assert.Equal(t, http.StatusOK, w.Code)
+	var resp WebhookSecretResponse
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+	assert.Equal(t, "0xsecret", resp.Secret)
+	dispatcher.AssertExpectations(t)
+}
+
+func TestHandler_WebhookSubscriptions(t *testing.T) {
+	did := testingidentity.GenerateRandomDID()
+	sender := testingidentity.GenerateRandomDID()
+	rctx := chi.NewRouteContext()
+	rctx.URLParams.Keys = []string{SubscriptionIDParam}
+	rctx.URLParams.Values = []string{"0x01"}
+	ctx := context.WithValue(context.Background(), chi.RouteCtxKey, rctx)
+	ctx, err := contextutil.New(ctx, &configstore.Account{IdentityID: did[:]})
+	assert.NoError(t, err)
+	dispatcher := new(notification.MockDispatcher)
+	h := handler{srv: Service{dispatcher: dispatcher}}
+	getHTTPReqAndResp := func(method, body string) (*httptest.ResponseRecorder, *http.Request) {
+		return httptest.NewRecorder(), httptest.NewRequest(method, "/webhooks/subscriptions", strings.NewReader(body)).WithContext(ctx)
+	}
+
+	// invalid body
+	w, r := getHTTPReqAndResp("POST", "invalid")
+	h.CreateWebhookSubscription(w, r)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+
+	// invalid event type
+	w, r = getHTTPReqAndResp("POST", `{"url":"http://localhost/hook","event_types":["unknown"]}`)
+	h.CreateWebhookSubscription(w, r)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	assert.Contains(t, w.Body.String(), notification.ErrInvalidSubscription.Error())
+
+	// create
+	req := &notification.WebhookSubscription{
+		URL:        "http://localhost/hook",
+		EventTypes: []notification.EventType{notification.JobCompleted},
+		Schemes:    []string{"generic"},
+		SenderIDs:  []identity.DID{sender},
+	}
+	sub := &notification.WebhookSubscription{
+		ID:         "0x01",
+		AccountID:  did,
+		URL:        req.URL,
+		EventTypes: req.EventTypes,
+		Schemes:    req.Schemes,
+		SenderIDs:  req.SenderIDs,
+		Delivered:  2,
+	}
+	dispatcher.On("CreateSubscription", did, req).Return(sub, nil).Once()
+	w, r = getHTTPReqAndResp("POST", `{"url":"http://localhost/hook","event_types":["job_completed"],"schemes":["generic"],"sender_ids":["`+sender.String()+`"]}`)
+	h.CreateWebhookSubscription(w, r)
+	assert.Equal(t, http.StatusCreated, w.Code)
+	var resp WebhookSubscription
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+	assert.Equal(t, "0x01", resp.ID)
+	assert.Equal(t, []string{"job_completed"}, resp.EventTypes)
+	assert.Equal(t, []identity.DID{sender}, resp.SenderIDs)
+	assert.Equal(t, 2, resp.Delivered)
+
+	// list
+	dispatcher.On("GetSubscriptions", did).Return([]*notification.WebhookSubscription{sub}, nil).Once()
+	w, r = getHTTPReqAndResp("GET", "")
+	h.GetWebhookSubscriptions(w, r)
+	assert.Equal(t, http.StatusOK, w.Code)
+	var list WebhookSubscriptionsResponse
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &list))
+	assert.Len(t, list.Subscriptions, 1)
+
+	// get
+	dispatcher.On("GetSubscription", did, "0x01").Return(nil, notification.ErrSubscriptionNotFound).Once()
+	w, r = getHTTPReqAndResp("GET", "")
+	h.GetWebhookSubscription(w, r)
+	assert.Equal(t, http.StatusNotFound, w.Code)
+	dispatcher.On("GetSubscription", did, "0x01").Return(sub, nil).Once()
+	w, r = getHTTPReqAndResp("GET", "")
+	h.GetWebhookSubscription(w, r)
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	// delete
+	dispatcher.On("DeleteSubscription", did, "0x01").Return(nil).Once()
+	w, r = getHTTPReqAndResp("DELETE", "")
+	h.DeleteWebhookSubscription(w, r)
+	assert.Equal(t, http.StatusNoContent, w.Code)
+	dispatcher.AssertExpectations(t)
+}
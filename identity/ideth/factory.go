@@ -46,8 +46,8 @@ func CalculateCreatedAddress(address common.Address, nonce uint64) common.Addres
 	return crypto.CreateAddress(address, nonce)
 }
 
-func (s *factory) createIdentityTX(opts *bind.TransactOpts) func(accountID id.DID, jobID jobs.JobID, txMan jobs.Manager, errOut chan<- error) {
-	return func(accountID id.DID, jobID jobs.JobID, txMan jobs.Manager, errOut chan<- error) {
+func (s *factory) createIdentityTX(opts *bind.TransactOpts) func(ctx context.Context, accountID id.DID, jobID jobs.JobID, txMan jobs.Manager, errOut chan<- error) {
+	return func(ctx context.Context, accountID id.DID, jobID jobs.JobID, txMan jobs.Manager, errOut chan<- error) {
 		ethTX, err := s.client.SubmitTransactionWithRetries(s.factoryContract.CreateIdentity, opts)
 		if err != nil {
 			errOut <- err
@@ -15,11 +15,13 @@ import (
 	"github.com/centrifuge/go-centrifuge/ethereum"
 	id "github.com/centrifuge/go-centrifuge/identity"
 	"github.com/centrifuge/go-centrifuge/jobs"
+	"github.com/centrifuge/go-centrifuge/notification"
 	"github.com/centrifuge/go-centrifuge/queue"
 	"github.com/centrifuge/go-centrifuge/utils"
 	"github.com/ethereum/go-ethereum/accounts/abi"
 	"github.com/ethereum/go-ethereum/accounts/abi/bind"
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
 	"github.com/ethereum/go-ethereum/core/types"
 )
 
@@ -145,6 +147,8 @@ func (i service) AddKey(ctx context.Context, key id.Key) error {
 		return errors.New("add key  Job failed: jobID:%s with error [%s]", jobID.String(), err)
 
 	}
+
+	notifyKey(ctx, notification.KeyAdded, did, key.GetKey(), []*big.Int{key.GetPurpose()}, jobID)
 	return nil
 
 }
@@ -172,6 +176,8 @@ func (i service) AddMultiPurposeKey(ctx context.Context, key [32]byte, purposes
 	if err != nil {
 		return errors.New("add key multi purpose  Job failed: jobID[%s] with error [%s]", jobID.String(), err.Error())
 	}
+
+	notifyKey(ctx, notification.KeyAdded, did, key, purposes, jobID)
 	return nil
 }
 
@@ -200,12 +206,29 @@ func (i service) RevokeKey(ctx context.Context, key [32]byte) error {
 		return errors.New("revoke key Job failed: jobID:%s with error [%s]", jobID.String(), err.Error())
 	}
 
+	notifyKey(ctx, notification.KeyRevoked, did, key, nil, jobID)
 	return nil
 }
 
+// notifyKey sends the identity key event in the background.
+func notifyKey(ctx context.Context, eventType notification.EventType, did id.DID, key [32]byte, purposes []*big.Int, jobID jobs.JobID) {
+	data := notification.KeyData{Key: hexutil.Encode(key[:]), JobID: jobID.String()}
+	for _, p := range purposes {
+		data.Purposes = append(data.Purposes, hexutil.EncodeBig(p))
+	}
+
+	notification.Notify(ctx, notification.Message{
+		EventType: eventType,
+		AccountID: did.String(),
+		Recorded:  time.Now().UTC(),
+		Status:    string(jobs.Success),
+		Data:      data,
+	})
+}
+
 // ethereumTX is submitting an Ethereum transaction and starts a task to wait for the transaction result
-func (i service) ethereumTX(opts *bind.TransactOpts, contractMethod interface{}, params ...interface{}) func(accountID id.DID, txID jobs.JobID, txMan jobs.Manager, errOut chan<- error) {
-	return func(accountID id.DID, txID jobs.JobID, txMan jobs.Manager, errOut chan<- error) {
+func (i service) ethereumTX(opts *bind.TransactOpts, contractMethod interface{}, params ...interface{}) func(ctx context.Context, accountID id.DID, txID jobs.JobID, txMan jobs.Manager, errOut chan<- error) {
+	return func(ctx context.Context, accountID id.DID, txID jobs.JobID, txMan jobs.Manager, errOut chan<- error) {
 		ethTX, err := i.client.SubmitTransactionWithRetries(contractMethod, opts, params...)
 		if err != nil {
 			errOut <- err
@@ -0,0 +1,52 @@
+// +build unit
+
+package ideth
+
+import (
+	"context"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/centrifuge/go-centrifuge/jobs"
+	"github.com/centrifuge/go-centrifuge/notification"
+	"github.com/centrifuge/go-centrifuge/testingutils/identity"
+	"github.com/centrifuge/go-centrifuge/utils"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNotifyKey(t *testing.T) {
+	did := testingidentity.GenerateRandomDID()
+	sub := notification.GetBroker().Subscribe(did.String(), notification.Filter{})
+	defer notification.GetBroker().Unsubscribe(sub)
+	key, err := utils.SliceToByte32(utils.RandomSlice(32))
+	assert.NoError(t, err)
+	jobID := jobs.NewJobID()
+	next := func() notification.Message {
+		select {
+		case msg := <-sub.Events():
+			return msg
+		case <-time.After(5 * time.Second):
+			assert.Fail(t, "timed out waiting for the key event")
+			return notification.Message{}
+		}
+	}
+
+	// added key with its purposes
+	notifyKey(context.Background(), notification.KeyAdded, did, key, []*big.Int{big.NewInt(1), big.NewInt(2)}, jobID)
+	msg := next()
+	assert.Equal(t, notification.KeyAdded, msg.EventType)
+	assert.Equal(t, string(jobs.Success), msg.Status)
+	assert.Equal(t, notification.KeyData{
+		Key:      hexutil.Encode(key[:]),
+		Purposes: []string{"0x1", "0x2"},
+		JobID:    jobID.String(),
+	}, msg.Data)
+
+	// revoked key
+	notifyKey(context.Background(), notification.KeyRevoked, did, key, nil, jobID)
+	msg = next()
+	assert.Equal(t, notification.KeyRevoked, msg.EventType)
+	assert.Equal(t, notification.KeyData{Key: hexutil.Encode(key[:]), JobID: jobID.String()}, msg.Data)
+}
@@ -12,4 +12,16 @@ const (
 
 	// ErrKeyConstructionFailed error when the key construction failed.
 	ErrKeyConstructionFailed = errors.Error("failed to construct job key")
+
+	// ErrJobNotPending error when a job that already completed is cancelled.
+	ErrJobNotPending = errors.Error("job is not pending")
+
+	// ErrJobCancelled error when the job of a task was cancelled.
+	ErrJobCancelled = errors.Error("job cancelled")
+
+	// ErrJobNotFailed error when a job that didn't fail is retried.
+	ErrJobNotFailed = errors.Error("job is not failed")
+
+	// ErrJobNotRetryable error when none of the failed tasks of a job can be retried.
+	ErrJobNotRetryable = errors.Error("job cannot be retried")
 )
@@ -4,6 +4,7 @@ import (
 	"context"
 	"encoding/json"
 	"reflect"
+	"sync"
 	"time"
 
 	"github.com/centrifuge/go-centrifuge/identity"
@@ -23,6 +24,8 @@ const (
 	Failed Status = "failed"
 	// Pending is the pending status for a job or a task
 	Pending Status = "pending"
+	// Cancelled is the status of a job cancelled before it completed
+	Cancelled Status = "cancelled"
 
 	// JobIDParam maps job ID in the kwargs.
 	JobIDParam = "jobID"
@@ -33,6 +36,9 @@ const (
 	// BootstrappedService is the key to mapped jobs.JobManager
 	BootstrappedService = "BootstrappedService"
 
+	// BootstrappedPruner is the key mapped to the job pruner server.
+	BootstrappedPruner = "BootstrappedJobPruner"
+
 	// JobDataTypeURL is the type of the job data
 	JobDataTypeURL = "http://github.com/centrifuge/go-centrifuge/jobs/#Job"
 )
@@ -121,6 +127,9 @@ type Job struct {
 
 	// Values retrieved from events
 	Values map[string]JobValue
+
+	// Inclusions are the blocks the transactions of the job were confirmed in, keyed by the transaction hash
+	Inclusions map[string]Inclusion
 }
 
 // JSON returns json marshaled job.
@@ -148,6 +157,7 @@ func NewJob(identity identity.DID, description string) *Job {
 		TaskStatus:  make(map[string]Status),
 		CreatedAt:   time.Now().UTC(),
 		Values:      make(map[string]JobValue),
+		Inclusions:  make(map[string]Inclusion),
 	}
 }
 
@@ -158,6 +168,19 @@ type JobValue struct {
 	Value  []byte
 }
 
+// Inclusion holds the block a transaction of a job was included in once it reached the confirmation depth of its chain.
+type Inclusion struct {
+	// Chain is the chain of the transaction: ethereum or centchain
+	Chain       string
+	TxHash      string
+	BlockNumber uint64
+	BlockHash   string
+
+	// Confirmations is the number of blocks, including the block of the transaction, seen when it was confirmed
+	Confirmations uint64
+	ConfirmedAt   time.Time
+}
+
 // StatusResponse holds the job status details.
 type StatusResponse struct {
 	JobID       string    `json:"job_id"`
@@ -173,18 +196,75 @@ type Config interface {
 
 // Manager is a manager for centrifuge Jobs.
 type Manager interface {
-	// ExecuteWithinJob executes the given unit of work within a Job
-	ExecuteWithinJob(ctx context.Context, accountID identity.DID, existingJobID JobID, desc string, work func(accountID identity.DID, jobID JobID, jobManager Manager, err chan<- error)) (jobID JobID, done chan error, err error)
+	// ExecuteWithinJob executes the given unit of work within a Job.
+	// The context of the work is cancelled once the job is cancelled, the work should stop at the next step.
+	ExecuteWithinJob(ctx context.Context, accountID identity.DID, existingJobID JobID, desc string, work func(ctx context.Context, accountID identity.DID, jobID JobID, jobManager Manager, err chan<- error)) (jobID JobID, done chan error, err error)
 	GetJob(accountID identity.DID, id JobID) (*Job, error)
 	UpdateJobWithValue(accountID identity.DID, id JobID, key string, value []byte) error
+	// UpdateJobInclusion records the block a transaction of the job was confirmed in
+	UpdateJobInclusion(accountID identity.DID, id JobID, inclusion Inclusion) error
 	UpdateTaskStatus(accountID identity.DID, id JobID, status Status, taskName, message string) error
+	// UpdateJobStatus updates the overall status of a job whose work continued outside of ExecuteWithinJob
+	UpdateJobStatus(accountID identity.DID, id JobID, status Status, message string) error
 	GetJobStatus(accountID identity.DID, id JobID) (StatusResponse, error)
 	WaitForJob(accountID identity.DID, txID JobID) error
 	GetDefaultTaskTimeout() time.Duration
+	// ListJobs returns the jobs of the account matching the filter, newest first, and the total number of matches
+	ListJobs(accountID identity.DID, filter Filter) ([]*Job, int, error)
+	// CancelJob cancels the context of the work of a pending job and marks the job cancelled
+	CancelJob(accountID identity.DID, id JobID) error
+	// RegisterRetrier registers the retrier of the jobs that failed in the task
+	RegisterRetrier(taskName string, retrier Retrier)
+	// RetryJob resumes a failed job from its first failed task that has a retrier
+	RetryJob(ctx context.Context, accountID identity.DID, id JobID) (done chan error, err error)
+}
+
+// Retrier returns the work that resumes a failed job.
+// The work should skip the tasks that already succeeded, see Job.TaskStatus.
+type Retrier func(job *Job) (work func(ctx context.Context, accountID identity.DID, jobID JobID, jobManager Manager, err chan<- error), err error)
+
+// Filter selects the jobs to be listed.
+type Filter struct {
+	// Status of the jobs, all statuses if empty
+	Status Status
+
+	// Description the job description must contain, case insensitive
+	Description string
+
+	// From and To limit the creation time of the jobs, unbounded if zero
+	From time.Time
+	To   time.Time
+
+	// Offset and Limit paginate the jobs, all the jobs are returned if Limit is zero
+	Offset int
+	Limit  int
 }
 
 // Repository can be implemented by a type that handles storage for Jobs.
 type Repository interface {
 	Get(did identity.DID, id JobID) (*Job, error)
 	Save(job *Job) error
+	// List returns the jobs of the account matching the filter, newest first, and the total number of matches
+	List(did identity.DID, filter Filter) ([]*Job, int, error)
+	// Delete deletes the job
+	Delete(did identity.DID, id JobID) error
+	// ListExpired returns the jobs of all the accounts with the status that were last updated before the given time
+	ListExpired(status Status, before time.Time) ([]*Job, error)
+}
+
+// PruneReport holds the result of a prune run.
+type PruneReport struct {
+	// Pruned is the number of jobs deleted per status
+	Pruned map[Status]int
+
+	// Archive is the file the pruned jobs are written to, empty if archival is disabled
+	Archive string
+}
+
+// Pruner deletes the finished jobs after their retention period. It runs as a node server.
+type Pruner interface {
+	Name() string
+	Start(ctx context.Context, wg *sync.WaitGroup, startupErr chan<- error)
+	// Prune deletes the jobs expired at the given time
+	Prune(now time.Time) (PruneReport, error)
 }
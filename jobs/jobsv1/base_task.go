@@ -44,6 +44,13 @@ func (b *BaseTask) UpdateJob(accountID identity.DID, taskTypeName string, err er
 // UpdateJobWithValue add a new log and updates the status of the transaction based on the error and adds a value to the tx
 func (b *BaseTask) UpdateJobWithValue(accountID identity.DID, taskTypeName string, err error, txValue *jobs.JobValue) error {
 	if err == gocelery.ErrTaskRetryable {
+		// stop retrying once the job is cancelled
+		job, jerr := b.JobManager.GetJob(accountID, b.JobID)
+		if jerr == nil && job.Status == jobs.Cancelled {
+			log.Infof("Task %s stopped for cancelled job: %v\n", taskTypeName, b.JobID.String())
+			return jobs.ErrJobCancelled
+		}
+
 		return err
 	}
 
@@ -18,7 +18,7 @@ func TestDocumentAnchorTask_updateTransaction(t *testing.T) {
 	accountID := testingidentity.GenerateRandomDID()
 	name := "some task"
 	task.JobID = jobs.NewJobID()
-	task.JobManager = NewManager(&mockConfig{}, NewRepository(ctx[storage.BootstrappedDB].(storage.Repository)))
+	task.JobManager = NewManager(&mockConfig{}, NewRepository(ctx[storage.BootstrappedDB].(storage.Repository)), nil)
 
 	// missing transaction with nil error
 	err := task.UpdateJob(accountID, name, nil)
@@ -1,6 +1,7 @@
 package jobsv1
 
 import (
+	"github.com/centrifuge/go-centrifuge/config"
 	"github.com/centrifuge/go-centrifuge/config/configstore"
 	"github.com/centrifuge/go-centrifuge/jobs"
 	"github.com/centrifuge/go-centrifuge/storage"
@@ -9,7 +10,7 @@ import (
 // Bootstrapper implements bootstrap.Bootstrapper.
 type Bootstrapper struct{}
 
-// Bootstrap adds transaction.Repository into context.
+// Bootstrap adds transaction.Repository, the job manager and the job pruner into context.
 func (b Bootstrapper) Bootstrap(ctx map[string]interface{}) error {
 	cfg, err := configstore.RetrieveConfig(false, ctx)
 	if err != nil {
@@ -24,7 +25,12 @@ func (b Bootstrapper) Bootstrap(ctx map[string]interface{}) error {
 	jobsRepo := NewRepository(repo)
 	ctx[jobs.BootstrappedRepo] = jobsRepo
 
-	jobsMan := NewManager(cfg, jobsRepo)
+	// config service is bootstrapped after the jobs
+	jobsMan := NewManager(cfg, jobsRepo, func() config.Service {
+		cfgSrv, _ := ctx[config.BootstrappedConfigStorage].(config.Service)
+		return cfgSrv
+	})
 	ctx[jobs.BootstrappedService] = jobsMan
+	ctx[jobs.BootstrappedPruner] = NewPruner(cfg, jobsRepo)
 	return nil
 }
@@ -30,4 +30,5 @@ func TestBootstrapper_Bootstrap(t *testing.T) {
 	assert.Nil(t, err)
 	assert.NotNil(t, ctx[jobs.BootstrappedRepo])
 	assert.NotNil(t, ctx[jobs.BootstrappedService])
+	assert.NotNil(t, ctx[jobs.BootstrappedPruner])
 }
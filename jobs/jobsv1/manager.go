@@ -3,8 +3,11 @@ package jobsv1
 import (
 	"context"
 	"fmt"
+	"sync"
 	"time"
 
+	"github.com/centrifuge/go-centrifuge/config"
+	"github.com/centrifuge/go-centrifuge/contextutil"
 	"github.com/centrifuge/go-centrifuge/errors"
 	"github.com/centrifuge/go-centrifuge/identity"
 	"github.com/centrifuge/go-centrifuge/jobs"
@@ -29,8 +32,25 @@ type extendedManager interface {
 }
 
 // NewManager returns a JobManager implementation.
-func NewManager(config jobs.Config, repo jobs.Repository) jobs.Manager {
-	return &manager{config: config, repo: repo, notifier: notification.NewWebhookSender()}
+// cfgSrv returns the config service used to notify the accounts of the jobs completed outside of a request.
+func NewManager(config jobs.Config, repo jobs.Repository, cfgSrv func() config.Service) jobs.Manager {
+	return &manager{
+		config:   config,
+		repo:     repo,
+		cfgSrv:   cfgSrv,
+		notifier: notification.NewWebhookSender(),
+		running:  make(map[jobs.JobID][]*runningJob),
+		retriers: make(map[string]jobs.Retrier),
+	}
+}
+
+// runningJob holds the cancel function of a job executing within this manager.
+type runningJob struct {
+	cancel    context.CancelFunc
+	cancelled bool
+
+	// stopped is closed once the job status is saved
+	stopped chan struct{}
 }
 
 // manager implements JobManager.
@@ -39,6 +59,11 @@ type manager struct {
 	config   jobs.Config
 	repo     jobs.Repository
 	notifier notification.Sender
+	cfgSrv   func() config.Service
+
+	mu       sync.Mutex
+	running  map[jobs.JobID][]*runningJob
+	retriers map[string]jobs.Retrier
 }
 
 func (s *manager) GetDefaultTaskTimeout() time.Duration {
@@ -54,6 +79,21 @@ func (s *manager) UpdateJobWithValue(accountID identity.DID, id jobs.JobID, key
 	return s.saveJob(tx)
 }
 
+func (s *manager) UpdateJobInclusion(accountID identity.DID, id jobs.JobID, inclusion jobs.Inclusion) error {
+	job, err := s.GetJob(accountID, id)
+	if err != nil {
+		return err
+	}
+
+	// jobs saved before the inclusions were recorded
+	if job.Inclusions == nil {
+		job.Inclusions = make(map[string]jobs.Inclusion)
+	}
+
+	job.Inclusions[inclusion.TxHash] = inclusion
+	return s.saveJob(job)
+}
+
 func (s *manager) UpdateTaskStatus(accountID identity.DID, id jobs.JobID, status jobs.Status, taskName, message string) error {
 	tx, err := s.GetJob(accountID, id)
 	if err != nil {
@@ -63,11 +103,26 @@ func (s *manager) UpdateTaskStatus(accountID identity.DID, id jobs.JobID, status
 	// status particular to the task
 	tx.TaskStatus[taskName] = status
 	tx.Logs = append(tx.Logs, jobs.NewLog(taskName, message))
-	return s.saveJob(tx)
+	err = s.saveJob(tx)
+	if err != nil {
+		return err
+	}
+
+	notification.GetBroker().Publish(notification.Message{
+		EventType:    notification.TaskUpdated,
+		AccountID:    accountID.String(),
+		Recorded:     time.Now().UTC(),
+		DocumentType: jobs.JobDataTypeURL,
+		DocumentID:   id.String(),
+		Status:       string(status),
+		Message:      message,
+		TaskName:     taskName,
+	})
+	return nil
 }
 
 // ExecuteWithinJob executes a task within a Job.
-func (s *manager) ExecuteWithinJob(ctx context.Context, accountID identity.DID, existingJobID jobs.JobID, desc string, work func(accountID identity.DID, txID jobs.JobID, txMan jobs.Manager, err chan<- error)) (txID jobs.JobID, done chan error, err error) {
+func (s *manager) ExecuteWithinJob(ctx context.Context, accountID identity.DID, existingJobID jobs.JobID, desc string, work func(ctx context.Context, accountID identity.DID, txID jobs.JobID, txMan jobs.Manager, err chan<- error)) (txID jobs.JobID, done chan error, err error) {
 	job, err := s.repo.Get(accountID, existingJobID)
 	if err != nil {
 		job = jobs.NewJob(accountID, desc)
@@ -76,11 +131,26 @@ func (s *manager) ExecuteWithinJob(ctx context.Context, accountID identity.DID,
 			return jobs.NilJobID(), nil, err
 		}
 	}
+
+	// update job success status only if this wasn't an existing job.
+	// Otherwise it might update an existing tx pending status to success without actually being a success,
+	// It is assumed that status update is already handled per task in that case.
+	// Checking individual task success is upto the transaction manager users.
+	return job.ID, s.execute(ctx, job, jobs.JobIDEqual(existingJobID, jobs.NilJobID()), desc, work), nil
+}
+
+// execute runs the work of the job in the background and returns the channel notified once the work is done.
+// ownStatus marks the job success once the work succeeds.
+func (s *manager) execute(ctx context.Context, job *jobs.Job, ownStatus bool, desc string, work func(ctx context.Context, accountID identity.DID, txID jobs.JobID, txMan jobs.Manager, err chan<- error)) (done chan error) {
+	accountID := job.DID
 	// set capacity to one so that any late listener won't block this routine.
 	done = make(chan error, 1)
+	ctx, rj := s.addRunningJob(ctx, job.ID)
 	go func(ctx context.Context) {
-		err := make(chan error)
-		go work(accountID, job.ID, s, err)
+		defer s.removeRunningJob(job.ID, rj)
+		// set capacity to one so that the work doesn't block once the job is cancelled.
+		err := make(chan error, 1)
+		go work(ctx, accountID, job.ID, s, err)
 
 		var mJob *jobs.Job
 		var doneErr error
@@ -92,11 +162,7 @@ func (s *manager) ExecuteWithinJob(ctx context.Context, accountID identity.DID,
 				doneErr = errors.AppendError(e, err)
 				break
 			}
-			// update job success status only if this wasn't an existing job.
-			// Otherwise it might update an existing tx pending status to success without actually being a success,
-			// It is assumed that status update is already handled per task in that case.
-			// Checking individual task success is upto the transaction manager users.
-			if e == nil && jobs.JobIDEqual(existingJobID, jobs.NilJobID()) {
+			if e == nil && ownStatus {
 				tempJob.Status = jobs.Success
 			} else if e != nil {
 				log.Error(e)
@@ -121,6 +187,10 @@ func (s *manager) ExecuteWithinJob(ctx context.Context, accountID identity.DID,
 				break
 			}
 			tempJob.Logs = append(tempJob.Logs, jobs.NewLog("context closed", msg))
+			if s.isCancelled(rj) {
+				tempJob.Status = jobs.Cancelled
+				doneErr = jobs.ErrJobCancelled
+			}
 			e := s.saveJob(tempJob)
 			if e != nil {
 				log.Error(e)
@@ -137,27 +207,210 @@ func (s *manager) ExecuteWithinJob(ctx context.Context, accountID identity.DID,
 			log.Error("job done channel capacity breach")
 		}
 
-		if mJob != nil && jobs.JobIDEqual(existingJobID, jobs.NilJobID()) {
-			notificationMsg := notification.Message{
-				EventType:    notification.JobCompleted,
-				AccountID:    accountID.String(),
-				Recorded:     time.Now().UTC(),
-				DocumentType: jobs.JobDataTypeURL,
-				DocumentID:   mJob.ID.String(),
-				Status:       string(mJob.Status),
-			}
-			if len(mJob.Logs) > 0 {
-				notificationMsg.Message = mJob.Logs[len(mJob.Logs)-1].Message
-			}
-			// Send Job notification webhook
-			_, err := s.notifier.Send(ctx, notificationMsg)
-			if err != nil {
-				log.Error(err)
-			}
+		if mJob != nil && (ownStatus || mJob.Status == jobs.Cancelled) {
+			s.notify(ctx, mJob)
 		}
 
 	}(ctx)
-	return job.ID, done, nil
+	return done
+}
+
+// addRunningJob derives a cancellable context for the job.
+func (s *manager) addRunningJob(ctx context.Context, id jobs.JobID) (context.Context, *runningJob) {
+	ctx, cancel := context.WithCancel(ctx)
+	rj := &runningJob{cancel: cancel, stopped: make(chan struct{})}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.running[id] = append(s.running[id], rj)
+	return ctx, rj
+}
+
+// removeRunningJob releases the context of the job and signals the job is stopped.
+func (s *manager) removeRunningJob(id jobs.JobID, rj *runningJob) {
+	s.mu.Lock()
+	rjs := s.running[id]
+	for i, r := range rjs {
+		if r == rj {
+			rjs = append(rjs[:i], rjs[i+1:]...)
+			break
+		}
+	}
+
+	if len(rjs) == 0 {
+		delete(s.running, id)
+	} else {
+		s.running[id] = rjs
+	}
+	s.mu.Unlock()
+
+	rj.cancel()
+	close(rj.stopped)
+}
+
+func (s *manager) isCancelled(rj *runningJob) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return rj.cancelled
+}
+
+// CancelJob cancels the context of the work of a pending job and marks the job cancelled.
+// The work stops at its next step, the anchor task of the job stops before its next anchoring step.
+// Jobs that are no longer running on this node, e.g. after a restart, are marked cancelled directly.
+func (s *manager) CancelJob(accountID identity.DID, id jobs.JobID) error {
+	job, err := s.GetJob(accountID, id)
+	if err != nil {
+		return err
+	}
+
+	if job.Status != jobs.Pending {
+		return jobs.ErrJobNotPending
+	}
+
+	s.mu.Lock()
+	rjs := append([]*runningJob(nil), s.running[id]...)
+	for _, rj := range rjs {
+		rj.cancelled = true
+	}
+	s.mu.Unlock()
+
+	if len(rjs) == 0 {
+		return s.UpdateJobStatus(accountID, id, jobs.Cancelled, "job cancelled")
+	}
+
+	for _, rj := range rjs {
+		rj.cancel()
+		<-rj.stopped
+	}
+
+	return nil
+}
+
+// RegisterRetrier registers the retrier of the jobs that failed in the task.
+func (s *manager) RegisterRetrier(taskName string, retrier jobs.Retrier) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.retriers[taskName] = retrier
+}
+
+// RetryJob resumes a failed job from its first failed task that has a retrier.
+// The job is pending again until the work of the retrier completes.
+func (s *manager) RetryJob(ctx context.Context, accountID identity.DID, id jobs.JobID) (chan error, error) {
+	job, err := s.GetJob(accountID, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if job.Status != jobs.Failed {
+		return nil, jobs.ErrJobNotFailed
+	}
+
+	task, retrier := s.retrier(job)
+	if retrier == nil {
+		return nil, jobs.ErrJobNotRetryable
+	}
+
+	work, err := retrier(job)
+	if err != nil {
+		return nil, errors.NewTypedError(jobs.ErrJobNotRetryable, err)
+	}
+
+	job.Status = jobs.Pending
+	job.Logs = append(job.Logs, jobs.NewLog(fmt.Sprintf("%s[%s]", managerLogPrefix, job.Description), fmt.Sprintf("retrying from task %s", task)))
+	err = s.saveJob(job)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.execute(ctx, job, true, job.Description, work), nil
+}
+
+// retrier returns the first failed task of the job, in the order of the job logs, that has a retrier.
+func (s *manager) retrier(job *jobs.Job) (string, jobs.Retrier) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, l := range job.Logs {
+		if job.TaskStatus[l.Action] != jobs.Failed {
+			continue
+		}
+
+		if r, ok := s.retriers[l.Action]; ok {
+			return l.Action, r
+		}
+	}
+
+	return "", nil
+}
+
+// ListJobs returns the jobs of the account matching the filter, newest first, and the total number of matches.
+func (s *manager) ListJobs(accountID identity.DID, filter jobs.Filter) ([]*jobs.Job, int, error) {
+	return s.repo.List(accountID, filter)
+}
+
+// UpdateJobStatus updates the overall status of the job and sends the job notification once the job is complete.
+func (s *manager) UpdateJobStatus(accountID identity.DID, id jobs.JobID, status jobs.Status, message string) error {
+	job, err := s.GetJob(accountID, id)
+	if err != nil {
+		return err
+	}
+
+	job.Status = status
+	job.Logs = append(job.Logs, jobs.NewLog(fmt.Sprintf("%s[%s]", managerLogPrefix, job.Description), message))
+	err = s.saveJob(job)
+	if err != nil {
+		return err
+	}
+
+	if status == jobs.Pending {
+		return nil
+	}
+
+	ctx, err := s.accountContext(accountID)
+	if err != nil {
+		log.Errorf("failed to notify the status of job %s: %v", id.String(), err)
+		return nil
+	}
+
+	s.notify(ctx, job)
+	return nil
+}
+
+// accountContext returns the context of the account to notify the jobs completed outside of a request.
+func (s *manager) accountContext(accountID identity.DID) (context.Context, error) {
+	var cfgSrv config.Service
+	if s.cfgSrv != nil {
+		cfgSrv = s.cfgSrv()
+	}
+
+	if cfgSrv == nil {
+		return nil, errors.New("config service not bootstrapped")
+	}
+
+	acc, err := cfgSrv.GetAccount(accountID[:])
+	if err != nil {
+		return nil, errors.New("failed to get account: %v", err)
+	}
+
+	return contextutil.New(context.Background(), acc)
+}
+
+// notify sends the job completed notification.
+func (s *manager) notify(ctx context.Context, job *jobs.Job) {
+	notificationMsg := notification.Message{
+		EventType:    notification.JobCompleted,
+		AccountID:    job.DID.String(),
+		Recorded:     time.Now().UTC(),
+		DocumentType: jobs.JobDataTypeURL,
+		DocumentID:   job.ID.String(),
+		Status:       string(job.Status),
+	}
+	if len(job.Logs) > 0 {
+		notificationMsg.Message = job.Logs[len(job.Logs)-1].Message
+	}
+	// Send Job notification webhook
+	_, err := s.notifier.Send(ctx, notificationMsg)
+	if err != nil {
+		log.Error(err)
+	}
 }
 
 // saveJob saves the transaction.
@@ -195,6 +448,8 @@ func (s *manager) WaitForJob(accountID identity.DID, txID jobs.JobID) error {
 			return errors.New("job failed: %v", resp.Message)
 		case jobs.Success:
 			return nil
+		case jobs.Cancelled:
+			return jobs.ErrJobCancelled
 		default:
 			time.Sleep(10 * time.Millisecond)
 			continue
@@ -8,6 +8,10 @@ import (
 	"testing"
 	"time"
 
+	"github.com/centrifuge/go-centrifuge/bootstrap"
+	"github.com/centrifuge/go-centrifuge/config"
+	"github.com/centrifuge/go-centrifuge/config/configstore"
+	"github.com/centrifuge/go-centrifuge/contextutil"
 	"github.com/centrifuge/go-centrifuge/errors"
 	"github.com/centrifuge/go-centrifuge/identity"
 	"github.com/centrifuge/go-centrifuge/jobs"
@@ -33,10 +37,18 @@ func (mockSender) Send(ctx context.Context, ntf notification.Message) (notificat
 	return notification.Failure, nil
 }
 
+// ctxSender returns the context of the notifications to the channel.
+type ctxSender chan context.Context
+
+func (s ctxSender) Send(ctx context.Context, ntf notification.Message) (notification.Status, error) {
+	s <- ctx
+	return notification.Success, nil
+}
+
 func TestService_ExecuteWithinTX_happy(t *testing.T) {
 	did := testingidentity.GenerateRandomDID()
 	srv := ctx[jobs.BootstrappedService].(jobs.Manager)
-	jobID, done, err := srv.ExecuteWithinJob(context.Background(), did, jobs.NilJobID(), "", func(accountID identity.DID, jobID jobs.JobID, txMan jobs.Manager, err chan<- error) {
+	jobID, done, err := srv.ExecuteWithinJob(context.Background(), did, jobs.NilJobID(), "", func(ctx context.Context, accountID identity.DID, jobID jobs.JobID, txMan jobs.Manager, err chan<- error) {
 		err <- nil
 	})
 	assert.NoError(t, err)
@@ -53,11 +65,11 @@ func TestService_ExecuteWithinTX_err(t *testing.T) {
 	did := testingidentity.GenerateRandomDID()
 	srv := ctx[jobs.BootstrappedService].(jobs.Manager)
 	msrv := srv.(*manager)
-	mngr := NewManager(msrv.config, msrv.repo)
+	mngr := NewManager(msrv.config, msrv.repo, msrv.cfgSrv)
 	omgr := mngr.(*manager)
 	omgr.notifier = &mockSender{}
 	sendChan = make(chan notification.Message)
-	jobID, done, err := omgr.ExecuteWithinJob(context.Background(), did, jobs.NilJobID(), "SomeTask", func(accountID identity.DID, jobID jobs.JobID, txMan jobs.Manager, err chan<- error) {
+	jobID, done, err := omgr.ExecuteWithinJob(context.Background(), did, jobs.NilJobID(), "SomeTask", func(ctx context.Context, accountID identity.DID, jobID jobs.JobID, txMan jobs.Manager, err chan<- error) {
 		err <- errors.New(errStr)
 	})
 	assert.NoError(t, err)
@@ -82,7 +94,7 @@ func TestService_ExecuteWithinTX_ctxDone(t *testing.T) {
 	did := testingidentity.GenerateRandomDID()
 	srv := ctx[jobs.BootstrappedService].(jobs.Manager)
 	ctx, canc := context.WithCancel(context.Background())
-	tid, done, err := srv.ExecuteWithinJob(ctx, did, jobs.NilJobID(), "", func(accountID identity.DID, txID jobs.JobID, txMan jobs.Manager, err chan<- error) {
+	tid, done, err := srv.ExecuteWithinJob(ctx, did, jobs.NilJobID(), "", func(ctx context.Context, accountID identity.DID, txID jobs.JobID, txMan jobs.Manager, err chan<- error) {
 		// doing nothing
 	})
 	canc()
@@ -165,3 +177,162 @@ func TestService_WaitForTransaction(t *testing.T) {
 	assert.NoError(t, repo.Save(job))
 	assert.NoError(t, srv.WaitForJob(did, job.ID))
 }
+
+func TestService_UpdateJobInclusion(t *testing.T) {
+	srv := ctx[jobs.BootstrappedService].(extendedManager)
+	repo := ctx[jobs.BootstrappedRepo].(jobs.Repository)
+	did := testingidentity.GenerateRandomDID()
+
+	// missing job
+	inclusion := jobs.Inclusion{Chain: "ethereum", TxHash: "0x1", BlockNumber: 10, Confirmations: 6}
+	assert.Error(t, srv.UpdateJobInclusion(did, jobs.NewJobID(), inclusion))
+
+	// job saved before the inclusions
+	job, err := srv.createJob(did, "test")
+	assert.NoError(t, err)
+	job.Inclusions = nil
+	assert.NoError(t, repo.Save(job))
+	assert.NoError(t, srv.UpdateJobInclusion(did, job.ID, inclusion))
+	job, err = srv.GetJob(did, job.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]jobs.Inclusion{"0x1": inclusion}, job.Inclusions)
+}
+
+func TestService_CancelJob(t *testing.T) {
+	did := testingidentity.GenerateRandomDID()
+	srv := ctx[jobs.BootstrappedService].(jobs.Manager)
+
+	// missing job
+	err := srv.CancelJob(did, jobs.NewJobID())
+	assert.True(t, errors.IsOfType(jobs.ErrJobsMissing, err))
+
+	// running job
+	started, stopped := make(chan struct{}), make(chan struct{})
+	jobID, done, err := srv.ExecuteWithinJob(context.Background(), did, jobs.NilJobID(), "cancel me", func(ctx context.Context, accountID identity.DID, jobID jobs.JobID, txMan jobs.Manager, err chan<- error) {
+		close(started)
+		<-ctx.Done()
+		close(stopped)
+		err <- ctx.Err()
+	})
+	assert.NoError(t, err)
+	<-started
+	assert.NoError(t, srv.CancelJob(did, jobID))
+	assert.True(t, errors.IsOfType(jobs.ErrJobCancelled, <-done))
+
+	// the work is stopped with the job
+	select {
+	case <-stopped:
+	case <-time.After(time.Second):
+		t.Fatal("work of the cancelled job is still running")
+	}
+	job, err := srv.GetJob(did, jobID)
+	assert.NoError(t, err)
+	assert.Equal(t, jobs.Cancelled, job.Status)
+	assert.True(t, errors.IsOfType(jobs.ErrJobCancelled, srv.WaitForJob(did, jobID)))
+
+	// not pending anymore
+	err = srv.CancelJob(did, jobID)
+	assert.True(t, errors.IsOfType(jobs.ErrJobNotPending, err))
+
+	// job not running on this node
+	job, err = srv.(extendedManager).createJob(did, "orphan")
+	assert.NoError(t, err)
+	assert.NoError(t, srv.CancelJob(did, job.ID))
+	job, err = srv.GetJob(did, job.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, jobs.Cancelled, job.Status)
+}
+
+func TestService_ListJobs(t *testing.T) {
+	did := testingidentity.GenerateRandomDID()
+	srv := ctx[jobs.BootstrappedService].(extendedManager)
+	job, err := srv.createJob(did, "list me")
+	assert.NoError(t, err)
+
+	list, total, err := srv.ListJobs(did, jobs.Filter{Status: jobs.Pending})
+	assert.NoError(t, err)
+	assert.Equal(t, 1, total)
+	assert.Equal(t, job.ID, list[0].ID)
+}
+
+func TestService_RetryJob(t *testing.T) {
+	did := testingidentity.GenerateRandomDID()
+	srv := ctx[jobs.BootstrappedService].(extendedManager)
+
+	// missing job
+	_, err := srv.RetryJob(context.Background(), did, jobs.NewJobID())
+	assert.True(t, errors.IsOfType(jobs.ErrJobsMissing, err))
+
+	// pending job
+	job, err := srv.createJob(did, "retry me")
+	assert.NoError(t, err)
+	_, err = srv.RetryJob(context.Background(), did, job.ID)
+	assert.True(t, errors.IsOfType(jobs.ErrJobNotFailed, err))
+
+	// no retrier for the failed task
+	assert.NoError(t, srv.UpdateTaskStatus(did, job.ID, jobs.Success, "first task", ""))
+	assert.NoError(t, srv.UpdateTaskStatus(did, job.ID, jobs.Failed, "second task", "timeout"))
+	assert.NoError(t, srv.UpdateJobStatus(did, job.ID, jobs.Failed, "timeout"))
+	_, err = srv.RetryJob(context.Background(), did, job.ID)
+	assert.True(t, errors.IsOfType(jobs.ErrJobNotRetryable, err))
+
+	// retrier fails
+	srv.RegisterRetrier("first task", func(job *jobs.Job) (func(context.Context, identity.DID, jobs.JobID, jobs.Manager, chan<- error), error) {
+		panic("retried a successful task")
+	})
+	srv.RegisterRetrier("second task", func(job *jobs.Job) (func(context.Context, identity.DID, jobs.JobID, jobs.Manager, chan<- error), error) {
+		return nil, errors.New("missing state")
+	})
+	_, err = srv.RetryJob(context.Background(), did, job.ID)
+	assert.True(t, errors.IsOfType(jobs.ErrJobNotRetryable, err))
+
+	// success
+	srv.RegisterRetrier("second task", func(job *jobs.Job) (func(context.Context, identity.DID, jobs.JobID, jobs.Manager, chan<- error), error) {
+		assert.Equal(t, jobs.Success, job.TaskStatus["first task"])
+		return func(ctx context.Context, accountID identity.DID, jobID jobs.JobID, jobMan jobs.Manager, err chan<- error) {
+			err <- jobMan.UpdateTaskStatus(accountID, jobID, jobs.Success, "second task", "")
+		}, nil
+	})
+	done, err := srv.RetryJob(context.Background(), did, job.ID)
+	assert.NoError(t, err)
+	assert.NoError(t, <-done)
+	job, err = srv.GetJob(did, job.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, jobs.Success, job.Status)
+	assert.Equal(t, jobs.Success, job.TaskStatus["second task"])
+}
+
+func TestService_UpdateJobStatus_accountContext(t *testing.T) {
+	cfg := ctx[bootstrap.BootstrappedConfig].(config.Configuration)
+	acc, err := configstore.NewAccount("main", cfg)
+	assert.NoError(t, err)
+	did, err := identity.NewDIDFromBytes(acc.GetIdentityID())
+	assert.NoError(t, err)
+	cfgSrv := new(configstore.MockService)
+	srv := ctx[jobs.BootstrappedService].(*manager)
+	mngr := NewManager(srv.config, srv.repo, func() config.Service {
+		return cfgSrv
+	}).(*manager)
+	sender := make(ctxSender, 1)
+	mngr.notifier = sender
+
+	// pending jobs are not notified
+	job, err := mngr.createJob(did, "notify me")
+	assert.NoError(t, err)
+	assert.NoError(t, mngr.UpdateJobStatus(did, job.ID, jobs.Pending, "still running"))
+	assert.Len(t, sender, 0)
+
+	// notified with the account context
+	cfgSrv.On("GetAccount", did[:]).Return(acc, nil).Once()
+	assert.NoError(t, mngr.UpdateJobStatus(did, job.ID, jobs.Success, "finished after restart"))
+	nctx := <-sender
+	nacc, err := contextutil.Account(nctx)
+	assert.NoError(t, err)
+	assert.Equal(t, acc.GetIdentityID(), nacc.GetIdentityID())
+
+	// unknown account is not notified
+	cfgSrv.On("GetAccount", did[:]).Return(nil, errors.New("account not found")).Once()
+	assert.NoError(t, mngr.UpdateJobStatus(did, job.ID, jobs.Failed, "failed after restart"))
+	assert.Len(t, sender, 0)
+	cfgSrv.AssertExpectations(t)
+}
@@ -0,0 +1,154 @@
+package jobsv1
+
+import (
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/centrifuge/go-centrifuge/errors"
+	"github.com/centrifuge/go-centrifuge/jobs"
+)
+
+// PrunerConfig is the config for the job pruner.
+type PrunerConfig interface {
+	GetJobRetentionSuccess() time.Duration
+	GetJobRetentionFailed() time.Duration
+	GetJobRetentionCancelled() time.Duration
+	GetJobPruneInterval() time.Duration
+	GetJobArchivePath() string
+}
+
+// pruner deletes the finished jobs older than their retention period.
+type pruner struct {
+	config PrunerConfig
+	repo   jobs.Repository
+}
+
+// NewPruner returns a job pruner that runs as a node server.
+func NewPruner(config PrunerConfig, repo jobs.Repository) jobs.Pruner {
+	return &pruner{config: config, repo: repo}
+}
+
+// Name returns the name of the pruner server.
+func (p *pruner) Name() string {
+	return "JobPruner"
+}
+
+// Start prunes the jobs every prune interval until the context is done.
+func (p *pruner) Start(ctx context.Context, wg *sync.WaitGroup, startupErr chan<- error) {
+	defer wg.Done()
+	interval := p.config.GetJobPruneInterval()
+	if interval <= 0 {
+		log.Info("job pruner disabled")
+		<-ctx.Done()
+		return
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			log.Info("job pruner stopped")
+			return
+		case <-ticker.C:
+			if _, err := p.Prune(time.Now().UTC()); err != nil {
+				log.Errorf("failed to prune jobs: %v", err)
+			}
+		}
+	}
+}
+
+// Prune deletes the success, failed and cancelled jobs last updated before their retention period.
+// Pending jobs are never pruned. A zero retention keeps the jobs of the status forever.
+// If an archive path is configured, the jobs are written to a gzipped JSON lines file before they are deleted.
+func (p *pruner) Prune(now time.Time) (jobs.PruneReport, error) {
+	report := jobs.PruneReport{Pruned: make(map[jobs.Status]int)}
+	retentions := map[jobs.Status]time.Duration{
+		jobs.Success:   p.config.GetJobRetentionSuccess(),
+		jobs.Failed:    p.config.GetJobRetentionFailed(),
+		jobs.Cancelled: p.config.GetJobRetentionCancelled(),
+	}
+
+	var expired []*jobs.Job
+	for _, status := range []jobs.Status{jobs.Success, jobs.Failed, jobs.Cancelled} {
+		retention := retentions[status]
+		if retention <= 0 {
+			continue
+		}
+
+		list, err := p.repo.ListExpired(status, now.Add(-retention))
+		if err != nil {
+			return report, errors.New("failed to list expired %s jobs: %v", status, err)
+		}
+
+		expired = append(expired, list...)
+	}
+
+	if len(expired) == 0 {
+		return report, nil
+	}
+
+	if dir := p.config.GetJobArchivePath(); dir != "" {
+		file, err := archiveJobs(dir, now, expired)
+		if err != nil {
+			return report, errors.New("failed to archive jobs: %v", err)
+		}
+
+		report.Archive = file
+	}
+
+	for _, job := range expired {
+		err := p.repo.Delete(job.DID, job.ID)
+		if err != nil {
+			return report, errors.New("failed to delete job %s: %v", job.ID.String(), err)
+		}
+
+		report.Pruned[job.Status]++
+	}
+
+	log.Infof("pruned jobs: %d success, %d failed, %d cancelled, archive: %q",
+		report.Pruned[jobs.Success], report.Pruned[jobs.Failed], report.Pruned[jobs.Cancelled], report.Archive)
+	return report, nil
+}
+
+// archiveJobs writes the jobs as JSON lines to a new gzipped file in the directory and returns the file path.
+func archiveJobs(dir string, now time.Time, list []*jobs.Job) (file string, err error) {
+	err = os.MkdirAll(dir, 0700)
+	if err != nil {
+		return "", err
+	}
+
+	file = filepath.Join(dir, fmt.Sprintf("jobs-%s.jsonl.gz", now.UTC().Format("20060102T150405.000000000Z")))
+	f, err := os.OpenFile(file, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0600)
+	if err != nil {
+		return "", err
+	}
+
+	defer func() {
+		if cerr := f.Close(); err == nil {
+			err = cerr
+		}
+
+		if err != nil {
+			_ = os.Remove(file)
+			file = ""
+		}
+	}()
+
+	zw := gzip.NewWriter(f)
+	enc := json.NewEncoder(zw)
+	for _, job := range list {
+		err = enc.Encode(job)
+		if err != nil {
+			return file, err
+		}
+	}
+
+	return file, zw.Close()
+}
@@ -0,0 +1,112 @@
+// +build unit
+
+package jobsv1
+
+import (
+	"bufio"
+	"compress/gzip"
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/centrifuge/go-centrifuge/errors"
+	"github.com/centrifuge/go-centrifuge/jobs"
+	"github.com/centrifuge/go-centrifuge/testingutils/identity"
+	"github.com/stretchr/testify/assert"
+)
+
+type mockPrunerConfig struct {
+	retention time.Duration
+	archive   string
+}
+
+func (m mockPrunerConfig) GetJobRetentionSuccess() time.Duration {
+	return m.retention
+}
+
+func (m mockPrunerConfig) GetJobRetentionFailed() time.Duration {
+	return 2 * m.retention
+}
+
+func (m mockPrunerConfig) GetJobRetentionCancelled() time.Duration {
+	return 0
+}
+
+func (m mockPrunerConfig) GetJobPruneInterval() time.Duration {
+	return time.Hour
+}
+
+func (m mockPrunerConfig) GetJobArchivePath() string {
+	return m.archive
+}
+
+func saveJob(t *testing.T, repo jobs.Repository, status jobs.Status, updatedAt time.Time) *jobs.Job {
+	job := jobs.NewJob(testingidentity.GenerateRandomDID(), "some job")
+	job.Status = status
+	job.CreatedAt = updatedAt.Add(-time.Minute)
+	job.Logs = append(job.Logs, jobs.Log{Action: "done", CreatedAt: updatedAt})
+	assert.NoError(t, repo.Save(job))
+	return job
+}
+
+func TestPruner_Prune(t *testing.T) {
+	repo := ctx[jobs.BootstrappedRepo].(jobs.Repository)
+	dir, err := ioutil.TempDir("", "job-archive")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	now := time.Now().UTC().Add(100 * time.Hour)
+	p := NewPruner(mockPrunerConfig{retention: time.Hour, archive: dir}, repo)
+	oldSuccess := saveJob(t, repo, jobs.Success, now.Add(-90*time.Minute))
+	newSuccess := saveJob(t, repo, jobs.Success, now.Add(-30*time.Minute))
+	oldFailed := saveJob(t, repo, jobs.Failed, now.Add(-3*time.Hour))
+	newFailed := saveJob(t, repo, jobs.Failed, now.Add(-90*time.Minute))
+	cancelled := saveJob(t, repo, jobs.Cancelled, now.Add(-1000*time.Hour))
+	pending := saveJob(t, repo, jobs.Pending, now.Add(-1000*time.Hour))
+
+	report, err := p.Prune(now)
+	assert.NoError(t, err)
+	assert.True(t, report.Pruned[jobs.Success] >= 1)
+	assert.True(t, report.Pruned[jobs.Failed] >= 1)
+	assert.Equal(t, 0, report.Pruned[jobs.Cancelled])
+	assert.NotEmpty(t, report.Archive)
+
+	for _, job := range []*jobs.Job{oldSuccess, oldFailed} {
+		_, err = repo.Get(job.DID, job.ID)
+		assert.True(t, errors.IsOfType(jobs.ErrJobsMissing, err))
+		list, _, err := repo.List(job.DID, jobs.Filter{})
+		assert.NoError(t, err)
+		assert.Len(t, list, 0)
+	}
+
+	for _, job := range []*jobs.Job{newSuccess, newFailed, cancelled, pending} {
+		_, err = repo.Get(job.DID, job.ID)
+		assert.NoError(t, err)
+	}
+
+	// archived jobs
+	f, err := os.Open(report.Archive)
+	assert.NoError(t, err)
+	defer f.Close()
+	zr, err := gzip.NewReader(f)
+	assert.NoError(t, err)
+	archived := make(map[string]jobs.Status)
+	scanner := bufio.NewScanner(zr)
+	for scanner.Scan() {
+		job := new(jobs.Job)
+		assert.NoError(t, json.Unmarshal(scanner.Bytes(), job))
+		archived[job.ID.String()] = job.Status
+	}
+	assert.NoError(t, scanner.Err())
+	assert.Equal(t, jobs.Success, archived[oldSuccess.ID.String()])
+	assert.Equal(t, jobs.Failed, archived[oldFailed.ID.String()])
+	assert.NotContains(t, archived, newSuccess.ID.String())
+
+	// nothing left to prune
+	report, err = p.Prune(now)
+	assert.NoError(t, err)
+	assert.Len(t, report.Pruned, 0)
+	assert.Empty(t, report.Archive)
+}
@@ -1,6 +1,12 @@
 package jobsv1
 
 import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+	"time"
+
 	"github.com/centrifuge/go-centrifuge/errors"
 	"github.com/centrifuge/go-centrifuge/identity"
 	"github.com/centrifuge/go-centrifuge/jobs"
@@ -8,7 +14,63 @@ import (
 	"github.com/ethereum/go-ethereum/common/hexutil"
 )
 
-const jobPrefix string = "job_"
+const (
+	jobPrefix string = "job_"
+
+	// jobIndexPrefix is the prefix of the job index entries, ordered by account and creation time.
+	jobIndexPrefix string = "jobindex_"
+
+	// jobIndexVersionKey marks that the jobs saved before the index existed are indexed.
+	jobIndexVersionKey string = "jobindexversion"
+)
+
+// jobIndex is a summary of a job used to filter the jobs without loading them.
+type jobIndex struct {
+	ID          jobs.JobID
+	DID         identity.DID
+	Status      jobs.Status
+	Description string
+	CreatedAt   time.Time
+
+	// UpdatedAt is the time of the last log of the job
+	UpdatedAt time.Time
+}
+
+// JSON returns json marshaled index.
+func (i *jobIndex) JSON() ([]byte, error) {
+	return json.Marshal(i)
+}
+
+// FromJSON loads the data into index.
+func (i *jobIndex) FromJSON(data []byte) error {
+	return json.Unmarshal(data, i)
+}
+
+// Type returns the reflect.Type of the index.
+func (i *jobIndex) Type() reflect.Type {
+	return reflect.TypeOf(i)
+}
+
+// matches checks if the job satisfies the filter.
+func (i *jobIndex) matches(filter jobs.Filter) bool {
+	if filter.Status != "" && filter.Status != i.Status {
+		return false
+	}
+
+	if filter.Description != "" && !strings.Contains(strings.ToLower(i.Description), strings.ToLower(filter.Description)) {
+		return false
+	}
+
+	if !filter.From.IsZero() && i.CreatedAt.Before(filter.From) {
+		return false
+	}
+
+	if !filter.To.IsZero() && i.CreatedAt.After(filter.To) {
+		return false
+	}
+
+	return true
+}
 
 // jobRepository implements Repository.
 type jobRepository struct {
@@ -19,7 +81,48 @@ type jobRepository struct {
 // of the Repository.
 func NewRepository(repo storage.Repository) jobs.Repository {
 	repo.Register(new(jobs.Job))
-	return &jobRepository{repo: repo}
+	repo.Register(new(jobIndex))
+	r := &jobRepository{repo: repo}
+	if err := r.indexJobs(); err != nil {
+		log.Errorf("failed to index jobs: %v", err)
+	}
+
+	return r
+}
+
+// indexJobs adds the jobs saved before the index existed to the index.
+func (r *jobRepository) indexJobs() error {
+	if r.repo.Exists([]byte(jobIndexVersionKey)) {
+		return nil
+	}
+
+	models, err := r.repo.GetAllByPrefix(jobPrefix)
+	if err != nil {
+		return err
+	}
+
+	batch := storage.NewBatch()
+	for _, m := range models {
+		job, ok := m.(*jobs.Job)
+		if !ok {
+			continue
+		}
+
+		batch.Put(getIndexKey(job), newJobIndex(job))
+	}
+
+	batch.Create([]byte(jobIndexVersionKey), new(jobIndex))
+	return r.repo.Write(batch)
+}
+
+// getIndexPrefix returns the prefix of the index entries of the account.
+func getIndexPrefix(did identity.DID) string {
+	return jobIndexPrefix + hexutil.Encode(did[:]) + "_"
+}
+
+// getIndexKey orders the index entries of an account by the creation time of the jobs.
+func getIndexKey(job *jobs.Job) []byte {
+	return []byte(fmt.Sprintf("%s%020d_%s", getIndexPrefix(job.DID), job.CreatedAt.UnixNano(), job.ID.String()))
 }
 
 // getKey appends identity with id.
@@ -47,16 +150,117 @@ func (r *jobRepository) Get(did identity.DID, id jobs.JobID) (*jobs.Job, error)
 	return m.(*jobs.Job), nil
 }
 
-// Save saves the job to the repository.
+// Save saves the job and its index entry to the repository.
 func (r *jobRepository) Save(job *jobs.Job) error {
 	key, err := getKey(job.DID, job.ID)
 	if err != nil {
 		return errors.NewTypedError(jobs.ErrKeyConstructionFailed, err)
 	}
 
-	if r.repo.Exists(key) {
-		return r.repo.Update(key, job)
+	batch := storage.NewBatch()
+	batch.Put(key, job)
+	batch.Put(getIndexKey(job), newJobIndex(job))
+	return r.repo.Write(batch)
+}
+
+// newJobIndex returns the index entry of the job.
+func newJobIndex(job *jobs.Job) *jobIndex {
+	idx := &jobIndex{
+		ID:          job.ID,
+		DID:         job.DID,
+		Status:      job.Status,
+		Description: job.Description,
+		CreatedAt:   job.CreatedAt,
+		UpdatedAt:   job.CreatedAt,
+	}
+
+	if len(job.Logs) > 0 {
+		idx.UpdatedAt = job.Logs[len(job.Logs)-1].CreatedAt
+	}
+
+	return idx
+}
+
+// List returns the jobs of the account matching the filter, newest first, and the total number of matches.
+func (r *jobRepository) List(did identity.DID, filter jobs.Filter) ([]*jobs.Job, int, error) {
+	models, err := r.repo.GetAllByPrefix(getIndexPrefix(did))
+	if err != nil {
+		return nil, 0, err
+	}
+
+	// index entries are in the ascending order of creation
+	var matches []*jobIndex
+	for i := len(models) - 1; i >= 0; i-- {
+		idx, ok := models[i].(*jobIndex)
+		if !ok || !idx.matches(filter) {
+			continue
+		}
+
+		matches = append(matches, idx)
+	}
+
+	total := len(matches)
+	if filter.Offset >= total {
+		return nil, total, nil
+	}
+
+	matches = matches[filter.Offset:]
+	if filter.Limit > 0 && filter.Limit < len(matches) {
+		matches = matches[:filter.Limit]
+	}
+
+	var list []*jobs.Job
+	for _, idx := range matches {
+		job, err := r.Get(did, idx.ID)
+		if err != nil {
+			return nil, 0, err
+		}
+
+		list = append(list, job)
+	}
+
+	return list, total, nil
+}
+
+// Delete deletes the job and its index entry.
+func (r *jobRepository) Delete(did identity.DID, id jobs.JobID) error {
+	job, err := r.Get(did, id)
+	if err != nil {
+		return err
+	}
+
+	key, err := getKey(did, id)
+	if err != nil {
+		return errors.NewTypedError(jobs.ErrKeyConstructionFailed, err)
+	}
+
+	batch := storage.NewBatch()
+	batch.Delete(key)
+	batch.Delete(getIndexKey(job))
+	return r.repo.Write(batch)
+}
+
+// ListExpired returns the jobs of all the accounts with the status that were last updated before the given time.
+func (r *jobRepository) ListExpired(status jobs.Status, before time.Time) ([]*jobs.Job, error) {
+	models, err := r.repo.GetAllByPrefix(jobIndexPrefix)
+	if err != nil {
+		return nil, err
+	}
+
+	var list []*jobs.Job
+	for _, m := range models {
+		idx, ok := m.(*jobIndex)
+		if !ok || idx.Status != status || !idx.UpdatedAt.Before(before) {
+			continue
+		}
+
+		job, err := r.Get(idx.DID, idx.ID)
+		if err != nil {
+			return nil, err
+		}
+
+		list = append(list, job)
 	}
 
-	return r.repo.Create(key, job)
+	return list, nil
 }
@@ -5,6 +5,7 @@ package jobsv1
 import (
 	"os"
 	"testing"
+	"time"
 
 	"github.com/centrifuge/go-centrifuge/bootstrap"
 	"github.com/centrifuge/go-centrifuge/bootstrap/bootstrappers/testlogging"
@@ -28,8 +29,8 @@ func TestMain(m *testing.M) {
 		&testlogging.TestLoggingBootstrapper{},
 		&config.Bootstrapper{},
 		&leveldb.Bootstrapper{},
-		&configstore.Bootstrapper{},
 		Bootstrapper{},
+		&configstore.Bootstrapper{},
 	}
 	ctx[identity.BootstrappedDIDFactory] = &testingcommons.MockIdentityFactory{}
 	ctx[identity.BootstrappedDIDService] = &testingcommons.MockIdentityService{}
@@ -82,3 +83,90 @@ func TestRepository(t *testing.T) {
 	assert.Equal(t, did, job.DID)
 	assert.Equal(t, jobs.Success, job.Status)
 }
+
+func TestRepository_List(t *testing.T) {
+	did := testingidentity.GenerateRandomDID()
+	repo := ctx[jobs.BootstrappedRepo].(jobs.Repository)
+
+	// no jobs
+	list, total, err := repo.List(did, jobs.Filter{})
+	assert.NoError(t, err)
+	assert.Equal(t, 0, total)
+	assert.Len(t, list, 0)
+
+	now := time.Now().UTC()
+	var ids []jobs.JobID
+	for i, desc := range []string{"Anchor document", "Mint NFT", "anchor document"} {
+		job := jobs.NewJob(did, desc)
+		job.CreatedAt = now.Add(time.Duration(i) * time.Minute)
+		assert.NoError(t, repo.Save(job))
+		ids = append(ids, job.ID)
+	}
+
+	// other account
+	assert.NoError(t, repo.Save(jobs.NewJob(testingidentity.GenerateRandomDID(), "Anchor document")))
+
+	// newest first
+	list, total, err = repo.List(did, jobs.Filter{})
+	assert.NoError(t, err)
+	assert.Equal(t, 3, total)
+	assert.Len(t, list, 3)
+	assert.Equal(t, ids[2], list[0].ID)
+	assert.Equal(t, ids[0], list[2].ID)
+
+	// status index is updated
+	list[0].Status = jobs.Failed
+	assert.NoError(t, repo.Save(list[0]))
+	list, total, err = repo.List(did, jobs.Filter{Status: jobs.Failed})
+	assert.NoError(t, err)
+	assert.Equal(t, 1, total)
+	assert.Equal(t, ids[2], list[0].ID)
+
+	// description and time range
+	list, total, err = repo.List(did, jobs.Filter{Description: "ANCHOR", From: now.Add(time.Second)})
+	assert.NoError(t, err)
+	assert.Equal(t, 1, total)
+	assert.Equal(t, ids[2], list[0].ID)
+	list, total, err = repo.List(did, jobs.Filter{To: now.Add(time.Minute)})
+	assert.NoError(t, err)
+	assert.Equal(t, 2, total)
+	assert.Equal(t, ids[1], list[0].ID)
+
+	// pagination
+	list, total, err = repo.List(did, jobs.Filter{Offset: 1, Limit: 1})
+	assert.NoError(t, err)
+	assert.Equal(t, 3, total)
+	assert.Len(t, list, 1)
+	assert.Equal(t, ids[1], list[0].ID)
+	list, total, err = repo.List(did, jobs.Filter{Offset: 3})
+	assert.NoError(t, err)
+	assert.Equal(t, 3, total)
+	assert.Len(t, list, 0)
+}
+
+func TestRepository_Delete(t *testing.T) {
+	did := testingidentity.GenerateRandomDID()
+	repo := ctx[jobs.BootstrappedRepo].(jobs.Repository)
+
+	// missing job
+	err := repo.Delete(did, jobs.NewJobID())
+	assert.True(t, errors.IsOfType(jobs.ErrJobsMissing, err))
+
+	job := jobs.NewJob(did, "Anchor document")
+	job.Status = jobs.Success
+	assert.NoError(t, repo.Save(job))
+	list, err := repo.ListExpired(jobs.Success, time.Now().UTC().Add(time.Minute))
+	assert.NoError(t, err)
+	var found bool
+	for _, j := range list {
+		found = found || j.ID == job.ID
+	}
+	assert.True(t, found)
+
+	assert.NoError(t, repo.Delete(did, job.ID))
+	_, err = repo.Get(did, job.ID)
+	assert.True(t, errors.IsOfType(jobs.ErrJobsMissing, err))
+	_, total, err := repo.List(did, jobs.Filter{})
+	assert.NoError(t, err)
+	assert.Equal(t, 0, total)
+}
@@ -2,6 +2,7 @@ package migration
 
 import (
 	"encoding/json"
+	"reflect"
 	"time"
 
 	"github.com/go-errors/errors"
@@ -24,6 +25,21 @@ type Item struct {
 	Duration time.Duration `json:"duration,string"`
 }
 
+// JSON marshals the migration item to json bytes.
+func (i *Item) JSON() ([]byte, error) {
+	return json.Marshal(i)
+}
+
+// FromJSON loads the migration item from json bytes.
+func (i *Item) FromJSON(data []byte) error {
+	return json.Unmarshal(data, i)
+}
+
+// Type returns the type of the migration item.
+func (i *Item) Type() reflect.Type {
+	return reflect.TypeOf(i)
+}
+
 // NewMigrationRepository takes a path and creates a DB repository
 func NewMigrationRepository(path string) (*Repository, error) {
 	i, err := leveldb.OpenFile(path, nil)
@@ -12,6 +12,7 @@ import (
 	"time"
 
 	mfiles "github.com/centrifuge/go-centrifuge/migration/files"
+	"github.com/centrifuge/go-centrifuge/storage"
 	logging "github.com/ipfs/go-log"
 	"github.com/syndtr/goleveldb/leveldb"
 )
@@ -98,6 +99,38 @@ func (mr *Runner) RunMigrations(dbPath string) error {
 	return repo.Close()
 }
 
+// RunRepositoryMigrations records the migrations in the data repository of a storage backend other than LevelDB.
+// The migrations convert the LevelDB data of older node versions. Databases of the other backends are created
+// with the latest layout, so the migrations are recorded as run without executing them.
+func (mr *Runner) RunRepositoryMigrations(repo storage.Repository) error {
+	repo.Register(new(Item))
+	migrationList := make([]string, 0, len(migrations))
+	for k := range migrations {
+		migrationList = append(migrationList, k)
+	}
+	sort.Strings(migrationList)
+
+	for _, k := range migrationList {
+		key := getKeyFromID(k)
+		if repo.Exists(key) {
+			continue
+		}
+
+		mi := &Item{
+			ID:      k,
+			DateRun: time.Now().UTC(),
+			Hash:    "0x", // Not implemented yet
+		}
+		if err := repo.Create(key, mi); err != nil {
+			return err
+		}
+
+		log.Infof("Migration %s recorded, it only applies to LevelDB data", k)
+	}
+
+	return nil
+}
+
 func getBackupName(path, name string) string {
 	bkpPath := strings.TrimSuffix(path, ".leveldb")
 	return fmt.Sprintf("%s_%s.leveldb", bkpPath, name)
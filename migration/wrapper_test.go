@@ -7,6 +7,7 @@ import (
 	"testing"
 
 	"github.com/centrifuge/go-centrifuge/migration/utils"
+	"github.com/centrifuge/go-centrifuge/storage/boltdb"
 	"github.com/go-errors/errors"
 	"github.com/stretchr/testify/assert"
 	"github.com/syndtr/goleveldb/leveldb"
@@ -217,3 +218,27 @@ func TestRunner_RunMigrations_Failure(t *testing.T) {
 	assert.False(t, has)
 	assert.NoError(t, db.Close())
 }
+
+func TestRunner_RunRepositoryMigrations(t *testing.T) {
+	prefix := fmt.Sprintf("/tmp/datadir_%x", migrationutils.RandomByte32())
+	targetDir := fmt.Sprintf("%s.bolt", prefix)
+	defer os.Remove(targetDir)
+
+	db, err := boltdb.NewBoltStorage(targetDir)
+	assert.NoError(t, err)
+	repo := boltdb.NewBoltRepository(db)
+	defer repo.Close()
+
+	runner := NewMigrationRunner()
+	err = runner.RunRepositoryMigrations(repo)
+	assert.NoError(t, err)
+	for k := range migrations {
+		m, err := repo.Get(getKeyFromID(k))
+		assert.NoError(t, err)
+		assert.Equal(t, k, m.(*Item).ID)
+	}
+
+	// already recorded migrations are skipped
+	err = runner.RunRepositoryMigrations(repo)
+	assert.NoError(t, err)
+}
@@ -5,6 +5,7 @@ import (
 
 	"github.com/centrifuge/go-centrifuge/bootstrap"
 	"github.com/centrifuge/go-centrifuge/centchain"
+	"github.com/centrifuge/go-centrifuge/config"
 	"github.com/centrifuge/go-centrifuge/config/configstore"
 	"github.com/centrifuge/go-centrifuge/documents"
 	"github.com/centrifuge/go-centrifuge/errors"
@@ -12,6 +13,9 @@ import (
 	"github.com/centrifuge/go-centrifuge/identity"
 	"github.com/centrifuge/go-centrifuge/jobs"
 	"github.com/centrifuge/go-centrifuge/queue"
+	"github.com/centrifuge/go-centrifuge/storage"
+	geth "github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/core/types"
 )
 
 // Bootstrapper implements bootstrap.Bootstrapper.
@@ -24,6 +28,11 @@ func (*Bootstrapper) Bootstrap(ctx map[string]interface{}) error {
 		return err
 	}
 
+	err = validateRegistryAdapters(cfg)
+	if err != nil {
+		return err
+	}
+
 	centAPI, ok := ctx[centchain.BootstrappedCentChainClient].(centchain.API)
 	if !ok {
 		return errors.New("centchain client hasn't been initialized")
@@ -53,7 +62,29 @@ func (*Bootstrapper) Bootstrap(ctx map[string]interface{}) error {
 		return errors.New("transactions repository not initialised")
 	}
 
+	repo, ok := ctx[storage.BootstrappedDB].(storage.Repository)
+	if !ok {
+		return errors.New("storage repository not initialised")
+	}
+	repo.Register(new(Token))
+	repo.Register(new(Transfer))
+	repo.Register(new(watcherCursor))
+
+	configSrv, ok := ctx[config.BootstrappedConfigStorage].(config.Service)
+	if !ok {
+		return errors.New("config service not initialised")
+	}
+
 	client := ethereum.GetClient()
+	blockHeight := func() (uint64, error) {
+		h, err := client.GetEthClient().HeaderByNumber(context.Background(), nil)
+		if err != nil {
+			return 0, err
+		}
+
+		return h.Number.Uint64(), nil
+	}
+
 	nftSrv := newService(
 		cfg,
 		idService,
@@ -66,14 +97,23 @@ func (*Bootstrapper) Bootstrap(ctx map[string]interface{}) error {
 			api:     centAPI,
 			jobsMan: jobManager,
 		},
-		func() (uint64, error) {
-			h, err := client.GetEthClient().HeaderByNumber(context.Background(), nil)
-			if err != nil {
-				return 0, err
-			}
-
-			return h.Number.Uint64(), nil
-		})
+		blockHeight,
+		repo)
 	ctx[bootstrap.BootstrappedNFTService] = nftSrv
+	ctx[BootstrappedTransferWatcher] = &transferWatcher{
+		cfg:           cfg,
+		repo:          repo,
+		configSrv:     configSrv,
+		docSrv:        docSrv,
+		idSrv:         idService,
+		tokenRegistry: nftSrv,
+		blockHeight:   blockHeight,
+		filterLogs: func(ctx context.Context, query geth.FilterQuery) ([]types.Log, error) {
+			return client.GetEthClient().FilterLogs(ctx, query)
+		},
+		ethContext: func() (ctx context.Context, cancelFunc context.CancelFunc) {
+			return ethereum.DefaultWaitForTransactionMiningContext(cfg.GetEthereumContextReadWaitTimeout())
+		},
+	}
 	return nil
 }
@@ -0,0 +1,251 @@
+package nft
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"reflect"
+	"time"
+
+	"github.com/centrifuge/go-centrifuge/contextutil"
+	"github.com/centrifuge/go-centrifuge/documents"
+	"github.com/centrifuge/go-centrifuge/errors"
+	"github.com/centrifuge/go-centrifuge/identity"
+	"github.com/centrifuge/go-centrifuge/storage"
+	"github.com/centrifuge/go-centrifuge/utils"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+const (
+	// ErrTokenNotFound must be used when the NFT is not in the inventory of the account
+	ErrTokenNotFound = errors.Error("NFT not found")
+
+	tokenPrefix = "nft_token_"
+)
+
+// Token is the inventory record of an NFT minted by an account from a document.
+type Token struct {
+	Registry       common.Address `json:"registry"`
+	TokenID        hexutil.Bytes  `json:"token_id"`
+	AccountID      identity.DID   `json:"account_id"`
+	DocumentID     hexutil.Bytes  `json:"document_id"`
+	VersionID      hexutil.Bytes  `json:"version_id"`
+	AnchorID       hexutil.Bytes  `json:"anchor_id"`
+	DepositAddress common.Address `json:"deposit_address"`
+
+	// ProofFields are the document fields proven to the registry on minting
+	ProofFields []string `json:"proof_fields"`
+
+	// JobID of the minting job
+	JobID    string    `json:"job_id"`
+	MintedAt time.Time `json:"minted_at"`
+
+	// Replaces is the burned token this token was re-minted for
+	Replaces hexutil.Bytes `json:"replaces,omitempty"`
+
+	// ReplacedBy is the token re-minted after this token was burned
+	ReplacedBy hexutil.Bytes `json:"replaced_by,omitempty"`
+	BurnedAt   time.Time     `json:"burned_at"`
+}
+
+// JSON marshals the token to json bytes.
+func (t *Token) JSON() ([]byte, error) {
+	return json.Marshal(t)
+}
+
+// FromJSON loads the token from json bytes.
+func (t *Token) FromJSON(data []byte) error {
+	return json.Unmarshal(data, t)
+}
+
+// Type returns the type of the Token.
+func (t *Token) Type() reflect.Type {
+	return reflect.TypeOf(t)
+}
+
+// TokenInfo is the inventory record of an NFT with its current state on chain.
+// Owner and CurrentIndex are empty if the token is burned or the registry couldn't be queried.
+type TokenInfo struct {
+	Token
+	Owner        common.Address
+	CurrentIndex *big.Int
+}
+
+// MetadataAttribute is an ERC-721 metadata attribute of a token.
+type MetadataAttribute struct {
+	TraitType string `json:"trait_type"`
+	Value     string `json:"value"`
+}
+
+// Metadata is the ERC-721 JSON metadata of a token.
+type Metadata struct {
+	Name        string              `json:"name"`
+	Description string              `json:"description"`
+	Attributes  []MetadataAttribute `json:"attributes"`
+}
+
+func accountTokensPrefix(accountID identity.DID) string {
+	return tokenPrefix + accountID.String() + "_"
+}
+
+func tokenKey(accountID identity.DID, registry common.Address, tokenID []byte) []byte {
+	return []byte(accountTokensPrefix(accountID) + registry.Hex() + "_" + hexutil.Encode(tokenID))
+}
+
+// saveToken stores the inventory record of a minted token.
+func (s *service) saveToken(token *Token) error {
+	batch := storage.NewBatch()
+	batch.Put(tokenKey(token.AccountID, token.Registry, token.TokenID), token)
+	return s.repo.Write(batch)
+}
+
+// retireToken marks the burned token of the request as replaced by the re-minted token.
+// A record is created for tokens minted before the inventory existed.
+func (s *service) retireToken(accountID identity.DID, req RemintNFTRequest, replacedBy TokenID) error {
+	key := tokenKey(accountID, req.RegistryAddress, req.TokenID[:])
+	token := &Token{
+		Registry:   req.RegistryAddress,
+		TokenID:    req.TokenID[:],
+		AccountID:  accountID,
+		DocumentID: req.DocumentID,
+	}
+	if m, err := s.repo.Get(key); err == nil {
+		token = m.(*Token)
+	}
+
+	token.ReplacedBy = replacedBy[:]
+	token.BurnedAt = time.Now().UTC()
+	batch := storage.NewBatch()
+	batch.Put(key, token)
+	return s.repo.Write(batch)
+}
+
+// GetAccountTokens returns the NFTs minted by the account with their current state on chain.
+func (s *service) GetAccountTokens(ctx context.Context) ([]TokenInfo, error) {
+	did, err := contextutil.AccountDID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	models, err := s.repo.GetAllByPrefix(accountTokensPrefix(did))
+	if err != nil {
+		return nil, err
+	}
+
+	tokens := make([]TokenInfo, 0, len(models))
+	for _, m := range models {
+		tokens = append(tokens, s.tokenInfo(*m.(*Token)))
+	}
+
+	return tokens, nil
+}
+
+// GetDocumentTokens returns the NFTs of the current version of the document with their current state on chain.
+// NFTs not minted by the account only hold the registry and the token ID of the document.
+func (s *service) GetDocumentTokens(ctx context.Context, documentID []byte) ([]TokenInfo, error) {
+	did, err := contextutil.AccountDID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	model, err := s.docSrv.GetCurrentVersion(ctx, documentID)
+	if err != nil {
+		return nil, err
+	}
+
+	tokens := make([]TokenInfo, 0, len(model.NFTs()))
+	for _, n := range model.NFTs() {
+		registry := common.BytesToAddress(n.RegistryId[:common.AddressLength])
+		token := Token{Registry: registry, TokenID: n.TokenId, DocumentID: documentID}
+		if m, err := s.repo.Get(tokenKey(did, registry, n.TokenId)); err == nil {
+			token = *m.(*Token)
+		}
+
+		tokens = append(tokens, s.tokenInfo(token))
+	}
+
+	return tokens, nil
+}
+
+// GetTokenMetadata returns the ERC-721 metadata of the NFT minted by the account.
+func (s *service) GetTokenMetadata(ctx context.Context, registry common.Address, tokenID TokenID) (*Metadata, error) {
+	did, err := contextutil.AccountDID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	m, err := s.repo.Get(tokenKey(did, registry, tokenID[:]))
+	if err != nil {
+		return nil, errors.NewTypedError(ErrTokenNotFound, err)
+	}
+
+	token := m.(*Token)
+	model, err := s.docSrv.GetVersion(ctx, token.DocumentID, token.VersionID)
+	if err != nil {
+		return nil, err
+	}
+
+	return toMetadata(token, model), nil
+}
+
+// tokenInfo adds the current owner and index of the token in the registry.
+func (s *service) tokenInfo(token Token) TokenInfo {
+	info := TokenInfo{Token: token}
+	if !token.BurnedAt.IsZero() {
+		return info
+	}
+
+	owner, err := s.ownerOf(token.Registry, token.TokenID)
+	if err != nil && errors.IsOfType(ErrOwnerUnknown, err) {
+		// registries without single owners can only tell if the token is still at the deposit address
+		owner = token.DepositAddress
+		var owned bool
+		owned, err = s.isOwner(token.Registry, owner, token.TokenID)
+		if err == nil && !owned {
+			owner = common.Address{}
+		}
+	}
+
+	if err != nil {
+		log.Warningf("failed to get owner of token %s in registry %s: %v", token.TokenID, token.Registry.Hex(), err)
+	} else {
+		info.Owner = owner
+	}
+
+	index, err := s.CurrentIndexOfToken(token.Registry, token.TokenID)
+	if err != nil {
+		log.Warningf("failed to get current index of token %s in registry %s: %v", token.TokenID, token.Registry.Hex(), err)
+	} else {
+		info.CurrentIndex = index
+	}
+
+	return info
+}
+
+// toMetadata converts the token and the document version it was minted from to ERC-721 metadata.
+func toMetadata(token *Token, model documents.Model) *Metadata {
+	md := &Metadata{
+		Name: fmt.Sprintf("Centrifuge %s document %s", model.Scheme(), hexutil.Encode(token.DocumentID)),
+		Description: fmt.Sprintf("NFT %s of registry %s minted from version %s of the document",
+			utils.ByteSliceToBigInt(token.TokenID).String(), token.Registry.Hex(), hexutil.Encode(token.VersionID)),
+		Attributes: []MetadataAttribute{
+			{TraitType: "document_id", Value: hexutil.Encode(token.DocumentID)},
+			{TraitType: "version_id", Value: hexutil.Encode(token.VersionID)},
+			{TraitType: "anchor_id", Value: hexutil.Encode(token.AnchorID)},
+			{TraitType: "scheme", Value: model.Scheme()},
+		},
+	}
+
+	for _, attr := range model.GetAttributes() {
+		v, err := attr.Value.String()
+		if err != nil {
+			continue
+		}
+
+		md.Attributes = append(md.Attributes, MetadataAttribute{TraitType: attr.KeyLabel, Value: v})
+	}
+
+	return md
+}
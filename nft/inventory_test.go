@@ -0,0 +1,376 @@
+// +build unit
+
+package nft
+
+import (
+	"bytes"
+	"context"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/centrifuge/centrifuge-protobufs/gen/go/coredocument"
+	"github.com/centrifuge/go-centrifuge/documents"
+	"github.com/centrifuge/go-centrifuge/documents/generic"
+	"github.com/centrifuge/go-centrifuge/errors"
+	"github.com/centrifuge/go-centrifuge/ethereum"
+	"github.com/centrifuge/go-centrifuge/identity"
+	"github.com/centrifuge/go-centrifuge/jobs"
+	"github.com/centrifuge/go-centrifuge/storage/leveldb"
+	"github.com/centrifuge/go-centrifuge/testingutils/commons"
+	"github.com/centrifuge/go-centrifuge/testingutils/config"
+	"github.com/centrifuge/go-centrifuge/testingutils/documents"
+	"github.com/centrifuge/go-centrifuge/testingutils/testingjobs"
+	"github.com/centrifuge/go-centrifuge/utils"
+	geth "github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// registryCaller answers the ownerOf and currentIndexOfToken calls of a single token.
+type registryCaller struct {
+	tokenID *big.Int
+	owner   common.Address
+	index   *big.Int
+}
+
+func (c registryCaller) CodeAt(ctx context.Context, contract common.Address, blockNumber *big.Int) ([]byte, error) {
+	return []byte{1}, nil
+}
+
+func (c registryCaller) CallContract(ctx context.Context, call geth.CallMsg, blockNumber *big.Int) ([]byte, error) {
+	for method, value := range map[string]interface{}{"ownerOf": c.owner, "currentIndexOfToken": c.index} {
+		input, err := nftABI.Pack(method, c.tokenID)
+		if err != nil {
+			return nil, err
+		}
+
+		if bytes.Equal(input, call.Data) {
+			return nftABI.Methods[method].Outputs.Pack(value)
+		}
+	}
+
+	return nil, errors.New("execution reverted")
+}
+
+// burnCaller answers the calls of registryCaller until the token is burned.
+type burnCaller struct {
+	registryCaller
+	burned *bool
+}
+
+func (c burnCaller) CallContract(ctx context.Context, call geth.CallMsg, blockNumber *big.Int) ([]byte, error) {
+	if *c.burned {
+		return nil, errors.New("execution reverted")
+	}
+
+	return c.registryCaller.CallContract(ctx, call, blockNumber)
+}
+
+func newInventoryService(t *testing.T, docSrv documents.Service, caller bind.ContractCaller) (*service, context.Context) {
+	ethClient := new(ethereum.MockEthClient)
+	ethClient.On("GetGethCallOpts").Return(&bind.CallOpts{})
+	db, err := leveldb.NewLevelDBStorage(leveldb.GetRandomTestStoragePath())
+	assert.NoError(t, err)
+	repo := leveldb.NewLevelDBRepository(db)
+	repo.Register(new(Token))
+	srv := newService(cfg, nil, ethClient, nil, docSrv,
+		func(address common.Address, abi abi.ABI, client ethereum.Client) *bind.BoundContract {
+			return bind.NewBoundContract(address, abi, caller, nil, nil)
+		}, nil, nil, nil, repo)
+	return srv, testingconfig.CreateAccountContext(t, cfg)
+}
+
+func accountDID(t *testing.T) identity.DID {
+	id, err := cfg.GetIdentityID()
+	assert.NoError(t, err)
+	did, err := identity.NewDIDFromBytes(id)
+	assert.NoError(t, err)
+	return did
+}
+
+func TestService_GetAccountTokens(t *testing.T) {
+	tokenID := NewTokenID()
+	owner := common.HexToAddress("0x222855759a39fb75fc7341139f5d7a3974d4da08")
+	srv, ctx := newInventoryService(t, nil, registryCaller{tokenID: tokenID.BigInt(), owner: owner, index: big.NewInt(3)})
+
+	// no tokens
+	tokens, err := srv.GetAccountTokens(ctx)
+	assert.NoError(t, err)
+	assert.Len(t, tokens, 0)
+
+	// missing account
+	_, err = srv.GetAccountTokens(context.Background())
+	assert.Error(t, err)
+
+	did := accountDID(t)
+	token := &Token{
+		Registry:    common.HexToAddress("0x111855759a39fb75fc7341139f5d7a3974d4da08"),
+		AccountID:   did,
+		TokenID:     tokenID[:],
+		DocumentID:  utils.RandomSlice(32),
+		VersionID:   utils.RandomSlice(32),
+		ProofFields: []string{"generic.data"},
+		MintedAt:    time.Now().UTC(),
+	}
+	assert.NoError(t, srv.saveToken(token))
+
+	// same token ID in another registry
+	other := *token
+	other.Registry = common.HexToAddress("0x333855759a39fb75fc7341139f5d7a3974d4da08")
+	assert.NoError(t, srv.saveToken(&other))
+
+	tokens, err = srv.GetAccountTokens(ctx)
+	assert.NoError(t, err)
+	assert.Len(t, tokens, 2)
+	var found bool
+	for _, info := range tokens {
+		if info.Registry == token.Registry {
+			found = true
+			assert.Equal(t, token.DocumentID, info.DocumentID)
+			assert.Equal(t, token.ProofFields, info.ProofFields)
+			assert.Equal(t, owner, info.Owner)
+			assert.Equal(t, big.NewInt(3), info.CurrentIndex)
+		}
+	}
+	assert.True(t, found)
+}
+
+func TestService_GetDocumentTokens(t *testing.T) {
+	minted, other := NewTokenID(), NewTokenID()
+	registry := common.HexToAddress("0x111855759a39fb75fc7341139f5d7a3974d4da08")
+	owner := common.HexToAddress("0x222855759a39fb75fc7341139f5d7a3974d4da08")
+	docSrv := new(testingdocuments.MockService)
+	srv, ctx := newInventoryService(t, docSrv, registryCaller{tokenID: minted.BigInt(), owner: owner, index: big.NewInt(1)})
+	docID := utils.RandomSlice(32)
+
+	// missing document
+	docSrv.On("GetCurrentVersion", docID).Return(nil, documents.ErrDocumentNotFound).Once()
+	_, err := srv.GetDocumentTokens(ctx, docID)
+	assert.Error(t, err)
+
+	did := accountDID(t)
+	token := &Token{Registry: registry, TokenID: minted[:], AccountID: did, DocumentID: docID, JobID: "job", MintedAt: time.Now().UTC()}
+	assert.NoError(t, srv.saveToken(token))
+
+	registryID := append(registry.Bytes(), make([]byte, 12)...)
+	model := new(testingdocuments.MockModel)
+	model.On("NFTs").Return([]*coredocumentpb.NFT{
+		{RegistryId: registryID, TokenId: minted[:]},
+		{RegistryId: registryID, TokenId: other[:]},
+	})
+	docSrv.On("GetCurrentVersion", docID).Return(model, nil).Once()
+	tokens, err := srv.GetDocumentTokens(ctx, docID)
+	assert.NoError(t, err)
+	assert.Len(t, tokens, 2)
+
+	// minted by the account
+	assert.Equal(t, "job", tokens[0].JobID)
+	assert.Equal(t, owner, tokens[0].Owner)
+	assert.Equal(t, big.NewInt(1), tokens[0].CurrentIndex)
+
+	// not in the inventory and unknown to the registry
+	assert.Equal(t, registry, tokens[1].Registry)
+	assert.Equal(t, other[:], []byte(tokens[1].TokenID))
+	assert.Equal(t, docID, []byte(tokens[1].DocumentID))
+	assert.Empty(t, tokens[1].JobID)
+	assert.Nil(t, tokens[1].CurrentIndex)
+	docSrv.AssertExpectations(t)
+}
+
+func TestService_GetTokenMetadata(t *testing.T) {
+	tokenID := NewTokenID()
+	registry := common.HexToAddress("0x111855759a39fb75fc7341139f5d7a3974d4da08")
+	docSrv := new(testingdocuments.MockService)
+	srv, ctx := newInventoryService(t, docSrv, registryCaller{})
+
+	// not in the inventory
+	_, err := srv.GetTokenMetadata(ctx, registry, tokenID)
+	assert.True(t, errors.IsOfType(ErrTokenNotFound, err))
+
+	did := accountDID(t)
+	token := &Token{
+		Registry:   registry,
+		TokenID:    tokenID[:],
+		AccountID:  did,
+		DocumentID: utils.RandomSlice(32),
+		VersionID:  utils.RandomSlice(32),
+		AnchorID:   utils.RandomSlice(32),
+		MintedAt:   time.Now().UTC(),
+	}
+	assert.NoError(t, srv.saveToken(token))
+
+	attr, err := documents.NewStringAttribute("amount", documents.AttrString, "100")
+	assert.NoError(t, err)
+	model := new(testingdocuments.MockModel)
+	model.On("Scheme").Return("generic")
+	model.On("GetAttributes").Return([]documents.Attribute{attr})
+	docSrv.On("GetVersion", []byte(token.DocumentID), []byte(token.VersionID)).Return(model, nil).Once()
+	md, err := srv.GetTokenMetadata(ctx, registry, tokenID)
+	assert.NoError(t, err)
+	assert.Contains(t, md.Name, "generic")
+	assert.Contains(t, md.Description, tokenID.BigInt().String())
+	assert.Contains(t, md.Attributes, MetadataAttribute{TraitType: "scheme", Value: "generic"})
+	assert.Contains(t, md.Attributes, MetadataAttribute{TraitType: "amount", Value: "100"})
+	docSrv.AssertExpectations(t)
+}
+
+func TestService_RemintNFT(t *testing.T) {
+	docSrv := new(testingdocuments.MockService)
+	srv, ctx := newInventoryService(t, docSrv, registryCaller{})
+	jobMan := new(testingjobs.MockJobManager)
+	srv.jobsManager = jobMan
+	registry := common.HexToAddress("0x111855759a39fb75fc7341139f5d7a3974d4da08")
+	tokenID := NewTokenID()
+	req := RemintNFTRequest{
+		MintNFTRequest: MintNFTRequest{DocumentID: utils.RandomSlice(32), RegistryAddress: registry},
+		TokenID:        tokenID,
+	}
+
+	// missing account
+	_, _, err := srv.RemintNFT(context.Background(), req)
+	assert.Error(t, err)
+
+	// token isn't the NFT of the document
+	model := new(testingdocuments.MockModel)
+	model.On("NFTs").Return([]*coredocumentpb.NFT{
+		{RegistryId: append(registry.Bytes(), make([]byte, 12)...), TokenId: utils.RandomSlice(32)},
+	}).Once()
+	docSrv.On("GetCurrentVersion", req.DocumentID).Return(model, nil)
+	_, _, err = srv.RemintNFT(ctx, req)
+	assert.True(t, errors.IsOfType(documents.ErrNftNotFound, err))
+
+	// success
+	model.On("NFTs").Return([]*coredocumentpb.NFT{
+		{RegistryId: append(registry.Bytes(), make([]byte, 12)...), TokenId: tokenID[:]},
+	}).Once()
+	jobID := jobs.NewJobID()
+	jobMan.On("ExecuteWithinJob", mock.Anything, accountDID(t), jobs.NilJobID(), "Reminting NFT", mock.Anything).
+		Return(jobID, make(chan error), nil).Once()
+	resp, _, err := srv.RemintNFT(ctx, req)
+	assert.NoError(t, err)
+	assert.Equal(t, jobID.String(), resp.JobID)
+	assert.NotEqual(t, tokenID.String(), resp.TokenID)
+	jobMan.AssertExpectations(t)
+	model.AssertExpectations(t)
+}
+
+func TestService_reminterJob(t *testing.T) {
+	did := accountDID(t)
+	tokenID, newTokenID := NewTokenID(), NewTokenID()
+	registry := common.HexToAddress("0x111855759a39fb75fc7341139f5d7a3974d4da08")
+	req := RemintNFTRequest{
+		MintNFTRequest: MintNFTRequest{DocumentID: utils.RandomSlice(32), RegistryAddress: registry},
+		TokenID:        tokenID,
+	}
+	cd, err := documents.NewCoreDocument(nil, documents.CollaboratorsAccess{}, nil)
+	assert.NoError(t, err)
+	cd, err = cd.AddNFT(false, registry, tokenID[:])
+	assert.NoError(t, err)
+	model := &generic.Generic{CoreDocument: cd}
+	run := func(srv *service) error {
+		errOut := make(chan error, 1)
+		srv.reminterJob(newTokenID, model, req)(context.Background(), did, jobs.NewJobID(), nil, errOut)
+		return <-errOut
+	}
+
+	// identity doesn't own the token
+	other := common.HexToAddress("0x222855759a39fb75fc7341139f5d7a3974d4da08")
+	srv, _ := newInventoryService(t, nil, registryCaller{tokenID: tokenID.BigInt(), owner: other, index: big.NewInt(1)})
+	err = run(srv)
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "must own")
+
+	// mint request fails before the burn
+	docSrv := new(testingdocuments.MockService)
+	srv, _ = newInventoryService(t, docSrv, registryCaller{tokenID: tokenID.BigInt(), owner: did.ToAddress(), index: big.NewInt(1)})
+	idSrv := new(testingcommons.MockIdentityService)
+	srv.identityService = idSrv
+	docSrv.On("CreateProofs", mock.Anything, req.DocumentID, []string(nil)).Return(nil, errors.New("missing field")).Once()
+	err = run(srv)
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "failed to prepare mint request")
+	idSrv.AssertNotCalled(t, "Execute", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+
+	// burn failed
+	docSrv.On("CreateProofs", mock.Anything, req.DocumentID, []string(nil)).Return(&documents.DocumentProof{
+		LeftDataRooot: utils.RandomSlice(32),
+		RightDataRoot: utils.RandomSlice(32),
+	}, nil)
+	docSrv.On("GetCurrentVersion", req.DocumentID).Return(model, nil)
+	done := make(chan error, 1)
+	done <- errors.New("reverted")
+	idSrv.On("Execute", mock.Anything, registry, BurnMethodABI, "burn", []interface{}{tokenID.BigInt()}).
+		Return(jobs.NewJobID(), done, nil).Once()
+	err = run(srv)
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "failed to burn token")
+
+	// token still exists
+	done = make(chan error, 1)
+	done <- nil
+	idSrv.On("Execute", mock.Anything, registry, BurnMethodABI, "burn", []interface{}{tokenID.BigInt()}).
+		Return(jobs.NewJobID(), done, nil).Once()
+	err = run(srv)
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "still exists")
+	idSrv.AssertExpectations(t)
+	docSrv.AssertExpectations(t)
+
+	// burned, the new token replaces the burned token in the read rules of the new version
+	var burned bool
+	srv, _ = newInventoryService(t, docSrv, burnCaller{
+		registryCaller: registryCaller{tokenID: tokenID.BigInt(), owner: did.ToAddress(), index: big.NewInt(1)},
+		burned:         &burned,
+	})
+	srv.identityService = idSrv
+	req.GrantNFTReadAccess = true
+	cd, err = cd.AddNFT(true, registry, tokenID[:])
+	assert.NoError(t, err)
+	model.CoreDocument = cd
+	done = make(chan error, 1)
+	done <- nil
+	idSrv.On("Execute", mock.Anything, registry, BurnMethodABI, "burn", []interface{}{tokenID.BigInt()}).
+		Run(func(mock.Arguments) { burned = true }).Return(jobs.NewJobID(), done, nil).Once()
+	docSrv.On("Update", mock.Anything, model).Return(nil, nil, errors.New("update failed")).Once()
+	err = run(srv)
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "update failed")
+	assert.Equal(t, newTokenID[:], model.NFTs()[0].TokenId)
+	tr := new(testingdocuments.MockRegistry)
+	tr.On("IsOwner", registry, did.ToAddress(), newTokenID[:]).Return(true, nil).Once()
+	assert.NoError(t, model.NFTOwnerCanRead(tr, registry, newTokenID[:], did))
+	assert.Error(t, model.NFTOwnerCanRead(tr, registry, tokenID[:], did))
+	tr.AssertExpectations(t)
+	idSrv.AssertExpectations(t)
+	docSrv.AssertExpectations(t)
+}
+
+func TestService_retireToken(t *testing.T) {
+	tokenID, newTokenID := NewTokenID(), NewTokenID()
+	srv, ctx := newInventoryService(t, nil, registryCaller{tokenID: tokenID.BigInt(), owner: common.Address{1}, index: big.NewInt(1)})
+	did := accountDID(t)
+	req := RemintNFTRequest{
+		MintNFTRequest: MintNFTRequest{
+			DocumentID:      utils.RandomSlice(32),
+			RegistryAddress: common.HexToAddress("0x111855759a39fb75fc7341139f5d7a3974d4da08"),
+		},
+		TokenID: tokenID,
+	}
+
+	// token minted before the inventory existed
+	assert.NoError(t, srv.retireToken(did, req, newTokenID))
+	tokens, err := srv.GetAccountTokens(ctx)
+	assert.NoError(t, err)
+	assert.Len(t, tokens, 1)
+	assert.Equal(t, newTokenID[:], []byte(tokens[0].ReplacedBy))
+	assert.Equal(t, req.DocumentID, []byte(tokens[0].DocumentID))
+	assert.False(t, tokens[0].BurnedAt.IsZero())
+
+	// burned tokens are not looked up in the registry
+	assert.Equal(t, common.Address{}, tokens[0].Owner)
+	assert.Nil(t, tokens[0].CurrentIndex)
+}
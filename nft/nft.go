@@ -80,14 +80,39 @@ type MintNFTRequest struct {
 	SubmitNFTReadAccessProof bool
 }
 
-// Service defines the NFT service to mint and transfer NFTs.
+// RemintNFTRequest holds required fields for burning the NFT of a document and minting a new NFT
+// in the same registry against the latest version of the document.
+type RemintNFTRequest struct {
+	MintNFTRequest
+
+	// TokenID of the NFT to burn
+	TokenID TokenID
+}
+
+// Service defines the NFT service to mint, re-mint and transfer NFTs.
 type Service interface {
 	// MintNFT mints an NFT
 	MintNFT(ctx context.Context, request MintNFTRequest) (*TokenResponse, chan error, error)
+	// PreviewMint builds the mint of an NFT and checks its proofs without sending any transaction
+	PreviewMint(ctx context.Context, request MintNFTRequest) (*MintPreview, error)
+	// RemintNFT burns the NFT of a document and mints a new NFT against the latest version of the document
+	RemintNFT(ctx context.Context, request RemintNFTRequest) (*TokenResponse, chan error, error)
 	// TransferFrom transfers an NFT to another address
 	TransferFrom(ctx context.Context, registry common.Address, to common.Address, tokenID TokenID) (*TokenResponse, chan error, error)
 	// OwnerOf returns the owner of an NFT
 	OwnerOf(registry common.Address, tokenID []byte) (owner common.Address, err error)
+	// IsOwner returns true if the address owns the NFT
+	IsOwner(registry, owner common.Address, tokenID []byte) (owned bool, err error)
+	// CurrentIndexOfToken returns the current index of the token in the registry
+	CurrentIndexOfToken(registry common.Address, tokenID []byte) (*big.Int, error)
+	// GetAccountTokens returns the NFTs minted by the account
+	GetAccountTokens(ctx context.Context) ([]TokenInfo, error)
+	// GetDocumentTokens returns the NFTs of the current version of the document
+	GetDocumentTokens(ctx context.Context, documentID []byte) ([]TokenInfo, error)
+	// GetTokenMetadata returns the ERC-721 metadata of an NFT minted by the account
+	GetTokenMetadata(ctx context.Context, registry common.Address, tokenID TokenID) (*Metadata, error)
+	// GetTokenTransfers returns the observed transfers of an NFT minted by the account or linked to its documents
+	GetTokenTransfers(ctx context.Context, registry common.Address, tokenID TokenID) ([]Transfer, error)
 }
 
 // TokenResponse holds tokenID and transaction ID.
@@ -0,0 +1,152 @@
+package nft
+
+import (
+	"bytes"
+	"context"
+	"strings"
+
+	"github.com/centrifuge/go-centrifuge/contextutil"
+	"github.com/centrifuge/go-centrifuge/documents"
+	"github.com/centrifuge/go-centrifuge/errors"
+	"github.com/centrifuge/go-centrifuge/identity"
+	"github.com/centrifuge/precise-proofs/proofs"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto/sha3"
+)
+
+// ErrInvalidMintProofs must be used when the proofs of a mint don't match the document root
+const ErrInvalidMintProofs = errors.Error("mint proofs don't match the document root")
+
+// MintPreview is the mint of an NFT built against the latest version of a document without sending any transaction.
+type MintPreview struct {
+	TokenID TokenID
+
+	// Request holds the arguments of the mint
+	Request MintRequest
+
+	// DocumentRoot the proofs of the request were checked against
+	DocumentRoot []byte
+
+	// Calldata is the ABI encoded call of the mint method of the registry adapter
+	Calldata []byte
+
+	// Proofs and StaticProofs are the arguments of the ValidateNFT call on centchain
+	Proofs       []SubstrateProof
+	StaticProofs [3][32]byte
+}
+
+// PreviewMint builds the mint of an NFT against the latest version of the document and checks its proofs
+// against the document root. Like the mint, the preview adds the new token to a new version of the document,
+// but the version is only built in memory: it isn't signed or anchored, so the proofs and the anchor IDs are
+// those of the unsigned version. Nothing is sent to ethereum or centchain and the document isn't updated.
+func (s *service) PreviewMint(ctx context.Context, req MintNFTRequest) (*MintPreview, error) {
+	did, err := contextutil.AccountDID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if !req.GrantNFTReadAccess && req.SubmitNFTReadAccessProof {
+		return nil, errors.New("enable grant_nft_access to generate Read Access Proof")
+	}
+
+	model, err := s.docSrv.GetCurrentVersion(ctx, req.DocumentID)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, n := range model.NFTs() {
+		if !bytes.Equal(n.RegistryId[:common.AddressLength], req.RegistryAddress.Bytes()) {
+			continue
+		}
+
+		if _, err := s.ownerOf(req.RegistryAddress, n.TokenId); err == nil || errors.IsOfType(ErrOwnerUnknown, err) {
+			return nil, errors.NewTypedError(ErrNFTMinted, errors.New("registry %v", req.RegistryAddress.String()))
+		}
+	}
+
+	tokenID := s.newTokenID()
+	err = model.AddNFT(req.GrantNFTReadAccess, req.RegistryAddress, tokenID[:])
+	if err != nil {
+		return nil, err
+	}
+
+	// the version isn't anchored, so the proofs are created from the model instead of the document service
+	docProofs, err := model.CreateProofs(req.ProofFields)
+	if err != nil {
+		return nil, errors.NewTypedError(documents.ErrDocumentProof, err)
+	}
+
+	mreq, err := newMintRequestFromProofs(tokenID, did, model, docProofs, req)
+	if err != nil {
+		return nil, err
+	}
+
+	docRoot, err := model.CalculateDocumentRoot()
+	if err != nil {
+		return nil, err
+	}
+
+	err = validateMintProofs(mreq, docRoot)
+	if err != nil {
+		return nil, err
+	}
+
+	adapter, err := s.registryAdapter(req.RegistryAddress)
+	if err != nil {
+		return nil, err
+	}
+
+	call := adapter.Mint(mreq)
+	callABI, err := abi.JSON(strings.NewReader(call.ABI))
+	if err != nil {
+		return nil, err
+	}
+
+	calldata, err := callABI.Pack(call.Method, call.Args...)
+	if err != nil {
+		return nil, errors.New("failed to encode mint call: %v", err)
+	}
+
+	return &MintPreview{
+		TokenID:      tokenID,
+		Request:      mreq,
+		DocumentRoot: docRoot,
+		Calldata:     calldata,
+		Proofs:       mreq.substrateProofs(),
+		StaticProofs: mreq.staticProofs(),
+	}, nil
+}
+
+// buildMintRequest builds the mint request of the token against the latest anchored version of the document
+// and checks its proofs against the root of the document.
+func (s *service) buildMintRequest(ctx context.Context, tokenID TokenID, did identity.DID, model documents.Model, req MintNFTRequest) (mreq MintRequest, docRoot []byte, err error) {
+	mreq, err = s.prepareMintRequest(ctx, tokenID, did, req)
+	if err != nil {
+		return mreq, nil, err
+	}
+
+	docRoot, err = model.CalculateDocumentRoot()
+	if err != nil {
+		return mreq, nil, err
+	}
+
+	return mreq, docRoot, validateMintProofs(mreq, docRoot)
+}
+
+// validateMintProofs checks the leaves of the mint request against the document root.
+func validateMintProofs(mreq MintRequest, docRoot []byte) error {
+	for i, p := range mreq.substrateProofs() {
+		hashes := make([][]byte, 0, len(p.SortedHashes))
+		for _, h := range p.SortedHashes {
+			hashes = append(hashes, append([]byte(nil), h[:]...))
+		}
+
+		valid, err := proofs.ValidateProofSortedHashes(p.LeafHash[:], hashes, docRoot, sha3.NewKeccak256())
+		if err != nil || !valid {
+			return errors.NewTypedError(ErrInvalidMintProofs, errors.New("proof of %x: %v", mreq.Props[i], err))
+		}
+	}
+
+	return nil
+}
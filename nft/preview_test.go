@@ -0,0 +1,93 @@
+// +build unit
+
+package nft
+
+import (
+	"context"
+	"testing"
+
+	"github.com/centrifuge/centrifuge-protobufs/gen/go/coredocument"
+	"github.com/centrifuge/go-centrifuge/anchors"
+	"github.com/centrifuge/go-centrifuge/documents"
+	"github.com/centrifuge/go-centrifuge/documents/generic"
+	"github.com/centrifuge/go-centrifuge/errors"
+	"github.com/centrifuge/go-centrifuge/testingutils/documents"
+	"github.com/centrifuge/go-centrifuge/utils"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestService_PreviewMint(t *testing.T) {
+	registry := common.HexToAddress("0x111855759a39fb75fc7341139f5d7a3974d4da08")
+	tokenID := NewTokenID()
+	docSrv := new(testingdocuments.MockService)
+	srv, ctx := newInventoryService(t, docSrv, registryCaller{
+		tokenID: tokenID.BigInt(),
+		owner:   common.HexToAddress("0x222855759a39fb75fc7341139f5d7a3974d4da08"),
+	})
+	req := MintNFTRequest{DocumentID: utils.RandomSlice(32), RegistryAddress: registry}
+
+	// missing account
+	_, err := srv.PreviewMint(context.Background(), req)
+	assert.Error(t, err)
+
+	// read access proof without read access
+	req.SubmitNFTReadAccessProof = true
+	_, err = srv.PreviewMint(ctx, req)
+	assert.Error(t, err)
+	req.SubmitNFTReadAccessProof = false
+
+	// missing document
+	docSrv.On("GetCurrentVersion", req.DocumentID).Return(nil, errors.New("missing")).Once()
+	_, err = srv.PreviewMint(ctx, req)
+	assert.Error(t, err)
+
+	// token of the registry already minted
+	model := new(testingdocuments.MockModel)
+	model.On("NFTs").Return([]*coredocumentpb.NFT{
+		{RegistryId: append(registry.Bytes(), make([]byte, 12)...), TokenId: tokenID[:]},
+	}).Once()
+	docSrv.On("GetCurrentVersion", req.DocumentID).Return(model, nil).Once()
+	_, err = srv.PreviewMint(ctx, req)
+	assert.True(t, errors.IsOfType(ErrNFTMinted, err))
+
+	// token and read access proofs of the new version with the token
+	cd, err := documents.NewCoreDocument(nil, documents.CollaboratorsAccess{}, nil)
+	assert.NoError(t, err)
+	doc := &generic.Generic{CoreDocument: cd}
+	current := doc.CurrentVersion()
+	docSrv.On("GetCurrentVersion", req.DocumentID).Return(doc, nil).Once()
+	req.GrantNFTReadAccess, req.SubmitTokenProof, req.SubmitNFTReadAccessProof = true, true, true
+	preview, err := srv.PreviewMint(ctx, req)
+	assert.NoError(t, err)
+	assert.Equal(t, current, doc.PreviousVersion())
+	assert.Equal(t, preview.TokenID[:], doc.NFTs()[0].TokenId)
+	anchorID, err := anchors.ToAnchorID(doc.CurrentVersion())
+	assert.NoError(t, err)
+	assert.Equal(t, anchorID, preview.Request.AnchorID)
+	assert.Len(t, preview.Request.Props, 4)
+	docRoot, err := doc.CalculateDocumentRoot()
+	assert.NoError(t, err)
+	assert.Equal(t, docRoot, preview.DocumentRoot)
+	docSrv.AssertExpectations(t)
+	model.AssertExpectations(t)
+}
+
+func TestValidateMintProofs(t *testing.T) {
+	prop, value := utils.RandomSlice(8), utils.RandomSlice(32)
+	var salt [32]byte
+	copy(salt[:], utils.RandomSlice(32))
+	mreq := MintRequest{
+		Props:  [][]byte{prop},
+		Values: [][]byte{value},
+		Salts:  [][32]byte{salt},
+		Proofs: [][][32]byte{{}},
+	}
+
+	// single leaf is the root
+	assert.NoError(t, validateMintProofs(mreq, getLeafHash(prop, value, salt)))
+
+	// root of another document
+	err := validateMintProofs(mreq, utils.RandomSlice(32))
+	assert.True(t, errors.IsOfType(ErrInvalidMintProofs, err))
+}
@@ -0,0 +1,198 @@
+package nft
+
+import (
+	"math/big"
+	"strings"
+	"sync"
+
+	"github.com/centrifuge/go-centrifuge/documents"
+	"github.com/centrifuge/go-centrifuge/errors"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+const (
+	// ErrUnknownRegistryAdapter must be used when no registry adapter is registered with the configured name
+	ErrUnknownRegistryAdapter = errors.Error("unknown NFT registry adapter")
+
+	// ErrOwnerUnknown must be used when the registry doesn't track a single owner of its tokens
+	ErrOwnerUnknown = documents.ErrNFTOwnerUnknown
+
+	// ERC721Adapter is the name of the adapter of the generic Centrifuge ERC-721 registry
+	ERC721Adapter = "erc721"
+
+	// ERC1155Adapter is the name of the adapter of ERC-1155 multi-token registries
+	ERC1155Adapter = "erc1155"
+
+	// ERC1155MintMethodABI is the mint method of ERC-1155 registries. The proofs are the same as the generic mint,
+	// with the amount of the token, always 1 for NFTs.
+	ERC1155MintMethodABI = `[{"constant":false,"inputs":[{"internalType":"address","name":"to","type":"address"},{"internalType":"uint256","name":"id","type":"uint256"},{"internalType":"uint256","name":"amount","type":"uint256"},{"internalType":"bytes32","name":"dataRoot","type":"bytes32"},{"internalType":"bytes[]","name":"properties","type":"bytes[]"},{"internalType":"bytes[]","name":"values","type":"bytes[]"},{"internalType":"bytes32[]","name":"salts","type":"bytes32[]"}],"name":"mint","outputs":[],"payable":false,"stateMutability":"nonpayable","type":"function"}]`
+
+	// ERC1155ABI holds the ERC-1155 methods to transfer, burn and check the balance of tokens, and the transfer events
+	ERC1155ABI = `[{"anonymous":false,"inputs":[{"indexed":true,"name":"operator","type":"address"},{"indexed":true,"name":"from","type":"address"},{"indexed":true,"name":"to","type":"address"},{"indexed":false,"name":"id","type":"uint256"},{"indexed":false,"name":"value","type":"uint256"}],"name":"TransferSingle","type":"event"},{"anonymous":false,"inputs":[{"indexed":true,"name":"operator","type":"address"},{"indexed":true,"name":"from","type":"address"},{"indexed":true,"name":"to","type":"address"},{"indexed":false,"name":"ids","type":"uint256[]"},{"indexed":false,"name":"values","type":"uint256[]"}],"name":"TransferBatch","type":"event"},{"constant":true,"inputs":[{"name":"account","type":"address"},{"name":"id","type":"uint256"}],"name":"balanceOf","outputs":[{"name":"","type":"uint256"}],"payable":false,"stateMutability":"view","type":"function"},{"constant":false,"inputs":[{"name":"from","type":"address"},{"name":"to","type":"address"},{"name":"id","type":"uint256"},{"name":"amount","type":"uint256"},{"name":"data","type":"bytes"}],"name":"safeTransferFrom","outputs":[],"payable":false,"stateMutability":"nonpayable","type":"function"},{"constant":false,"inputs":[{"name":"account","type":"address"},{"name":"id","type":"uint256"},{"name":"value","type":"uint256"}],"name":"burn","outputs":[],"payable":false,"stateMutability":"nonpayable","type":"function"}]`
+)
+
+// RegistryCall is a transaction to a method of an NFT registry.
+type RegistryCall struct {
+	// ABI of the called method
+	ABI    string
+	Method string
+	Args   []interface{}
+}
+
+// RegistryCaller calls the read only method of an NFT registry described by the abi.
+type RegistryCaller func(abi abi.ABI, result interface{}, method string, args ...interface{}) error
+
+// RegistryAdapter encodes the calls to an NFT registry and decodes the ownership of its tokens.
+type RegistryAdapter interface {
+	// Mint returns the call minting the token of the request.
+	Mint(req MintRequest) RegistryCall
+
+	// Transfer returns the call transferring the token from the owner to the address.
+	Transfer(from, to common.Address, tokenID *big.Int) RegistryCall
+
+	// Burn returns the call burning the token of the owner.
+	Burn(owner common.Address, tokenID *big.Int) RegistryCall
+
+	// OwnerOf returns the owner of the token. Returns ErrOwnerUnknown if the registry doesn't track single owners.
+	OwnerOf(call RegistryCaller, tokenID *big.Int) (common.Address, error)
+
+	// IsOwner returns true if the address owns the token.
+	IsOwner(call RegistryCaller, owner common.Address, tokenID *big.Int) (bool, error)
+}
+
+var (
+	adaptersMu sync.RWMutex
+	adapters   = map[string]RegistryAdapter{
+		ERC721Adapter:  erc721Adapter{},
+		ERC1155Adapter: erc1155Adapter{},
+	}
+)
+
+// RegisterRegistryAdapter registers the adapter with the name used in the registry adapters config.
+// Adapters must be registered before the NFT service is bootstrapped.
+func RegisterRegistryAdapter(name string, adapter RegistryAdapter) {
+	adaptersMu.Lock()
+	defer adaptersMu.Unlock()
+	adapters[strings.ToLower(name)] = adapter
+}
+
+// lookupRegistryAdapter returns the adapter registered with the name.
+func lookupRegistryAdapter(name string) (RegistryAdapter, error) {
+	adaptersMu.RLock()
+	defer adaptersMu.RUnlock()
+	adapter, ok := adapters[strings.ToLower(name)]
+	if !ok {
+		return nil, errors.NewTypedError(ErrUnknownRegistryAdapter, errors.New("adapter %s", name))
+	}
+
+	return adapter, nil
+}
+
+// validateRegistryAdapters checks that the configured registries are addresses with registered adapters.
+func validateRegistryAdapters(cfg Config) error {
+	for registry, name := range cfg.GetNFTRegistryAdapters() {
+		if !common.IsHexAddress(registry) {
+			return errors.New("invalid NFT registry address %s", registry)
+		}
+
+		if _, err := lookupRegistryAdapter(name); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// registryAdapter returns the adapter configured for the registry, the ERC-721 adapter by default.
+func (s *service) registryAdapter(registry common.Address) (RegistryAdapter, error) {
+	for addr, name := range s.cfg.GetNFTRegistryAdapters() {
+		if common.HexToAddress(addr) == registry {
+			return lookupRegistryAdapter(name)
+		}
+	}
+
+	return lookupRegistryAdapter(ERC721Adapter)
+}
+
+// registryCaller returns the caller of the read only methods of the registry.
+func (s *service) registryCaller(registry common.Address) RegistryCaller {
+	return func(abi abi.ABI, result interface{}, method string, args ...interface{}) error {
+		c := s.bindCallerContract(registry, abi, s.ethClient)
+		opts, cancF := s.ethClient.GetGethCallOpts(false)
+		defer cancF()
+		return c.Call(opts, result, method, args...)
+	}
+}
+
+// erc721Adapter is the adapter of the generic Centrifuge ERC-721 registry.
+type erc721Adapter struct{}
+
+func (erc721Adapter) Mint(req MintRequest) RegistryCall {
+	// to common.Address, tokenId *big.Int, bytes32, properties [][]byte, values [][]byte, salts [][32]byte
+	return RegistryCall{
+		ABI:    GenericMintMethodABI,
+		Method: "mint",
+		Args:   []interface{}{req.To, req.TokenID, req.SigningRoot, req.Props, req.Values, req.Salts},
+	}
+}
+
+func (erc721Adapter) Transfer(from, to common.Address, tokenID *big.Int) RegistryCall {
+	return RegistryCall{ABI: ABI, Method: "transferFrom", Args: []interface{}{from, to, tokenID}}
+}
+
+func (erc721Adapter) Burn(owner common.Address, tokenID *big.Int) RegistryCall {
+	return RegistryCall{ABI: BurnMethodABI, Method: "burn", Args: []interface{}{tokenID}}
+}
+
+func (erc721Adapter) OwnerOf(call RegistryCaller, tokenID *big.Int) (common.Address, error) {
+	var owner common.Address
+	return owner, call(nftABI, &owner, "ownerOf", tokenID)
+}
+
+func (a erc721Adapter) IsOwner(call RegistryCaller, owner common.Address, tokenID *big.Int) (bool, error) {
+	o, err := a.OwnerOf(call, tokenID)
+	if err != nil {
+		return false, err
+	}
+
+	return o == owner, nil
+}
+
+// erc1155Adapter is the adapter of ERC-1155 multi-token registries holding each NFT with a supply of 1.
+// ERC-1155 doesn't track the owner of a token, so the registry must reject minting a token id twice.
+type erc1155Adapter struct{}
+
+func (erc1155Adapter) Mint(req MintRequest) RegistryCall {
+	return RegistryCall{
+		ABI:    ERC1155MintMethodABI,
+		Method: "mint",
+		Args:   []interface{}{req.To, req.TokenID, big.NewInt(1), req.SigningRoot, req.Props, req.Values, req.Salts},
+	}
+}
+
+func (erc1155Adapter) Transfer(from, to common.Address, tokenID *big.Int) RegistryCall {
+	return RegistryCall{
+		ABI:    ERC1155ABI,
+		Method: "safeTransferFrom",
+		Args:   []interface{}{from, to, tokenID, big.NewInt(1), []byte{}},
+	}
+}
+
+func (erc1155Adapter) Burn(owner common.Address, tokenID *big.Int) RegistryCall {
+	return RegistryCall{ABI: ERC1155ABI, Method: "burn", Args: []interface{}{owner, tokenID, big.NewInt(1)}}
+}
+
+func (erc1155Adapter) OwnerOf(call RegistryCaller, tokenID *big.Int) (common.Address, error) {
+	return common.Address{}, ErrOwnerUnknown
+}
+
+func (erc1155Adapter) IsOwner(call RegistryCaller, owner common.Address, tokenID *big.Int) (bool, error) {
+	balance := new(big.Int)
+	err := call(erc1155ABI, balance, "balanceOf", owner, tokenID)
+	if err != nil {
+		return false, err
+	}
+
+	return balance.Sign() > 0, nil
+}
@@ -0,0 +1,211 @@
+// +build unit
+
+package nft
+
+import (
+	"bytes"
+	"context"
+	"math/big"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/centrifuge/go-centrifuge/documents"
+	"github.com/centrifuge/go-centrifuge/documents/generic"
+	"github.com/centrifuge/go-centrifuge/errors"
+	"github.com/centrifuge/go-centrifuge/testingutils"
+	"github.com/centrifuge/go-centrifuge/testingutils/config"
+	"github.com/centrifuge/go-centrifuge/testingutils/documents"
+	"github.com/centrifuge/go-centrifuge/testingutils/identity"
+	"github.com/centrifuge/go-centrifuge/utils"
+	geth "github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestService_registryAdapter(t *testing.T) {
+	registry := common.HexToAddress("0x111855759a39fb75fc7341139f5d7a3974d4da08")
+	mockCfg := new(testingconfig.MockConfig)
+	srv := &service{cfg: mockCfg}
+
+	// default adapter
+	mockCfg.On("GetNFTRegistryAdapters").Return(map[string]string{}).Once()
+	adapter, err := srv.registryAdapter(registry)
+	assert.NoError(t, err)
+	assert.Equal(t, erc721Adapter{}, adapter)
+
+	// configured adapter, addresses are case insensitive
+	mockCfg.On("GetNFTRegistryAdapters").Return(map[string]string{strings.ToLower(registry.Hex()): "ERC1155"}).Once()
+	adapter, err = srv.registryAdapter(registry)
+	assert.NoError(t, err)
+	assert.Equal(t, erc1155Adapter{}, adapter)
+
+	// unknown adapter
+	mockCfg.On("GetNFTRegistryAdapters").Return(map[string]string{registry.Hex(): "erc20"}).Once()
+	_, err = srv.registryAdapter(registry)
+	assert.True(t, errors.IsOfType(ErrUnknownRegistryAdapter, err))
+	mockCfg.AssertExpectations(t)
+}
+
+func TestValidateRegistryAdapters(t *testing.T) {
+	mockCfg := new(testingconfig.MockConfig)
+	mockCfg.On("GetNFTRegistryAdapters").Return(map[string]string{"0x111855759a39fb75fc7341139f5d7a3974d4da08": "erc1155"}).Once()
+	assert.NoError(t, validateRegistryAdapters(mockCfg))
+
+	mockCfg.On("GetNFTRegistryAdapters").Return(map[string]string{"invalid address": "erc1155"}).Once()
+	assert.Error(t, validateRegistryAdapters(mockCfg))
+
+	mockCfg.On("GetNFTRegistryAdapters").Return(map[string]string{"0x111855759a39fb75fc7341139f5d7a3974d4da08": "erc20"}).Once()
+	assert.True(t, errors.IsOfType(ErrUnknownRegistryAdapter, validateRegistryAdapters(mockCfg)))
+	mockCfg.AssertExpectations(t)
+}
+
+func TestRegistryAdapters_calls(t *testing.T) {
+	from := common.HexToAddress("0x111855759a39fb75fc7341139f5d7a3974d4da08")
+	to := common.HexToAddress("0x222855759a39fb75fc7341139f5d7a3974d4da08")
+	tokenID := NewTokenID().BigInt()
+	mreq := MintRequest{
+		To:      to,
+		TokenID: tokenID,
+		Props:   [][]byte{{1}},
+		Values:  [][]byte{{2}},
+		Salts:   [][32]byte{{3}},
+	}
+
+	for name, adapter := range map[string]RegistryAdapter{ERC721Adapter: erc721Adapter{}, ERC1155Adapter: erc1155Adapter{}} {
+		for _, call := range []RegistryCall{adapter.Mint(mreq), adapter.Transfer(from, to, tokenID), adapter.Burn(from, tokenID)} {
+			callABI, err := abi.JSON(strings.NewReader(call.ABI))
+			assert.NoError(t, err, name)
+			_, err = callABI.Pack(call.Method, call.Args...)
+			assert.NoError(t, err, "%s: %s", name, call.Method)
+		}
+	}
+}
+
+func TestERC1155Adapter_ownership(t *testing.T) {
+	owner := common.HexToAddress("0x111855759a39fb75fc7341139f5d7a3974d4da08")
+	tokenID := NewTokenID().BigInt()
+	balances := map[common.Address]int64{owner: 1}
+	call := func(_ abi.ABI, result interface{}, method string, args ...interface{}) error {
+		assert.Equal(t, "balanceOf", method)
+		assert.Equal(t, tokenID, args[1])
+		result.(*big.Int).SetInt64(balances[args[0].(common.Address)])
+		return nil
+	}
+
+	adapter := erc1155Adapter{}
+	_, err := adapter.OwnerOf(call, tokenID)
+	assert.True(t, errors.IsOfType(ErrOwnerUnknown, err))
+
+	owned, err := adapter.IsOwner(call, owner, tokenID)
+	assert.NoError(t, err)
+	assert.True(t, owned)
+
+	owned, err = adapter.IsOwner(call, common.HexToAddress("0x222855759a39fb75fc7341139f5d7a3974d4da08"), tokenID)
+	assert.NoError(t, err)
+	assert.False(t, owned)
+}
+
+func TestRetryOwnerCall(t *testing.T) {
+	defer func(interval time.Duration) {
+		ownerCallRetryInterval = interval
+	}(ownerCallRetryInterval)
+	ownerCallRetryInterval = time.Millisecond
+	tokenID := NewTokenID()
+
+	// transient errors are retried
+	var calls int
+	err := retryOwnerCall(tokenID[:], func() error {
+		calls++
+		if calls < 3 {
+			return errors.New("connection refused")
+		}
+
+		return nil
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, 3, calls)
+
+	// up to the max tries
+	calls = 0
+	err = retryOwnerCall(tokenID[:], func() error {
+		calls++
+		return errors.New("connection refused")
+	})
+	assert.Error(t, err)
+	assert.Equal(t, 10, calls)
+
+	// registries without single owners fail fast
+	calls = 0
+	err = retryOwnerCall(tokenID[:], func() error {
+		calls++
+		return ErrOwnerUnknown
+	})
+	assert.True(t, errors.IsOfType(ErrOwnerUnknown, err))
+	assert.Equal(t, 1, calls)
+
+	// unknown registry adapters fail fast
+	registry := common.HexToAddress("0x111855759a39fb75fc7341139f5d7a3974d4da08")
+	mockCfg := new(testingconfig.MockConfig)
+	mockCfg.On("GetNFTRegistryAdapters").Return(map[string]string{registry.Hex(): "erc20"}).Twice()
+	srv := &service{cfg: mockCfg}
+	_, err = srv.IsOwner(registry, common.Address{1}, tokenID[:])
+	assert.True(t, errors.IsOfType(ErrUnknownRegistryAdapter, err))
+	_, err = srv.OwnerOf(registry, tokenID[:])
+	assert.True(t, errors.IsOfType(ErrUnknownRegistryAdapter, err))
+	mockCfg.AssertExpectations(t)
+}
+
+// erc1155Caller answers the balanceOf calls of a single token held by the owner.
+type erc1155Caller struct {
+	tokenID *big.Int
+	owner   common.Address
+}
+
+func (c erc1155Caller) CodeAt(ctx context.Context, contract common.Address, blockNumber *big.Int) ([]byte, error) {
+	return []byte{1}, nil
+}
+
+func (c erc1155Caller) CallContract(ctx context.Context, call geth.CallMsg, blockNumber *big.Int) ([]byte, error) {
+	input, err := erc1155ABI.Pack("balanceOf", c.owner, c.tokenID)
+	if err != nil {
+		return nil, err
+	}
+
+	balance := big.NewInt(0)
+	if bytes.Equal(input, call.Data) {
+		balance = big.NewInt(1)
+	}
+
+	return erc1155ABI.Methods["balanceOf"].Outputs.Pack(balance)
+}
+
+func TestService_ERC1155Ownership(t *testing.T) {
+	registry := common.HexToAddress("0x111855759a39fb75fc7341139f5d7a3974d4da08")
+	defer testingutils.MockConfigOption(cfg, "nft.registryAdapters", map[string]string{registry.Hex(): ERC1155Adapter})()
+	owner := testingidentity.GenerateRandomDID()
+	tokenID := NewTokenID()
+	docID := utils.RandomSlice(32)
+	cd, err := documents.NewCoreDocument(nil, documents.CollaboratorsAccess{}, nil)
+	assert.NoError(t, err)
+	cd, err = cd.AddNFT(true, registry, tokenID[:])
+	assert.NoError(t, err)
+	docSrv := new(testingdocuments.MockService)
+	docSrv.On("GetCurrentVersion", docID).Return(&generic.Generic{CoreDocument: cd}, nil).Once()
+	srv, ctx := newInventoryService(t, docSrv, erc1155Caller{tokenID: tokenID.BigInt(), owner: owner.ToAddress()})
+
+	// the owner can read the document with the token
+	assert.NoError(t, cd.NFTOwnerCanRead(srv, registry, tokenID[:], owner))
+	assert.Error(t, cd.NFTOwnerCanRead(srv, registry, tokenID[:], testingidentity.GenerateRandomDID()))
+
+	// the token of the document is not minted again
+	assert.True(t, cd.IsNFTMinted(srv, registry))
+	_, _, err = srv.MintNFT(ctx, MintNFTRequest{
+		DocumentID:      docID,
+		RegistryAddress: registry,
+		DepositAddress:  owner.ToAddress(),
+	})
+	assert.True(t, errors.IsOfType(ErrNFTMinted, err))
+	docSrv.AssertExpectations(t)
+}
@@ -1,8 +1,10 @@
 package nft
 
 import (
+	"bytes"
 	"context"
 	"encoding/json"
+	"fmt"
 	"math/big"
 	"strings"
 	"time"
@@ -14,7 +16,9 @@ import (
 	"github.com/centrifuge/go-centrifuge/ethereum"
 	"github.com/centrifuge/go-centrifuge/identity"
 	"github.com/centrifuge/go-centrifuge/jobs"
+	"github.com/centrifuge/go-centrifuge/notification"
 	"github.com/centrifuge/go-centrifuge/queue"
+	"github.com/centrifuge/go-centrifuge/storage"
 	"github.com/centrifuge/go-centrifuge/utils"
 	"github.com/centrifuge/precise-proofs/proofs"
 	"github.com/centrifuge/precise-proofs/proofs/proto"
@@ -36,6 +40,9 @@ const (
 	// GenericMintMethodABI constant interface to interact with mint methods
 	GenericMintMethodABI = `[{"constant":false,"inputs":[{"internalType":"address","name":"to","type":"address"},{"internalType":"uint256","name":"tkn","type":"uint256"},{"internalType":"bytes32","name":"dataRoot","type":"bytes32"},{"internalType":"bytes[]","name":"properties","type":"bytes[]"},{"internalType":"bytes[]","name":"values","type":"bytes[]"},{"internalType":"bytes32[]","name":"salts","type":"bytes32[]"}],"name":"mint","outputs":[],"payable":false,"stateMutability":"nonpayable","type":"function"}]`
 
+	// BurnMethodABI constant interface to interact with the burn method of registries
+	BurnMethodABI = `[{"constant":false,"inputs":[{"internalType":"uint256","name":"tokenId","type":"uint256"}],"name":"burn","outputs":[],"payable":false,"stateMutability":"nonpayable","type":"function"}]`
+
 	// AssetStoredEventSignature used for finding events
 	AssetStoredEventSignature = "AssetStored(bytes32)"
 
@@ -46,18 +53,27 @@ const (
 // nftABI is the default abi for caller functions on NFT registry
 var nftABI abi.ABI
 
+// erc1155ABI is the abi for caller functions on ERC-1155 registries
+var erc1155ABI abi.ABI
+
 func init() {
 	var err error
 	nftABI, err = abi.JSON(strings.NewReader(ABI))
 	if err != nil {
 		log.Fatalf("failed to decode NFT ABI: %v", err)
 	}
+
+	erc1155ABI, err = abi.JSON(strings.NewReader(ERC1155ABI))
+	if err != nil {
+		log.Fatalf("failed to decode ERC-1155 ABI: %v", err)
+	}
 }
 
 // Config is the config interface for nft package
 type Config interface {
 	GetEthereumContextWaitTimeout() time.Duration
 	GetLowEntropyNFTTokenEnabled() bool
+	GetNFTRegistryAdapters() map[string]string
 }
 
 // service handles all interactions related to minting of NFTs for unpaid invoices on Ethereum
@@ -71,6 +87,7 @@ type service struct {
 	jobsManager        jobs.Manager
 	api                API
 	blockHeightFunc    func() (height uint64, err error)
+	repo               storage.Repository
 }
 
 // newService creates InvoiceUnpaid given the parameters
@@ -83,7 +100,8 @@ func newService(
 	bindCallerContract func(address common.Address, abi abi.ABI, client ethereum.Client) *bind.BoundContract,
 	jobsMan jobs.Manager,
 	api API,
-	blockHeightFunc func() (uint64, error)) *service {
+	blockHeightFunc func() (uint64, error),
+	repo storage.Repository) *service {
 	return &service{
 		cfg:                cfg,
 		identityService:    identityService,
@@ -94,6 +112,7 @@ func newService(
 		jobsManager:        jobsMan,
 		blockHeightFunc:    blockHeightFunc,
 		api:                api,
+		repo:               repo,
 	}
 }
 
@@ -108,6 +127,11 @@ func (s *service) prepareMintRequest(ctx context.Context, tokenID TokenID, cid i
 		return mreq, err
 	}
 
+	return newMintRequestFromProofs(tokenID, cid, model, docProofs, req)
+}
+
+// newMintRequestFromProofs builds the mint request of the token from the proofs of the fields of the model.
+func newMintRequestFromProofs(tokenID TokenID, cid identity.DID, model documents.Model, docProofs *documents.DocumentProof, req MintNFTRequest) (mreq MintRequest, err error) {
 	pfs, err := model.CreateNFTProofs(cid,
 		req.RegistryAddress,
 		tokenID[:],
@@ -173,13 +197,7 @@ func (s *service) MintNFT(ctx context.Context, req MintNFTRequest) (*TokenRespon
 		return nil, nil, errors.New("enable grant_nft_access to generate Read Access Proof")
 	}
 
-	tokenID := NewTokenID()
-	if s.cfg.GetLowEntropyNFTTokenEnabled() {
-		log.Warningf("Security consideration: Using a reduced maximum of %s integer for NFT token ID generation. "+
-			"Suggested course of action: disable by setting nft.lowentropy=false in config.yaml file", LowEntropyTokenIDMax)
-		tokenID = NewLowEntropyTokenID()
-	}
-
+	tokenID := s.newTokenID()
 	model, err := s.docSrv.GetCurrentVersion(ctx, req.DocumentID)
 	if err != nil {
 		return nil, nil, err
@@ -200,7 +218,7 @@ func (s *service) MintNFT(ctx context.Context, req MintNFTRequest) (*TokenRespon
 	}
 
 	jobID, done, err := s.jobsManager.ExecuteWithinJob(contextutil.Copy(ctx), did, jobs.NilJobID(), "Minting NFT",
-		s.minterJob(ctx, tokenID, model, req))
+		s.minterJob(tokenID, model, req, nil))
 
 	if err != nil {
 		return nil, nil, err
@@ -212,6 +230,62 @@ func (s *service) MintNFT(ctx context.Context, req MintNFTRequest) (*TokenRespon
 	}, done, nil
 }
 
+// newTokenID returns a new token ID, with low entropy if enabled in the config.
+func (s *service) newTokenID() TokenID {
+	if !s.cfg.GetLowEntropyNFTTokenEnabled() {
+		return NewTokenID()
+	}
+
+	log.Warningf("Security consideration: Using a reduced maximum of %s integer for NFT token ID generation. "+
+		"Suggested course of action: disable by setting nft.lowentropy=false in config.yaml file", LowEntropyTokenIDMax)
+	return NewLowEntropyTokenID()
+}
+
+// RemintNFT burns the NFT of the document and mints a new NFT in the same registry against the latest version of the document.
+func (s *service) RemintNFT(ctx context.Context, req RemintNFTRequest) (*TokenResponse, chan error, error) {
+	did, err := contextutil.AccountDID(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if !req.GrantNFTReadAccess && req.SubmitNFTReadAccessProof {
+		return nil, nil, errors.New("enable grant_nft_access to generate Read Access Proof")
+	}
+
+	model, err := s.docSrv.GetCurrentVersion(ctx, req.DocumentID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if !hasNFT(model, req.RegistryAddress, req.TokenID) {
+		return nil, nil, errors.NewTypedError(documents.ErrNftNotFound, errors.New("token %s in registry %s",
+			req.TokenID.String(), req.RegistryAddress.Hex()))
+	}
+
+	tokenID := s.newTokenID()
+	jobID, done, err := s.jobsManager.ExecuteWithinJob(contextutil.Copy(ctx), did, jobs.NilJobID(), "Reminting NFT",
+		s.reminterJob(tokenID, model, req))
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return &TokenResponse{
+		JobID:   jobID.String(),
+		TokenID: tokenID.String(),
+	}, done, nil
+}
+
+// hasNFT returns true if the token of the registry is the NFT of the document.
+func hasNFT(model documents.Model, registry common.Address, tokenID TokenID) bool {
+	for _, n := range model.NFTs() {
+		if bytes.Equal(n.RegistryId[:common.AddressLength], registry.Bytes()) && bytes.Equal(n.TokenId, tokenID[:]) {
+			return true
+		}
+	}
+
+	return false
+}
+
 // TransferFrom transfers an NFT to another address
 func (s *service) TransferFrom(ctx context.Context, registry common.Address, to common.Address, tokenID TokenID) (*TokenResponse, chan error, error) {
 	tc, err := contextutil.Account(ctx)
@@ -226,7 +300,7 @@ func (s *service) TransferFrom(ctx context.Context, registry common.Address, to
 	}
 
 	jobID, done, err := s.jobsManager.ExecuteWithinJob(contextutil.Copy(ctx), did, jobs.NilJobID(), "Transfer From NFT",
-		s.transferFromJob(ctx, registry, did.ToAddress(), to, tokenID))
+		s.transferFromJob(registry, did.ToAddress(), to, tokenID))
 	if err != nil {
 		return nil, nil, err
 	}
@@ -237,16 +311,23 @@ func (s *service) TransferFrom(ctx context.Context, registry common.Address, to
 	}, done, nil
 }
 
-func (s *service) minterJob(ctx context.Context, tokenID TokenID, model documents.Model, req MintNFTRequest) func(accountID identity.DID, txID jobs.JobID, txMan jobs.Manager, errOut chan<- error) {
-	return func(accountID identity.DID, jobID jobs.JobID, txMan jobs.Manager, errOut chan<- error) {
-		err := model.AddNFT(req.GrantNFTReadAccess, req.RegistryAddress, tokenID[:])
+// minterJob mints the token against the document. replaces is the burned token the new token replaces, if any.
+func (s *service) minterJob(tokenID TokenID, model documents.Model, req MintNFTRequest, replaces []byte) func(ctx context.Context, accountID identity.DID, txID jobs.JobID, txMan jobs.Manager, errOut chan<- error) {
+	return func(ctx context.Context, accountID identity.DID, jobID jobs.JobID, txMan jobs.Manager, errOut chan<- error) {
+		adapter, err := s.registryAdapter(req.RegistryAddress)
+		if err != nil {
+			errOut <- err
+			return
+		}
+
+		err = model.AddNFT(req.GrantNFTReadAccess, req.RegistryAddress, tokenID[:])
 		if err != nil {
 			errOut <- err
 			return
 		}
 
 		jobCtx := contextutil.WithJob(ctx, jobID)
-		_, _, done, err := s.docSrv.Update(jobCtx, model)
+		updated, _, done, err := s.docSrv.Update(jobCtx, model)
 		if err != nil {
 			errOut <- err
 			return
@@ -265,15 +346,19 @@ func (s *service) minterJob(ctx context.Context, tokenID TokenID, model document
 			return
 		}
 
-		subProofs := toSubstrateProofs(requestData.Props, requestData.Values, requestData.Salts, requestData.Proofs)
-		staticProofs := [3][32]byte{requestData.LeftDataRoot, requestData.RightDataRoot, requestData.SignaturesRoot}
 		block, err := s.ethClient.GetEthClient().BlockByNumber(context.Background(), nil)
 		if err != nil {
 			errOut <- errors.New("failed to get latest block: %v", err)
 			return
 		}
 
-		done, err = s.api.ValidateNFT(ctx, requestData.AnchorID, requestData.To, subProofs, staticProofs)
+		// stop before the transactions once the job is cancelled
+		if ctx.Err() != nil {
+			errOut <- ctx.Err()
+			return
+		}
+
+		done, err = s.api.ValidateNFT(ctx, requestData.AnchorID, requestData.To, requestData.substrateProofs(), requestData.staticProofs())
 		if err != nil {
 			errOut <- err
 			return
@@ -306,10 +391,13 @@ func (s *service) minterJob(ctx context.Context, tokenID TokenID, model document
 			log.Infof("Asset successfully deposited with TX hash: %v\n", txHash.String())
 		}
 
-		// to common.Address, tokenId *big.Int, bytes32, properties [][]byte, values [][]byte, salts [][32]byte
-		args := []interface{}{requestData.To, requestData.TokenID, requestData.SigningRoot, requestData.Props, requestData.Values, requestData.Salts}
+		if ctx.Err() != nil {
+			errOut <- ctx.Err()
+			return
+		}
 
-		txID, done, err := s.identityService.Execute(ctx, req.RegistryAddress, GenericMintMethodABI, "mint", args...)
+		call := adapter.Mint(requestData)
+		txID, done, err := s.identityService.Execute(ctx, req.RegistryAddress, call.ABI, call.Method, call.Args...)
 		if err != nil {
 			errOut <- err
 			return
@@ -341,36 +429,174 @@ func (s *service) minterJob(ctx context.Context, tokenID TokenID, model document
 		}
 
 		// Check if tokenID exists in registry and owner is deposit address
-		owner, err := s.OwnerOf(req.RegistryAddress, tokenID[:])
+		owned, err := s.IsOwner(req.RegistryAddress, req.DepositAddress, tokenID[:])
 		if err != nil {
 			errOut <- errors.New("error while checking new NFT owner %v", err)
 			return
 		}
-		if owner.Hex() != req.DepositAddress.Hex() {
-			errOut <- errors.New("Owner for tokenID %s should be %s, instead got %s", tokenID.String(), req.DepositAddress.Hex(), owner.Hex())
+		if !owned {
+			errOut <- errors.New("Owner for tokenID %s should be %s", tokenID.String(), req.DepositAddress.Hex())
 			return
 		}
 
 		log.Infof("Document %s minted successfully within transaction %s", hexutil.Encode(req.DocumentID), txID)
+		err = s.saveToken(&Token{
+			Registry:       req.RegistryAddress,
+			TokenID:        tokenID[:],
+			AccountID:      accountID,
+			DocumentID:     req.DocumentID,
+			VersionID:      updated.CurrentVersion(),
+			AnchorID:       requestData.AnchorID[:],
+			DepositAddress: req.DepositAddress,
+			ProofFields:    req.ProofFields,
+			JobID:          jobID.String(),
+			MintedAt:       time.Now().UTC(),
+			Replaces:       replaces,
+		})
+		if err != nil {
+			// the token is minted, only the inventory record is missing
+			log.Errorf("failed to save token %s to the inventory: %v", tokenID.String(), err)
+		}
+
+		notification.Notify(ctx, notification.Message{
+			EventType:  notification.NFTMinted,
+			AccountID:  accountID.String(),
+			Recorded:   time.Now().UTC(),
+			DocumentID: hexutil.Encode(req.DocumentID),
+			Status:     string(jobs.Success),
+			Message:    fmt.Sprintf("minted token %s in registry %s", tokenID.String(), req.RegistryAddress.Hex()),
+			ToID:       req.DepositAddress.Hex(),
+			Data: notification.NFTData{
+				Registry: req.RegistryAddress.Hex(),
+				TokenID:  tokenID.String(),
+				To:       req.DepositAddress.Hex(),
+				JobID:    jobID.String(),
+				Replaces: encodeTokenID(replaces),
+			},
+		})
 
 		errOut <- nil
 		return
 	}
 }
 
-func (s *service) transferFromJob(ctx context.Context, registry common.Address, from common.Address, to common.Address, tokenID TokenID) func(accountID identity.DID, txID jobs.JobID, txMan jobs.Manager, errOut chan<- error) {
-	return func(accountID identity.DID, jobID jobs.JobID, txMan jobs.Manager, errOut chan<- error) {
-		owner, err := s.OwnerOf(registry, tokenID[:])
+// reminterJob burns the token of the request and mints the new token against the latest version of the document.
+func (s *service) reminterJob(tokenID TokenID, model documents.Model, req RemintNFTRequest) func(ctx context.Context, accountID identity.DID, txID jobs.JobID, txMan jobs.Manager, errOut chan<- error) {
+	return func(ctx context.Context, accountID identity.DID, jobID jobs.JobID, txMan jobs.Manager, errOut chan<- error) {
+		registry := req.RegistryAddress
+		adapter, err := s.registryAdapter(registry)
+		if err != nil {
+			errOut <- err
+			return
+		}
+
+		owner := accountID.ToAddress()
+		owned, err := s.IsOwner(registry, owner, req.TokenID[:])
+		if err != nil {
+			errOut <- errors.New("error while checking NFT owner %v", err)
+			return
+		}
+
+		// only the owner can burn the token
+		if !owned {
+			errOut <- errors.New("identity %s must own tokenID %s to burn it", accountID.String(), req.TokenID.String())
+			return
+		}
+
+		// the burn can't be undone, so the proofs of the mint are checked against the document first.
+		// The new token isn't in the document yet, the burned token stands in for it in the NFT proofs.
+		// The read access of the new token is only granted in the new version, so its proof isn't checked here.
+		check := req.MintNFTRequest
+		check.SubmitNFTReadAccessProof = false
+		_, _, err = s.buildMintRequest(ctx, req.TokenID, accountID, model, check)
+		if err != nil {
+			errOut <- errors.New("failed to prepare mint request: %v", err)
+			return
+		}
+
+		if ctx.Err() != nil {
+			errOut <- ctx.Err()
+			return
+		}
+
+		call := adapter.Burn(owner, req.TokenID.BigInt())
+		txID, done, err := s.identityService.Execute(ctx, registry, call.ABI, call.Method, call.Args...)
+		if err != nil {
+			errOut <- err
+			return
+		}
+		log.Infof("sent off ethTX to burn [registry: %s tokenID: %s].", registry.String(), req.TokenID.String())
+
+		err = <-done
+		if err != nil {
+			// some problem occurred in a child task
+			errOut <- errors.New("failed to burn token with transaction: %s with error %s", txID, err.Error())
+			return
+		}
+
+		// burned tokens have no owner
+		if owned, err := s.isOwner(registry, owner, req.TokenID[:]); err == nil && owned {
+			errOut <- errors.New("tokenID %s still exists after burn", req.TokenID.String())
+			return
+		}
+
+		err = s.retireToken(accountID, req, tokenID)
+		if err != nil {
+			// the token is burned, only the inventory record is stale
+			log.Errorf("failed to mark token %s as burned in the inventory: %v", req.TokenID.String(), err)
+		}
+
+		log.Infof("token %s burned with transaction %s, minting %s", req.TokenID.String(), txID, tokenID.String())
+		notification.Notify(ctx, notification.Message{
+			EventType:  notification.NFTBurned,
+			AccountID:  accountID.String(),
+			Recorded:   time.Now().UTC(),
+			DocumentID: hexutil.Encode(req.DocumentID),
+			Status:     string(jobs.Success),
+			Message:    fmt.Sprintf("burned token %s in registry %s", req.TokenID.String(), registry.Hex()),
+			FromID:     owner.Hex(),
+			Data: notification.NFTData{
+				Registry:   registry.Hex(),
+				TokenID:    req.TokenID.String(),
+				From:       owner.Hex(),
+				JobID:      jobID.String(),
+				ReplacedBy: tokenID.String(),
+			},
+		})
+
+		// the new token replaces the burned token in the NFTs of the document
+		s.minterJob(tokenID, model, req.MintNFTRequest, req.TokenID[:])(ctx, accountID, jobID, txMan, errOut)
+	}
+}
+
+func encodeTokenID(tokenID []byte) string {
+	if len(tokenID) == 0 {
+		return ""
+	}
+
+	return hexutil.Encode(tokenID)
+}
+
+func (s *service) transferFromJob(registry common.Address, from common.Address, to common.Address, tokenID TokenID) func(ctx context.Context, accountID identity.DID, txID jobs.JobID, txMan jobs.Manager, errOut chan<- error) {
+	return func(ctx context.Context, accountID identity.DID, jobID jobs.JobID, txMan jobs.Manager, errOut chan<- error) {
+		adapter, err := s.registryAdapter(registry)
+		if err != nil {
+			errOut <- err
+			return
+		}
+
+		owned, err := s.IsOwner(registry, from, tokenID[:])
 		if err != nil {
 			errOut <- errors.New("error while checking new NFT owner %v", err)
 			return
 		}
-		if owner.Hex() != from.Hex() {
-			errOut <- errors.New("from address is not the owner of tokenID %s from should be %s, instead got %s", tokenID.String(), from.Hex(), owner.Hex())
+		if !owned {
+			errOut <- errors.New("from address is not the owner of tokenID %s from should be %s", tokenID.String(), from.Hex())
 			return
 		}
 
-		txID, done, err := s.identityService.Execute(ctx, registry, ABI, "transferFrom", from, to, utils.ByteSliceToBigInt(tokenID[:]))
+		call := adapter.Transfer(from, to, tokenID.BigInt())
+		txID, done, err := s.identityService.Execute(ctx, registry, call.ABI, call.Method, call.Args...)
 		if err != nil {
 			errOut <- err
 			return
@@ -386,17 +612,33 @@ func (s *service) transferFromJob(ctx context.Context, registry common.Address,
 		}
 
 		// Check if tokenID is new owner is to address
-		owner, err = s.OwnerOf(registry, tokenID[:])
+		owned, err = s.IsOwner(registry, to, tokenID[:])
 		if err != nil {
 			errOut <- errors.New("error while checking new NFT owner %v", err)
 			return
 		}
-		if owner.Hex() != to.Hex() {
-			errOut <- errors.New("new owner for tokenID %s should be %s, instead got %s", tokenID.String(), registry.Hex(), owner.Hex())
+		if !owned {
+			errOut <- errors.New("new owner for tokenID %s should be %s", tokenID.String(), to.Hex())
 			return
 		}
 
 		log.Infof("token %s successfully transferred from %s to %s with transaction %s ", tokenID.String(), from.Hex(), to.Hex(), txID)
+		notification.Notify(ctx, notification.Message{
+			EventType: notification.NFTTransferred,
+			AccountID: accountID.String(),
+			Recorded:  time.Now().UTC(),
+			Status:    string(jobs.Success),
+			Message:   fmt.Sprintf("transferred token %s in registry %s", tokenID.String(), registry.Hex()),
+			FromID:    from.Hex(),
+			ToID:      to.Hex(),
+			Data: notification.NFTData{
+				Registry: registry.Hex(),
+				TokenID:  tokenID.String(),
+				From:     from.Hex(),
+				To:       to.Hex(),
+				JobID:    jobID.String(),
+			},
+		})
 
 		errOut <- nil
 		return
@@ -404,41 +646,70 @@ func (s *service) transferFromJob(ctx context.Context, registry common.Address,
 }
 
 // OwnerOf returns the owner of the NFT token on ethereum chain
-func (s *service) OwnerOf(registry common.Address, tokenID []byte) (common.Address, error) {
-	var owner common.Address
-	var err error
-	var current int
+func (s *service) OwnerOf(registry common.Address, tokenID []byte) (owner common.Address, err error) {
+	err = retryOwnerCall(tokenID, func() error {
+		owner, err = s.ownerOf(registry, tokenID)
+		return err
+	})
+	return owner, err
+}
+
+// ownerOf returns the owner of the NFT token on ethereum chain without retrying.
+func (s *service) ownerOf(registry common.Address, tokenID []byte) (common.Address, error) {
+	adapter, err := s.registryAdapter(registry)
+	if err != nil {
+		return common.Address{}, err
+	}
+
+	return adapter.OwnerOf(s.registryCaller(registry), utils.ByteSliceToBigInt(tokenID))
+}
 
-	c := s.bindCallerContract(registry, nftABI, s.ethClient)
-	opts, cancF := s.ethClient.GetGethCallOpts(false)
-	defer cancF()
+// IsOwner returns true if the address owns the NFT token on ethereum chain
+func (s *service) IsOwner(registry, owner common.Address, tokenID []byte) (owned bool, err error) {
+	err = retryOwnerCall(tokenID, func() error {
+		owned, err = s.isOwner(registry, owner, tokenID)
+		return err
+	})
+	return owned, err
+}
+
+// isOwner returns true if the address owns the NFT token on ethereum chain without retrying.
+func (s *service) isOwner(registry, owner common.Address, tokenID []byte) (bool, error) {
+	adapter, err := s.registryAdapter(registry)
+	if err != nil {
+		return false, err
+	}
 
+	return adapter.IsOwner(s.registryCaller(registry), owner, utils.ByteSliceToBigInt(tokenID))
+}
+
+// ownerCallRetryInterval is the wait between the tries of an ownership call.
+var ownerCallRetryInterval = 2 * time.Second
+
+// retryOwnerCall calls the ownership call of the token until it succeeds, up to 10 tries.
+// Errors of the registry configuration and of registries without single owners are not retried.
+func retryOwnerCall(tokenID []byte, call func() error) error {
 	maxTries := 10
-	for {
-		current++
-		if current == maxTries {
-			return common.Address{}, errors.New("Error retrying getting NFT owner of tokenID %x: %v", tokenID, err)
+	var err error
+	for current := 1; current <= maxTries; current++ {
+		err = call()
+		if err == nil || errors.IsOfType(ErrOwnerUnknown, err) || errors.IsOfType(ErrUnknownRegistryAdapter, err) {
+			return err
 		}
-		err = c.Call(opts, &owner, "ownerOf", utils.ByteSliceToBigInt(tokenID))
-		if err != nil {
-			log.Warningf("[%d/%d] Error getting NFT owner for token [%x]: %v", current, maxTries, tokenID, err)
-			time.Sleep(2 * time.Second)
-			continue
+
+		log.Warningf("[%d/%d] Error checking NFT owner for token [%x]: %v", current, maxTries, tokenID, err)
+		if current < maxTries {
+			time.Sleep(ownerCallRetryInterval)
 		}
-		break
 	}
 
-	return owner, err
+	return errors.New("Error retrying checking NFT owner of tokenID %x: %v", tokenID, err)
 }
 
 // CurrentIndexOfToken returns the current index of the token in the given registry
 func (s *service) CurrentIndexOfToken(registry common.Address, tokenID []byte) (*big.Int, error) {
-	c := s.bindCallerContract(registry, nftABI, s.ethClient)
-	opts, cancF := s.ethClient.GetGethCallOpts(false)
-	defer cancF()
-
 	res := new(big.Int)
-	return res, c.Call(opts, res, "currentIndexOfToken", utils.ByteSliceToBigInt(tokenID))
+	return res, s.registryCaller(registry)(nftABI, res, "currentIndexOfToken", utils.ByteSliceToBigInt(tokenID))
 }
 
 // MintRequest holds the data needed to mint and NFT from a Centrifuge document
@@ -520,6 +791,16 @@ func NewMintRequest(
 		BundledHash:    bh}, nil
 }
 
+// substrateProofs returns the proofs of the ValidateNFT call on centchain.
+func (m MintRequest) substrateProofs() []SubstrateProof {
+	return toSubstrateProofs(m.Props, m.Values, m.Salts, m.Proofs)
+}
+
+// staticProofs returns the data roots and the signatures root of the ValidateNFT call on centchain.
+func (m MintRequest) staticProofs() [3][32]byte {
+	return [3][32]byte{m.LeftDataRoot, m.RightDataRoot, m.SignaturesRoot}
+}
+
 type proofData struct {
 	Props  [][]byte
 	Values [][]byte
@@ -3,6 +3,7 @@
 package nft
 
 import (
+	"context"
 	"math/big"
 	"testing"
 	"time"
@@ -14,6 +15,7 @@ import (
 	"github.com/centrifuge/go-centrifuge/errors"
 	"github.com/centrifuge/go-centrifuge/ethereum"
 	"github.com/centrifuge/go-centrifuge/jobs"
+	"github.com/centrifuge/go-centrifuge/notification"
 	"github.com/centrifuge/go-centrifuge/testingutils"
 	"github.com/centrifuge/go-centrifuge/testingutils/commons"
 	"github.com/centrifuge/go-centrifuge/testingutils/config"
@@ -200,7 +202,7 @@ func TestInvoiceUnpaid(t *testing.T) {
 			docService, paymentOb, idService, ethClient, mockCfg, queueSrv, txMan := test.mocker()
 			// with below config the documentType has to be test.name to avoid conflicts since registry is a singleton
 			queueSrv.On("EnqueueJobWithMaxTries", mock.Anything, mock.Anything).Return(nil, nil).Once()
-			service := newService(&mockCfg, &idService, &ethClient, queueSrv, &docService, ethereum.BindContract, txMan, nil, func() (uint64, error) { return 10, nil })
+			service := newService(&mockCfg, &idService, &ethClient, queueSrv, &docService, ethereum.BindContract, txMan, nil, func() (uint64, error) { return 10, nil }, nil)
 			ctxh := testingconfig.CreateAccountContext(t, &mockCfg)
 			req := MintNFTRequest{
 				DocumentID:      test.request.DocumentID,
@@ -242,7 +244,7 @@ func TestTokenTransfer(t *testing.T) {
 
 	idServiceMock := &testingcommons.MockIdentityService{}
 
-	service := newService(configMock, idServiceMock, nil, nil, nil, nil, jobMan, nil, nil)
+	service := newService(configMock, idServiceMock, nil, nil, nil, nil, jobMan, nil, nil, nil)
 	ctxh := testingconfig.CreateAccountContext(t, configMock)
 
 	registryAddress := common.HexToAddress("0x111855759a39fb75fc7341139f5d7a3974d4da08")
@@ -254,6 +256,52 @@ func TestTokenTransfer(t *testing.T) {
 	assert.Equal(t, jobID.String(), resp.JobID)
 }
 
+func TestService_transferFromJob(t *testing.T) {
+	did := accountDID(t)
+	tokenID := NewTokenID()
+	registry := common.HexToAddress("0x111855759a39fb75fc7341139f5d7a3974d4da08")
+	from, to := did.ToAddress(), common.HexToAddress("0x222855759a39fb75fc7341139f5d7a3974d4da08")
+	caller := &registryCaller{tokenID: tokenID.BigInt(), owner: from, index: big.NewInt(1)}
+	srv, _ := newInventoryService(t, nil, caller)
+	idSrv := new(testingcommons.MockIdentityService)
+	srv.identityService = idSrv
+	done := make(chan error, 1)
+	done <- nil
+	idSrv.On("Execute", mock.Anything, registry, mock.Anything, mock.Anything, mock.Anything).
+		Return(jobs.NewJobID(), done, nil).Run(func(args mock.Arguments) {
+		caller.owner = to
+	}).Once()
+	sub := notification.GetBroker().Subscribe(did.String(), notification.Filter{
+		Types: []notification.EventType{notification.NFTTransferred},
+	})
+	defer notification.GetBroker().Unsubscribe(sub)
+
+	// transferred token sends the event
+	jobID := jobs.NewJobID()
+	errOut := make(chan error, 1)
+	srv.transferFromJob(registry, from, to, tokenID)(context.Background(), did, jobID, nil, errOut)
+	assert.NoError(t, <-errOut)
+	select {
+	case msg := <-sub.Events():
+		assert.Equal(t, notification.NFTData{
+			Registry: registry.Hex(),
+			TokenID:  tokenID.String(),
+			From:     from.Hex(),
+			To:       to.Hex(),
+			JobID:    jobID.String(),
+		}, msg.Data)
+	case <-time.After(5 * time.Second):
+		assert.Fail(t, "timed out waiting for the transfer event")
+	}
+
+	// from doesn't own the token
+	srv.transferFromJob(registry, from, to, tokenID)(context.Background(), did, jobID, nil, errOut)
+	err := <-errOut
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "not the owner")
+	idSrv.AssertExpectations(t)
+}
+
 func getDummyProof(coreDoc *coredocumentpb.CoreDocument) *documents.DocumentProof {
 	v1, _ := hexutil.Decode("0x76616c756531")
 	v2, _ := hexutil.Decode("0x76616c756532")
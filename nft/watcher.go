@@ -0,0 +1,600 @@
+package nft
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"reflect"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/centrifuge/centrifuge-protobufs/gen/go/coredocument"
+	"github.com/centrifuge/go-centrifuge/bootstrap"
+	"github.com/centrifuge/go-centrifuge/config"
+	"github.com/centrifuge/go-centrifuge/contextutil"
+	"github.com/centrifuge/go-centrifuge/documents"
+	"github.com/centrifuge/go-centrifuge/errors"
+	"github.com/centrifuge/go-centrifuge/identity"
+	"github.com/centrifuge/go-centrifuge/jobs"
+	"github.com/centrifuge/go-centrifuge/notification"
+	"github.com/centrifuge/go-centrifuge/storage"
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+const (
+	// BootstrappedTransferWatcher is the key to the NFT transfer watcher in the bootstrap context.
+	BootstrappedTransferWatcher = "BootstrappedTransferWatcher"
+
+	// TransferEventSignature is the ERC-721 Transfer event of NFT registries
+	TransferEventSignature = "Transfer(address,address,uint256)"
+
+	// TransferSingleEventSignature is the ERC-1155 event of a transfer of a single token
+	TransferSingleEventSignature = "TransferSingle(address,address,address,uint256,uint256)"
+
+	// TransferBatchEventSignature is the ERC-1155 event of a transfer of several tokens
+	TransferBatchEventSignature = "TransferBatch(address,address,address,uint256[],uint256[])"
+
+	transferPrefix   = "nft_transfer_"
+	watcherCursorKey = "nft_transfer_watcher_cursor"
+
+	// maxWatchedBlocks is the maximum number of blocks filtered in a single round of the watcher
+	maxWatchedBlocks = 5000
+
+	// TransferKindTransfer is the kind of a transfer between two owners
+	TransferKindTransfer = "transfer"
+
+	// TransferKindMint is the kind of a transfer from the zero address
+	TransferKindMint = "mint"
+
+	// TransferKindBurn is the kind of a transfer to the zero address
+	TransferKindBurn = "burn"
+)
+
+var (
+	// transferTopic is the topic of the Transfer event
+	transferTopic = common.BytesToHash(crypto.Keccak256([]byte(TransferEventSignature)))
+
+	// transferSingleTopic is the topic of the TransferSingle event
+	transferSingleTopic = common.BytesToHash(crypto.Keccak256([]byte(TransferSingleEventSignature)))
+
+	// transferBatchTopic is the topic of the TransferBatch event
+	transferBatchTopic = common.BytesToHash(crypto.Keccak256([]byte(TransferBatchEventSignature)))
+)
+
+// Transfer is the ownership history record of an NFT transfer observed on chain.
+type Transfer struct {
+	Registry    common.Address `json:"registry"`
+	TokenID     hexutil.Bytes  `json:"token_id"`
+	AccountID   identity.DID   `json:"account_id"`
+	DocumentID  hexutil.Bytes  `json:"document_id"`
+	From        common.Address `json:"from"`
+	To          common.Address `json:"to"`
+	BlockNumber uint64         `json:"block_number"`
+	TxHash      common.Hash    `json:"tx_hash"`
+	LogIndex    uint           `json:"log_index"`
+	ObservedAt  time.Time      `json:"observed_at"`
+
+	// Kind is the kind of the transfer: TransferKindTransfer, TransferKindMint or TransferKindBurn
+	Kind string `json:"kind"`
+
+	// DocumentSent is true if the document was sent to the Centrifuge identity of the new owner
+	DocumentSent bool `json:"document_sent"`
+}
+
+// JSON marshals the transfer to json bytes.
+func (t *Transfer) JSON() ([]byte, error) {
+	return json.Marshal(t)
+}
+
+// FromJSON loads the transfer from json bytes.
+func (t *Transfer) FromJSON(data []byte) error {
+	return json.Unmarshal(data, t)
+}
+
+// Type returns the type of the Transfer.
+func (t *Transfer) Type() reflect.Type {
+	return reflect.TypeOf(t)
+}
+
+// watcherCursor is the last block processed by the transfer watcher.
+type watcherCursor struct {
+	Block uint64 `json:"block"`
+}
+
+// JSON marshals the cursor to json bytes.
+func (c *watcherCursor) JSON() ([]byte, error) {
+	return json.Marshal(c)
+}
+
+// FromJSON loads the cursor from json bytes.
+func (c *watcherCursor) FromJSON(data []byte) error {
+	return json.Unmarshal(data, c)
+}
+
+// Type returns the type of the watcherCursor.
+func (c *watcherCursor) Type() reflect.Type {
+	return reflect.TypeOf(c)
+}
+
+func tokenTransfersPrefix(accountID identity.DID, registry common.Address, tokenID []byte) string {
+	return transferPrefix + accountID.String() + "_" + registry.Hex() + "_" + hexutil.Encode(tokenID) + "_"
+}
+
+func transferKey(t *Transfer) []byte {
+	return []byte(fmt.Sprintf("%s%020d_%010d", tokenTransfersPrefix(t.AccountID, t.Registry, t.TokenID), t.BlockNumber, t.LogIndex))
+}
+
+// GetTokenTransfers returns the observed transfers of the NFT minted by the account or linked to its documents, oldest first.
+func (s *service) GetTokenTransfers(ctx context.Context, registry common.Address, tokenID TokenID) ([]Transfer, error) {
+	did, err := contextutil.AccountDID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	models, err := s.repo.GetAllByPrefix(tokenTransfersPrefix(did, registry, tokenID[:]))
+	if err != nil {
+		return nil, err
+	}
+
+	// transfers of the tokens linked to the documents of the account are recorded without an inventory record
+	if len(models) == 0 && !s.repo.Exists(tokenKey(did, registry, tokenID[:])) {
+		return nil, ErrTokenNotFound
+	}
+
+	transfers := make([]Transfer, 0, len(models))
+	for _, m := range models {
+		transfers = append(transfers, *m.(*Transfer))
+	}
+
+	sort.Slice(transfers, func(i, j int) bool {
+		if transfers[i].BlockNumber != transfers[j].BlockNumber {
+			return transfers[i].BlockNumber < transfers[j].BlockNumber
+		}
+
+		return transfers[i].LogIndex < transfers[j].LogIndex
+	})
+
+	return transfers, nil
+}
+
+// WatcherConfig is the config of the transfer watcher.
+type WatcherConfig interface {
+	GetNFTTransferWatchInterval() time.Duration
+	GetNFTTransferPushDocuments() bool
+	GetEthereumConfirmationDepth() int
+}
+
+// documentSender sends a document to a Centrifuge identity.
+type documentSender interface {
+	Send(ctx context.Context, cd coredocumentpb.CoreDocument, recipient identity.DID) error
+}
+
+// transferWatcher follows the Transfer events of the NFTs minted by the node accounts and of the NFTs linked to their documents.
+// Every transfer is recorded in the ownership history of the token. Transfers and burns are notified to the account.
+// If enabled, the document is sent to the new owner if it is a Centrifuge identity the NFT grants read access to.
+// Read access of the previous owner is checked on chain on every request, so it ends with the transfer.
+type transferWatcher struct {
+	cfg           WatcherConfig
+	repo          storage.Repository
+	configSrv     config.Service
+	docSrv        documents.Service
+	idSrv         identity.Service
+	tokenRegistry documents.TokenRegistry
+	sender        documentSender
+	blockHeight   func() (uint64, error)
+	filterLogs    func(ctx context.Context, query ethereum.FilterQuery) ([]types.Log, error)
+	ethContext    func() (ctx context.Context, cancelFunc context.CancelFunc)
+
+	// linked are the NFTs of the documents of the accounts that are not in the inventory
+	linked []*Token
+}
+
+// Name returns the name of the transfer watcher server.
+func (w *transferWatcher) Name() string {
+	return "NFTTransferWatcher"
+}
+
+// Start watches the transfers every watch interval until the context is done.
+func (w *transferWatcher) Start(ctx context.Context, wg *sync.WaitGroup, startupErr chan<- error) {
+	defer wg.Done()
+	interval := w.cfg.GetNFTTransferWatchInterval()
+	if interval <= 0 {
+		log.Info("NFT transfer watcher disabled")
+		<-ctx.Done()
+		return
+	}
+
+	// the anchor processor is bootstrapped after the NFT service
+	if cctx, ok := ctx.Value(bootstrap.NodeObjRegistry).(map[string]interface{}); ok && w.sender == nil {
+		w.sender, _ = cctx[documents.BootstrappedAnchorProcessor].(documentSender)
+	}
+
+	if err := w.linkDocumentTokens(); err != nil {
+		log.Errorf("failed to find the NFTs of the documents: %v", err)
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			log.Info("NFT transfer watcher stopped")
+			return
+		case <-ticker.C:
+			if err := w.watch(); err != nil {
+				log.Errorf("failed to watch NFT transfers: %v", err)
+			}
+		}
+	}
+}
+
+// watch records the transfers of the watched tokens since the last processed block up to the confirmation depth.
+// The first round only marks the latest confirmed block, transfers before the watcher started are not recorded.
+func (w *transferWatcher) watch() error {
+	head, err := w.blockHeight()
+	if err != nil {
+		return errors.New("failed to get latest block: %v", err)
+	}
+
+	// reorged transfers are not recorded
+	depth := uint64(w.cfg.GetEthereumConfirmationDepth())
+	if depth > 1 {
+		if head+1 < depth {
+			return nil
+		}
+
+		head = head + 1 - depth
+	}
+
+	var from uint64
+	if m, err := w.repo.Get([]byte(watcherCursorKey)); err == nil {
+		from = m.(*watcherCursor).Block + 1
+	} else {
+		from = head + 1
+	}
+
+	to := head
+	if from+maxWatchedBlocks <= to {
+		to = from + maxWatchedBlocks - 1
+	}
+
+	batch := storage.NewBatch()
+	batch.Put([]byte(watcherCursorKey), &watcherCursor{Block: to})
+	if from > to {
+		return w.repo.Write(batch)
+	}
+
+	tokens, registries, err := w.watchedTokens()
+	if err != nil {
+		return err
+	}
+
+	var logs []types.Log
+	if len(registries) > 0 {
+		ctx, cancel := w.ethContext()
+		defer cancel()
+		logs, err = w.filterLogs(ctx, ethereum.FilterQuery{
+			FromBlock: new(big.Int).SetUint64(from),
+			ToBlock:   new(big.Int).SetUint64(to),
+			Addresses: registries,
+			Topics:    [][]common.Hash{{transferTopic, transferSingleTopic, transferBatchTopic}},
+		})
+		if err != nil {
+			return errors.New("failed to filter transfer logs: %v", err)
+		}
+	}
+
+	var transfers []*Transfer
+	for _, l := range logs {
+		if l.Removed {
+			continue
+		}
+
+		from, to, tokenIDs, err := transferredTokens(l)
+		if err != nil {
+			log.Warningf("skipping transfer log %d of transaction %s: %v", l.Index, l.TxHash.Hex(), err)
+			continue
+		}
+
+		kind := TransferKindTransfer
+		switch {
+		case from == common.Address{}:
+			kind = TransferKindMint
+		case to == common.Address{}:
+			kind = TransferKindBurn
+		}
+
+		for _, tokenID := range tokenIDs {
+			for _, token := range tokens[watchKey(l.Address, tokenID)] {
+				t := &Transfer{
+					Registry:    token.Registry,
+					TokenID:     token.TokenID,
+					AccountID:   token.AccountID,
+					DocumentID:  token.DocumentID,
+					From:        from,
+					To:          to,
+					BlockNumber: l.BlockNumber,
+					TxHash:      l.TxHash,
+					LogIndex:    l.Index,
+					ObservedAt:  time.Now().UTC(),
+					Kind:        kind,
+				}
+				batch.Put(transferKey(t), t)
+				transfers = append(transfers, t)
+			}
+		}
+	}
+
+	err = w.repo.Write(batch)
+	if err != nil {
+		return err
+	}
+
+	for _, t := range transfers {
+		w.handleTransfer(t)
+	}
+
+	return nil
+}
+
+// transferredTokens returns the from, the to and the tokens transferred by the log.
+// ERC-721 transfers index the from, the to and the token. ERC-1155 transfers index the operator, the from and the to,
+// the tokens and their amounts are in the data. ERC-20 transfers don't index a token and are ignored.
+func transferredTokens(l types.Log) (from, to common.Address, tokenIDs [][]byte, err error) {
+	if len(l.Topics) != 4 {
+		return from, to, nil, nil
+	}
+
+	switch l.Topics[0] {
+	case transferTopic:
+		return common.BytesToAddress(l.Topics[1].Bytes()), common.BytesToAddress(l.Topics[2].Bytes()), [][]byte{l.Topics[3].Bytes()}, nil
+	case transferSingleTopic:
+		var ev struct {
+			ID    *big.Int `abi:"id"`
+			Value *big.Int `abi:"value"`
+		}
+		if err := erc1155ABI.Unpack(&ev, "TransferSingle", l.Data); err != nil {
+			return from, to, nil, err
+		}
+
+		if ev.Value.Sign() > 0 {
+			tokenIDs = append(tokenIDs, ev.ID.Bytes())
+		}
+	case transferBatchTopic:
+		var ev struct {
+			IDs    []*big.Int `abi:"ids"`
+			Values []*big.Int `abi:"values"`
+		}
+		if err := erc1155ABI.Unpack(&ev, "TransferBatch", l.Data); err != nil {
+			return from, to, nil, err
+		}
+
+		if len(ev.IDs) != len(ev.Values) {
+			return from, to, nil, errors.New("%d tokens with %d amounts", len(ev.IDs), len(ev.Values))
+		}
+
+		for i, id := range ev.IDs {
+			if ev.Values[i].Sign() > 0 {
+				tokenIDs = append(tokenIDs, id.Bytes())
+			}
+		}
+	default:
+		return from, to, nil, nil
+	}
+
+	return common.BytesToAddress(l.Topics[2].Bytes()), common.BytesToAddress(l.Topics[3].Bytes()), tokenIDs, nil
+}
+
+func watchKey(registry common.Address, tokenID []byte) string {
+	return registry.Hex() + hexutil.Encode(common.LeftPadBytes(tokenID, 32))
+}
+
+// watchedTokens returns the tokens of all the accounts that are not burned, by registry and token, and their registries.
+// The inventory records replace the linked tokens of the same account.
+func (w *transferWatcher) watchedTokens() (map[string][]*Token, []common.Address, error) {
+	models, err := w.repo.GetAllByPrefix(tokenPrefix)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	inventory := make(map[string]bool)
+	all := make([]*Token, 0, len(models)+len(w.linked))
+	for _, m := range models {
+		token := m.(*Token)
+		inventory[string(tokenKey(token.AccountID, token.Registry, token.TokenID))] = true
+		all = append(all, token)
+	}
+
+	for _, token := range w.linked {
+		if !inventory[string(tokenKey(token.AccountID, token.Registry, token.TokenID))] {
+			all = append(all, token)
+		}
+	}
+
+	tokens := make(map[string][]*Token)
+	var registries []common.Address
+	for _, token := range all {
+		if !token.BurnedAt.IsZero() {
+			continue
+		}
+
+		if !containsAddress(registries, token.Registry) {
+			registries = append(registries, token.Registry)
+		}
+
+		key := watchKey(token.Registry, token.TokenID)
+		tokens[key] = append(tokens[key], token)
+	}
+
+	return tokens, registries, nil
+}
+
+// linkDocumentTokens finds the NFTs of the current versions of the documents of the accounts that are not in the inventory,
+// like the NFTs minted before the inventory existed or minted by the collaborators.
+func (w *transferWatcher) linkDocumentTokens() error {
+	accs, err := w.configSrv.GetAccounts()
+	if err != nil {
+		return err
+	}
+
+	var linked []*Token
+	for _, acc := range accs {
+		did, err := identity.NewDIDFromBytes(acc.GetIdentityID())
+		if err != nil {
+			return err
+		}
+
+		ctx, err := contextutil.New(context.Background(), acc)
+		if err != nil {
+			return err
+		}
+
+		// every version of the documents of the account is stored under its prefix
+		models, err := w.repo.GetAllByPrefix(documents.DocPrefix + hexutil.Encode(did[:]))
+		if err != nil {
+			return err
+		}
+
+		docs := make(map[string]bool)
+		for _, m := range models {
+			doc, ok := m.(documents.Model)
+			if !ok || docs[hexutil.Encode(doc.ID())] {
+				continue
+			}
+			docs[hexutil.Encode(doc.ID())] = true
+
+			model, err := w.docSrv.GetCurrentVersion(ctx, doc.ID())
+			if err != nil {
+				log.Warningf("failed to get document %s of account %s: %v", hexutil.Encode(doc.ID()), did.String(), err)
+				continue
+			}
+
+			for _, n := range model.NFTs() {
+				registry := common.BytesToAddress(n.RegistryId[:common.AddressLength])
+				if w.repo.Exists(tokenKey(did, registry, n.TokenId)) {
+					continue
+				}
+
+				linked = append(linked, &Token{
+					Registry:   registry,
+					TokenID:    n.TokenId,
+					AccountID:  did,
+					DocumentID: model.ID(),
+					VersionID:  model.CurrentVersion(),
+				})
+			}
+		}
+	}
+
+	w.linked = linked
+	log.Infof("watching %d NFT(s) of the documents not minted through the inventory", len(linked))
+	return nil
+}
+
+func containsAddress(addrs []common.Address, addr common.Address) bool {
+	for _, a := range addrs {
+		if bytes.Equal(a.Bytes(), addr.Bytes()) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// handleTransfer notifies the account of the transfer and sends the document to the new owner if enabled.
+func (w *transferWatcher) handleTransfer(t *Transfer) {
+	acc, err := w.configSrv.GetAccount(t.AccountID[:])
+	if err != nil {
+		log.Errorf("failed to get account %s of token %s: %v", t.AccountID.String(), hexutil.Encode(t.TokenID), err)
+		return
+	}
+
+	ctx, err := contextutil.New(context.Background(), acc)
+	if err != nil {
+		log.Errorf("failed to create context of account %s: %v", t.AccountID.String(), err)
+		return
+	}
+
+	// mints are notified by the minting job, the watcher only records them
+	if t.Kind == TransferKindMint {
+		log.Infof("token %s of registry %s minted to %s", hexutil.Encode(t.TokenID), t.Registry.Hex(), t.To.Hex())
+		return
+	}
+
+	event := notification.NFTTransferred
+	if t.Kind == TransferKindBurn {
+		event = notification.NFTBurned
+	}
+
+	log.Infof("token %s of registry %s transferred from %s to %s", hexutil.Encode(t.TokenID), t.Registry.Hex(), t.From.Hex(), t.To.Hex())
+	notification.Notify(ctx, notification.Message{
+		EventType:  event,
+		AccountID:  t.AccountID.String(),
+		Recorded:   time.Now().UTC(),
+		DocumentID: hexutil.Encode(t.DocumentID),
+		Status:     string(jobs.Success),
+		Message:    fmt.Sprintf("observed %s of token %s in registry %s", t.Kind, hexutil.Encode(t.TokenID), t.Registry.Hex()),
+		FromID:     t.From.Hex(),
+		ToID:       t.To.Hex(),
+		Data: notification.NFTData{
+			Registry: t.Registry.Hex(),
+			TokenID:  hexutil.Encode(t.TokenID),
+			From:     t.From.Hex(),
+			To:       t.To.Hex(),
+		},
+	})
+
+	if t.Kind == TransferKindBurn || !w.cfg.GetNFTTransferPushDocuments() || w.sender == nil {
+		return
+	}
+
+	sent, err := w.pushDocument(ctx, t)
+	if err != nil {
+		log.Warningf("failed to send document %s to the new owner %s: %v", hexutil.Encode(t.DocumentID), t.To.Hex(), err)
+		return
+	}
+
+	if !sent {
+		return
+	}
+
+	t.DocumentSent = true
+	batch := storage.NewBatch()
+	batch.Put(transferKey(t), t)
+	if err := w.repo.Write(batch); err != nil {
+		log.Errorf("failed to update transfer of token %s: %v", hexutil.Encode(t.TokenID), err)
+	}
+}
+
+// pushDocument sends the latest version of the document to the new owner.
+// The document is only sent to Centrifuge identities that can read it with the NFT.
+func (w *transferWatcher) pushDocument(ctx context.Context, t *Transfer) (sent bool, err error) {
+	owner := identity.NewDID(t.To)
+	if err := w.idSrv.Exists(ctx, owner); err != nil {
+		return false, nil
+	}
+
+	model, err := w.docSrv.GetCurrentVersion(ctx, t.DocumentID)
+	if err != nil {
+		return false, err
+	}
+
+	if err := model.NFTOwnerCanRead(w.tokenRegistry, t.Registry, t.TokenID, owner); err != nil {
+		log.Infof("NFT owner %s can't read document %s: %v", owner.String(), hexutil.Encode(t.DocumentID), err)
+		return false, nil
+	}
+
+	cd, err := model.PackCoreDocument()
+	if err != nil {
+		return false, err
+	}
+
+	return true, w.sender.Send(ctx, cd, owner)
+}
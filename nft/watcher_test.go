@@ -0,0 +1,281 @@
+// +build unit
+
+package nft
+
+import (
+	"context"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/centrifuge/centrifuge-protobufs/gen/go/coredocument"
+	"github.com/centrifuge/go-centrifuge/config"
+	"github.com/centrifuge/go-centrifuge/config/configstore"
+	"github.com/centrifuge/go-centrifuge/documents"
+	"github.com/centrifuge/go-centrifuge/documents/generic"
+	"github.com/centrifuge/go-centrifuge/errors"
+	"github.com/centrifuge/go-centrifuge/identity"
+	"github.com/centrifuge/go-centrifuge/testingutils"
+	"github.com/centrifuge/go-centrifuge/testingutils/commons"
+	"github.com/centrifuge/go-centrifuge/testingutils/config"
+	"github.com/centrifuge/go-centrifuge/testingutils/documents"
+	"github.com/centrifuge/go-centrifuge/utils"
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type senderFunc func(ctx context.Context, cd coredocumentpb.CoreDocument, recipient identity.DID) error
+
+func (f senderFunc) Send(ctx context.Context, cd coredocumentpb.CoreDocument, recipient identity.DID) error {
+	return f(ctx, cd, recipient)
+}
+
+func newTransferWatcher(t *testing.T) (*transferWatcher, *service, context.Context) {
+	srv, ctx := newInventoryService(t, nil, registryCaller{})
+	srv.repo.Register(new(Transfer))
+	srv.repo.Register(new(watcherCursor))
+	return &transferWatcher{
+		cfg:           cfg,
+		repo:          srv.repo,
+		tokenRegistry: srv,
+		ethContext: func() (context.Context, context.CancelFunc) {
+			return context.WithTimeout(context.Background(), time.Second)
+		},
+	}, srv, ctx
+}
+
+// erc1155Transfer returns the ERC-1155 transfer log of the event with the data of the values.
+func erc1155Transfer(t *testing.T, event string, registry, from, to common.Address, block uint64, values ...interface{}) types.Log {
+	data, err := erc1155ABI.Events[event].Inputs.NonIndexed().Pack(values...)
+	assert.NoError(t, err)
+	topic := transferSingleTopic
+	if event == "TransferBatch" {
+		topic = transferBatchTopic
+	}
+
+	return types.Log{
+		Address:     registry,
+		Topics:      []common.Hash{topic, common.Address{9}.Hash(), from.Hash(), to.Hash()},
+		Data:        data,
+		BlockNumber: block,
+	}
+}
+
+func TestTransferWatcher_watch(t *testing.T) {
+	defer testingutils.MockConfigOption(cfg, "ethereum.confirmationDepth", 3)()
+	w, srv, ctx := newTransferWatcher(t)
+	configSrv := new(configstore.MockService)
+	configSrv.On("GetAccount", mock.Anything).Return(nil, errors.New("missing account"))
+	w.configSrv = configSrv
+	did := accountDID(t)
+	registry := common.HexToAddress("0x111855759a39fb75fc7341139f5d7a3974d4da08")
+	tokenID, burnedID := NewTokenID(), NewTokenID()
+	assert.NoError(t, srv.saveToken(&Token{Registry: registry, TokenID: tokenID[:], AccountID: did, DocumentID: utils.RandomSlice(32)}))
+	assert.NoError(t, srv.saveToken(&Token{Registry: common.Address{1}, TokenID: burnedID[:], AccountID: did, BurnedAt: time.Now()}))
+
+	// tokens linked to the documents are replaced by the inventory records
+	linkedID := NewTokenID()
+	w.linked = []*Token{
+		{Registry: registry, TokenID: linkedID[:], AccountID: did, DocumentID: utils.RandomSlice(32)},
+		{Registry: registry, TokenID: tokenID[:], AccountID: did},
+	}
+	tokens, registries, err := w.watchedTokens()
+	assert.NoError(t, err)
+	assert.Len(t, tokens, 2)
+	assert.Len(t, tokens[watchKey(registry, tokenID[:])], 1)
+	assert.Equal(t, []common.Address{registry}, registries)
+
+	var head uint64 = 100
+	w.blockHeight = func() (uint64, error) {
+		return head, nil
+	}
+
+	var queries []ethereum.FilterQuery
+	otherID := NewTokenID()
+	from, to := common.Address{2}, common.Address{3}
+	transfer := func(registry common.Address, tokenID []byte, block uint64, index uint) types.Log {
+		return types.Log{
+			Address:     registry,
+			Topics:      []common.Hash{transferTopic, from.Hash(), to.Hash(), common.BytesToHash(tokenID)},
+			BlockNumber: block,
+			Index:       index,
+		}
+	}
+	w.filterLogs = func(ctx context.Context, query ethereum.FilterQuery) ([]types.Log, error) {
+		queries = append(queries, query)
+		erc20 := transfer(registry, tokenID[:], 105, 0)
+		erc20.Topics = erc20.Topics[:3]
+		mint := transfer(registry, tokenID[:], 104, 0)
+		mint.Topics[1] = common.Address{}.Hash()
+		burn := transfer(registry, linkedID[:], 106, 0)
+		burn.Topics[2] = common.Address{}.Hash()
+		return []types.Log{
+			mint,
+			burn,
+			transfer(registry, tokenID[:], 107, 2),
+			transfer(registry, otherID[:], 105, 1),
+			transfer(registry, tokenID[:], 105, 3),
+			erc20,
+			erc1155Transfer(t, "TransferSingle", registry, to, from, 108, tokenID.BigInt(), big.NewInt(1)),
+			erc1155Transfer(t, "TransferSingle", registry, from, to, 108, tokenID.BigInt(), big.NewInt(0)),
+			erc1155Transfer(t, "TransferBatch", registry, from, to, 109,
+				[]*big.Int{otherID.BigInt(), tokenID.BigInt()}, []*big.Int{big.NewInt(1), big.NewInt(1)}),
+		}, nil
+	}
+
+	// first round only marks the latest confirmed block
+	assert.NoError(t, w.watch())
+	assert.Len(t, queries, 0)
+
+	// transfers of the watched tokens since the last round, up to the confirmation depth
+	head = 112
+	assert.NoError(t, w.watch())
+	assert.Len(t, queries, 1)
+	assert.Equal(t, big.NewInt(99), queries[0].FromBlock)
+	assert.Equal(t, big.NewInt(110), queries[0].ToBlock)
+	assert.Equal(t, []common.Address{registry}, queries[0].Addresses)
+	assert.Equal(t, [][]common.Hash{{transferTopic, transferSingleTopic, transferBatchTopic}}, queries[0].Topics)
+	transfers, err := srv.GetTokenTransfers(ctx, registry, tokenID)
+	assert.NoError(t, err)
+	assert.Len(t, transfers, 5)
+	assert.Equal(t, uint64(104), transfers[0].BlockNumber)
+	assert.Equal(t, uint64(105), transfers[1].BlockNumber)
+	assert.Equal(t, uint64(107), transfers[2].BlockNumber)
+	assert.Equal(t, uint64(108), transfers[3].BlockNumber)
+	assert.Equal(t, uint64(109), transfers[4].BlockNumber)
+	assert.Equal(t, TransferKindMint, transfers[0].Kind)
+	assert.Equal(t, common.Address{}, transfers[0].From)
+	assert.Equal(t, from, transfers[1].From)
+	assert.Equal(t, to, transfers[1].To)
+	assert.Equal(t, TransferKindTransfer, transfers[1].Kind)
+	assert.Equal(t, to, transfers[3].From)
+	assert.Equal(t, from, transfers[3].To)
+	assert.Equal(t, from, transfers[4].From)
+	assert.Equal(t, to, transfers[4].To)
+	assert.False(t, transfers[1].DocumentSent)
+
+	// transfers of the linked tokens are recorded without an inventory record
+	transfers, err = srv.GetTokenTransfers(ctx, registry, linkedID)
+	assert.NoError(t, err)
+	assert.Len(t, transfers, 1)
+	assert.Equal(t, TransferKindBurn, transfers[0].Kind)
+	assert.Equal(t, common.Address{}, transfers[0].To)
+
+	// no new blocks
+	assert.NoError(t, w.watch())
+	assert.Len(t, queries, 1)
+
+	// tokens not minted by the account
+	_, err = srv.GetTokenTransfers(ctx, registry, burnedID)
+	assert.True(t, errors.IsOfType(ErrTokenNotFound, err))
+}
+
+func TestTransferWatcher_handleTransfer(t *testing.T) {
+	w, srv, ctx := newTransferWatcher(t)
+	acc, err := configstore.NewAccount("main", cfg)
+	assert.NoError(t, err)
+	configSrv := new(configstore.MockService)
+	configSrv.On("GetAccount", mock.Anything).Return(acc, nil)
+	w.configSrv = configSrv
+	mockCfg := new(testingconfig.MockConfig)
+	mockCfg.On("GetNFTTransferPushDocuments").Return(true)
+	w.cfg = mockCfg
+	idSrv := new(testingcommons.MockIdentityService)
+	w.idSrv = idSrv
+	docSrv := new(testingdocuments.MockService)
+	w.docSrv = docSrv
+	var recipients []identity.DID
+	w.sender = senderFunc(func(ctx context.Context, cd coredocumentpb.CoreDocument, recipient identity.DID) error {
+		recipients = append(recipients, recipient)
+		return nil
+	})
+
+	did := accountDID(t)
+	registry := common.HexToAddress("0x111855759a39fb75fc7341139f5d7a3974d4da08")
+	tokenID := NewTokenID()
+	assert.NoError(t, srv.saveToken(&Token{Registry: registry, TokenID: tokenID[:], AccountID: did}))
+	owner := identity.NewDID(common.Address{3})
+	tr := &Transfer{
+		Registry:    registry,
+		TokenID:     tokenID[:],
+		AccountID:   did,
+		DocumentID:  utils.RandomSlice(32),
+		To:          owner.ToAddress(),
+		BlockNumber: 10,
+		Kind:        TransferKindTransfer,
+	}
+
+	// new owner isn't a centrifuge identity
+	idSrv.On("Exists", mock.Anything, owner).Return(errors.New("not an identity")).Once()
+	w.handleTransfer(tr)
+	assert.Len(t, recipients, 0)
+
+	// NFT doesn't grant read access
+	model := new(testingdocuments.MockModel)
+	idSrv.On("Exists", mock.Anything, owner).Return(nil)
+	docSrv.On("GetCurrentVersion", []byte(tr.DocumentID)).Return(model, nil)
+	model.On("NFTOwnerCanRead", registry, []byte(tr.TokenID), owner).Return(errors.New("no read access")).Once()
+	w.handleTransfer(tr)
+	assert.Len(t, recipients, 0)
+
+	// document sent to the new owner
+	model.On("NFTOwnerCanRead", registry, []byte(tr.TokenID), owner).Return(nil).Once()
+	model.On("PackCoreDocument").Return(coredocumentpb.CoreDocument{}, nil).Once()
+	w.handleTransfer(tr)
+	assert.Equal(t, []identity.DID{owner}, recipients)
+	transfers, err := srv.GetTokenTransfers(ctx, registry, tokenID)
+	assert.NoError(t, err)
+	assert.Len(t, transfers, 1)
+	assert.True(t, transfers[0].DocumentSent)
+
+	// documents are not sent on mints and burns
+	for _, kind := range []string{TransferKindMint, TransferKindBurn} {
+		tr.Kind = kind
+		w.handleTransfer(tr)
+	}
+	assert.Len(t, recipients, 1)
+	model.AssertExpectations(t)
+	idSrv.AssertExpectations(t)
+}
+
+func TestTransferWatcher_linkDocumentTokens(t *testing.T) {
+	w, srv, _ := newTransferWatcher(t)
+	acc, err := configstore.NewAccount("main", cfg)
+	assert.NoError(t, err)
+	configSrv := new(configstore.MockService)
+	w.configSrv = configSrv
+	docSrv := new(testingdocuments.MockService)
+	w.docSrv = docSrv
+
+	// missing accounts
+	configSrv.On("GetAccounts").Return(nil, errors.New("missing accounts")).Once()
+	assert.Error(t, w.linkDocumentTokens())
+
+	// NFTs of the current version that are not in the inventory
+	did := accountDID(t)
+	registry, other := common.HexToAddress("0x111855759a39fb75fc7341139f5d7a3974d4da08"), common.Address{2}
+	linkedID, mintedID := NewTokenID(), NewTokenID()
+	cd, err := documents.NewCoreDocument(nil, documents.CollaboratorsAccess{}, nil)
+	assert.NoError(t, err)
+	doc := &generic.Generic{CoreDocument: cd}
+	repo := documents.NewDBRepository(srv.repo)
+	repo.Register(new(generic.Generic))
+	assert.NoError(t, repo.Create(did[:], doc.CurrentVersion(), doc))
+	assert.NoError(t, doc.AddNFT(false, registry, linkedID[:]))
+	assert.NoError(t, doc.AddNFT(false, other, mintedID[:]))
+	assert.NoError(t, repo.Create(did[:], doc.CurrentVersion(), doc))
+	assert.NoError(t, srv.saveToken(&Token{Registry: other, TokenID: mintedID[:], AccountID: did}))
+	configSrv.On("GetAccounts").Return([]config.Account{acc}, nil).Once()
+	docSrv.On("GetCurrentVersion", doc.ID()).Return(doc, nil).Once()
+	assert.NoError(t, w.linkDocumentTokens())
+	assert.Len(t, w.linked, 1)
+	assert.Equal(t, registry, w.linked[0].Registry)
+	assert.Equal(t, linkedID[:], []byte(w.linked[0].TokenID))
+	assert.Equal(t, did, w.linked[0].AccountID)
+	assert.Equal(t, doc.ID(), []byte(w.linked[0].DocumentID))
+	configSrv.AssertExpectations(t)
+	docSrv.AssertExpectations(t)
+}
@@ -6,7 +6,13 @@ import (
 	"os/signal"
 
 	"github.com/centrifuge/go-centrifuge/bootstrap"
+	"github.com/centrifuge/go-centrifuge/centchain"
+	"github.com/centrifuge/go-centrifuge/documents"
 	"github.com/centrifuge/go-centrifuge/errors"
+	"github.com/centrifuge/go-centrifuge/ethereum"
+	"github.com/centrifuge/go-centrifuge/jobs"
+	"github.com/centrifuge/go-centrifuge/nft"
+	"github.com/centrifuge/go-centrifuge/notification"
 	"github.com/centrifuge/go-centrifuge/storage"
 )
 
@@ -66,7 +72,38 @@ func GetServers(ctx map[string]interface{}) ([]Server, error) {
 		return nil, errors.New("queue server not initialized")
 	}
 
+	collector, ok := ctx[documents.BootstrappedSignatureCollector]
+	if !ok {
+		return nil, errors.New("signature collector not initialized")
+	}
+
+	pruner, ok := ctx[jobs.BootstrappedPruner]
+	if !ok {
+		return nil, errors.New("job pruner not initialized")
+	}
+
+	dispatcher, ok := ctx[notification.BootstrappedDispatcher]
+	if !ok {
+		return nil, errors.New("webhook dispatcher not initialized")
+	}
+
+	watcher, ok := ctx[nft.BootstrappedTransferWatcher]
+	if !ok {
+		return nil, errors.New("NFT transfer watcher not initialized")
+	}
+
+	txMan, ok := ctx[ethereum.BootstrappedTxManager]
+	if !ok {
+		return nil, errors.New("ethereum transaction manager not initialized")
+	}
+
+	indexer, ok := ctx[centchain.BootstrappedEventIndexer]
+	if !ok {
+		return nil, errors.New("centchain event indexer not initialized")
+	}
+
 	var servers []Server
-	servers = append(servers, p2pSrv.(Server), apiSrv.(Server), queueSrv.(Server))
+	servers = append(servers, p2pSrv.(Server), apiSrv.(Server), queueSrv.(Server), collector.(Server), pruner.(Server), dispatcher.(Server),
+		watcher.(Server), txMan.(Server), indexer.(Server))
 	return servers, nil
 }
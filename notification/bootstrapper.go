@@ -0,0 +1,28 @@
+package notification
+
+import (
+	"github.com/centrifuge/go-centrifuge/config/configstore"
+	"github.com/centrifuge/go-centrifuge/errors"
+	"github.com/centrifuge/go-centrifuge/storage"
+)
+
+// Bootstrapper implements bootstrap.Bootstrapper.
+type Bootstrapper struct{}
+
+// Bootstrap adds the webhook Dispatcher into context and sets it as the dispatcher of the webhook notifications.
+func (Bootstrapper) Bootstrap(ctx map[string]interface{}) error {
+	cfg, err := configstore.RetrieveConfig(false, ctx)
+	if err != nil {
+		return err
+	}
+
+	repo, ok := ctx[storage.BootstrappedDB].(storage.Repository)
+	if !ok {
+		return errors.New("storage repository not initialised")
+	}
+
+	d := NewDispatcher(cfg, repo)
+	SetDispatcher(d)
+	ctx[BootstrappedDispatcher] = d
+	return nil
+}
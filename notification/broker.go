@@ -0,0 +1,142 @@
+package notification
+
+import (
+	"strings"
+	"sync"
+)
+
+// subscriptionBuffer is the number of events a subscriber can lag behind before events are dropped.
+const subscriptionBuffer = 64
+
+// Filter selects the events of a subscription.
+type Filter struct {
+	// Types of the events, all types if empty
+	Types []EventType
+
+	// DocumentID of the events, hex encoded, all documents if empty
+	DocumentID string
+}
+
+func (f Filter) matches(msg Message) bool {
+	if f.DocumentID != "" && !strings.EqualFold(f.DocumentID, msg.DocumentID) {
+		return false
+	}
+
+	if len(f.Types) == 0 {
+		return true
+	}
+
+	for _, t := range f.Types {
+		if t == msg.EventType {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Subscription receives the events of an account matching the filter.
+type Subscription struct {
+	accountID string
+	filter    Filter
+	events    chan Message
+}
+
+// Events returns the channel of the events. The channel is closed once the subscription is closed.
+func (s *Subscription) Events() <-chan Message {
+	return s.events
+}
+
+// Broker streams the events of the node to the subscribers of the accounts.
+type Broker interface {
+	// Publish sends the event to the subscribers of the account of the event.
+	// Events are dropped for the subscribers that are not keeping up.
+	Publish(msg Message)
+
+	// Subscribe returns a subscription to the events of the account.
+	Subscribe(accountID string, filter Filter) *Subscription
+
+	// Unsubscribe closes the subscription.
+	Unsubscribe(sub *Subscription)
+
+	// CloseSubscriptions closes all the subscriptions, e.g. when the API server shuts down.
+	CloseSubscriptions()
+}
+
+// NewBroker returns an in memory Broker.
+func NewBroker() Broker {
+	return &broker{subs: make(map[*Subscription]struct{})}
+}
+
+type broker struct {
+	mu   sync.RWMutex
+	subs map[*Subscription]struct{}
+}
+
+func (b *broker) Publish(msg Message) {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	for sub := range b.subs {
+		if !strings.EqualFold(sub.accountID, msg.AccountID) || !sub.filter.matches(msg) {
+			continue
+		}
+
+		select {
+		case sub.events <- msg:
+		default:
+			log.Warningf("dropped %s event for a slow subscriber of account %s", msg.EventType, msg.AccountID)
+		}
+	}
+}
+
+func (b *broker) Subscribe(accountID string, filter Filter) *Subscription {
+	sub := &Subscription{
+		accountID: accountID,
+		filter:    filter,
+		events:    make(chan Message, subscriptionBuffer),
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.subs[sub] = struct{}{}
+	return sub
+}
+
+func (b *broker) Unsubscribe(sub *Subscription) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if _, ok := b.subs[sub]; !ok {
+		return
+	}
+
+	delete(b.subs, sub)
+	close(sub.events)
+}
+
+func (b *broker) CloseSubscriptions() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	for sub := range b.subs {
+		delete(b.subs, sub)
+		close(sub.events)
+	}
+}
+
+var (
+	defaultBroker   = NewBroker()
+	defaultBrokerMu sync.RWMutex
+)
+
+// SetBroker sets the broker the node events are published to.
+func SetBroker(b Broker) {
+	defaultBrokerMu.Lock()
+	defer defaultBrokerMu.Unlock()
+	defaultBroker = b
+}
+
+// GetBroker returns the broker the node events are published to.
+func GetBroker() Broker {
+	defaultBrokerMu.RLock()
+	defer defaultBrokerMu.RUnlock()
+	return defaultBroker
+}
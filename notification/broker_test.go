@@ -0,0 +1,54 @@
+// +build unit
+
+package notification
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEventTypeFromString(t *testing.T) {
+	for et := range eventTypeNames {
+		got, err := EventTypeFromString(et.String())
+		assert.NoError(t, err)
+		assert.Equal(t, et, got)
+	}
+
+	_, err := EventTypeFromString("unknown")
+	assert.Error(t, err)
+}
+
+func TestBroker(t *testing.T) {
+	b := NewBroker()
+	all := b.Subscribe("0xA", Filter{})
+	tasks := b.Subscribe("0xa", Filter{Types: []EventType{TaskUpdated}, DocumentID: "0x01"})
+	other := b.Subscribe("0xb", Filter{})
+
+	b.Publish(Message{EventType: TaskUpdated, AccountID: "0xa", DocumentID: "0x01"})
+	b.Publish(Message{EventType: JobCompleted, AccountID: "0xa", DocumentID: "0x01"})
+	b.Publish(Message{EventType: TaskUpdated, AccountID: "0xa", DocumentID: "0x02"})
+
+	assert.Len(t, all.Events(), 3)
+	assert.Len(t, tasks.Events(), 1)
+	assert.Len(t, other.Events(), 0)
+	msg := <-tasks.Events()
+	assert.Equal(t, TaskUpdated, msg.EventType)
+	assert.Equal(t, "0x01", msg.DocumentID)
+
+	// slow subscribers drop events
+	for i := 0; i < subscriptionBuffer; i++ {
+		b.Publish(Message{EventType: ReceivedPayload, AccountID: "0xa"})
+	}
+	assert.Len(t, all.Events(), subscriptionBuffer)
+
+	// closed subscriptions
+	b.Unsubscribe(tasks)
+	b.Unsubscribe(tasks)
+	_, ok := <-tasks.Events()
+	assert.False(t, ok)
+	b.CloseSubscriptions()
+	_, ok = <-other.Events()
+	assert.False(t, ok)
+	b.Publish(Message{EventType: ReceivedPayload, AccountID: "0xb"})
+}
@@ -0,0 +1,170 @@
+package notification
+
+import (
+	"context"
+	"strings"
+
+	"github.com/centrifuge/go-centrifuge/errors"
+)
+
+// Event types in addition to ReceivedPayload and JobCompleted.
+// The Data of the notification message holds the payload of the event type documented below.
+const (
+	// TaskUpdated is the event of a new task log line of a job. No payload, see TaskName and Message.
+	TaskUpdated EventType = 3
+
+	// NFTMinted is the event of an NFT minted for a document of the account. Payload: NFTData.
+	NFTMinted EventType = 4
+
+	// SignatureRequested is the event of a collaborator requesting the signature of the account. Payload: SignatureData.
+	SignatureRequested EventType = 5
+
+	// SignatureGiven is the event of the account signing a document for a collaborator. Payload: SignatureData.
+	SignatureGiven EventType = 6
+
+	// SignatureRefused is the event of the signature policy of the account rejecting a signature request. Payload: SignatureData.
+	SignatureRefused EventType = 7
+
+	// DocumentChanged is the event of a collaborator sending a new version of a document of the account
+	// for signing, e.g. a role member updating the fields it is allowed to change. Payload: DocumentChangedData.
+	DocumentChanged EventType = 8
+
+	// NFTTransferred is the event of an NFT of a document of the account being transferred. Payload: NFTData.
+	NFTTransferred EventType = 9
+
+	// AccessTokenGranted is the event of the account granting access to an entity. Payload: AccessTokenData.
+	AccessTokenGranted EventType = 10
+
+	// AccessTokenRevoked is the event of the account revoking access to an entity. Payload: AccessTokenData.
+	AccessTokenRevoked EventType = 11
+
+	// KeyAdded is the event of a key being added to the identity of the account. Payload: KeyData.
+	KeyAdded EventType = 12
+
+	// KeyRevoked is the event of a key of the identity of the account being revoked. Payload: KeyData.
+	KeyRevoked EventType = 13
+
+	// NFTBurned is the event of an NFT of a document of the account being burned to be re-minted. Payload: NFTData.
+	NFTBurned EventType = 14
+)
+
+var eventTypeNames = map[EventType]string{
+	ReceivedPayload:    "received_payload",
+	JobCompleted:       "job_completed",
+	TaskUpdated:        "task_updated",
+	NFTMinted:          "nft_minted",
+	SignatureRequested: "signature_requested",
+	SignatureGiven:     "signature_given",
+	SignatureRefused:   "signature_refused",
+	DocumentChanged:    "document_changed",
+	NFTTransferred:     "nft_transferred",
+	AccessTokenGranted: "access_token_granted",
+	AccessTokenRevoked: "access_token_revoked",
+	KeyAdded:           "key_added",
+	KeyRevoked:         "key_revoked",
+	NFTBurned:          "nft_burned",
+}
+
+// String returns the name of the event type.
+func (e EventType) String() string {
+	return eventTypeNames[e]
+}
+
+// EventTypeFromString returns the event type with the name.
+func EventTypeFromString(name string) (EventType, error) {
+	for t, n := range eventTypeNames {
+		if strings.EqualFold(n, name) {
+			return t, nil
+		}
+	}
+
+	return 0, errors.New("unknown event type: %s", name)
+}
+
+// SignatureData is the payload of the signature events. DocumentID of the message is the signed document.
+type SignatureData struct {
+	// Collaborator that requested the signature, hex encoded
+	Collaborator string `json:"collaborator"`
+
+	// VersionID of the document to sign, hex encoded
+	VersionID string `json:"version_id"`
+
+	// SigningRoot of the version, hex encoded
+	SigningRoot string `json:"signing_root"`
+
+	// Reason the signature was refused
+	Reason string `json:"reason,omitempty"`
+}
+
+// DocumentChangedData is the payload of the DocumentChanged event. DocumentID of the message is the changed document.
+type DocumentChangedData struct {
+	// Collaborator that changed the document, hex encoded
+	Collaborator string `json:"collaborator"`
+
+	// VersionID of the new version, hex encoded
+	VersionID string `json:"version_id"`
+
+	// PreviousVersionID of the changed version, hex encoded
+	PreviousVersionID string `json:"previous_version_id"`
+
+	// ChangedFields are the names of the fields changed by the new version
+	ChangedFields []string `json:"changed_fields"`
+}
+
+// NFTData is the payload of the NFT events. DocumentID of the message is the document of the NFT.
+type NFTData struct {
+	// Registry of the NFT, hex encoded
+	Registry string `json:"registry"`
+
+	// TokenID of the NFT, hex encoded
+	TokenID string `json:"token_id"`
+
+	// From is the previous owner, empty for minted NFTs
+	From string `json:"from,omitempty"`
+
+	// To is the new owner
+	To string `json:"to"`
+
+	// JobID of the mint or transfer, if any
+	JobID string `json:"job_id,omitempty"`
+
+	// Replaces is the burned NFT a re-minted NFT replaces, hex encoded
+	Replaces string `json:"replaces,omitempty"`
+
+	// ReplacedBy is the NFT re-minted in place of a burned NFT, hex encoded
+	ReplacedBy string `json:"replaced_by,omitempty"`
+}
+
+// AccessTokenData is the payload of the access token events. DocumentID of the message is the entity relationship.
+type AccessTokenData struct {
+	// Grantee of the access token, hex encoded
+	Grantee string `json:"grantee"`
+
+	// EntityID of the entity the token grants access to, hex encoded
+	EntityID string `json:"entity_id"`
+
+	// JobID anchoring the entity relationship. The change takes effect once the job succeeds.
+	JobID string `json:"job_id"`
+}
+
+// KeyData is the payload of the identity key events.
+type KeyData struct {
+	// Key is the hex encoded key
+	Key string `json:"key"`
+
+	// Purposes of the key, hex encoded
+	Purposes []string `json:"purposes,omitempty"`
+
+	// JobID of the identity transaction
+	JobID string `json:"job_id,omitempty"`
+}
+
+// Notify sends the notification with the account of the context in the background. Failures are logged.
+func Notify(ctx context.Context, msg Message) {
+	go func() {
+		_, err := NewWebhookSender().Send(ctx, msg)
+		if err != nil {
+			log.Errorf("failed to send %s notification: %v", msg.EventType, err)
+		}
+	}()
+}
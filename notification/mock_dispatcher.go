@@ -0,0 +1,70 @@
+// +build unit
+
+package notification
+
+import (
+	"context"
+	"sync"
+
+	"github.com/centrifuge/go-centrifuge/identity"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockDispatcher struct {
+	mock.Mock
+}
+
+func (m *MockDispatcher) Enqueue(accountID identity.DID, subscriptionID, url string, payload []byte) (*Delivery, error) {
+	args := m.Called(accountID, subscriptionID, url, payload)
+	del, _ := args.Get(0).(*Delivery)
+	return del, args.Error(1)
+}
+
+func (m *MockDispatcher) DeadLetters(accountID identity.DID) ([]*Delivery, error) {
+	args := m.Called(accountID)
+	list, _ := args.Get(0).([]*Delivery)
+	return list, args.Error(1)
+}
+
+func (m *MockDispatcher) Replay(accountID identity.DID, id string) (*Delivery, error) {
+	args := m.Called(accountID, id)
+	del, _ := args.Get(0).(*Delivery)
+	return del, args.Error(1)
+}
+
+func (m *MockDispatcher) RotateSecret(accountID identity.DID) (string, error) {
+	args := m.Called(accountID)
+	return args.String(0), args.Error(1)
+}
+
+func (m *MockDispatcher) CreateSubscription(accountID identity.DID, sub *WebhookSubscription) (*WebhookSubscription, error) {
+	args := m.Called(accountID, sub)
+	s, _ := args.Get(0).(*WebhookSubscription)
+	return s, args.Error(1)
+}
+
+func (m *MockDispatcher) GetSubscriptions(accountID identity.DID) ([]*WebhookSubscription, error) {
+	args := m.Called(accountID)
+	list, _ := args.Get(0).([]*WebhookSubscription)
+	return list, args.Error(1)
+}
+
+func (m *MockDispatcher) GetSubscription(accountID identity.DID, id string) (*WebhookSubscription, error) {
+	args := m.Called(accountID, id)
+	s, _ := args.Get(0).(*WebhookSubscription)
+	return s, args.Error(1)
+}
+
+func (m *MockDispatcher) DeleteSubscription(accountID identity.DID, id string) error {
+	args := m.Called(accountID, id)
+	return args.Error(0)
+}
+
+func (m *MockDispatcher) Name() string {
+	return "MockDispatcher"
+}
+
+func (m *MockDispatcher) Start(ctx context.Context, wg *sync.WaitGroup, startupErr chan<- error) {
+	defer wg.Done()
+	<-ctx.Done()
+}
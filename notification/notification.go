@@ -7,7 +7,9 @@ import (
 
 	"github.com/centrifuge/go-centrifuge/contextutil"
 	"github.com/centrifuge/go-centrifuge/errors"
+	"github.com/centrifuge/go-centrifuge/identity"
 	"github.com/centrifuge/go-centrifuge/utils"
+	"github.com/ethereum/go-ethereum/common"
 	logging "github.com/ipfs/go-log"
 )
 
@@ -38,6 +40,11 @@ type Message struct {
 	AccountID    string    `json:"account_id"` // account_id is the account associated to webhook
 	FromID       string    `json:"from_id"`    // from_id if provided, original trigger of the event
 	ToID         string    `json:"to_id"`      // to_id if provided, final destination of the event
+	TaskName     string    `json:"task_name,omitempty"`
+	Scheme       string    `json:"scheme,omitempty"` // scheme of the document, e.g. generic
+
+	// Data is the payload of the event type, see the event type constants
+	Data interface{} `json:"data,omitempty" swaggertype:"object"`
 }
 
 // Sender defines methods that can handle a notification.
@@ -54,23 +61,35 @@ func NewWebhookSender() Sender {
 // Sends notification through a webhook defined.
 type webhookSender struct{}
 
-// Send sends notification to the defined webhook.
+// Send sends notification to the defined webhook and publishes it to the event stream.
+// With a dispatcher, the notification is also enqueued to the matching webhook subscriptions of the account.
 func (wh webhookSender) Send(ctx context.Context, notification Message) (Status, error) {
+	GetBroker().Publish(notification)
 	tc, err := contextutil.Account(ctx)
 	if err != nil {
 		return Failure, err
 	}
-	url := tc.GetReceiveEventNotificationEndpoint()
-	if url == "" {
-		log.Warningf("Webhook URL not defined, manually fetch received document")
-		return Success, nil
-	}
 
 	payload, err := json.Marshal(notification)
 	if err != nil {
 		return Failure, err
 	}
 
+	url := tc.GetReceiveEventNotificationEndpoint()
+	if d := GetDispatcher(); d != nil {
+		err = enqueue(d, identity.NewDID(common.BytesToAddress(tc.GetIdentityID())), url, notification, payload)
+		if err != nil {
+			return Failure, err
+		}
+
+		return Success, nil
+	}
+
+	if url == "" {
+		log.Warningf("Webhook URL not defined, manually fetch received document")
+		return Success, nil
+	}
+
 	statusCode, err := utils.SendPOSTRequest(url, "application/json", payload)
 	if err != nil {
 		return Failure, err
@@ -84,3 +103,35 @@ func (wh webhookSender) Send(ctx context.Context, notification Message) (Status,
 
 	return Success, nil
 }
+
+// enqueue enqueues the notification to the endpoint, if defined, and to the matching subscriptions of the account.
+func enqueue(d Dispatcher, accountID identity.DID, url string, notification Message, payload []byte) error {
+	if url != "" {
+		del, err := d.Enqueue(accountID, "", url, payload)
+		if err != nil {
+			return err
+		}
+
+		log.Infof("Enqueued Webhook Notification %s with Payload [%v] to [%s]", del.ID, notification, url)
+	}
+
+	subs, err := d.GetSubscriptions(accountID)
+	if err != nil {
+		return err
+	}
+
+	for _, sub := range subs {
+		if !sub.Matches(notification) {
+			continue
+		}
+
+		del, err := d.Enqueue(accountID, sub.ID, sub.URL, payload)
+		if err != nil {
+			return err
+		}
+
+		log.Infof("Enqueued Webhook Notification %s with Payload [%v] to subscription %s", del.ID, notification, sub.ID)
+	}
+
+	return nil
+}
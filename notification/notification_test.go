@@ -15,6 +15,7 @@ import (
 	"github.com/centrifuge/go-centrifuge/bootstrap"
 	"github.com/centrifuge/go-centrifuge/bootstrap/bootstrappers/testlogging"
 	"github.com/centrifuge/go-centrifuge/config"
+	"github.com/centrifuge/go-centrifuge/contextutil"
 	"github.com/centrifuge/go-centrifuge/identity"
 	"github.com/centrifuge/go-centrifuge/testingutils/config"
 	"github.com/centrifuge/go-centrifuge/utils"
@@ -96,3 +97,27 @@ func TestWebhookSender_Send(t *testing.T) {
 	assert.Equal(t, status, Success)
 	wg.Wait()
 }
+
+func TestWebhookSender_Send_dispatcher(t *testing.T) {
+	d := new(MockDispatcher)
+	SetDispatcher(d)
+	defer SetDispatcher(nil)
+	cfg.Set("notifications.endpoint", "http://localhost:8090/webhook")
+	ctx := testingconfig.CreateAccountContext(t, cfg)
+	did, err := contextutil.AccountDID(ctx)
+	assert.NoError(t, err)
+	subs := []*WebhookSubscription{
+		{ID: "0x01", URL: "http://localhost:8091/jobs", EventTypes: []EventType{JobCompleted}},
+		{ID: "0x02", URL: "http://localhost:8091/documents", EventTypes: []EventType{ReceivedPayload}},
+	}
+	notif := Message{EventType: ReceivedPayload, AccountID: did.String(), Recorded: time.Now().UTC()}
+	payload, err := json.Marshal(notif)
+	assert.NoError(t, err)
+	d.On("Enqueue", did, "", "http://localhost:8090/webhook", payload).Return(&Delivery{ID: "0x03"}, nil).Once()
+	d.On("GetSubscriptions", did).Return(subs, nil).Once()
+	d.On("Enqueue", did, "0x02", "http://localhost:8091/documents", payload).Return(&Delivery{ID: "0x04"}, nil).Once()
+	status, err := NewWebhookSender().Send(ctx, notif)
+	assert.NoError(t, err)
+	assert.Equal(t, Success, status)
+	d.AssertExpectations(t)
+}
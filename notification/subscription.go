@@ -0,0 +1,201 @@
+package notification
+
+import (
+	"encoding/json"
+	"net/url"
+	"reflect"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/centrifuge/go-centrifuge/errors"
+	"github.com/centrifuge/go-centrifuge/identity"
+)
+
+// WebhookSubscription is a webhook of an account that receives the notifications matching its filters.
+// Empty filters match all the notifications.
+type WebhookSubscription struct {
+	ID        string       `json:"id"`
+	AccountID identity.DID `json:"account_id"`
+	URL       string       `json:"url"`
+
+	// EventTypes of the notifications
+	EventTypes []EventType `json:"event_types"`
+
+	// Schemes of the documents of the notifications, e.g. generic
+	Schemes []string `json:"schemes"`
+
+	// SenderIDs are the DIDs that triggered the notifications
+	SenderIDs []identity.DID `json:"sender_ids"`
+
+	CreatedAt time.Time `json:"created_at"`
+
+	// Delivered is the number of notifications delivered to the webhook
+	Delivered int `json:"delivered"`
+
+	// Failed is the number of notifications dead-lettered after all the attempts failed
+	Failed int `json:"failed"`
+
+	LastDeliveredAt time.Time `json:"last_delivered_at"`
+	LastFailedAt    time.Time `json:"last_failed_at"`
+	LastError       string    `json:"last_error"`
+}
+
+// JSON marshals the subscription to json bytes.
+func (s *WebhookSubscription) JSON() ([]byte, error) {
+	return json.Marshal(s)
+}
+
+// FromJSON loads the subscription from json bytes.
+func (s *WebhookSubscription) FromJSON(data []byte) error {
+	return json.Unmarshal(data, s)
+}
+
+// Type returns the type of the WebhookSubscription.
+func (s *WebhookSubscription) Type() reflect.Type {
+	return reflect.TypeOf(s)
+}
+
+// Matches returns true if the notification passes all the filters of the subscription.
+func (s *WebhookSubscription) Matches(msg Message) bool {
+	if len(s.EventTypes) > 0 {
+		found := false
+		for _, t := range s.EventTypes {
+			if t == msg.EventType {
+				found = true
+				break
+			}
+		}
+
+		if !found {
+			return false
+		}
+	}
+
+	if len(s.Schemes) > 0 {
+		found := false
+		for _, scheme := range s.Schemes {
+			if strings.EqualFold(scheme, msg.Scheme) {
+				found = true
+				break
+			}
+		}
+
+		if !found {
+			return false
+		}
+	}
+
+	if len(s.SenderIDs) > 0 {
+		found := false
+		for _, did := range s.SenderIDs {
+			if strings.EqualFold(did.String(), msg.FromID) {
+				found = true
+				break
+			}
+		}
+
+		if !found {
+			return false
+		}
+	}
+
+	return true
+}
+
+func subscriptionKey(accountID identity.DID, id string) []byte {
+	return []byte(subscriptionPrefix + accountID.String() + "_" + id)
+}
+
+func (d *dispatcher) CreateSubscription(accountID identity.DID, sub *WebhookSubscription) (*WebhookSubscription, error) {
+	u, err := url.Parse(sub.URL)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return nil, errors.NewTypedError(ErrInvalidSubscription, errors.New("invalid url: %s", sub.URL))
+	}
+
+	sub = &WebhookSubscription{
+		ID:         newID(),
+		AccountID:  accountID,
+		URL:        sub.URL,
+		EventTypes: sub.EventTypes,
+		Schemes:    sub.Schemes,
+		SenderIDs:  sub.SenderIDs,
+		CreatedAt:  time.Now().UTC(),
+	}
+
+	err = d.repo.Create(subscriptionKey(accountID, sub.ID), sub)
+	if err != nil {
+		return nil, err
+	}
+
+	return sub, nil
+}
+
+func (d *dispatcher) GetSubscriptions(accountID identity.DID) ([]*WebhookSubscription, error) {
+	models, err := d.repo.GetAllByPrefix(subscriptionPrefix + accountID.String() + "_")
+	if err != nil {
+		return nil, err
+	}
+
+	var list []*WebhookSubscription
+	for _, m := range models {
+		if sub, ok := m.(*WebhookSubscription); ok {
+			list = append(list, sub)
+		}
+	}
+
+	sort.Slice(list, func(i, j int) bool {
+		return list[i].CreatedAt.Before(list[j].CreatedAt)
+	})
+
+	return list, nil
+}
+
+func (d *dispatcher) GetSubscription(accountID identity.DID, id string) (*WebhookSubscription, error) {
+	m, err := d.repo.Get(subscriptionKey(accountID, id))
+	if err != nil {
+		return nil, ErrSubscriptionNotFound
+	}
+
+	return m.(*WebhookSubscription), nil
+}
+
+func (d *dispatcher) DeleteSubscription(accountID identity.DID, id string) error {
+	d.subMu.Lock()
+	defer d.subMu.Unlock()
+	key := subscriptionKey(accountID, id)
+	if !d.repo.Exists(key) {
+		return ErrSubscriptionNotFound
+	}
+
+	return d.repo.Delete(key)
+}
+
+// recordDelivery updates the delivery state of the subscription of the delivery.
+// err is nil for delivered deliveries and the last error for dead-lettered deliveries.
+func (d *dispatcher) recordDelivery(del *Delivery, err error) {
+	if del.SubscriptionID == "" {
+		return
+	}
+
+	d.subMu.Lock()
+	defer d.subMu.Unlock()
+	sub, gerr := d.GetSubscription(del.AccountID, del.SubscriptionID)
+	if gerr != nil {
+		return
+	}
+
+	now := time.Now().UTC()
+	if err == nil {
+		sub.Delivered++
+		sub.LastDeliveredAt = now
+	} else {
+		sub.Failed++
+		sub.LastFailedAt = now
+		sub.LastError = err.Error()
+	}
+
+	if uerr := d.repo.Update(subscriptionKey(del.AccountID, del.SubscriptionID), sub); uerr != nil {
+		log.Error(uerr)
+	}
+}
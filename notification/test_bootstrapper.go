@@ -0,0 +1,12 @@
+// +build unit integration
+
+package notification
+
+func (b Bootstrapper) TestBootstrap(ctx map[string]interface{}) error {
+	return b.Bootstrap(ctx)
+}
+
+func (b Bootstrapper) TestTearDown() error {
+	SetDispatcher(nil)
+	return nil
+}
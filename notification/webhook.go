@@ -0,0 +1,587 @@
+package notification
+
+import (
+	"bytes"
+	"container/heap"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"crypto/tls"
+	"encoding/json"
+	"net/http"
+	"reflect"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/centrifuge/go-centrifuge/errors"
+	"github.com/centrifuge/go-centrifuge/identity"
+	"github.com/centrifuge/go-centrifuge/storage"
+	"github.com/centrifuge/go-centrifuge/utils"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/satori/go.uuid"
+)
+
+const (
+	// BootstrappedDispatcher is the key mapped to the webhook Dispatcher.
+	BootstrappedDispatcher = "BootstrappedWebhookDispatcher"
+
+	// SignatureHeader holds the hex encoded HMAC-SHA256 of the webhook body, keyed with the secret of the account.
+	SignatureHeader = "X-Centrifuge-Signature"
+
+	// IdempotencyKeyHeader holds the ID of the delivery. It is the same for every attempt of a delivery.
+	IdempotencyKeyHeader = "X-Centrifuge-Idempotency-Key"
+
+	deliveryPrefix     = "webhook_delivery_"
+	secretPrefix       = "webhook_secret_"
+	subscriptionPrefix = "webhook_subscription_"
+
+	// dispatchInterval is the interval at which the due deliveries are attempted.
+	dispatchInterval = time.Second
+)
+
+const (
+	// ErrDeliveryNotFound error when the webhook delivery doesn't exist.
+	ErrDeliveryNotFound = errors.Error("webhook delivery not found")
+
+	// ErrDeliveryNotDead error when a delivery that is not dead-lettered is replayed.
+	ErrDeliveryNotDead = errors.Error("webhook delivery is not dead-lettered")
+
+	// ErrSubscriptionNotFound error when the webhook subscription doesn't exist.
+	ErrSubscriptionNotFound = errors.Error("webhook subscription not found")
+
+	// ErrInvalidSubscription error when the webhook subscription is invalid.
+	ErrInvalidSubscription = errors.Error("invalid webhook subscription")
+)
+
+// DeliveryStatus is the status of a webhook delivery.
+type DeliveryStatus string
+
+const (
+	// DeliveryPending is the status of a delivery waiting for its next attempt
+	DeliveryPending DeliveryStatus = "pending"
+
+	// DeliveryDead is the status of a delivery that failed all its attempts
+	DeliveryDead DeliveryStatus = "dead"
+)
+
+// Delivery is a webhook notification to be delivered. Delivered notifications are deleted.
+type Delivery struct {
+	ID        string       `json:"id"`
+	AccountID identity.DID `json:"account_id" swaggertype:"primitive,string"`
+
+	// SubscriptionID is the subscription the delivery is for, empty for the notification endpoint of the account
+	SubscriptionID string          `json:"subscription_id,omitempty"`
+	URL            string          `json:"url"`
+	Payload        json.RawMessage `json:"payload" swaggertype:"object"`
+	Status         DeliveryStatus  `json:"status" enums:"pending,dead"`
+	Attempts       int             `json:"attempts"`
+	LastError      string          `json:"last_error,omitempty"`
+	NextAttempt    time.Time       `json:"next_attempt" swaggertype:"primitive,string"`
+	CreatedAt      time.Time       `json:"created_at" swaggertype:"primitive,string"`
+	UpdatedAt      time.Time       `json:"updated_at" swaggertype:"primitive,string"`
+}
+
+// JSON marshals the delivery to json bytes.
+func (d *Delivery) JSON() ([]byte, error) {
+	return json.Marshal(d)
+}
+
+// FromJSON loads the delivery from json bytes.
+func (d *Delivery) FromJSON(data []byte) error {
+	return json.Unmarshal(data, d)
+}
+
+// Type returns the type of the Delivery.
+func (d *Delivery) Type() reflect.Type {
+	return reflect.TypeOf(d)
+}
+
+// webhookSecret is the secret the webhooks of an account are signed with.
+type webhookSecret struct {
+	Secret string `json:"secret"`
+}
+
+// JSON marshals the secret to json bytes.
+func (s *webhookSecret) JSON() ([]byte, error) {
+	return json.Marshal(s)
+}
+
+// FromJSON loads the secret from json bytes.
+func (s *webhookSecret) FromJSON(data []byte) error {
+	return json.Unmarshal(data, s)
+}
+
+// Type returns the type of the webhookSecret.
+func (s *webhookSecret) Type() reflect.Type {
+	return reflect.TypeOf(s)
+}
+
+// WebhookConfig is the config of the webhook deliveries.
+type WebhookConfig interface {
+	GetWebhookMaxAttempts() int
+	GetWebhookRetryBackoff() time.Duration
+	GetWebhookMaxRetryBackoff() time.Duration
+	GetWebhookTimeout() time.Duration
+}
+
+// Dispatcher delivers the webhooks durably. Failed deliveries are retried with an exponential backoff
+// and are dead-lettered once they fail all the attempts.
+type Dispatcher interface {
+	// Enqueue stores the webhook payload to be delivered to the url.
+	// The subscriptionID is empty for the deliveries to the notification endpoint of the account.
+	Enqueue(accountID identity.DID, subscriptionID, url string, payload []byte) (*Delivery, error)
+
+	// DeadLetters returns the dead-lettered deliveries of the account, oldest first.
+	DeadLetters(accountID identity.DID) ([]*Delivery, error)
+
+	// Replay schedules a dead-lettered delivery to be attempted again.
+	Replay(accountID identity.DID, id string) (*Delivery, error)
+
+	// RotateSecret generates a new secret for the webhooks of the account and returns it.
+	RotateSecret(accountID identity.DID) (string, error)
+
+	// CreateSubscription stores a new webhook subscription of the account.
+	CreateSubscription(accountID identity.DID, sub *WebhookSubscription) (*WebhookSubscription, error)
+
+	// GetSubscriptions returns the webhook subscriptions of the account, oldest first.
+	GetSubscriptions(accountID identity.DID) ([]*WebhookSubscription, error)
+
+	// GetSubscription returns the webhook subscription of the account.
+	GetSubscription(accountID identity.DID, id string) (*WebhookSubscription, error)
+
+	// DeleteSubscription deletes the webhook subscription of the account.
+	// Pending deliveries of the subscription are dropped.
+	DeleteSubscription(accountID identity.DID, id string) error
+
+	// Name returns the name of the dispatcher server.
+	Name() string
+
+	// Start attempts the due deliveries until the context is done.
+	Start(ctx context.Context, wg *sync.WaitGroup, startupErr chan<- error)
+}
+
+// NewDispatcher returns a Dispatcher storing the deliveries in the repository.
+func NewDispatcher(config WebhookConfig, repo storage.Repository) Dispatcher {
+	repo.Register(new(Delivery))
+	repo.Register(new(webhookSecret))
+	repo.Register(new(WebhookSubscription))
+	return &dispatcher{
+		config: config,
+		repo:   repo,
+		client: &http.Client{
+			Timeout: config.GetWebhookTimeout(),
+			Transport: &http.Transport{
+				TLSClientConfig: &tls.Config{InsecureSkipVerify: true}, // Temporary until we have defined a cert truststore
+			},
+		},
+		wake: make(chan struct{}, 1),
+		due:  make(map[string][]*pendingDelivery),
+	}
+}
+
+// dispatcher implements Dispatcher.
+// Only the workers update pending deliveries and only Replay updates dead deliveries.
+// The pending deliveries are indexed in memory by their next attempt, the index is loaded from the repository
+// on the first dispatch. Each subscription has its own worker, so a slow endpoint only delays its own deliveries.
+type dispatcher struct {
+	config WebhookConfig
+	repo   storage.Repository
+	client *http.Client
+	wake   chan struct{}
+
+	// subMu guards the updates of the subscriptions
+	subMu sync.Mutex
+
+	// mu guards the index of the pending deliveries and the due deliveries of the workers
+	mu      sync.Mutex
+	loaded  bool
+	pending pendingIndex
+	due     map[string][]*pendingDelivery
+	workers sync.WaitGroup
+}
+
+// pendingDelivery is the entry of a pending delivery in the index.
+type pendingDelivery struct {
+	accountID   identity.DID
+	id          string
+	queue       string
+	nextAttempt time.Time
+}
+
+func newPendingDelivery(del *Delivery) *pendingDelivery {
+	return &pendingDelivery{
+		accountID:   del.AccountID,
+		id:          del.ID,
+		queue:       del.AccountID.String() + "_" + del.SubscriptionID,
+		nextAttempt: del.NextAttempt,
+	}
+}
+
+// pendingIndex is a min heap of the pending deliveries by next attempt.
+type pendingIndex []*pendingDelivery
+
+func (p pendingIndex) Len() int { return len(p) }
+
+func (p pendingIndex) Less(i, j int) bool {
+	if p[i].nextAttempt.Equal(p[j].nextAttempt) {
+		return p[i].id < p[j].id
+	}
+
+	return p[i].nextAttempt.Before(p[j].nextAttempt)
+}
+
+func (p pendingIndex) Swap(i, j int) { p[i], p[j] = p[j], p[i] }
+
+func (p *pendingIndex) Push(x interface{}) {
+	*p = append(*p, x.(*pendingDelivery))
+}
+
+func (p *pendingIndex) Pop() interface{} {
+	old := *p
+	n := len(old)
+	e := old[n-1]
+	old[n-1] = nil
+	*p = old[:n-1]
+	return e
+}
+
+// newID returns a new hex encoded uuid.
+func newID() string {
+	return hexutil.Encode(uuid.Must(uuid.NewV4()).Bytes())
+}
+
+func deliveryKey(accountID identity.DID, id string) []byte {
+	return []byte(deliveryPrefix + accountID.String() + "_" + id)
+}
+
+func secretKey(accountID identity.DID) []byte {
+	return []byte(secretPrefix + accountID.String())
+}
+
+func (d *dispatcher) Enqueue(accountID identity.DID, subscriptionID, url string, payload []byte) (*Delivery, error) {
+	now := time.Now().UTC()
+	del := &Delivery{
+		ID:             newID(),
+		AccountID:      accountID,
+		SubscriptionID: subscriptionID,
+		URL:            url,
+		Payload:        payload,
+		Status:         DeliveryPending,
+		NextAttempt:    now,
+		CreatedAt:      now,
+		UpdatedAt:      now,
+	}
+
+	err := d.repo.Create(deliveryKey(accountID, del.ID), del)
+	if err != nil {
+		return nil, err
+	}
+
+	d.schedule(del)
+	d.notify()
+	return del, nil
+}
+
+func (d *dispatcher) DeadLetters(accountID identity.DID) ([]*Delivery, error) {
+	models, err := d.repo.GetAllByPrefix(deliveryPrefix + accountID.String() + "_")
+	if err != nil {
+		return nil, err
+	}
+
+	var list []*Delivery
+	for _, m := range models {
+		del, ok := m.(*Delivery)
+		if ok && del.Status == DeliveryDead {
+			list = append(list, del)
+		}
+	}
+
+	sort.Slice(list, func(i, j int) bool {
+		return list[i].CreatedAt.Before(list[j].CreatedAt)
+	})
+
+	return list, nil
+}
+
+func (d *dispatcher) Replay(accountID identity.DID, id string) (*Delivery, error) {
+	key := deliveryKey(accountID, id)
+	m, err := d.repo.Get(key)
+	if err != nil {
+		return nil, ErrDeliveryNotFound
+	}
+
+	del, ok := m.(*Delivery)
+	if !ok {
+		return nil, ErrDeliveryNotFound
+	}
+
+	if del.Status != DeliveryDead {
+		return nil, ErrDeliveryNotDead
+	}
+
+	now := time.Now().UTC()
+	del.Status = DeliveryPending
+	del.Attempts = 0
+	del.NextAttempt = now
+	del.UpdatedAt = now
+	err = d.repo.Update(key, del)
+	if err != nil {
+		return nil, err
+	}
+
+	d.schedule(del)
+	d.notify()
+	return del, nil
+}
+
+func (d *dispatcher) RotateSecret(accountID identity.DID) (string, error) {
+	secret := &webhookSecret{Secret: hexutil.Encode(utils.RandomSlice(32))}
+	key := secretKey(accountID)
+	var err error
+	if d.repo.Exists(key) {
+		err = d.repo.Update(key, secret)
+	} else {
+		err = d.repo.Create(key, secret)
+	}
+	if err != nil {
+		return "", err
+	}
+
+	return secret.Secret, nil
+}
+
+// secret returns the webhook secret of the account, empty if the account has none.
+func (d *dispatcher) secret(accountID identity.DID) string {
+	m, err := d.repo.Get(secretKey(accountID))
+	if err != nil {
+		return ""
+	}
+
+	return m.(*webhookSecret).Secret
+}
+
+// notify wakes up the dispatch loop.
+func (d *dispatcher) notify() {
+	select {
+	case d.wake <- struct{}{}:
+	default:
+	}
+}
+
+func (d *dispatcher) Name() string {
+	return "WebhookDispatcher"
+}
+
+func (d *dispatcher) Start(ctx context.Context, wg *sync.WaitGroup, startupErr chan<- error) {
+	defer wg.Done()
+	ticker := time.NewTicker(dispatchInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			d.workers.Wait()
+			log.Info("webhook dispatcher stopped")
+			return
+		case <-ticker.C:
+		case <-d.wake:
+		}
+
+		d.dispatch(ctx, time.Now().UTC())
+	}
+}
+
+// schedule indexes the pending delivery by its next attempt.
+// Deliveries stored before the index is loaded are indexed by the load.
+func (d *dispatcher) schedule(del *Delivery) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if d.loaded {
+		heap.Push(&d.pending, newPendingDelivery(del))
+	}
+}
+
+// load indexes the pending deliveries of the repository. Must be called with the lock held.
+func (d *dispatcher) load() error {
+	models, err := d.repo.GetAllByPrefix(deliveryPrefix)
+	if err != nil {
+		return err
+	}
+
+	for _, m := range models {
+		del, ok := m.(*Delivery)
+		if ok && del.Status == DeliveryPending {
+			d.pending = append(d.pending, newPendingDelivery(del))
+		}
+	}
+
+	heap.Init(&d.pending)
+	d.loaded = true
+	return nil
+}
+
+// dispatch hands the pending deliveries that are due to the workers of their subscriptions.
+func (d *dispatcher) dispatch(ctx context.Context, now time.Time) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if !d.loaded {
+		if err := d.load(); err != nil {
+			log.Error(err)
+			return
+		}
+	}
+
+	for d.pending.Len() > 0 && !d.pending[0].nextAttempt.After(now) {
+		pd := heap.Pop(&d.pending).(*pendingDelivery)
+		queue, running := d.due[pd.queue]
+		d.due[pd.queue] = append(queue, pd)
+		if !running {
+			d.workers.Add(1)
+			go d.work(ctx, pd.queue)
+		}
+	}
+}
+
+// work attempts the due deliveries of the subscription in order until there are none left.
+// Deliveries not attempted before the context is done are indexed again.
+func (d *dispatcher) work(ctx context.Context, queue string) {
+	defer d.workers.Done()
+	for {
+		d.mu.Lock()
+		due := d.due[queue]
+		if len(due) == 0 || ctx.Err() != nil {
+			for _, pd := range due {
+				heap.Push(&d.pending, pd)
+			}
+			delete(d.due, queue)
+			d.mu.Unlock()
+			return
+		}
+
+		pd := due[0]
+		d.due[queue] = due[1:]
+		d.mu.Unlock()
+		d.attempt(pd)
+	}
+}
+
+// attempt posts the delivery and deletes it once delivered. Failed deliveries are rescheduled or dead-lettered.
+func (d *dispatcher) attempt(pd *pendingDelivery) {
+	key := deliveryKey(pd.accountID, pd.id)
+	m, err := d.repo.Get(key)
+	if err != nil {
+		return
+	}
+
+	// skip the deliveries that are no longer pending or were rescheduled since they were indexed
+	del, ok := m.(*Delivery)
+	if !ok || del.Status != DeliveryPending || del.NextAttempt.After(pd.nextAttempt) {
+		return
+	}
+
+	if del.SubscriptionID != "" && !d.repo.Exists(subscriptionKey(del.AccountID, del.SubscriptionID)) {
+		log.Infof("dropped webhook delivery %s of deleted subscription %s", del.ID, del.SubscriptionID)
+		if err := d.repo.Delete(key); err != nil {
+			log.Error(err)
+		}
+		return
+	}
+
+	err = postWebhook(d.client, del.URL, d.secret(del.AccountID), del.ID, del.Payload)
+	if err == nil {
+		log.Infof("Sent Webhook Notification %s to [%s]", del.ID, del.URL)
+		d.recordDelivery(del, nil)
+		if err = d.repo.Delete(key); err != nil {
+			log.Error(err)
+		}
+		return
+	}
+
+	now := time.Now().UTC()
+	del.Attempts++
+	del.LastError = err.Error()
+	del.UpdatedAt = now
+	del.NextAttempt = now.Add(d.backoff(del.Attempts))
+	if del.Attempts >= d.config.GetWebhookMaxAttempts() {
+		del.Status = DeliveryDead
+		log.Errorf("webhook delivery %s to [%s] is dead-lettered after %d attempts: %v", del.ID, del.URL, del.Attempts, err)
+		d.recordDelivery(del, err)
+	} else {
+		log.Warningf("webhook delivery %s to [%s] failed, attempt %d: %v", del.ID, del.URL, del.Attempts, err)
+	}
+
+	if err = d.repo.Update(key, del); err != nil {
+		log.Error(err)
+		return
+	}
+
+	if del.Status == DeliveryPending {
+		d.schedule(del)
+	}
+}
+
+// backoff returns the wait after the given number of failed attempts.
+func (d *dispatcher) backoff(attempts int) time.Duration {
+	wait, max := d.config.GetWebhookRetryBackoff(), d.config.GetWebhookMaxRetryBackoff()
+	for i := 1; i < attempts && wait < max; i++ {
+		wait *= 2
+	}
+
+	if wait > max {
+		wait = max
+	}
+
+	return wait
+}
+
+// signPayload returns the hex encoded HMAC-SHA256 of the payload.
+func signPayload(secret string, payload []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	_, _ = mac.Write(payload)
+	return hexutil.Encode(mac.Sum(nil))
+}
+
+// postWebhook posts the payload to the url with the signature and idempotency key headers.
+func postWebhook(client *http.Client, url, secret, id string, payload []byte) error {
+	req, err := http.NewRequest(http.MethodPost, url, bytes.NewReader(payload))
+	if err != nil {
+		return err
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(IdempotencyKeyHeader, id)
+	if secret != "" {
+		req.Header.Set(SignatureHeader, signPayload(secret, payload))
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if !utils.InRange(resp.StatusCode, 200, 299) {
+		return errors.New("failed to send webhook: status = %v", resp.StatusCode)
+	}
+
+	return nil
+}
+
+var (
+	defaultDispatcher   Dispatcher
+	defaultDispatcherMu sync.RWMutex
+)
+
+// SetDispatcher sets the dispatcher the webhooks are enqueued to.
+func SetDispatcher(d Dispatcher) {
+	defaultDispatcherMu.Lock()
+	defer defaultDispatcherMu.Unlock()
+	defaultDispatcher = d
+}
+
+// GetDispatcher returns the dispatcher the webhooks are enqueued to, nil if the webhooks are sent directly.
+func GetDispatcher() Dispatcher {
+	defaultDispatcherMu.RLock()
+	defer defaultDispatcherMu.RUnlock()
+	return defaultDispatcher
+}
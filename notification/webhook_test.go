@@ -0,0 +1,295 @@
+// +build unit
+
+package notification
+
+import (
+	"context"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/centrifuge/go-centrifuge/errors"
+	"github.com/centrifuge/go-centrifuge/identity"
+	"github.com/centrifuge/go-centrifuge/storage/leveldb"
+	"github.com/centrifuge/go-centrifuge/testingutils/identity"
+	"github.com/stretchr/testify/assert"
+)
+
+type mockWebhookConfig struct{}
+
+func (mockWebhookConfig) GetWebhookMaxAttempts() int {
+	return 3
+}
+
+func (mockWebhookConfig) GetWebhookRetryBackoff() time.Duration {
+	return time.Minute
+}
+
+func (mockWebhookConfig) GetWebhookMaxRetryBackoff() time.Duration {
+	return 3 * time.Minute
+}
+
+func (mockWebhookConfig) GetWebhookTimeout() time.Duration {
+	return time.Second
+}
+
+func newTestDispatcher(t *testing.T) (*dispatcher, func()) {
+	path := leveldb.GetRandomTestStoragePath()
+	db, err := leveldb.NewLevelDBStorage(path)
+	assert.NoError(t, err)
+	repo := leveldb.NewLevelDBRepository(db)
+	return NewDispatcher(mockWebhookConfig{}, repo).(*dispatcher), func() {
+		repo.Close()
+		os.RemoveAll(path)
+	}
+}
+
+// dispatchAndWait dispatches the due deliveries and waits for the workers to attempt them.
+func dispatchAndWait(d *dispatcher, now time.Time) {
+	d.dispatch(context.Background(), now)
+	d.workers.Wait()
+}
+
+func TestDispatcher_backoff(t *testing.T) {
+	d := &dispatcher{config: mockWebhookConfig{}}
+	assert.Equal(t, time.Minute, d.backoff(1))
+	assert.Equal(t, 2*time.Minute, d.backoff(2))
+	assert.Equal(t, 3*time.Minute, d.backoff(3))
+	assert.Equal(t, 3*time.Minute, d.backoff(30))
+}
+
+func TestDispatcher_Deliver(t *testing.T) {
+	d, cleanup := newTestDispatcher(t)
+	defer cleanup()
+	did := testingidentity.GenerateRandomDID()
+	payload := []byte(`{"event_type":2}`)
+	var mu sync.Mutex
+	var headers []http.Header
+	fail := true
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+		body, err := ioutil.ReadAll(r.Body)
+		assert.NoError(t, err)
+		assert.Equal(t, payload, body)
+		headers = append(headers, r.Header)
+		if fail {
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+	}))
+	defer srv.Close()
+
+	secret, err := d.RotateSecret(did)
+	assert.NoError(t, err)
+	del, err := d.Enqueue(did, "", srv.URL, payload)
+	assert.NoError(t, err)
+	assert.Equal(t, DeliveryPending, del.Status)
+
+	// fails until dead-lettered
+	now := time.Now().UTC()
+	for i := 0; i < 3; i++ {
+		dispatchAndWait(d, now)
+		now = now.Add(time.Hour)
+	}
+
+	// dead deliveries are not attempted
+	dispatchAndWait(d, now)
+	assert.Len(t, headers, 3)
+	for _, h := range headers {
+		assert.Equal(t, del.ID, h.Get(IdempotencyKeyHeader))
+		assert.Equal(t, signPayload(secret, payload), h.Get(SignatureHeader))
+	}
+
+	dead, err := d.DeadLetters(did)
+	assert.NoError(t, err)
+	assert.Len(t, dead, 1)
+	assert.Equal(t, del.ID, dead[0].ID)
+	assert.Equal(t, DeliveryDead, dead[0].Status)
+	assert.Equal(t, 3, dead[0].Attempts)
+	assert.Contains(t, dead[0].LastError, "status = 500")
+
+	// other accounts don't see the dead letters
+	dead, err = d.DeadLetters(testingidentity.GenerateRandomDID())
+	assert.NoError(t, err)
+	assert.Len(t, dead, 0)
+
+	// replay
+	_, err = d.Replay(did, "unknown")
+	assert.Equal(t, ErrDeliveryNotFound, err)
+	replayed, err := d.Replay(did, del.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, DeliveryPending, replayed.Status)
+	assert.Equal(t, 0, replayed.Attempts)
+	_, err = d.Replay(did, del.ID)
+	assert.Equal(t, ErrDeliveryNotDead, err)
+
+	// delivered deliveries are deleted
+	fail = false
+	dispatchAndWait(d, time.Now().UTC())
+	assert.Len(t, headers, 4)
+	assert.False(t, d.repo.Exists(deliveryKey(did, del.ID)))
+
+	// rotated secret
+	newSecret, err := d.RotateSecret(did)
+	assert.NoError(t, err)
+	assert.NotEqual(t, secret, newSecret)
+	assert.Equal(t, newSecret, d.secret(did))
+}
+
+func TestDispatcher_Subscriptions(t *testing.T) {
+	d, cleanup := newTestDispatcher(t)
+	defer cleanup()
+	did := testingidentity.GenerateRandomDID()
+	sender := testingidentity.GenerateRandomDID()
+	fail := false
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if fail {
+			w.WriteHeader(http.StatusBadGateway)
+		}
+	}))
+	defer srv.Close()
+
+	// invalid url
+	_, err := d.CreateSubscription(did, &WebhookSubscription{URL: "ftp://localhost"})
+	assert.True(t, errors.IsOfType(ErrInvalidSubscription, err))
+
+	all, err := d.CreateSubscription(did, &WebhookSubscription{URL: srv.URL})
+	assert.NoError(t, err)
+	filtered, err := d.CreateSubscription(did, &WebhookSubscription{
+		URL:        srv.URL,
+		EventTypes: []EventType{ReceivedPayload},
+		Schemes:    []string{"generic"},
+		SenderIDs:  []identity.DID{sender},
+	})
+	assert.NoError(t, err)
+
+	subs, err := d.GetSubscriptions(did)
+	assert.NoError(t, err)
+	assert.Len(t, subs, 2)
+	assert.Equal(t, all.ID, subs[0].ID)
+	assert.Equal(t, filtered.ID, subs[1].ID)
+
+	// filters
+	msg := Message{EventType: ReceivedPayload, Scheme: "generic", FromID: sender.String()}
+	assert.True(t, all.Matches(msg))
+	assert.True(t, filtered.Matches(msg))
+	assert.False(t, filtered.Matches(Message{EventType: JobCompleted, Scheme: "generic", FromID: sender.String()}))
+	assert.False(t, filtered.Matches(Message{EventType: ReceivedPayload, Scheme: "entity", FromID: sender.String()}))
+	assert.False(t, filtered.Matches(Message{EventType: ReceivedPayload, Scheme: "generic", FromID: did.String()}))
+
+	// delivery state
+	_, err = d.Enqueue(did, filtered.ID, srv.URL, []byte(`{}`))
+	assert.NoError(t, err)
+	dispatchAndWait(d, time.Now().UTC())
+	fail = true
+	_, err = d.Enqueue(did, filtered.ID, srv.URL, []byte(`{}`))
+	assert.NoError(t, err)
+	now := time.Now().UTC()
+	for i := 0; i < 3; i++ {
+		dispatchAndWait(d, now)
+		now = now.Add(time.Hour)
+	}
+
+	sub, err := d.GetSubscription(did, filtered.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, sub.Delivered)
+	assert.Equal(t, 1, sub.Failed)
+	assert.Contains(t, sub.LastError, "status = 502")
+	sub, err = d.GetSubscription(did, all.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, 0, sub.Delivered)
+
+	// deliveries of deleted subscriptions are dropped
+	del, err := d.Enqueue(did, all.ID, srv.URL, []byte(`{}`))
+	assert.NoError(t, err)
+	assert.NoError(t, d.DeleteSubscription(did, all.ID))
+	dispatchAndWait(d, time.Now().UTC())
+	assert.False(t, d.repo.Exists(deliveryKey(did, del.ID)))
+	assert.Equal(t, ErrSubscriptionNotFound, d.DeleteSubscription(did, all.ID))
+	_, err = d.GetSubscription(did, all.ID)
+	assert.Equal(t, ErrSubscriptionNotFound, err)
+}
+
+func TestDispatcher_dispatch_index(t *testing.T) {
+	d, cleanup := newTestDispatcher(t)
+	defer cleanup()
+	did := testingidentity.GenerateRandomDID()
+	var mu sync.Mutex
+	var posted int
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+		posted++
+	}))
+	defer srv.Close()
+
+	// stored before the index is loaded
+	dead := &Delivery{ID: newID(), AccountID: did, URL: srv.URL, Status: DeliveryDead, NextAttempt: time.Now().UTC()}
+	assert.NoError(t, d.repo.Create(deliveryKey(did, dead.ID), dead))
+	later := &Delivery{ID: newID(), AccountID: did, URL: srv.URL, Status: DeliveryPending, NextAttempt: time.Now().UTC().Add(time.Hour)}
+	assert.NoError(t, d.repo.Create(deliveryKey(did, later.ID), later))
+
+	// dead letters are not indexed and future deliveries are not attempted
+	dispatchAndWait(d, time.Now().UTC())
+	assert.Len(t, d.pending, 1)
+	assert.Equal(t, later.ID, d.pending[0].id)
+	assert.Equal(t, 0, posted)
+
+	// enqueued after the index is loaded
+	del, err := d.Enqueue(did, "", srv.URL, []byte(`{}`))
+	assert.NoError(t, err)
+	assert.Len(t, d.pending, 2)
+	assert.Equal(t, del.ID, d.pending[0].id)
+	dispatchAndWait(d, time.Now().UTC())
+	assert.Equal(t, 1, posted)
+	assert.False(t, d.repo.Exists(deliveryKey(did, del.ID)))
+
+	dispatchAndWait(d, time.Now().UTC().Add(2*time.Hour))
+	assert.Equal(t, 2, posted)
+	assert.Len(t, d.pending, 0)
+	assert.True(t, d.repo.Exists(deliveryKey(did, dead.ID)))
+}
+
+func TestDispatcher_dispatch_workers(t *testing.T) {
+	d, cleanup := newTestDispatcher(t)
+	defer cleanup()
+	did := testingidentity.GenerateRandomDID()
+	release := make(chan struct{})
+	blocked := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-release
+	}))
+	defer blocked.Close()
+	delivered := make(chan struct{}, 1)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		delivered <- struct{}{}
+	}))
+	defer srv.Close()
+
+	slow, err := d.CreateSubscription(did, &WebhookSubscription{URL: blocked.URL})
+	assert.NoError(t, err)
+	fast, err := d.CreateSubscription(did, &WebhookSubscription{URL: srv.URL})
+	assert.NoError(t, err)
+	_, err = d.Enqueue(did, slow.ID, blocked.URL, []byte(`{}`))
+	assert.NoError(t, err)
+	del, err := d.Enqueue(did, fast.ID, srv.URL, []byte(`{}`))
+	assert.NoError(t, err)
+
+	// the blocked subscription doesn't delay the other one
+	d.dispatch(context.Background(), time.Now().UTC())
+	select {
+	case <-delivered:
+	case <-time.After(500 * time.Millisecond):
+		assert.Fail(t, "delivery was delayed by the blocked subscription")
+	}
+
+	close(release)
+	d.workers.Wait()
+	assert.False(t, d.repo.Exists(deliveryKey(did, del.ID)))
+	sub, err := d.GetSubscription(did, fast.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, sub.Delivered)
+}
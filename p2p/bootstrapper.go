@@ -40,8 +40,15 @@ func (b Bootstrapper) Bootstrap(ctx map[string]interface{}) error {
 		return errors.New("token registry is not initialised")
 	}
 
+	collector, ok := ctx[documents.BootstrappedSignatureCollector].(documents.SignatureCollector)
+	if !ok {
+		return errors.New("signature collector not initialised")
+	}
+
+	// limiter is shared by all the handlers
+	limiter := receiver.NewLimiter(cfg)
 	ctx[bootstrap.BootstrappedPeer] = &peer{config: cfgService, idService: idService, handlerCreator: func() *receiver.Handler {
-		return receiver.New(cfgService, receiver.HandshakeValidator(cfg.GetNetworkID(), idService), docSrv, tokenRegistry, idService)
+		return receiver.New(cfgService, receiver.HandshakeValidator(cfg.GetNetworkID(), idService), docSrv, tokenRegistry, idService, limiter, collector)
 	}}
 	return nil
 }
@@ -33,8 +33,9 @@ func TestBootstrapper_Bootstrap(t *testing.T) {
 	cs.On("GetConfig").Return(&configstore.NodeConfig{}, nil)
 	ids := new(testingcommons.MockIdentityService)
 	m[identity.BootstrappedDIDService] = ids
-	m[documents.BootstrappedDocumentService] = documents.DefaultService(cfg, nil, nil, documents.NewServiceRegistry(), ids, nil, nil)
+	m[documents.BootstrappedDocumentService] = documents.DefaultService(cfg, nil, nil, documents.NewServiceRegistry(), ids, nil, nil, nil)
 	m[bootstrap.BootstrappedNFTService] = new(testingdocuments.MockRegistry)
+	m[documents.BootstrappedSignatureCollector] = new(documents.MockSignatureCollector)
 
 	err = b.Bootstrap(m)
 	assert.Nil(t, err)
@@ -55,15 +55,10 @@ func (s *peer) SendAnchoredDocument(ctx context.Context, receiverID identity.DID
 		return nil, err
 	}
 
-	envelope, err := p2pcommon.PrepareP2PEnvelope(ctx, nc.GetNetworkID(), p2pcommon.MessageTypeSendAnchoredDoc, in)
-	if err != nil {
-		return nil, err
-	}
-
-	recv, err := s.mes.SendMessage(
-		ctx, pid,
-		envelope,
-		p2pcommon.ProtocolForDID(&receiverID))
+	recv, err := s.sendMessage(
+		ctx, nc, pid,
+		p2pcommon.ProtocolForDID(&receiverID),
+		p2pcommon.MessageTypeSendAnchoredDoc, in)
 	if err != nil {
 		return nil, err
 	}
@@ -134,10 +129,11 @@ func (s *peer) GetDocumentRequest(ctx context.Context, requesterID identity.DID,
 		return nil, err
 	}
 
-	recv, err := s.mes.SendMessage(
+	protoc := p2pcommon.ProtocolForDID(&requesterID)
+	recv, err := s.currentMessenger().SendMessage(
 		ctx, pid,
 		envelope,
-		p2pcommon.ProtocolForDID(&requesterID))
+		protoc)
 	if err != nil {
 		return nil, err
 	}
@@ -147,6 +143,14 @@ func (s *peer) GetDocumentRequest(ctx context.Context, requesterID identity.DID,
 		return nil, err
 	}
 
+	// documents too big for a single message are downloaded in chunks
+	if p2pcommon.MessageTypeGetDocChunkedRep.Equals(recvEnvelope.Header.Type) {
+		recvEnvelope, err = s.resolveChunkedResponse(ctx, nc, pid, protoc, recvEnvelope)
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	// handle client error
 	if p2pcommon.MessageTypeError.Equals(recvEnvelope.Header.Type) {
 		return nil, p2pcommon.ConvertClientError(recvEnvelope)
@@ -195,14 +199,14 @@ func (s *peer) getPeerID(ctx context.Context, id identity.DID) (libp2pPeer.ID, e
 		}
 		c, canc := context.WithTimeout(ctx, nc.GetP2PConnectionTimeout())
 		defer canc()
-		pinfo, err := s.dht.FindPeer(c, peerID)
+		pinfo, err := s.currentDHT().FindPeer(c, peerID)
 		if err != nil {
 			return peerID, err
 		}
 
 		// We have a peer ID and a targetAddr so we add it to the peer store
 		// so LibP2P knows how to contact it (this call might be redundant)
-		s.host.Peerstore().AddAddrs(peerID, pinfo.Addrs, pstore.PermanentAddrTTL)
+		s.currentHost().Peerstore().AddAddrs(peerID, pinfo.Addrs, pstore.PermanentAddrTTL)
 	}
 
 	return peerID, nil
@@ -246,12 +250,8 @@ func (s *peer) getSignatureForDocument(ctx context.Context, model documents.Mode
 		if err != nil {
 			return nil, err
 		}
-		envelope, err := p2pcommon.PrepareP2PEnvelope(ctx, nc.GetNetworkID(), p2pcommon.MessageTypeRequestSignature, &p2ppb.SignatureRequest{Document: &cd})
-		if err != nil {
-			return nil, err
-		}
 		log.Infof("Requesting signature from %s\n", receiverPeer)
-		recv, err := s.mes.SendMessage(ctx, receiverPeer, envelope, p2pcommon.ProtocolForDID(&collaborator))
+		recv, err := s.sendMessage(ctx, nc, receiverPeer, p2pcommon.ProtocolForDID(&collaborator), p2pcommon.MessageTypeRequestSignature, &p2ppb.SignatureRequest{Document: &cd})
 		if err != nil {
 			return nil, err
 		}
@@ -298,6 +298,21 @@ func (s *peer) getSignatureAsync(ctx context.Context, model documents.Model, col
 
 // GetSignaturesForDocument requests peer nodes for the signature, verifies them, and returns those signatures.
 func (s *peer) GetSignaturesForDocument(ctx context.Context, model documents.Model) (signatures []*coredocumentpb.Signature, signatureCollectionErrors []error, err error) {
+	selfDID, err := contextutil.AccountDID(ctx)
+	if err != nil {
+		return nil, nil, errors.New("failed to get self ID")
+	}
+
+	cs, err := model.GetSignerCollaborators(selfDID)
+	if err != nil {
+		return nil, nil, errors.New("failed to get external collaborators")
+	}
+
+	return s.GetSignaturesFromCollaborators(ctx, model, cs)
+}
+
+// GetSignaturesFromCollaborators requests the given collaborators for the signature, verifies them, and returns those signatures.
+func (s *peer) GetSignaturesFromCollaborators(ctx context.Context, model documents.Model, collaborators []identity.DID) (signatures []*coredocumentpb.Signature, signatureCollectionErrors []error, err error) {
 	in := make(chan signatureResponseWrap)
 	defer close(in)
 
@@ -311,15 +326,10 @@ func (s *peer) GetSignaturesForDocument(ctx context.Context, model documents.Mod
 		return nil, nil, errors.New("failed to get self ID")
 	}
 
-	cs, err := model.GetSignerCollaborators(selfDID)
-	if err != nil {
-		return nil, nil, errors.New("failed to get external collaborators")
-	}
-
 	var count int
 	peerCtx, cancel := context.WithTimeout(ctx, nc.GetP2PConnectionTimeout())
 	defer cancel()
-	for _, c := range cs {
+	for _, c := range collaborators {
 		count++
 		go s.getSignatureAsync(peerCtx, model, c, selfDID, in)
 	}
@@ -342,38 +352,94 @@ func (s *peer) GetSignaturesForDocument(ctx context.Context, model documents.Mod
 	return signatures, signatureCollectionErrors, nil
 }
 
-func (s *peer) validateSignatureResp(
-	model documents.Model,
-	receiver identity.DID,
-	header *p2ppb.Header,
-	resp *p2ppb.SignatureResponse) error {
+// SendSignatures sends the signatures of a document version to the collaborator that requested them earlier.
+func (s *peer) SendSignatures(ctx context.Context, receiverID identity.DID, version []byte, signatures []*coredocumentpb.Signature) error {
+	nc, err := s.config.GetConfig()
+	if err != nil {
+		return err
+	}
 
-	compatible := version.CheckVersion(header.NodeVersion)
-	if !compatible {
-		return version.IncompatibleVersionError(header.NodeVersion)
+	selfDID, err := contextutil.AccountDID(ctx)
+	if err != nil {
+		return err
+	}
+
+	peerCtx, cancel := context.WithTimeout(ctx, nc.GetP2PConnectionTimeout())
+	defer cancel()
+
+	sigs := &p2pcommon.Signatures{DocumentVersion: version, Signatures: signatures}
+	tc, err := s.config.GetAccount(receiverID[:])
+	if err == nil {
+		// this is a local account
+		h := s.handlerCreator()
+		// the following context has to be different from the parent context since its initiating a local peer call
+		localCtx, err := contextutil.New(peerCtx, tc)
+		if err != nil {
+			return err
+		}
+
+		_, err = h.SendSignatures(localCtx, sigs, selfDID)
+		return err
+	}
+
+	err = s.idService.Exists(ctx, receiverID)
+	if err != nil {
+		return err
 	}
 
-	tm, err := model.Timestamp()
+	// this is a remote account
+	pid, err := s.getPeerID(ctx, receiverID)
 	if err != nil {
-		return errors.New("cannot get model timestamp : %s", err.Error())
+		return err
 	}
 
-	signingRoot, err := model.CalculateSigningRoot()
+	envelope, err := p2pcommon.PrepareTransferEnvelope(ctx, nc.GetNetworkID(), p2pcommon.MessageTypeSendSignatures, sigs)
 	if err != nil {
-		return errors.New("failed to calculate signing root: %s", err.Error())
+		return err
 	}
 
-	for _, sig := range resp.Signatures {
-		err = identity.ValidateDIDBytes(sig.SignerId, receiver)
-		if err != nil {
-			return errors.New("signature invalid with err: %s", err.Error())
-		}
+	recv, err := s.currentMessenger().SendMessage(peerCtx, pid, envelope, p2pcommon.ProtocolForDID(&receiverID))
+	if err != nil {
+		return err
+	}
 
-		err = s.idService.ValidateSignature(receiver, sig.PublicKey, sig.Signature, documents.ConsensusSignaturePayload(signingRoot, sig.TransitionValidated), tm)
-		if err != nil {
-			return errors.New("signature invalid with err: %s", err.Error())
-		}
+	recvEnvelope, err := p2pcommon.ResolveDataEnvelope(recv)
+	if err != nil {
+		return err
+	}
+
+	// handle client error
+	if p2pcommon.MessageTypeError.Equals(recvEnvelope.Header.Type) {
+		return p2pcommon.ConvertClientError(recvEnvelope)
+	}
+
+	if !p2pcommon.MessageTypeSendSignaturesRep.Equals(recvEnvelope.Header.Type) {
+		return errors.New("the received send signatures response is incorrect")
+	}
+
+	ack := new(p2pcommon.SignaturesAck)
+	err = p2pcommon.ResolveTransferMessage(recvEnvelope, ack)
+	if err != nil {
+		return err
+	}
+
+	if !ack.Accepted {
+		return errors.New("signatures were not accepted by %s", receiverID)
 	}
 
 	return nil
 }
+
+func (s *peer) validateSignatureResp(
+	model documents.Model,
+	receiver identity.DID,
+	header *p2ppb.Header,
+	resp *p2ppb.SignatureResponse) error {
+
+	compatible := version.CheckVersion(header.NodeVersion)
+	if !compatible {
+		return version.IncompatibleVersionError(header.NodeVersion)
+	}
+
+	return documents.ValidateCollaboratorSignatures(s.idService, model, receiver, resp.Signatures)
+}
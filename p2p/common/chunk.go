@@ -0,0 +1,239 @@
+package p2pcommon
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/json"
+	"io"
+
+	"github.com/centrifuge/centrifuge-protobufs/gen/go/p2p"
+	"github.com/centrifuge/centrifuge-protobufs/gen/go/protocol"
+	"github.com/centrifuge/go-centrifuge/errors"
+)
+
+const (
+	// ErrChunkChecksumMismatch must be used when the checksum of a received chunk doesn't match its data
+	ErrChunkChecksumMismatch = errors.Error("chunk checksum mismatch")
+
+	// ErrTransferChecksumMismatch must be used when the assembled payload doesn't match the transfer ID
+	ErrTransferChecksumMismatch = errors.Error("transfer checksum mismatch")
+
+	// ErrInvalidChunk must be used when the chunk doesn't fit in the transfer it claims to belong to
+	ErrInvalidChunk = errors.Error("invalid chunk")
+
+	// ErrTransferTooLarge must be used when the announced size of a transfer exceeds the configured maximum
+	ErrTransferTooLarge = errors.Error("transfer too large")
+)
+
+// Chunk is a single checksummed piece of a payload that is too big to be sent in a single message.
+// Chunks of a transfer must be sent in order, the receiver acknowledges every chunk with the index of the next chunk it expects
+// which allows the sender to resume an interrupted transfer.
+type Chunk struct {
+	// TransferID is the sha256 of the complete payload. Sending the same payload again resumes the transfer.
+	TransferID []byte `json:"transfer_id"`
+
+	// MessageType is the type of the message carried by the transfer
+	MessageType string `json:"message_type"`
+
+	TotalSize int64  `json:"total_size"`
+	ChunkSize int    `json:"chunk_size"`
+	Index     int    `json:"index"`
+	Data      []byte `json:"data"`
+
+	// Checksum is the sha256 of the Data
+	Checksum []byte `json:"checksum"`
+}
+
+// ChunkAck is the response to a received chunk that is not the last one of the transfer.
+type ChunkAck struct {
+	TransferID []byte `json:"transfer_id"`
+
+	// Next is the index of the next chunk the receiver expects
+	Next int `json:"next"`
+}
+
+// TransferHeader describes a payload that the responding node has prepared to be downloaded in chunks.
+type TransferHeader struct {
+	TransferID  []byte `json:"transfer_id"`
+	MessageType string `json:"message_type"`
+	TotalSize   int64  `json:"total_size"`
+	ChunkSize   int    `json:"chunk_size"`
+}
+
+// Chunks returns the number of chunks in the transfer.
+func (h TransferHeader) Chunks() int {
+	return ChunkCount(h.TotalSize, h.ChunkSize)
+}
+
+// CheckSize checks the announced sizes of the transfer against the maximum transfer size.
+// Must be called before anything is allocated or spooled for the transfer, the sizes are chosen by the peer.
+func (h TransferHeader) CheckSize(maxSize int64) error {
+	return CheckTransferSize(h.TotalSize, h.ChunkSize, maxSize)
+}
+
+// CheckTransferSize checks that the transfer and chunk sizes are positive and do not exceed maxSize.
+func CheckTransferSize(totalSize int64, chunkSize int, maxSize int64) error {
+	if totalSize <= 0 || chunkSize <= 0 {
+		return ErrInvalidChunk
+	}
+
+	if totalSize > maxSize || int64(chunkSize) > maxSize {
+		return errors.NewTypedError(ErrTransferTooLarge, errors.New("size %d, chunk size %d, max %d", totalSize, chunkSize, maxSize))
+	}
+
+	return nil
+}
+
+// ChunkRequest requests a single chunk of a prepared transfer.
+type ChunkRequest struct {
+	TransferHeader
+	Index int `json:"index"`
+}
+
+// NewTransferID returns the transfer ID for the given payload.
+func NewTransferID(data []byte) []byte {
+	h := sha256.Sum256(data)
+	return h[:]
+}
+
+// NewTransferIDFromReader returns the transfer ID and the size of the payload read from r.
+func NewTransferIDFromReader(r io.Reader) (transferID []byte, size int64, err error) {
+	h := sha256.New()
+	size, err = io.Copy(h, r)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return h.Sum(nil), size, nil
+}
+
+// ChunkCount returns the number of chunks required to send totalSize bytes with the given chunkSize.
+func ChunkCount(totalSize int64, chunkSize int) int {
+	if chunkSize <= 0 || totalSize <= 0 {
+		return 0
+	}
+
+	cs := int64(chunkSize)
+	return int((totalSize + cs - 1) / cs)
+}
+
+// NewChunk creates the chunk with the given index out of the complete payload.
+func NewChunk(transferID []byte, messageType MessageType, data []byte, chunkSize, index int) (Chunk, error) {
+	total := int64(len(data))
+	if index < 0 || index >= ChunkCount(total, chunkSize) {
+		return Chunk{}, ErrInvalidChunk
+	}
+
+	start := index * chunkSize
+	end := start + chunkSize
+	if end > len(data) {
+		end = len(data)
+	}
+
+	return newChunk(transferID, messageType.String(), total, chunkSize, index, data[start:end]), nil
+}
+
+func newChunk(transferID []byte, messageType string, totalSize int64, chunkSize, index int, data []byte) Chunk {
+	sum := sha256.Sum256(data)
+	return Chunk{
+		TransferID:  transferID,
+		MessageType: messageType,
+		TotalSize:   totalSize,
+		ChunkSize:   chunkSize,
+		Index:       index,
+		Data:        data,
+		Checksum:    sum[:],
+	}
+}
+
+// ReadChunk reads the chunk with the given index of the transfer from the spooled payload.
+func ReadChunk(r io.ReaderAt, h TransferHeader, index int) (Chunk, error) {
+	if index < 0 || index >= h.Chunks() {
+		return Chunk{}, ErrInvalidChunk
+	}
+
+	offset := int64(index) * int64(h.ChunkSize)
+	size := int64(h.ChunkSize)
+	if offset+size > h.TotalSize {
+		size = h.TotalSize - offset
+	}
+
+	data := make([]byte, size)
+	_, err := r.ReadAt(data, offset)
+	if err != nil {
+		return Chunk{}, err
+	}
+
+	return NewChunkFromHeader(h, index, data), nil
+}
+
+// ReadPayload reads the verified payload of a transfer from the spool to decode its message.
+// Messages are decoded from a byte slice, so the payload is read once into a buffer of the transfer size.
+func ReadPayload(r io.Reader, size int64) ([]byte, error) {
+	data := make([]byte, size)
+	_, err := io.ReadFull(r, data)
+	if err != nil {
+		return nil, err
+	}
+
+	return data, nil
+}
+
+// NewChunkFromHeader creates the chunk with the given index and data for a prepared transfer.
+func NewChunkFromHeader(h TransferHeader, index int, data []byte) Chunk {
+	return newChunk(h.TransferID, h.MessageType, h.TotalSize, h.ChunkSize, index, data)
+}
+
+// Validate checks that the chunk fits in the transfer and the data matches the checksum.
+func (c Chunk) Validate() error {
+	count := ChunkCount(c.TotalSize, c.ChunkSize)
+	if len(c.TransferID) != sha256.Size || c.Index < 0 || c.Index >= count {
+		return ErrInvalidChunk
+	}
+
+	expected := c.ChunkSize
+	if c.Index == count-1 {
+		expected = int(c.TotalSize - int64(c.Index)*int64(c.ChunkSize))
+	}
+
+	if len(c.Data) != expected {
+		return ErrInvalidChunk
+	}
+
+	sum := sha256.Sum256(c.Data)
+	if !bytes.Equal(sum[:], c.Checksum) {
+		return ErrChunkChecksumMismatch
+	}
+
+	return nil
+}
+
+// IsLast returns true if this is the last chunk of the transfer.
+func (c Chunk) IsLast() bool {
+	return c.Index == ChunkCount(c.TotalSize, c.ChunkSize)-1
+}
+
+// ValidateTransfer checks that the assembled payload matches the transfer ID.
+func ValidateTransfer(transferID, data []byte) error {
+	if !bytes.Equal(NewTransferID(data), transferID) {
+		return ErrTransferChecksumMismatch
+	}
+
+	return nil
+}
+
+// PrepareTransferEnvelope wraps a json encoded message(Chunk, ChunkAck, TransferHeader, ChunkRequest, Signatures) into p2p envelope.
+func PrepareTransferEnvelope(ctx context.Context, networkID uint32, messageType MessageType, mes interface{}) (*protocolpb.P2PEnvelope, error) {
+	body, err := json.Marshal(mes)
+	if err != nil {
+		return nil, err
+	}
+
+	return prepareP2PEnvelope(ctx, networkID, messageType, body)
+}
+
+// ResolveTransferMessage unmarshals the body of the envelope into the json encoded message.
+func ResolveTransferMessage(envelope *p2ppb.Envelope, mes interface{}) error {
+	return json.Unmarshal(envelope.Body, mes)
+}
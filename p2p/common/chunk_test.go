@@ -0,0 +1,131 @@
+// +build unit
+
+package p2pcommon
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/centrifuge/go-centrifuge/errors"
+	"github.com/centrifuge/go-centrifuge/testingutils/config"
+	"github.com/centrifuge/go-centrifuge/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestChunkCount(t *testing.T) {
+	assert.Equal(t, 0, ChunkCount(10, 0))
+	assert.Equal(t, 0, ChunkCount(0, 10))
+	assert.Equal(t, 1, ChunkCount(10, 10))
+	assert.Equal(t, 2, ChunkCount(11, 10))
+	assert.Equal(t, 3, ChunkCount(30, 10))
+}
+
+func TestCheckTransferSize(t *testing.T) {
+	assert.Equal(t, ErrInvalidChunk, CheckTransferSize(0, 10, 100))
+	assert.Equal(t, ErrInvalidChunk, CheckTransferSize(10, 0, 100))
+	assert.True(t, errors.IsOfType(ErrTransferTooLarge, CheckTransferSize(101, 10, 100)))
+	assert.True(t, errors.IsOfType(ErrTransferTooLarge, TransferHeader{TotalSize: 10, ChunkSize: 1 << 20}.CheckSize(100)))
+	assert.NoError(t, TransferHeader{TotalSize: 100, ChunkSize: 10}.CheckSize(100))
+}
+
+func TestNewChunk(t *testing.T) {
+	data := utils.RandomSlice(25)
+	tid := NewTransferID(data)
+
+	// invalid index
+	_, err := NewChunk(tid, MessageTypeSendAnchoredDoc, data, 10, 3)
+	assert.Error(t, err)
+	assert.Equal(t, ErrInvalidChunk, err)
+
+	var assembled []byte
+	for i := 0; i < ChunkCount(int64(len(data)), 10); i++ {
+		c, err := NewChunk(tid, MessageTypeSendAnchoredDoc, data, 10, i)
+		assert.NoError(t, err)
+		assert.NoError(t, c.Validate())
+		assert.Equal(t, MessageTypeSendAnchoredDoc.String(), c.MessageType)
+		assert.Equal(t, i == 2, c.IsLast())
+		assembled = append(assembled, c.Data...)
+	}
+
+	assert.Equal(t, data, assembled)
+	assert.NoError(t, ValidateTransfer(tid, assembled))
+	assert.Equal(t, ErrTransferChecksumMismatch, ValidateTransfer(tid, assembled[1:]))
+}
+
+func TestReadChunk(t *testing.T) {
+	data := utils.RandomSlice(25)
+	tid, size, err := NewTransferIDFromReader(bytes.NewReader(data))
+	assert.NoError(t, err)
+	assert.Equal(t, NewTransferID(data), tid)
+	assert.Equal(t, int64(25), size)
+	h := TransferHeader{TransferID: tid, MessageType: MessageTypeGetDocRep.String(), TotalSize: size, ChunkSize: 10}
+
+	// invalid index
+	_, err = ReadChunk(bytes.NewReader(data), h, 3)
+	assert.Equal(t, ErrInvalidChunk, err)
+
+	// chunks are the same as the ones of the payload
+	for i := 0; i < h.Chunks(); i++ {
+		c, err := ReadChunk(bytes.NewReader(data), h, i)
+		assert.NoError(t, err)
+		ec, err := NewChunk(tid, MessageTypeGetDocRep, data, 10, i)
+		assert.NoError(t, err)
+		assert.Equal(t, ec, c)
+	}
+
+	// payload shorter than announced
+	_, err = ReadChunk(bytes.NewReader(data[:21]), h, 2)
+	assert.Error(t, err)
+
+	payload, err := ReadPayload(bytes.NewReader(data), size)
+	assert.NoError(t, err)
+	assert.Equal(t, data, payload)
+	_, err = ReadPayload(bytes.NewReader(data), size+1)
+	assert.Error(t, err)
+}
+
+func TestChunk_Validate(t *testing.T) {
+	data := utils.RandomSlice(25)
+	tid := NewTransferID(data)
+	c, err := NewChunk(tid, MessageTypeSendAnchoredDoc, data, 10, 1)
+	assert.NoError(t, err)
+
+	// tampered data
+	tc := c
+	tc.Data = utils.RandomSlice(10)
+	assert.Equal(t, ErrChunkChecksumMismatch, tc.Validate())
+
+	// wrong size
+	tc = c
+	tc.Data = c.Data[1:]
+	assert.Equal(t, ErrInvalidChunk, tc.Validate())
+
+	// invalid transfer ID
+	tc = c
+	tc.TransferID = utils.RandomSlice(10)
+	assert.Equal(t, ErrInvalidChunk, tc.Validate())
+
+	// out of range
+	tc = c
+	tc.Index = 3
+	assert.Equal(t, ErrInvalidChunk, tc.Validate())
+}
+
+func TestPrepareTransferEnvelope(t *testing.T) {
+	ctx := testingconfig.CreateAccountContext(t, cfg)
+	data := utils.RandomSlice(25)
+	c, err := NewChunk(NewTransferID(data), MessageTypeSendAnchoredDoc, data, 10, 0)
+	assert.NoError(t, err)
+
+	pe, err := PrepareTransferEnvelope(ctx, cfg.GetNetworkID(), MessageTypeChunk, c)
+	assert.NoError(t, err)
+
+	env, err := ResolveDataEnvelope(pe)
+	assert.NoError(t, err)
+	assert.True(t, MessageTypeChunk.Equals(env.Header.Type))
+
+	rc := new(Chunk)
+	assert.NoError(t, ResolveTransferMessage(env, rc))
+	assert.Equal(t, c, *rc)
+	assert.NoError(t, rc.Validate())
+}
@@ -41,6 +41,20 @@ const (
 	MessageTypeGetDoc MessageType = "MessageTypeGetDoc"
 	//MessageTypeGetDocRep defines GetAnchoredDoc response type
 	MessageTypeGetDocRep MessageType = "MessageTypeGetDocRep"
+	// MessageTypeGetDocChunkedRep defines GetAnchoredDoc response type when the document has to be downloaded in chunks
+	MessageTypeGetDocChunkedRep MessageType = "MessageTypeGetDocChunkedRep"
+	// MessageTypeChunk defines a single chunk of a chunked transfer
+	MessageTypeChunk MessageType = "MessageTypeChunk"
+	// MessageTypeChunkRep defines the acknowledgement of a received chunk
+	MessageTypeChunkRep MessageType = "MessageTypeChunkRep"
+	// MessageTypeGetChunk defines a request for a chunk of a prepared transfer
+	MessageTypeGetChunk MessageType = "MessageTypeGetChunk"
+	// MessageTypeGetChunkRep defines GetChunk response type
+	MessageTypeGetChunkRep MessageType = "MessageTypeGetChunkRep"
+	// MessageTypeSendSignatures defines signatures sent after the signature request returned
+	MessageTypeSendSignatures MessageType = "MessageTypeSendSignatures"
+	// MessageTypeSendSignaturesRep defines SendSignatures response type
+	MessageTypeSendSignaturesRep MessageType = "MessageTypeSendSignaturesRep"
 )
 
 //MessageTypes map for MessageTypeFromString function
@@ -53,6 +67,13 @@ var messageTypes = map[string]MessageType{
 	"MessageTypeSendAnchoredDocRep":  "MessageTypeSendAnchoredDocRep",
 	"MessageTypeGetDoc":              "MessageTypeGetDoc",
 	"MessageTypeGetDocRep":           "MessageTypeGetDocRep",
+	"MessageTypeGetDocChunkedRep":    "MessageTypeGetDocChunkedRep",
+	"MessageTypeChunk":               "MessageTypeChunk",
+	"MessageTypeChunkRep":            "MessageTypeChunkRep",
+	"MessageTypeGetChunk":            "MessageTypeGetChunk",
+	"MessageTypeGetChunkRep":         "MessageTypeGetChunkRep",
+	"MessageTypeSendSignatures":      "MessageTypeSendSignatures",
+	"MessageTypeSendSignaturesRep":   "MessageTypeSendSignaturesRep",
 }
 
 // Equals compares if string is of a particular MessageType
@@ -108,6 +129,16 @@ func ResolveDataEnvelope(mes proto.Message) (*p2ppb.Envelope, error) {
 
 // PrepareP2PEnvelope wraps content message into p2p envelope
 func PrepareP2PEnvelope(ctx context.Context, networkID uint32, messageType MessageType, mes proto.Message) (*protocolpb.P2PEnvelope, error) {
+	body, err := proto.Marshal(mes)
+	if err != nil {
+		return nil, err
+	}
+
+	return prepareP2PEnvelope(ctx, networkID, messageType, body)
+}
+
+// prepareP2PEnvelope wraps an already serialised body into p2p envelope
+func prepareP2PEnvelope(ctx context.Context, networkID uint32, messageType MessageType, body []byte) (*protocolpb.P2PEnvelope, error) {
 	self, err := contextutil.Account(ctx)
 	if err != nil {
 		return nil, err
@@ -127,11 +158,6 @@ func PrepareP2PEnvelope(ctx context.Context, networkID uint32, messageType Messa
 		Timestamp:         tm,
 	}
 
-	body, err := proto.Marshal(mes)
-	if err != nil {
-		return nil, err
-	}
-
 	envelope := &p2ppb.Envelope{
 		Header: p2pheader,
 		Body:   body,
@@ -0,0 +1,19 @@
+package p2pcommon
+
+import (
+	"github.com/centrifuge/centrifuge-protobufs/gen/go/coredocument"
+)
+
+// Signatures carries the signatures of a document version that are sent after the signature request returned,
+// e.g. when a held signature request is approved.
+type Signatures struct {
+	// DocumentVersion is the version of the document the signatures are for
+	DocumentVersion []byte `json:"document_version"`
+
+	Signatures []*coredocumentpb.Signature `json:"signatures"`
+}
+
+// SignaturesAck is the response to received signatures.
+type SignaturesAck struct {
+	Accepted bool `json:"accepted"`
+}
@@ -54,5 +54,20 @@ Once a message is forwarded to the stream handler registered for a particular pr
 2.3 Once the message has been decoded(unmarshalled) in to `MessageEnvelope` the handler(router) can identify the message type and forward to the relevant specific handler for the given message type. The message type in this case is also serves as a protocol multiplexer.
 
 2.4 The actual message byte encoding depends on the message type as well, which the router can decide to decode or forward as is.
+
+3. Chunked Transfers
+
+Messages bigger than the configured `p2p.chunkSize` are split into chunks so that no single envelope exceeds the stream limits.
+Chunk messages are JSON encoded in the envelope body.
+
+3.1 Push (SendAnchoredDocument, RequestSignature): the sender sends `Chunk` messages in order. Every chunk carries the
+transfer ID(sha256 of the complete payload), the message type of the payload, the total size, the chunk size, the index and
+a sha256 checksum of the chunk data. The receiver spools the chunks to disk and acknowledges each one with a `ChunkAck`
+containing the index of the next chunk it expects. The response to the last chunk is the response to the assembled message.
+Sending the same payload again resumes an interrupted transfer from the acknowledged index.
+
+3.2 Pull (GetDocument): a responder whose response is bigger than its chunk size replies with a `TransferHeader` of type
+`MessageTypeGetDocChunkedRep`. The requester then downloads every chunk with `GetChunk` requests and validates the
+assembled payload against the transfer ID. A prepared transfer is only served to the node that requested it.
 */
 package p2p
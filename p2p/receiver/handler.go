@@ -30,6 +30,8 @@ type Handler struct {
 	docSrv             documents.Service
 	tokenRegistry      documents.TokenRegistry
 	srvDID             identity.Service
+	limiter            *Limiter
+	collector          documents.SignatureCollector
 }
 
 // New returns an implementation of P2PServiceServer
@@ -38,13 +40,17 @@ func New(
 	handshakeValidator ValidatorGroup,
 	docSrv documents.Service,
 	tokenRegistry documents.TokenRegistry,
-	srvDID identity.Service) *Handler {
+	srvDID identity.Service,
+	limiter *Limiter,
+	collector documents.SignatureCollector) *Handler {
 	return &Handler{
 		config:             config,
 		handshakeValidator: handshakeValidator,
 		docSrv:             docSrv,
 		tokenRegistry:      tokenRegistry,
 		srvDID:             srvDID,
+		limiter:            limiter,
+		collector:          collector,
 	}
 }
 
@@ -56,11 +62,18 @@ func (srv *Handler) HandleInterceptor(ctx context.Context, peer peer.ID, protoc
 	}
 	defer timeutils.EnsureDelayOperation(time.Now(), cfg.GetP2PResponseDelay())
 
+	releasePeer, err := srv.limiter.AcquirePeer(peer)
+	if err != nil {
+		return srv.convertToErrorEnvelop(err)
+	}
+	defer releasePeer()
+
 	if msg == nil {
 		return srv.convertToErrorEnvelop(errors.New("nil payload provided"))
 	}
 	envelope, err := p2pcommon.ResolveDataEnvelope(msg)
 	if err != nil {
+		srv.limiter.FailPeer(peer)
 		return srv.convertToErrorEnvelop(err)
 	}
 
@@ -84,9 +97,29 @@ func (srv *Handler) HandleInterceptor(ctx context.Context, peer peer.ID, protoc
 	}
 	err = srv.handshakeValidator.Validate(envelope.Header, &collaborator, &peer)
 	if err != nil {
+		srv.limiter.FailPeer(peer)
 		return srv.convertToErrorEnvelop(err)
 	}
 
+	// the DID is only limited once it is validated against the peer
+	releaseDID, err := srv.limiter.AcquireDID(collaborator)
+	if err != nil {
+		return srv.convertToErrorEnvelop(err)
+	}
+	defer releaseDID()
+
+	switch p2pcommon.MessageTypeFromString(envelope.Header.Type) {
+	case p2pcommon.MessageTypeChunk:
+		return srv.HandleChunk(ctx, peer, protoc, envelope)
+	case p2pcommon.MessageTypeGetChunk:
+		return srv.HandleGetChunk(ctx, peer, protoc, envelope)
+	default:
+		return srv.dispatch(ctx, peer, protoc, envelope)
+	}
+}
+
+// dispatch routes a complete message to the handler of its type
+func (srv *Handler) dispatch(ctx context.Context, peer peer.ID, protoc protocol.ID, envelope *p2ppb.Envelope) (*pb.P2PEnvelope, error) {
 	switch p2pcommon.MessageTypeFromString(envelope.Header.Type) {
 	case p2pcommon.MessageTypeRequestSignature:
 		return srv.HandleRequestDocumentSignature(ctx, peer, protoc, envelope)
@@ -94,10 +127,11 @@ func (srv *Handler) HandleInterceptor(ctx context.Context, peer peer.ID, protoc
 		return srv.HandleSendAnchoredDocument(ctx, peer, protoc, envelope)
 	case p2pcommon.MessageTypeGetDoc:
 		return srv.HandleGetDocument(ctx, peer, protoc, envelope)
+	case p2pcommon.MessageTypeSendSignatures:
+		return srv.HandleSendSignatures(ctx, peer, protoc, envelope)
 	default:
 		return srv.convertToErrorEnvelop(errors.New("MessageType [%s] not found", envelope.Header.Type))
 	}
-
 }
 
 // HandleRequestDocumentSignature handles the RequestDocumentSignature message
@@ -114,6 +148,9 @@ func (srv *Handler) HandleRequestDocumentSignature(ctx context.Context, peer pee
 	}
 	res, err := srv.RequestDocumentSignature(ctx, req, collaborator)
 	if err != nil {
+		if errors.IsOfType(documents.ErrDocumentInvalid, err) {
+			srv.fail(peer, collaborator)
+		}
 		return srv.convertToErrorEnvelop(err)
 	}
 
@@ -166,6 +203,9 @@ func (srv *Handler) HandleSendAnchoredDocument(ctx context.Context, peer peer.ID
 	}
 	res, err := srv.SendAnchoredDocument(ctx, m, collaborator)
 	if err != nil {
+		if errors.IsOfType(documents.ErrDocumentInvalid, err) {
+			srv.fail(peer, collaborator)
+		}
 		return srv.convertToErrorEnvelop(err)
 	}
 
@@ -201,6 +241,58 @@ func (srv *Handler) SendAnchoredDocument(ctx context.Context, docReq *p2ppb.Anch
 	return &p2ppb.AnchorDocumentResponse{Accepted: true}, nil
 }
 
+// HandleSendSignatures handles the SendSignatures message
+func (srv *Handler) HandleSendSignatures(ctx context.Context, peer peer.ID, protoc protocol.ID, msg *p2ppb.Envelope) (*pb.P2PEnvelope, error) {
+	m := new(p2pcommon.Signatures)
+	err := p2pcommon.ResolveTransferMessage(msg, m)
+	if err != nil {
+		return srv.convertToErrorEnvelop(err)
+	}
+
+	collaborator, err := identity.NewDIDFromBytes(msg.Header.SenderId)
+	if err != nil {
+		return srv.convertToErrorEnvelop(err)
+	}
+
+	res, err := srv.SendSignatures(ctx, m, collaborator)
+	if err != nil {
+		if errors.IsOfType(documents.ErrDocumentInvalid, err) {
+			srv.fail(peer, collaborator)
+		}
+		return srv.convertToErrorEnvelop(err)
+	}
+
+	nc, err := srv.config.GetConfig()
+	if err != nil {
+		return srv.convertToErrorEnvelop(err)
+	}
+
+	p2pEnv, err := p2pcommon.PrepareTransferEnvelope(ctx, nc.GetNetworkID(), p2pcommon.MessageTypeSendSignaturesRep, res)
+	if err != nil {
+		return srv.convertToErrorEnvelop(err)
+	}
+
+	return p2pEnv, nil
+}
+
+// SendSignatures receives the signatures of a document version whose signature request returned earlier
+func (srv *Handler) SendSignatures(ctx context.Context, sigs *p2pcommon.Signatures, collaborator identity.DID) (*p2pcommon.SignaturesAck, error) {
+	if sigs == nil || len(sigs.Signatures) == 0 {
+		return nil, errors.New("no signatures provided")
+	}
+
+	if srv.collector == nil {
+		return nil, errors.New("signature collector not initialised")
+	}
+
+	err := srv.collector.ReceiveSignatures(ctx, collaborator, sigs.DocumentVersion, sigs.Signatures)
+	if err != nil {
+		return nil, err
+	}
+
+	return &p2pcommon.SignaturesAck{Accepted: true}, nil
+}
+
 // HandleGetDocument handles HandleGetDocument message
 func (srv *Handler) HandleGetDocument(ctx context.Context, peer peer.ID, protoc protocol.ID, msg *p2ppb.Envelope) (*pb.P2PEnvelope, error) {
 	m := new(p2ppb.GetDocumentRequest)
@@ -216,6 +308,9 @@ func (srv *Handler) HandleGetDocument(ctx context.Context, peer peer.ID, protoc
 
 	res, err := srv.GetDocument(ctx, m, requesterDID)
 	if err != nil {
+		if errors.IsOfType(ErrAccessDenied, err) {
+			srv.fail(peer, requesterDID)
+		}
 		return srv.convertToErrorEnvelop(err)
 	}
 
@@ -224,6 +319,11 @@ func (srv *Handler) HandleGetDocument(ctx context.Context, peer peer.ID, protoc
 		return srv.convertToErrorEnvelop(err)
 	}
 
+	// documents too big for a single message are prepared for a chunked download
+	if nc.GetP2PChunkSize() > 0 && proto.Size(res) > nc.GetP2PChunkSize() {
+		return srv.prepareChunkedResponse(ctx, nc, requesterDID, p2pcommon.MessageTypeGetDocRep, res)
+	}
+
 	p2pEnv, err := p2pcommon.PrepareP2PEnvelope(ctx, nc.GetNetworkID(), p2pcommon.MessageTypeGetDocRep, res)
 	if err != nil {
 		return srv.convertToErrorEnvelop(err)
@@ -251,6 +351,137 @@ func (srv *Handler) GetDocument(ctx context.Context, docReq *p2ppb.GetDocumentRe
 	return &p2ppb.GetDocumentResponse{Document: &cd}, nil
 }
 
+// HandleChunk handles a single chunk of a chunked transfer.
+// Every chunk is acknowledged with the index of the next expected chunk. Once the last chunk is received,
+// the assembled message is handled as if it was received in a single envelope.
+func (srv *Handler) HandleChunk(ctx context.Context, peer peer.ID, protoc protocol.ID, msg *p2ppb.Envelope) (*pb.P2PEnvelope, error) {
+	c := new(p2pcommon.Chunk)
+	err := p2pcommon.ResolveTransferMessage(msg, c)
+	if err != nil {
+		return srv.convertToErrorEnvelop(err)
+	}
+
+	// only messages carrying documents can be sent in chunks
+	mt := p2pcommon.MessageTypeFromString(c.MessageType)
+	if mt != p2pcommon.MessageTypeSendAnchoredDoc && mt != p2pcommon.MessageTypeRequestSignature {
+		return srv.convertToErrorEnvelop(errors.New("MessageType [%s] cannot be chunked", c.MessageType))
+	}
+
+	sender, err := identity.NewDIDFromBytes(msg.Header.SenderId)
+	if err != nil {
+		return srv.convertToErrorEnvelop(err)
+	}
+
+	nc, err := srv.config.GetConfig()
+	if err != nil {
+		return srv.convertToErrorEnvelop(err)
+	}
+
+	ts, err := newTransferStore(nc.GetP2PTransferPath(), int64(nc.GetP2PMaxTransferSize()))
+	if err != nil {
+		return srv.convertToErrorEnvelop(err)
+	}
+
+	next, err := ts.append(sender, *c)
+	if err != nil {
+		srv.fail(peer, sender)
+		return srv.convertToErrorEnvelop(err)
+	}
+
+	if next < p2pcommon.ChunkCount(c.TotalSize, c.ChunkSize) {
+		p2pEnv, err := p2pcommon.PrepareTransferEnvelope(ctx, nc.GetNetworkID(), p2pcommon.MessageTypeChunkRep, p2pcommon.ChunkAck{
+			TransferID: c.TransferID,
+			Next:       next,
+		})
+		if err != nil {
+			return srv.convertToErrorEnvelop(err)
+		}
+
+		return p2pEnv, nil
+	}
+
+	data, err := ts.payload(sender, *c)
+	if err != nil {
+		return srv.convertToErrorEnvelop(err)
+	}
+
+	return srv.dispatch(ctx, peer, protoc, &p2ppb.Envelope{
+		Header: &p2ppb.Header{
+			SenderId:          msg.Header.SenderId,
+			NodeVersion:       msg.Header.NodeVersion,
+			NetworkIdentifier: msg.Header.NetworkIdentifier,
+			Type:              c.MessageType,
+			Timestamp:         msg.Header.Timestamp,
+		},
+		Body: data,
+	})
+}
+
+// HandleGetChunk handles a request for a chunk of a transfer prepared for the requester.
+func (srv *Handler) HandleGetChunk(ctx context.Context, peer peer.ID, protoc protocol.ID, msg *p2ppb.Envelope) (*pb.P2PEnvelope, error) {
+	req := new(p2pcommon.ChunkRequest)
+	err := p2pcommon.ResolveTransferMessage(msg, req)
+	if err != nil {
+		return srv.convertToErrorEnvelop(err)
+	}
+
+	requester, err := identity.NewDIDFromBytes(msg.Header.SenderId)
+	if err != nil {
+		return srv.convertToErrorEnvelop(err)
+	}
+
+	nc, err := srv.config.GetConfig()
+	if err != nil {
+		return srv.convertToErrorEnvelop(err)
+	}
+
+	ts, err := newTransferStore(nc.GetP2PTransferPath(), int64(nc.GetP2PMaxTransferSize()))
+	if err != nil {
+		return srv.convertToErrorEnvelop(err)
+	}
+
+	c, err := ts.chunk(requester, p2pcommon.TransferHeader{
+		TransferID:  req.TransferID,
+		MessageType: req.MessageType,
+		ChunkSize:   req.ChunkSize,
+	}, req.Index)
+	if err != nil {
+		return srv.convertToErrorEnvelop(err)
+	}
+
+	p2pEnv, err := p2pcommon.PrepareTransferEnvelope(ctx, nc.GetNetworkID(), p2pcommon.MessageTypeGetChunkRep, c)
+	if err != nil {
+		return srv.convertToErrorEnvelop(err)
+	}
+
+	return p2pEnv, nil
+}
+
+// prepareChunkedResponse spools the response for a chunked download and returns the transfer header to the requester.
+func (srv *Handler) prepareChunkedResponse(ctx context.Context, nc config.Configuration, requester identity.DID, messageType p2pcommon.MessageType, res proto.Message) (*pb.P2PEnvelope, error) {
+	data, err := proto.Marshal(res)
+	if err != nil {
+		return srv.convertToErrorEnvelop(err)
+	}
+
+	ts, err := newTransferStore(nc.GetP2PTransferPath(), int64(nc.GetP2PMaxTransferSize()))
+	if err != nil {
+		return srv.convertToErrorEnvelop(err)
+	}
+
+	h, err := ts.prepare(requester, messageType, data, nc.GetP2PChunkSize())
+	if err != nil {
+		return srv.convertToErrorEnvelop(err)
+	}
+
+	p2pEnv, err := p2pcommon.PrepareTransferEnvelope(ctx, nc.GetNetworkID(), p2pcommon.MessageTypeGetDocChunkedRep, h)
+	if err != nil {
+		return srv.convertToErrorEnvelop(err)
+	}
+
+	return p2pEnv, nil
+}
+
 // validateDocumentAccess validates the GetDocument request against the AccessType indicated in the request
 func (srv *Handler) validateDocumentAccess(ctx context.Context, docReq *p2ppb.GetDocumentRequest, m documents.Model, peer identity.DID) error {
 	// checks which access type is relevant for the request
@@ -285,6 +516,12 @@ func (srv *Handler) validateDocumentAccess(ctx context.Context, docReq *p2ppb.Ge
 	return nil
 }
 
+// fail records a failed validation of a request for the peer and the validated DID of the sender.
+func (srv *Handler) fail(peer peer.ID, did identity.DID) {
+	srv.limiter.FailPeer(peer)
+	srv.limiter.FailDID(did)
+}
+
 func (srv *Handler) convertToErrorEnvelop(ierr error) (*pb.P2PEnvelope, error) {
 	// Log on server side
 	log.Error(ierr)
@@ -9,6 +9,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/centrifuge/centrifuge-protobufs/gen/go/coredocument"
 	errorspb "github.com/centrifuge/centrifuge-protobufs/gen/go/errors"
 	"github.com/centrifuge/centrifuge-protobufs/gen/go/p2p"
 	"github.com/centrifuge/centrifuge-protobufs/gen/go/protocol"
@@ -60,9 +61,9 @@ func TestMain(m *testing.M) {
 		&testlogging.TestLoggingBootstrapper{},
 		&config.Bootstrapper{},
 		&leveldb.Bootstrapper{},
+		jobsv1.Bootstrapper{},
 		&configstore.Bootstrapper{},
 		&queue.Bootstrapper{},
-		jobsv1.Bootstrapper{},
 		&anchors.Bootstrapper{},
 		documents.Bootstrapper{},
 	}
@@ -74,11 +75,11 @@ func TestMain(m *testing.M) {
 	cfg = ctx[bootstrap.BootstrappedConfig].(config.Configuration)
 	cfgService := ctx[config.BootstrappedConfigStorage].(config.Service)
 	registry = ctx[documents.BootstrappedRegistry].(*documents.ServiceRegistry)
-	docSrv := documents.DefaultService(cfg, nil, nil, registry, mockIDService, nil, nil)
+	docSrv := documents.DefaultService(cfg, nil, nil, registry, mockIDService, nil, nil, nil)
 	_, pub, _ := crypto.GenerateEd25519Key(rand.Reader)
 	defaultPID, _ = libp2pPeer.IDFromPublicKey(pub)
 	mockIDService.On("ValidateKey", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil)
-	handler = New(cfgService, HandshakeValidator(cfg.GetNetworkID(), mockIDService), docSrv, new(testingdocuments.MockRegistry), mockIDService)
+	handler = New(cfgService, HandshakeValidator(cfg.GetNetworkID(), mockIDService), docSrv, new(testingdocuments.MockRegistry), mockIDService, nil, nil)
 	result := m.Run()
 	bootstrap.RunTestTeardown(ibootstappers)
 	os.Exit(result)
@@ -91,7 +92,7 @@ func TestHandler_HandleInterceptor_noConfig(t *testing.T) {
 	assert.NoError(t, err)
 	fkRepo := configstore.NewDBRepository(leveldb.NewLevelDBRepository(db))
 	fkCfg := configstore.DefaultService(fkRepo, mockIDService)
-	hndlr := New(fkCfg, nil, nil, nil, nil)
+	hndlr := New(fkCfg, nil, nil, nil, nil, nil, nil)
 	resp, err := hndlr.HandleInterceptor(context.Background(), libp2pPeer.ID("SomePeer"), protocol.ID("protocolX"), &protocolpb.P2PEnvelope{})
 	assert.NoError(t, err)
 	err = p2pcommon.ConvertP2PEnvelopeToError(resp)
@@ -106,6 +107,32 @@ func TestHandler_RequestDocumentSignature_nilDocument(t *testing.T) {
 	assert.Nil(t, resp, "must be nil")
 }
 
+func TestHandler_SendSignatures(t *testing.T) {
+	id := testingidentity.GenerateRandomDID()
+	version := utils.RandomSlice(32)
+	sigs := []*coredocumentpb.Signature{{SignerId: id[:]}}
+
+	// no signatures
+	_, err := handler.SendSignatures(context.Background(), &p2pcommon.Signatures{DocumentVersion: version}, id)
+	assert.Error(t, err)
+
+	// no collector
+	_, err = handler.SendSignatures(context.Background(), &p2pcommon.Signatures{DocumentVersion: version, Signatures: sigs}, id)
+	assert.Error(t, err)
+
+	collector := new(documents.MockSignatureCollector)
+	hndlr := New(nil, nil, nil, nil, nil, nil, collector)
+	collector.On("ReceiveSignatures", mock.Anything, id, version, sigs).Return(documents.ErrSignatureCollectionNotFound).Once()
+	_, err = hndlr.SendSignatures(context.Background(), &p2pcommon.Signatures{DocumentVersion: version, Signatures: sigs}, id)
+	assert.True(t, errors.IsOfType(documents.ErrSignatureCollectionNotFound, err))
+
+	collector.On("ReceiveSignatures", mock.Anything, id, version, sigs).Return(nil).Once()
+	ack, err := hndlr.SendSignatures(context.Background(), &p2pcommon.Signatures{DocumentVersion: version, Signatures: sigs}, id)
+	assert.NoError(t, err)
+	assert.True(t, ack.Accepted)
+	collector.AssertExpectations(t)
+}
+
 func TestHandler_HandleInterceptor_nilPayload(t *testing.T) {
 	resp, err := handler.HandleInterceptor(context.Background(), libp2pPeer.ID("SomePeer"), protocol.ID("protocolX"), nil)
 	assert.NoError(t, err)
@@ -223,6 +250,72 @@ func TestHandler_HandleInterceptor_getServiceAndModel_fail(t *testing.T) {
 	assert.Contains(t, err.Error(), "core document embed data is nil")
 }
 
+func TestHandler_HandleInterceptor_Chunk(t *testing.T) {
+	ctx := testingconfig.CreateAccountContext(t, cfg)
+	cd, err := documents.NewCoreDocument(nil, documents.CollaboratorsAccess{}, nil)
+	assert.NoError(t, err)
+	data, err := proto.Marshal(&p2ppb.AnchorDocumentRequest{Document: cd.GetTestCoreDocWithReset()})
+	assert.NoError(t, err)
+	id, _ := cfg.GetIdentityID()
+	tid := p2pcommon.NewTransferID(data)
+	chunkSize := len(data)/2 + 1
+
+	// only document messages can be chunked
+	c, err := p2pcommon.NewChunk(tid, p2pcommon.MessageTypeGetDoc, data, chunkSize, 0)
+	assert.NoError(t, err)
+	p2pEnv, err := p2pcommon.PrepareTransferEnvelope(ctx, cfg.GetNetworkID(), p2pcommon.MessageTypeChunk, c)
+	assert.NoError(t, err)
+	resp, err := handler.HandleInterceptor(context.Background(), defaultPID, protocol.ID(hexutil.Encode(id)), p2pEnv)
+	assert.NoError(t, err)
+	err = p2pcommon.ConvertP2PEnvelopeToError(resp)
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "cannot be chunked")
+
+	// first chunk is acknowledged
+	c, err = p2pcommon.NewChunk(tid, p2pcommon.MessageTypeSendAnchoredDoc, data, chunkSize, 0)
+	assert.NoError(t, err)
+	p2pEnv, err = p2pcommon.PrepareTransferEnvelope(ctx, cfg.GetNetworkID(), p2pcommon.MessageTypeChunk, c)
+	assert.NoError(t, err)
+	resp, err = handler.HandleInterceptor(context.Background(), defaultPID, protocol.ID(hexutil.Encode(id)), p2pEnv)
+	assert.NoError(t, err)
+	env, err := p2pcommon.ResolveDataEnvelope(resp)
+	assert.NoError(t, err)
+	assert.True(t, p2pcommon.MessageTypeChunkRep.Equals(env.Header.Type))
+	ack := new(p2pcommon.ChunkAck)
+	assert.NoError(t, p2pcommon.ResolveTransferMessage(env, ack))
+	assert.Equal(t, tid, ack.TransferID)
+	assert.Equal(t, 1, ack.Next)
+
+	// last chunk is handled as the assembled message
+	c, err = p2pcommon.NewChunk(tid, p2pcommon.MessageTypeSendAnchoredDoc, data, chunkSize, 1)
+	assert.NoError(t, err)
+	p2pEnv, err = p2pcommon.PrepareTransferEnvelope(ctx, cfg.GetNetworkID(), p2pcommon.MessageTypeChunk, c)
+	assert.NoError(t, err)
+	resp, err = handler.HandleInterceptor(context.Background(), defaultPID, protocol.ID(hexutil.Encode(id)), p2pEnv)
+	assert.NoError(t, err)
+	err = p2pcommon.ConvertP2PEnvelopeToError(resp)
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "core document embed data is nil")
+}
+
+func TestHandler_HandleInterceptor_GetChunk_missing(t *testing.T) {
+	ctx := testingconfig.CreateAccountContext(t, cfg)
+	p2pEnv, err := p2pcommon.PrepareTransferEnvelope(ctx, cfg.GetNetworkID(), p2pcommon.MessageTypeGetChunk, p2pcommon.ChunkRequest{
+		TransferHeader: p2pcommon.TransferHeader{
+			TransferID: utils.RandomSlice(32),
+			ChunkSize:  10,
+		},
+	})
+	assert.NoError(t, err)
+
+	id, _ := cfg.GetIdentityID()
+	resp, err := handler.HandleInterceptor(context.Background(), defaultPID, protocol.ID(hexutil.Encode(id)), p2pEnv)
+	assert.NoError(t, err)
+	err = p2pcommon.ConvertP2PEnvelopeToError(resp)
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), ErrTransferNotFound.Error())
+}
+
 func TestP2PService_basicChecks(t *testing.T) {
 	tm, err := utils.ToTimestamp(time.Now())
 	assert.NoError(t, err)
@@ -0,0 +1,242 @@
+package receiver
+
+import (
+	"expvar"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/centrifuge/go-centrifuge/config"
+	"github.com/centrifuge/go-centrifuge/errors"
+	"github.com/centrifuge/go-centrifuge/identity"
+	"github.com/libp2p/go-libp2p-peer"
+)
+
+const (
+	// ErrRateLimited must be used when a peer or DID sent more requests than allowed
+	ErrRateLimited = errors.Error("too many requests")
+
+	// ErrTooManyConcurrentRequests must be used when a peer or DID has too many requests in flight
+	ErrTooManyConcurrentRequests = errors.Error("too many concurrent requests")
+
+	// ErrBanned must be used when a peer or DID is temporarily banned
+	ErrBanned = errors.Error("temporarily banned")
+)
+
+// maxTracked is the number of keys after which idle state is pruned from the limiter.
+const maxTracked = 10000
+
+// metrics exposes the limiter counters under /debug/p2p.
+var metrics = expvar.NewMap("p2p_receiver")
+
+// bucket is a token bucket refilled at a constant rate up to its burst.
+type bucket struct {
+	tokens float64
+	last   time.Time
+}
+
+// failures counts the failed validations of a key. The count restarts once no failure was seen for the ban duration.
+type failures struct {
+	count int
+	last  time.Time
+}
+
+// Limiter limits the requests handled per peer and per DID and bans peers that repeatedly fail validation.
+// A single Limiter must be shared by all the handlers.
+// A nil Limiter allows every request.
+type Limiter struct {
+	mu            sync.Mutex
+	rate          float64 // tokens per second
+	burst         float64
+	maxConcurrent int
+	banThreshold  int
+	banDuration   time.Duration
+	buckets       map[string]*bucket
+	active        map[string]int
+	failures      map[string]*failures
+	bans          map[string]time.Time
+	now           func() time.Time
+}
+
+// NewLimiter returns a Limiter with the limits from the config.
+func NewLimiter(cfg config.Configuration) *Limiter {
+	l := &Limiter{
+		rate:          float64(cfg.GetP2PRateLimit()) / 60,
+		burst:         float64(cfg.GetP2PRateLimit()),
+		maxConcurrent: cfg.GetP2PMaxConcurrentRequests(),
+		banThreshold:  cfg.GetP2PBanThreshold(),
+		banDuration:   cfg.GetP2PBanDuration(),
+		buckets:       make(map[string]*bucket),
+		active:        make(map[string]int),
+		failures:      make(map[string]*failures),
+		bans:          make(map[string]time.Time),
+		now:           time.Now,
+	}
+
+	metrics.Set("banned_peers", expvar.Func(func() interface{} {
+		return l.Banned()
+	}))
+	return l
+}
+
+func peerKey(p peer.ID) string {
+	return "peer:" + p.Pretty()
+}
+
+func didKey(did identity.DID) string {
+	return "did:" + did.String()
+}
+
+// AcquirePeer checks the limits of the peer before any validation is done.
+// The returned release func must be called once the request is handled.
+func (l *Limiter) AcquirePeer(p peer.ID) (release func(), err error) {
+	return l.acquire(peerKey(p))
+}
+
+// AcquireDID checks the limits of the DID. Must only be called once the DID is validated against the peer
+// so that a peer cannot exhaust the limits of a DID it doesn't own.
+// The returned release func must be called once the request is handled.
+func (l *Limiter) AcquireDID(did identity.DID) (release func(), err error) {
+	return l.acquire(didKey(did))
+}
+
+func (l *Limiter) acquire(key string) (func(), error) {
+	if l == nil {
+		return func() {}, nil
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	now := l.now()
+	l.prune(now)
+	if until, ok := l.bans[key]; ok {
+		if now.Before(until) {
+			metrics.Add("rejected_banned", 1)
+			return nil, ErrBanned
+		}
+
+		delete(l.bans, key)
+	}
+
+	if l.maxConcurrent > 0 && l.active[key] >= l.maxConcurrent {
+		metrics.Add("throttled", 1)
+		return nil, ErrTooManyConcurrentRequests
+	}
+
+	if l.rate > 0 && !l.allow(key, now) {
+		metrics.Add("throttled", 1)
+		return nil, ErrRateLimited
+	}
+
+	l.active[key]++
+	return func() {
+		l.mu.Lock()
+		defer l.mu.Unlock()
+		l.active[key]--
+		if l.active[key] <= 0 {
+			delete(l.active, key)
+		}
+	}, nil
+}
+
+// allow takes a token from the bucket of the key.
+func (l *Limiter) allow(key string, now time.Time) bool {
+	b, ok := l.buckets[key]
+	if !ok {
+		b = &bucket{tokens: l.burst, last: now}
+		l.buckets[key] = b
+	}
+
+	b.tokens += now.Sub(b.last).Seconds() * l.rate
+	if b.tokens > l.burst {
+		b.tokens = l.burst
+	}
+	b.last = now
+	if b.tokens < 1 {
+		return false
+	}
+
+	b.tokens--
+	return true
+}
+
+// prune drops the state of keys that would behave the same as unseen keys.
+func (l *Limiter) prune(now time.Time) {
+	if len(l.buckets)+len(l.failures)+len(l.bans) < maxTracked {
+		return
+	}
+
+	for key, b := range l.buckets {
+		if b.tokens+now.Sub(b.last).Seconds()*l.rate >= l.burst {
+			delete(l.buckets, key)
+		}
+	}
+
+	for key, f := range l.failures {
+		if now.Sub(f.last) > l.banDuration {
+			delete(l.failures, key)
+		}
+	}
+
+	for key, until := range l.bans {
+		if !now.Before(until) {
+			delete(l.bans, key)
+		}
+	}
+}
+
+// FailPeer records a failed validation of a request from the peer. The peer is banned once the threshold is reached.
+func (l *Limiter) FailPeer(p peer.ID) {
+	l.fail(peerKey(p))
+}
+
+// FailDID records a failed validation of a request from the DID. The DID is banned once the threshold is reached.
+func (l *Limiter) FailDID(did identity.DID) {
+	l.fail(didKey(did))
+}
+
+func (l *Limiter) fail(key string) {
+	if l == nil || l.banThreshold <= 0 {
+		return
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	now := l.now()
+	f, ok := l.failures[key]
+	if !ok || now.Sub(f.last) > l.banDuration {
+		f = new(failures)
+		l.failures[key] = f
+	}
+
+	f.count++
+	f.last = now
+	if f.count < l.banThreshold {
+		return
+	}
+
+	delete(l.failures, key)
+	l.bans[key] = now.Add(l.banDuration)
+	metrics.Add("banned", 1)
+	log.Warningf("%s banned until %s after %d failed validations", key, l.bans[key], l.banThreshold)
+}
+
+// Banned returns the peers and DIDs that are currently banned.
+func (l *Limiter) Banned() []string {
+	if l == nil {
+		return nil
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	now := l.now()
+	var banned []string
+	for key, until := range l.bans {
+		if now.Before(until) {
+			banned = append(banned, key)
+		}
+	}
+
+	sort.Strings(banned)
+	return banned
+}
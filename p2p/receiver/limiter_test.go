@@ -0,0 +1,106 @@
+// +build unit
+
+package receiver
+
+import (
+	"testing"
+	"time"
+
+	"github.com/centrifuge/go-centrifuge/errors"
+	"github.com/centrifuge/go-centrifuge/testingutils/config"
+	"github.com/centrifuge/go-centrifuge/testingutils/identity"
+	libp2pPeer "github.com/libp2p/go-libp2p-peer"
+	"github.com/stretchr/testify/assert"
+)
+
+func newTestLimiter(rate, concurrent, threshold int) (*Limiter, *time.Time) {
+	c := new(testingconfig.MockConfig)
+	c.On("GetP2PRateLimit").Return(rate)
+	c.On("GetP2PMaxConcurrentRequests").Return(concurrent)
+	c.On("GetP2PBanThreshold").Return(threshold)
+	c.On("GetP2PBanDuration").Return(time.Minute)
+	l := NewLimiter(c)
+	now := time.Now()
+	l.now = func() time.Time {
+		return now
+	}
+	return l, &now
+}
+
+func TestLimiter_nil(t *testing.T) {
+	var l *Limiter
+	release, err := l.AcquirePeer(libp2pPeer.ID("peer"))
+	assert.NoError(t, err)
+	release()
+	l.FailPeer(libp2pPeer.ID("peer"))
+	assert.Empty(t, l.Banned())
+}
+
+func TestLimiter_rate(t *testing.T) {
+	l, now := newTestLimiter(2, 0, 0)
+	p := libp2pPeer.ID("peer")
+	for i := 0; i < 2; i++ {
+		release, err := l.AcquirePeer(p)
+		assert.NoError(t, err)
+		release()
+	}
+
+	_, err := l.AcquirePeer(p)
+	assert.True(t, errors.IsOfType(ErrRateLimited, err))
+
+	// other peers are not affected
+	release, err := l.AcquirePeer(libp2pPeer.ID("other"))
+	assert.NoError(t, err)
+	release()
+
+	// one token every 30 seconds
+	*now = now.Add(30 * time.Second)
+	release, err = l.AcquirePeer(p)
+	assert.NoError(t, err)
+	release()
+	_, err = l.AcquirePeer(p)
+	assert.True(t, errors.IsOfType(ErrRateLimited, err))
+}
+
+func TestLimiter_concurrent(t *testing.T) {
+	l, _ := newTestLimiter(0, 1, 0)
+	did := testingidentity.GenerateRandomDID()
+	release, err := l.AcquireDID(did)
+	assert.NoError(t, err)
+	_, err = l.AcquireDID(did)
+	assert.True(t, errors.IsOfType(ErrTooManyConcurrentRequests, err))
+
+	release()
+	release, err = l.AcquireDID(did)
+	assert.NoError(t, err)
+	release()
+}
+
+func TestLimiter_ban(t *testing.T) {
+	l, now := newTestLimiter(0, 0, 2)
+	p := libp2pPeer.ID("peer")
+	l.FailPeer(p)
+	release, err := l.AcquirePeer(p)
+	assert.NoError(t, err)
+	release()
+
+	l.FailPeer(p)
+	_, err = l.AcquirePeer(p)
+	assert.True(t, errors.IsOfType(ErrBanned, err))
+	assert.Equal(t, []string{peerKey(p)}, l.Banned())
+
+	// ban expires
+	*now = now.Add(time.Minute)
+	release, err = l.AcquirePeer(p)
+	assert.NoError(t, err)
+	release()
+	assert.Empty(t, l.Banned())
+
+	// failures are forgotten after the ban duration
+	l.FailPeer(p)
+	*now = now.Add(2 * time.Minute)
+	l.FailPeer(p)
+	release, err = l.AcquirePeer(p)
+	assert.NoError(t, err)
+	release()
+}
@@ -0,0 +1,255 @@
+package receiver
+
+import (
+	"bytes"
+	"context"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/centrifuge/go-centrifuge/errors"
+	"github.com/centrifuge/go-centrifuge/identity"
+	"github.com/centrifuge/go-centrifuge/p2p/common"
+)
+
+const (
+	// ErrTransferNotFound must be used when the requested chunked transfer doesn't exist
+	ErrTransferNotFound = errors.Error("transfer not found")
+
+	incomingSuffix = ".part"
+	outgoingSuffix = ".out"
+
+	// transferMaxAge is the time after which an untouched transfer is removed from the spool
+	transferMaxAge = 24 * time.Hour
+
+	// TransferExpiryInterval is the interval at which expired transfers are removed from the spool
+	TransferExpiryInterval = time.Hour
+)
+
+// transferMu guards the spool files. Handlers are created per message so the lock is shared at package level.
+var transferMu sync.Mutex
+
+// transferStore spools chunked transfers to disk.
+// Incoming chunks are appended to a file named after the transfer ID and the sender, so the number of chunks received
+// survives a restart and a transfer can be resumed. Outgoing payloads are written once and served chunk by chunk.
+type transferStore struct {
+	dir string
+
+	// maxSize is the maximum size of an incoming transfer
+	maxSize int64
+}
+
+// newTransferStore returns a transferStore that spools into dir and accepts incoming transfers up to maxSize bytes.
+func newTransferStore(dir string, maxSize int64) (*transferStore, error) {
+	if dir == "" {
+		return nil, errors.New("transfer path not configured")
+	}
+
+	err := os.MkdirAll(dir, os.ModePerm)
+	if err != nil {
+		return nil, errors.New("failed to create transfer path: %v", err)
+	}
+
+	return &transferStore{dir: dir, maxSize: maxSize}, nil
+}
+
+func (t *transferStore) path(transferID []byte, peer identity.DID, suffix string) string {
+	return filepath.Join(t.dir, fmt.Sprintf("%s_%s%s", hex.EncodeToString(transferID), hex.EncodeToString(peer[:]), suffix))
+}
+
+// received returns the number of complete chunks already received for the transfer.
+func (t *transferStore) received(path string, chunkSize int) (int, error) {
+	fi, err := os.Stat(path)
+	if os.IsNotExist(err) {
+		return 0, nil
+	}
+
+	if err != nil {
+		return 0, err
+	}
+
+	return int(fi.Size() / int64(chunkSize)), nil
+}
+
+// append validates and writes the chunk if it is the next one expected.
+// Returns the index of the next expected chunk. Duplicates and out of order chunks are not written.
+func (t *transferStore) append(sender identity.DID, c p2pcommon.Chunk) (next int, err error) {
+	err = p2pcommon.CheckTransferSize(c.TotalSize, c.ChunkSize, t.maxSize)
+	if err != nil {
+		return 0, err
+	}
+
+	err = c.Validate()
+	if err != nil {
+		return 0, err
+	}
+
+	transferMu.Lock()
+	defer transferMu.Unlock()
+	path := t.path(c.TransferID, sender, incomingSuffix)
+	next, err = t.received(path, c.ChunkSize)
+	if err != nil {
+		return 0, err
+	}
+
+	if c.Index != next {
+		return next, nil
+	}
+
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY, 0600)
+	if err != nil {
+		return 0, err
+	}
+	defer f.Close()
+
+	// truncate any partially written chunk left behind by a crash
+	offset := int64(c.Index) * int64(c.ChunkSize)
+	err = f.Truncate(offset)
+	if err != nil {
+		return 0, err
+	}
+
+	_, err = f.WriteAt(c.Data, offset)
+	if err != nil {
+		return 0, err
+	}
+
+	return next + 1, f.Sync()
+}
+
+// payload returns the assembled payload of a completed incoming transfer and removes it from the spool.
+// The spooled file is hashed from disk first, so only a payload of the announced size that matches the transfer ID
+// is read into memory.
+func (t *transferStore) payload(sender identity.DID, c p2pcommon.Chunk) ([]byte, error) {
+	transferMu.Lock()
+	defer transferMu.Unlock()
+	path := t.path(c.TransferID, sender, incomingSuffix)
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, errors.NewTypedError(ErrTransferNotFound, err)
+	}
+	defer os.Remove(path)
+	defer f.Close()
+
+	transferID, n, err := p2pcommon.NewTransferIDFromReader(f)
+	if err != nil {
+		return nil, err
+	}
+
+	if n != c.TotalSize {
+		return nil, p2pcommon.ErrInvalidChunk
+	}
+
+	if !bytes.Equal(transferID, c.TransferID) {
+		return nil, p2pcommon.ErrTransferChecksumMismatch
+	}
+
+	_, err = f.Seek(0, io.SeekStart)
+	if err != nil {
+		return nil, err
+	}
+
+	return p2pcommon.ReadPayload(f, n)
+}
+
+// prepare writes an outgoing payload to the spool so that the requester can download it in chunks.
+func (t *transferStore) prepare(requester identity.DID, messageType p2pcommon.MessageType, data []byte, chunkSize int) (p2pcommon.TransferHeader, error) {
+	t.cleanup(transferMaxAge)
+	h := p2pcommon.TransferHeader{
+		TransferID:  p2pcommon.NewTransferID(data),
+		MessageType: messageType.String(),
+		TotalSize:   int64(len(data)),
+		ChunkSize:   chunkSize,
+	}
+
+	transferMu.Lock()
+	defer transferMu.Unlock()
+	err := ioutil.WriteFile(t.path(h.TransferID, requester, outgoingSuffix), data, 0600)
+	return h, err
+}
+
+// chunk reads a single chunk of an outgoing transfer prepared for the requester.
+// The transfer is removed from the spool once the last chunk is served.
+func (t *transferStore) chunk(requester identity.DID, h p2pcommon.TransferHeader, index int) (p2pcommon.Chunk, error) {
+	transferMu.Lock()
+	defer transferMu.Unlock()
+	path := t.path(h.TransferID, requester, outgoingSuffix)
+	f, err := os.Open(path)
+	if err != nil {
+		return p2pcommon.Chunk{}, errors.NewTypedError(ErrTransferNotFound, err)
+	}
+	defer f.Close()
+
+	fi, err := f.Stat()
+	if err != nil {
+		return p2pcommon.Chunk{}, err
+	}
+
+	h.TotalSize = fi.Size()
+	c, err := p2pcommon.ReadChunk(f, h, index)
+	if err != nil {
+		return p2pcommon.Chunk{}, err
+	}
+
+	if c.IsLast() {
+		err = os.Remove(path)
+		if err != nil {
+			log.Warningf("failed to remove served transfer %s: %v", path, err)
+		}
+	}
+
+	return c, nil
+}
+
+// ExpireTransfers removes the spooled transfers in dir that were not touched for the maximum transfer age every interval
+// until the context is done. Abandoned incoming transfers are otherwise never completed and removed.
+func ExpireTransfers(ctx context.Context, dir string, interval time.Duration) {
+	t, err := newTransferStore(dir, 0)
+	if err != nil {
+		log.Warningf("transfers will not expire: %v", err)
+		return
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			t.cleanup(transferMaxAge)
+		}
+	}
+}
+
+// cleanup removes spooled transfers that were not touched for maxAge.
+func (t *transferStore) cleanup(maxAge time.Duration) {
+	transferMu.Lock()
+	defer transferMu.Unlock()
+	fis, err := ioutil.ReadDir(t.dir)
+	if err != nil {
+		log.Warningf("failed to read transfer path: %v", err)
+		return
+	}
+
+	for _, fi := range fis {
+		if !strings.HasSuffix(fi.Name(), incomingSuffix) && !strings.HasSuffix(fi.Name(), outgoingSuffix) {
+			continue
+		}
+
+		if time.Since(fi.ModTime()) < maxAge {
+			continue
+		}
+
+		err = os.Remove(filepath.Join(t.dir, fi.Name()))
+		if err != nil {
+			log.Warningf("failed to remove expired transfer %s: %v", fi.Name(), err)
+		}
+	}
+}
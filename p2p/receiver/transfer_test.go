@@ -0,0 +1,232 @@
+// +build unit
+
+package receiver
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/centrifuge/go-centrifuge/errors"
+	"github.com/centrifuge/go-centrifuge/p2p/common"
+	"github.com/centrifuge/go-centrifuge/testingutils/identity"
+	"github.com/centrifuge/go-centrifuge/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+func newTestTransferStore(t *testing.T) *transferStore {
+	dir, err := ioutil.TempDir("", "centrifuge_transfers")
+	assert.NoError(t, err)
+	ts, err := newTransferStore(dir, 100)
+	assert.NoError(t, err)
+	return ts
+}
+
+func TestTransferStore_append(t *testing.T) {
+	ts := newTestTransferStore(t)
+	defer os.RemoveAll(ts.dir)
+	sender := testingidentity.GenerateRandomDID()
+	data := utils.RandomSlice(25)
+	tid := p2pcommon.NewTransferID(data)
+	chunk := func(i int) p2pcommon.Chunk {
+		c, err := p2pcommon.NewChunk(tid, p2pcommon.MessageTypeSendAnchoredDoc, data, 10, i)
+		assert.NoError(t, err)
+		return c
+	}
+
+	// invalid chunk
+	c := chunk(0)
+	c.Data = utils.RandomSlice(10)
+	_, err := ts.append(sender, c)
+	assert.Equal(t, p2pcommon.ErrChunkChecksumMismatch, err)
+
+	// transfer too large is not spooled
+	c = chunk(0)
+	c.TotalSize = 101
+	_, err = ts.append(sender, c)
+	assert.True(t, errors.IsOfType(p2pcommon.ErrTransferTooLarge, err))
+	_, err = os.Stat(ts.path(tid, sender, incomingSuffix))
+	assert.True(t, os.IsNotExist(err))
+
+	// out of order chunk is not stored
+	next, err := ts.append(sender, chunk(1))
+	assert.NoError(t, err)
+	assert.Equal(t, 0, next)
+
+	next, err = ts.append(sender, chunk(0))
+	assert.NoError(t, err)
+	assert.Equal(t, 1, next)
+
+	// duplicate chunk is acknowledged with the next expected index
+	next, err = ts.append(sender, chunk(0))
+	assert.NoError(t, err)
+	assert.Equal(t, 1, next)
+
+	// other senders don't share the transfer
+	next, err = ts.append(testingidentity.GenerateRandomDID(), chunk(1))
+	assert.NoError(t, err)
+	assert.Equal(t, 0, next)
+
+	next, err = ts.append(sender, chunk(1))
+	assert.NoError(t, err)
+	assert.Equal(t, 2, next)
+
+	next, err = ts.append(sender, chunk(2))
+	assert.NoError(t, err)
+	assert.Equal(t, 3, next)
+
+	payload, err := ts.payload(sender, chunk(2))
+	assert.NoError(t, err)
+	assert.Equal(t, data, payload)
+
+	// transfer is removed once assembled
+	_, err = ts.payload(sender, chunk(2))
+	assert.Error(t, err)
+}
+
+func TestTransferStore_append_resume(t *testing.T) {
+	ts := newTestTransferStore(t)
+	defer os.RemoveAll(ts.dir)
+	sender := testingidentity.GenerateRandomDID()
+	data := utils.RandomSlice(25)
+	tid := p2pcommon.NewTransferID(data)
+	c, err := p2pcommon.NewChunk(tid, p2pcommon.MessageTypeSendAnchoredDoc, data, 10, 0)
+	assert.NoError(t, err)
+	_, err = ts.append(sender, c)
+	assert.NoError(t, err)
+
+	// simulate a crash in the middle of writing the second chunk
+	f, err := os.OpenFile(ts.path(tid, sender, incomingSuffix), os.O_APPEND|os.O_WRONLY, 0600)
+	assert.NoError(t, err)
+	_, err = f.Write(data[10:15])
+	assert.NoError(t, err)
+	assert.NoError(t, f.Close())
+
+	// a new store on the same path resumes from the second chunk
+	ts, err = newTransferStore(ts.dir, 100)
+	assert.NoError(t, err)
+	c, err = p2pcommon.NewChunk(tid, p2pcommon.MessageTypeSendAnchoredDoc, data, 10, 0)
+	assert.NoError(t, err)
+	next, err := ts.append(sender, c)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, next)
+
+	for i := 1; i < 3; i++ {
+		c, err = p2pcommon.NewChunk(tid, p2pcommon.MessageTypeSendAnchoredDoc, data, 10, i)
+		assert.NoError(t, err)
+		next, err = ts.append(sender, c)
+		assert.NoError(t, err)
+		assert.Equal(t, i+1, next)
+	}
+
+	payload, err := ts.payload(sender, c)
+	assert.NoError(t, err)
+	assert.Equal(t, data, payload)
+}
+
+func TestTransferStore_payload_mismatch(t *testing.T) {
+	ts := newTestTransferStore(t)
+	defer os.RemoveAll(ts.dir)
+	sender := testingidentity.GenerateRandomDID()
+	data := utils.RandomSlice(25)
+	c, err := p2pcommon.NewChunk(p2pcommon.NewTransferID(data), p2pcommon.MessageTypeSendAnchoredDoc, data, 25, 0)
+	assert.NoError(t, err)
+
+	// spooled data doesn't match the transfer ID
+	assert.NoError(t, ioutil.WriteFile(ts.path(c.TransferID, sender, incomingSuffix), utils.RandomSlice(25), 0600))
+	_, err = ts.payload(sender, c)
+	assert.Equal(t, p2pcommon.ErrTransferChecksumMismatch, err)
+
+	// spooled data is shorter than announced
+	assert.NoError(t, ioutil.WriteFile(ts.path(c.TransferID, sender, incomingSuffix), data[:20], 0600))
+	_, err = ts.payload(sender, c)
+	assert.Equal(t, p2pcommon.ErrInvalidChunk, err)
+}
+
+func TestTransferStore_prepare_chunk(t *testing.T) {
+	ts := newTestTransferStore(t)
+	defer os.RemoveAll(ts.dir)
+	requester := testingidentity.GenerateRandomDID()
+	data := utils.RandomSlice(25)
+	h, err := ts.prepare(requester, p2pcommon.MessageTypeGetDocRep, data, 10)
+	assert.NoError(t, err)
+	assert.Equal(t, p2pcommon.NewTransferID(data), h.TransferID)
+	assert.Equal(t, 3, h.Chunks())
+
+	// unknown requester
+	_, err = ts.chunk(testingidentity.GenerateRandomDID(), h, 0)
+	assert.Error(t, err)
+
+	// out of range
+	_, err = ts.chunk(requester, h, 3)
+	assert.Equal(t, p2pcommon.ErrInvalidChunk, err)
+
+	var assembled []byte
+	for i := 0; i < h.Chunks(); i++ {
+		c, err := ts.chunk(requester, h, i)
+		assert.NoError(t, err)
+		assert.NoError(t, c.Validate())
+		assert.Equal(t, p2pcommon.MessageTypeGetDocRep.String(), c.MessageType)
+		assembled = append(assembled, c.Data...)
+	}
+
+	assert.Equal(t, data, assembled)
+
+	// transfer is removed once the last chunk is served
+	_, err = ts.chunk(requester, h, 0)
+	assert.Error(t, err)
+}
+
+func TestTransferStore_cleanup(t *testing.T) {
+	ts := newTestTransferStore(t)
+	defer os.RemoveAll(ts.dir)
+	requester := testingidentity.GenerateRandomDID()
+	h, err := ts.prepare(requester, p2pcommon.MessageTypeGetDocRep, utils.RandomSlice(25), 10)
+	assert.NoError(t, err)
+	other := filepath.Join(ts.dir, "other")
+	assert.NoError(t, ioutil.WriteFile(other, nil, 0600))
+	old := time.Now().Add(-2 * transferMaxAge)
+	assert.NoError(t, os.Chtimes(other, old, old))
+
+	ts.cleanup(transferMaxAge)
+	_, err = os.Stat(ts.path(h.TransferID, requester, outgoingSuffix))
+	assert.NoError(t, err)
+
+	ts.cleanup(0)
+	_, err = os.Stat(ts.path(h.TransferID, requester, outgoingSuffix))
+	assert.True(t, os.IsNotExist(err))
+
+	// unrelated files are kept
+	_, err = os.Stat(other)
+	assert.NoError(t, err)
+}
+
+func TestExpireTransfers(t *testing.T) {
+	ts := newTestTransferStore(t)
+	defer os.RemoveAll(ts.dir)
+	sender := testingidentity.GenerateRandomDID()
+	data := utils.RandomSlice(25)
+	c, err := p2pcommon.NewChunk(p2pcommon.NewTransferID(data), p2pcommon.MessageTypeSendAnchoredDoc, data, 10, 0)
+	assert.NoError(t, err)
+	_, err = ts.append(sender, c)
+	assert.NoError(t, err)
+	path := ts.path(c.TransferID, sender, incomingSuffix)
+	old := time.Now().Add(-2 * transferMaxAge)
+	assert.NoError(t, os.Chtimes(path, old, old))
+
+	// abandoned incoming transfer is removed on the next tick
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		ExpireTransfers(ctx, ts.dir, 10*time.Millisecond)
+		close(done)
+	}()
+	time.Sleep(100 * time.Millisecond)
+	cancel()
+	<-done
+	_, err = os.Stat(path)
+	assert.True(t, os.IsNotExist(err))
+}
@@ -49,6 +49,12 @@ type peer struct {
 	handlerCreator   func() *receiver.Handler
 	mes              messenger
 	dht              *dht.IpfsDHT
+
+	// ctx is the context the host was started with, used to restart the host with a new key
+	ctx context.Context
+
+	// mu guards the host, the messenger, and the DHT which are replaced when the p2p key is switched
+	mu sync.RWMutex
 }
 
 // Name returns the P2PServer
@@ -71,41 +77,90 @@ func (s *peer) Start(ctx context.Context, wg *sync.WaitGroup, startupErr chan<-
 		return
 	}
 
-	// Make a host that listens on the given multiaddress
-	// first obtain the keys configured
-	priv, pub, err := crypto2.ObtainP2PKeypair(nc.GetP2PKeyPair())
+	s.mu.Lock()
+	s.ctx = ctx
+	pub, priv := nc.GetP2PKeyPair()
+	err = s.startHost(nc, pub, priv)
+	s.mu.Unlock()
 	if err != nil {
 		startupErr <- err
 		return
 	}
-	s.host, err = makeBasicHost(ctx, priv, pub, nc.GetP2PExternalIP(), nc.GetP2PPort())
+
+	go receiver.ExpireTransfers(ctx, nc.GetP2PTransferPath(), receiver.TransferExpiryInterval)
+	if nc.IsDebugLogEnabled() {
+		go func() {
+			for {
+				h := s.currentHost()
+				num := h.Peerstore().Peers()
+				log.Debugf("for host %s the peers in the peerstore are", h.ID(), num)
+				time.Sleep(2 * time.Second)
+			}
+		}()
+	}
+
+	<-ctx.Done()
+
+}
+
+// startHost starts the libp2p host with the given keys, the messenger, and the DHT.
+// The caller must hold the lock.
+func (s *peer) startHost(nc config.Configuration, pubKey, privKey string) error {
+	// Make a host that listens on the given multiaddress
+	// first obtain the keys configured
+	priv, pub, err := crypto2.ObtainP2PKeypair(pubKey, privKey)
+	if err != nil {
+		return err
+	}
+	s.host, err = makeBasicHost(s.ctx, priv, pub, nc.GetP2PExternalIP(), nc.GetP2PPort())
 	if err != nil {
-		startupErr <- err
-		return
+		return err
 	}
 
-	s.mes = ms.NewP2PMessenger(ctx, s.host, nc.GetP2PConnectionTimeout(), s.handlerCreator().HandleInterceptor)
+	s.mes = ms.NewP2PMessenger(s.ctx, s.host, nc.GetP2PConnectionTimeout(), s.handlerCreator().HandleInterceptor)
 	err = s.initProtocols()
 	if err != nil {
-		startupErr <- err
-		return
+		return err
 	}
 
 	// Start DHT and properly ignore errors :)
-	_ = s.runDHT(ctx, nc.GetBootstrapPeers())
+	_ = s.runDHT(s.ctx, nc.GetBootstrapPeers())
+	return nil
+}
 
-	if nc.IsDebugLogEnabled() {
-		go func() {
-			for {
-				num := s.host.Peerstore().Peers()
-				log.Debugf("for host %s the peers in the peerstore are", s.host.ID(), num)
-				time.Sleep(2 * time.Second)
-			}
-		}()
+// SwitchP2PKey restarts the libp2p host with the given keys.
+// Peers resolve the new host once the new key is added to the identities of the accounts.
+func (s *peer) SwitchP2PKey(pub, priv string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.host == nil {
+		return errors.New("p2p host not started")
 	}
 
-	<-ctx.Done()
+	nc, err := s.config.GetConfig()
+	if err != nil {
+		return err
+	}
 
+	if s.dht != nil {
+		if err := s.dht.Close(); err != nil {
+			log.Warningf("failed to close DHT: %v", err)
+		}
+	}
+
+	// the new host listens on the same port
+	err = s.host.Close()
+	if err != nil {
+		return err
+	}
+
+	err = s.startHost(nc, pub, priv)
+	if err != nil {
+		return err
+	}
+
+	log.Infof("p2p host switched to %s", s.host.ID().Pretty())
+	return nil
 }
 
 func (s *peer) initProtocols() error {
@@ -128,7 +183,28 @@ func (s *peer) initProtocols() error {
 
 func (s *peer) InitProtocolForDID(DID *identity.DID) {
 	p := p2pcommon.ProtocolForDID(DID)
-	s.mes.Init(p)
+	s.currentMessenger().Init(p)
+}
+
+// currentHost returns the running libp2p host.
+func (s *peer) currentHost() host.Host {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.host
+}
+
+// currentMessenger returns the messenger of the running libp2p host.
+func (s *peer) currentMessenger() messenger {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.mes
+}
+
+// currentDHT returns the DHT of the running libp2p host.
+func (s *peer) currentDHT() *dht.IpfsDHT {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.dht
 }
 
 func (s *peer) runDHT(ctx context.Context, bootstrapPeers []string) error {
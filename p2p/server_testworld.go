@@ -75,7 +75,7 @@ func (s *peer) getSignatureForDocumentIncorrectMessage(ctx context.Context, mode
 		}
 	}
 	log.Infof("Requesting signature from %s\n", receiverPeer)
-	recv, err := s.mes.SendMessage(ctx, receiverPeer, envelope, p2pcommon.ProtocolForDID(&collaborator))
+	recv, err := s.currentMessenger().SendMessage(ctx, receiverPeer, envelope, p2pcommon.ProtocolForDID(&collaborator))
 	if err != nil {
 		return nil, err
 	}
@@ -192,6 +192,6 @@ func (s *peer) SendOverSizedMessage(ctx context.Context, model documents.Model,
 	if err != nil {
 		return nil, err
 	}
-	msg, err := s.mes.SendMessage(ctx, receiverPeer, p2pEnv, p2pcommon.ProtocolForDID(&collaborator))
+	msg, err := s.currentMessenger().SendMessage(ctx, receiverPeer, p2pEnv, p2pcommon.ProtocolForDID(&collaborator))
 	return msg, err
 }
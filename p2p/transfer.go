@@ -0,0 +1,275 @@
+package p2p
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"io"
+	"io/ioutil"
+	"os"
+
+	"github.com/centrifuge/centrifuge-protobufs/gen/go/p2p"
+	pb "github.com/centrifuge/centrifuge-protobufs/gen/go/protocol"
+	"github.com/centrifuge/go-centrifuge/config"
+	"github.com/centrifuge/go-centrifuge/errors"
+	"github.com/centrifuge/go-centrifuge/p2p/common"
+	"github.com/golang/protobuf/proto"
+	libp2pPeer "github.com/libp2p/go-libp2p-peer"
+	"github.com/libp2p/go-libp2p-protocol"
+)
+
+// chunkRetries is the number of times a single chunk is retried before the transfer is given up.
+// An interrupted transfer can still be resumed by sending the same payload again.
+const chunkRetries = 3
+
+// sendMessage sends the message to the peer. Messages bigger than the configured chunk size are sent in chunks.
+// Returns the response envelope of the message.
+func (s *peer) sendMessage(ctx context.Context, nc config.Configuration, pid libp2pPeer.ID, protoc protocol.ID, messageType p2pcommon.MessageType, mes proto.Message) (*pb.P2PEnvelope, error) {
+	chunkSize := nc.GetP2PChunkSize()
+	if chunkSize <= 0 || proto.Size(mes) <= chunkSize {
+		envelope, err := p2pcommon.PrepareP2PEnvelope(ctx, nc.GetNetworkID(), messageType, mes)
+		if err != nil {
+			return nil, err
+		}
+
+		return s.currentMessenger().SendMessage(ctx, pid, envelope, protoc)
+	}
+
+	spool, h, err := spoolMessage(nc.GetP2PTransferPath(), messageType, mes, chunkSize)
+	if err != nil {
+		return nil, err
+	}
+	defer removeSpool(spool)
+
+	return s.sendChunked(ctx, nc, pid, protoc, spool, h)
+}
+
+// spoolMessage writes the marshalled message to the transfer path and returns the spool with the header of the transfer.
+// The chunks are read from the spool while the transfer is in progress, the marshalled message is only held until it is written.
+func spoolMessage(dir string, messageType p2pcommon.MessageType, mes proto.Message, chunkSize int) (*os.File, p2pcommon.TransferHeader, error) {
+	data, err := proto.Marshal(mes)
+	if err != nil {
+		return nil, p2pcommon.TransferHeader{}, err
+	}
+
+	h := p2pcommon.TransferHeader{
+		TransferID:  p2pcommon.NewTransferID(data),
+		MessageType: messageType.String(),
+		TotalSize:   int64(len(data)),
+		ChunkSize:   chunkSize,
+	}
+
+	f, err := newSpool(dir, "send_*.out")
+	if err != nil {
+		return nil, h, err
+	}
+
+	_, err = f.Write(data)
+	if err != nil {
+		removeSpool(f)
+		return nil, h, err
+	}
+
+	return f, h, nil
+}
+
+// newSpool creates a spool file in the transfer path.
+// Spools left behind by a crash are removed with the expired transfers.
+func newSpool(dir, pattern string) (*os.File, error) {
+	if dir == "" {
+		return nil, errors.New("transfer path not configured")
+	}
+
+	err := os.MkdirAll(dir, os.ModePerm)
+	if err != nil {
+		return nil, errors.New("failed to create transfer path: %v", err)
+	}
+
+	return ioutil.TempFile(dir, pattern)
+}
+
+// removeSpool closes and removes the spool file.
+func removeSpool(f *os.File) {
+	f.Close()
+	err := os.Remove(f.Name())
+	if err != nil {
+		log.Warningf("failed to remove spooled transfer %s: %v", f.Name(), err)
+	}
+}
+
+// sendChunked sends the spooled payload in chunks. The receiver acknowledges every chunk with the index of the next chunk
+// it expects, so a transfer interrupted earlier continues from the last chunk the receiver has stored.
+// Returns the response to the assembled message.
+func (s *peer) sendChunked(ctx context.Context, nc config.Configuration, pid libp2pPeer.ID, protoc protocol.ID, spool io.ReaderAt, h p2pcommon.TransferHeader) (*pb.P2PEnvelope, error) {
+	count := h.Chunks()
+	var idx, failures int
+	for sent := 0; sent < count*chunkRetries; sent++ {
+		c, err := p2pcommon.ReadChunk(spool, h, idx)
+		if err != nil {
+			return nil, err
+		}
+
+		envelope, err := p2pcommon.PrepareTransferEnvelope(ctx, nc.GetNetworkID(), p2pcommon.MessageTypeChunk, c)
+		if err != nil {
+			return nil, err
+		}
+
+		recv, err := s.currentMessenger().SendMessage(ctx, pid, envelope, protoc)
+		if err != nil {
+			failures++
+			if failures >= chunkRetries {
+				return nil, errors.New("failed to send chunk %d of %d: %v", idx, count, err)
+			}
+
+			log.Warningf("failed to send chunk %d of %d, retrying: %v", idx, count, err)
+			continue
+		}
+
+		failures = 0
+		recvEnvelope, err := p2pcommon.ResolveDataEnvelope(recv)
+		if err != nil {
+			return nil, err
+		}
+
+		// anything other than an ack is the response to the assembled message
+		if !p2pcommon.MessageTypeChunkRep.Equals(recvEnvelope.Header.Type) {
+			return recv, nil
+		}
+
+		ack := new(p2pcommon.ChunkAck)
+		err = p2pcommon.ResolveTransferMessage(recvEnvelope, ack)
+		if err != nil {
+			return nil, err
+		}
+
+		if !bytes.Equal(ack.TransferID, h.TransferID) || ack.Next < 0 || ack.Next >= count {
+			return nil, errors.New("invalid chunk acknowledgement received")
+		}
+
+		idx = ack.Next
+	}
+
+	return nil, errors.New("chunked transfer did not complete after %d chunks", count*chunkRetries)
+}
+
+// downloadChunked downloads a transfer prepared by the peer chunk by chunk into a spool and returns the verified spool.
+// The sizes in the header are chosen by the peer, so they are checked against the maximum transfer size before anything
+// is spooled. The caller must remove the spool.
+func (s *peer) downloadChunked(ctx context.Context, nc config.Configuration, pid libp2pPeer.ID, protoc protocol.ID, h p2pcommon.TransferHeader) (spool *os.File, err error) {
+	err = h.CheckSize(int64(nc.GetP2PMaxTransferSize()))
+	if err != nil {
+		return nil, err
+	}
+
+	spool, err = newSpool(nc.GetP2PTransferPath(), "get_*.part")
+	if err != nil {
+		return nil, err
+	}
+
+	defer func() {
+		if err != nil {
+			removeSpool(spool)
+		}
+	}()
+
+	count := h.Chunks()
+	hash := sha256.New()
+	w := io.MultiWriter(spool, hash)
+	for idx := 0; idx < count; idx++ {
+		var c *p2pcommon.Chunk
+		for i := 0; i < chunkRetries; i++ {
+			c, err = s.getChunk(ctx, nc, pid, protoc, h, idx)
+			if err == nil {
+				break
+			}
+
+			log.Warningf("failed to get chunk %d of %d: %v", idx, count, err)
+		}
+
+		if err != nil {
+			return nil, errors.New("failed to download chunk %d of %d: %v", idx, count, err)
+		}
+
+		_, err = w.Write(c.Data)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if !bytes.Equal(hash.Sum(nil), h.TransferID) {
+		return nil, p2pcommon.ErrTransferChecksumMismatch
+	}
+
+	_, err = spool.Seek(0, io.SeekStart)
+	if err != nil {
+		return nil, err
+	}
+
+	return spool, nil
+}
+
+// getChunk requests a single chunk of a prepared transfer and validates it.
+func (s *peer) getChunk(ctx context.Context, nc config.Configuration, pid libp2pPeer.ID, protoc protocol.ID, h p2pcommon.TransferHeader, idx int) (*p2pcommon.Chunk, error) {
+	envelope, err := p2pcommon.PrepareTransferEnvelope(ctx, nc.GetNetworkID(), p2pcommon.MessageTypeGetChunk, p2pcommon.ChunkRequest{
+		TransferHeader: h,
+		Index:          idx,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	recv, err := s.currentMessenger().SendMessage(ctx, pid, envelope, protoc)
+	if err != nil {
+		return nil, err
+	}
+
+	recvEnvelope, err := p2pcommon.ResolveDataEnvelope(recv)
+	if err != nil {
+		return nil, err
+	}
+
+	// handle client error
+	if p2pcommon.MessageTypeError.Equals(recvEnvelope.Header.Type) {
+		return nil, p2pcommon.ConvertClientError(recvEnvelope)
+	}
+
+	if !p2pcommon.MessageTypeGetChunkRep.Equals(recvEnvelope.Header.Type) {
+		return nil, errors.New("the received get chunk response is incorrect")
+	}
+
+	c := new(p2pcommon.Chunk)
+	err = p2pcommon.ResolveTransferMessage(recvEnvelope, c)
+	if err != nil {
+		return nil, err
+	}
+
+	if !bytes.Equal(c.TransferID, h.TransferID) || c.Index != idx || c.TotalSize != h.TotalSize || c.ChunkSize != h.ChunkSize {
+		return nil, p2pcommon.ErrInvalidChunk
+	}
+
+	return c, c.Validate()
+}
+
+// resolveChunkedResponse downloads the payload announced by a chunked response and returns it as a data envelope.
+func (s *peer) resolveChunkedResponse(ctx context.Context, nc config.Configuration, pid libp2pPeer.ID, protoc protocol.ID, recvEnvelope *p2ppb.Envelope) (*p2ppb.Envelope, error) {
+	h := new(p2pcommon.TransferHeader)
+	err := p2pcommon.ResolveTransferMessage(recvEnvelope, h)
+	if err != nil {
+		return nil, err
+	}
+
+	spool, err := s.downloadChunked(ctx, nc, pid, protoc, *h)
+	if err != nil {
+		return nil, err
+	}
+	defer removeSpool(spool)
+
+	data, err := p2pcommon.ReadPayload(spool, h.TotalSize)
+	if err != nil {
+		return nil, err
+	}
+
+	recvEnvelope.Header.Type = h.MessageType
+	recvEnvelope.Body = data
+	return recvEnvelope, nil
+}
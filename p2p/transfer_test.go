@@ -0,0 +1,47 @@
+// +build unit
+
+package p2p
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/centrifuge/centrifuge-protobufs/gen/go/p2p"
+	"github.com/centrifuge/go-centrifuge/p2p/common"
+	"github.com/centrifuge/go-centrifuge/utils"
+	"github.com/golang/protobuf/proto"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSpoolMessage(t *testing.T) {
+	dir, err := ioutil.TempDir("", "spool")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	// transfer path not configured
+	_, _, err = spoolMessage("", p2pcommon.MessageTypeSendAnchoredDoc, &p2ppb.Envelope{}, 10)
+	assert.Error(t, err)
+
+	mes := &p2ppb.Envelope{Body: utils.RandomSlice(45)}
+	data, err := proto.Marshal(mes)
+	assert.NoError(t, err)
+	f, h, err := spoolMessage(filepath.Join(dir, "transfers"), p2pcommon.MessageTypeSendAnchoredDoc, mes, 10)
+	assert.NoError(t, err)
+	assert.Equal(t, p2pcommon.NewTransferID(data), h.TransferID)
+	assert.Equal(t, int64(len(data)), h.TotalSize)
+	assert.Equal(t, ".out", filepath.Ext(f.Name()))
+
+	var got []byte
+	for i := 0; i < h.Chunks(); i++ {
+		c, err := p2pcommon.ReadChunk(f, h, i)
+		assert.NoError(t, err)
+		got = append(got, c.Data...)
+	}
+	assert.Equal(t, data, got)
+
+	removeSpool(f)
+	_, err = os.Stat(f.Name())
+	assert.True(t, os.IsNotExist(err))
+}
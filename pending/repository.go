@@ -28,6 +28,9 @@ type Repository interface {
 
 	// Delete deletes the data associated with account and ID.
 	Delete(accountID, id []byte) error
+
+	// DeleteInBatch adds the deletion of the data associated with account and ID to the batch.
+	DeleteInBatch(batch *storage.Batch, accountID, id []byte)
 }
 
 // NewRepository creates an instance of the pending document Repository
@@ -77,3 +80,7 @@ func (r *repo) Delete(accountID, id []byte) error {
 	key := r.getKey(accountID, id)
 	return r.db.Delete(key)
 }
+
+func (r *repo) DeleteInBatch(batch *storage.Batch, accountID, id []byte) {
+	batch.Delete(r.getKey(accountID, id))
+}
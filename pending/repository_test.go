@@ -43,8 +43,8 @@ func TestMain(m *testing.M) {
 		&testlogging.TestLoggingBootstrapper{},
 		&config.Bootstrapper{},
 		&leveldb.Bootstrapper{},
-		&configstore.Bootstrapper{},
 		jobsv1.Bootstrapper{},
+		&configstore.Bootstrapper{},
 		&queue.Bootstrapper{},
 		&anchors.Bootstrapper{},
 	}
@@ -167,3 +167,20 @@ func TestLevelDBRepo_Get_Create_Update(t *testing.T) {
 		assert.Contains(t, err.Error(), "is not a model object")
 	}
 }
+
+func TestRepo_DeleteInBatch(t *testing.T) {
+	r := getRepository(ctx)
+	accountID, id := utils.RandomSlice(32), utils.RandomSlice(32)
+	d := &doc{SomeString: "Hello, Repo!", DocID: id}
+	assert.NoError(t, r.Create(accountID, id, d))
+
+	batch := storage.NewBatch()
+	r.DeleteInBatch(batch, accountID, id)
+	assert.Equal(t, 1, batch.Len())
+
+	// not deleted until the batch is written
+	db := r.(*repo).db
+	assert.True(t, db.Exists(r.(*repo).getKey(accountID, id)))
+	assert.NoError(t, db.Write(batch))
+	assert.False(t, db.Exists(r.(*repo).getKey(accountID, id)))
+}
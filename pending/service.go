@@ -10,6 +10,7 @@ import (
 	"github.com/centrifuge/go-centrifuge/errors"
 	"github.com/centrifuge/go-centrifuge/identity"
 	"github.com/centrifuge/go-centrifuge/jobs"
+	"github.com/centrifuge/go-centrifuge/storage"
 	"github.com/centrifuge/go-centrifuge/utils/byteutils"
 )
 
@@ -178,12 +179,15 @@ func (s service) Commit(ctx context.Context, docID []byte) (documents.Model, job
 		return nil, jobs.NilJobID(), err
 	}
 
-	jobID, err := s.docSrv.Commit(ctx, doc)
+	// the pending document is deleted atomically with the creation of the committed version
+	batch := storage.NewBatch()
+	s.pendingRepo.DeleteInBatch(batch, accID[:], docID)
+	jobID, err := s.docSrv.CommitWithBatch(ctx, doc, batch)
 	if err != nil {
 		return nil, jobs.NilJobID(), err
 	}
 
-	return doc, jobID, s.pendingRepo.Delete(accID[:], docID)
+	return doc, jobID, nil
 }
 
 func (s service) AddSignedAttribute(ctx context.Context, docID []byte, label string, value []byte) (documents.Model, error) {
@@ -12,6 +12,7 @@ import (
 	"github.com/centrifuge/go-centrifuge/errors"
 	"github.com/centrifuge/go-centrifuge/identity"
 	"github.com/centrifuge/go-centrifuge/jobs"
+	"github.com/centrifuge/go-centrifuge/storage"
 	testingconfig "github.com/centrifuge/go-centrifuge/testingutils/config"
 	testingdocuments "github.com/centrifuge/go-centrifuge/testingutils/documents"
 	testingidentity "github.com/centrifuge/go-centrifuge/testingutils/identity"
@@ -36,6 +37,11 @@ func (m *mockRepo) Delete(accID, id []byte) error {
 	return args.Error(0)
 }
 
+func (m *mockRepo) DeleteInBatch(batch *storage.Batch, accID, id []byte) {
+	m.Called(batch, accID, id)
+	batch.Delete(id)
+}
+
 func (m *mockRepo) Create(accID, id []byte, doc documents.Model) error {
 	args := m.Called(accID, id, doc)
 	return args.Error(0)
@@ -67,22 +73,25 @@ func TestService_Commit(t *testing.T) {
 	// failed commit
 	doc := new(documents.MockModel)
 	repo.On("Get", did[:], docID).Return(doc, nil)
+	repo.On("DeleteInBatch", mock.Anything, did[:], docID).Return()
 	docSrv := new(testingdocuments.MockService)
-	docSrv.On("Commit", ctx, doc).Return(nil, errors.New("failed to commit")).Once()
+	docSrv.On("CommitWithBatch", ctx, doc, mock.Anything).Return(nil, errors.New("failed to commit")).Once()
 	s.docSrv = docSrv
 	_, _, err = s.Commit(ctx, docID)
 	assert.Error(t, err)
 
-	// success
+	// success, pending document deleted with the commit
 	jobID := jobs.NewJobID()
-	repo.On("Delete", did[:], docID).Return(nil)
-	docSrv.On("Commit", ctx, doc).Return(jobID, nil)
+	docSrv.On("CommitWithBatch", ctx, doc, mock.MatchedBy(func(batch *storage.Batch) bool {
+		return batch.Len() == 1
+	})).Return(jobID, nil)
 	m, jid, err := s.Commit(ctx, docID)
 	assert.NoError(t, err)
 	assert.Equal(t, jobID, jid)
 	assert.NotNil(t, m)
 	docSrv.AssertExpectations(t)
 	doc.AssertExpectations(t)
+	repo.AssertExpectations(t)
 }
 
 func TestService_Create(t *testing.T) {
@@ -3,6 +3,9 @@ package queue
 import (
 	"github.com/centrifuge/go-centrifuge/bootstrap"
 	"github.com/centrifuge/go-centrifuge/config/configstore"
+	"github.com/centrifuge/go-centrifuge/errors"
+	"github.com/centrifuge/go-centrifuge/jobs"
+	"github.com/centrifuge/go-centrifuge/storage"
 )
 
 // Bootstrapper implements bootstrap.Bootstrapper.
@@ -16,7 +19,18 @@ func (b *Bootstrapper) Bootstrap(context map[string]interface{}) error {
 	if err != nil {
 		return err
 	}
-	srv := &Server{config: cfg, taskTypes: []TaskType{}}
+
+	db, ok := context[storage.BootstrappedDB].(storage.Repository)
+	if !ok {
+		return errors.New("storage repository not initialised")
+	}
+
+	jobMan, ok := context[jobs.BootstrappedService].(jobs.Manager)
+	if !ok {
+		return errors.New("jobs manager not initialised")
+	}
+
+	srv := NewServer(cfg, db, jobMan)
 	context[bootstrap.BootstrappedQueueServer] = srv
 	b.context = context
 	return nil
@@ -0,0 +1,238 @@
+package queue
+
+import (
+	"encoding/json"
+	"reflect"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/centrifuge/go-centrifuge/errors"
+	"github.com/centrifuge/go-centrifuge/storage"
+	"github.com/centrifuge/gocelery"
+)
+
+const (
+	// TaskRestoredParam is set in the kwargs of a task queued before the node restarted.
+	TaskRestoredParam = "QueueTaskRestored"
+
+	// TaskLastTryParam is set in the kwargs of a task that isn't retried if it fails.
+	TaskLastTryParam = "QueueTaskLastTry"
+
+	taskPrefix   = "queue_task_"
+	resultPrefix = "queue_result_"
+)
+
+// queuedTask is a task message persisted by the broker until the worker stores its result.
+type queuedTask struct {
+	Seq     uint64                `json:"seq"`
+	Message *gocelery.TaskMessage `json:"message"`
+}
+
+// JSON returns json marshaled task.
+func (t *queuedTask) JSON() ([]byte, error) {
+	return json.Marshal(t)
+}
+
+// FromJSON loads the task from json bytes.
+func (t *queuedTask) FromJSON(data []byte) error {
+	return json.Unmarshal(data, t)
+}
+
+// Type returns the reflect type of the task.
+func (t *queuedTask) Type() reflect.Type {
+	return reflect.TypeOf(t)
+}
+
+// taskResult is the result of a task persisted by the backend until it is read.
+type taskResult struct {
+	TaskID string                  `json:"task_id"`
+	Result *gocelery.ResultMessage `json:"result"`
+}
+
+// JSON returns json marshaled result.
+func (r *taskResult) JSON() ([]byte, error) {
+	return json.Marshal(r)
+}
+
+// FromJSON loads the result from json bytes.
+func (r *taskResult) FromJSON(data []byte) error {
+	return json.Unmarshal(data, r)
+}
+
+// Type returns the reflect type of the result.
+func (r *taskResult) Type() reflect.Type {
+	return reflect.TypeOf(r)
+}
+
+func getTaskKey(id string) []byte {
+	return []byte(taskPrefix + id)
+}
+
+func getResultKey(id string) []byte {
+	return []byte(resultPrefix + id)
+}
+
+// levelDBBroker implements gocelery.CeleryBroker on the storage repository.
+// A task message stays in the repository until the worker stores its result, so the tasks that were queued
+// or running when the node stopped are queued again when the broker is created. Tasks are delivered at least once.
+type levelDBBroker struct {
+	db    storage.Repository
+	mu    sync.Mutex
+	seq   uint64
+	queue []*queuedTask
+}
+
+// newLevelDBBroker returns a broker that queues the task messages left in the repository.
+func newLevelDBBroker(db storage.Repository) (gocelery.CeleryBroker, error) {
+	db.Register(new(queuedTask))
+	models, err := db.GetAllByPrefix(taskPrefix)
+	if err != nil {
+		return nil, errors.New("failed to load queued tasks: %v", err)
+	}
+
+	b := &levelDBBroker{db: db}
+	for _, m := range models {
+		qt, ok := m.(*queuedTask)
+		if !ok || qt.Message == nil {
+			continue
+		}
+
+		// no one waits for the result of the task anymore
+		setKwarg(qt.Message, TaskRestoredParam, true)
+		err = db.Update(getTaskKey(qt.Message.ID), qt)
+		if err != nil {
+			return nil, errors.New("failed to restore task %s[%s]: %v", qt.Message.Task, qt.Message.ID, err)
+		}
+
+		b.queue = append(b.queue, qt)
+		if qt.Seq >= b.seq {
+			b.seq = qt.Seq + 1
+		}
+
+		log.Infof("Restored task %s[%s]", qt.Message.Task, qt.Message.ID)
+	}
+
+	sort.Slice(b.queue, func(i, j int) bool {
+		return b.queue[i].Seq < b.queue[j].Seq
+	})
+
+	return b, nil
+}
+
+// SendCeleryMessage persists and queues the task message. Retries of a task replace its persisted message.
+func (b *levelDBBroker) SendCeleryMessage(m *gocelery.CeleryMessage) error {
+	msg := m.GetTaskMessage()
+	if msg == nil {
+		return errors.New("failed to get task message")
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	qt := &queuedTask{Seq: b.seq, Message: msg}
+	batch := storage.NewBatch()
+	batch.Put(getTaskKey(msg.ID), qt)
+	err := b.db.Write(batch)
+	if err != nil {
+		return errors.New("failed to save task %s[%s]: %v", msg.Task, msg.ID, err)
+	}
+
+	b.seq++
+	b.queue = append(b.queue, qt)
+	return nil
+}
+
+// GetTaskMessage returns the first queued task message that is due.
+// Messages delayed to the future stay queued, so they aren't written again on every poll of the workers.
+func (b *levelDBBroker) GetTaskMessage() (*gocelery.TaskMessage, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	now := time.Now().UTC()
+	for i, qt := range b.queue {
+		msg := qt.Message
+		if msg.Settings != nil && now.Before(msg.Settings.Delay) {
+			continue
+		}
+
+		b.queue = append(b.queue[:i], b.queue[i+1:]...)
+		setKwarg(msg, TaskLastTryParam, msg.Settings == nil || msg.Tries+1 >= msg.Settings.MaxTries)
+		return msg, nil
+	}
+
+	return nil, nil
+}
+
+// setKwarg sets the kwarg of the task message.
+func setKwarg(msg *gocelery.TaskMessage, key string, value interface{}) {
+	if msg.Kwargs == nil {
+		msg.Kwargs = make(map[string]interface{})
+	}
+
+	msg.Kwargs[key] = value
+}
+
+// levelDBBackend implements gocelery.CeleryBackend on the storage repository.
+// Storing the result of a task removes its message from the broker.
+type levelDBBackend struct {
+	db storage.Repository
+}
+
+// newLevelDBBackend returns a backend on the repository.
+// Results left by a previous run are removed, no one waits for them anymore.
+func newLevelDBBackend(db storage.Repository) (gocelery.CeleryBackend, error) {
+	db.Register(new(taskResult))
+	models, err := db.GetAllByPrefix(resultPrefix)
+	if err != nil {
+		return nil, errors.New("failed to load task results: %v", err)
+	}
+
+	batch := storage.NewBatch()
+	for _, m := range models {
+		if r, ok := m.(*taskResult); ok {
+			batch.Delete(getResultKey(r.TaskID))
+		}
+	}
+
+	err = db.Write(batch)
+	if err != nil {
+		return nil, errors.New("failed to remove task results: %v", err)
+	}
+
+	return levelDBBackend{db: db}, nil
+}
+
+// GetResult returns the result of the task and removes it.
+// The result is read by the AsyncResult of the task only, which keeps it once read.
+func (b levelDBBackend) GetResult(taskID string) (*gocelery.ResultMessage, error) {
+	key := getResultKey(taskID)
+	m, err := b.db.Get(key)
+	if err != nil {
+		return nil, err
+	}
+
+	r, ok := m.(*taskResult)
+	if !ok || r.Result == nil {
+		return nil, errors.New("result of task %s is not a task result", taskID)
+	}
+
+	err = b.db.Delete(key)
+	if err != nil {
+		log.Warningf("failed to remove the result of task %s: %v", taskID, err)
+	}
+
+	return r.Result, nil
+}
+
+// SetResult stores the result of the task and removes the finished task from the broker.
+func (b levelDBBackend) SetResult(taskID string, result *gocelery.ResultMessage) error {
+	if result == nil {
+		return errors.New("empty result")
+	}
+
+	// the worker reuses the result message
+	res := *result
+	batch := storage.NewBatch()
+	batch.Put(getResultKey(taskID), &taskResult{TaskID: taskID, Result: &res})
+	batch.Delete(getTaskKey(taskID))
+	return b.db.Write(batch)
+}
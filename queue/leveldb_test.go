@@ -0,0 +1,117 @@
+// +build unit
+
+package queue
+
+import (
+	"testing"
+	"time"
+
+	"github.com/centrifuge/go-centrifuge/storage"
+	"github.com/centrifuge/go-centrifuge/storage/leveldb"
+	"github.com/centrifuge/gocelery"
+	"github.com/stretchr/testify/assert"
+)
+
+func newTestRepo(t *testing.T) storage.Repository {
+	db, err := leveldb.NewLevelDBStorage(leveldb.GetRandomTestStoragePath())
+	assert.NoError(t, err)
+	return leveldb.NewLevelDBRepository(db)
+}
+
+func celeryMessage(t *testing.T, id string, tries uint, delay time.Time) *gocelery.CeleryMessage {
+	body, err := (&gocelery.TaskMessage{
+		ID:       id,
+		Task:     "mock task",
+		Kwargs:   map[string]interface{}{"key": "value"},
+		Tries:    tries,
+		Settings: &gocelery.TaskSettings{MaxTries: 2, Delay: delay},
+	}).Encode()
+	assert.NoError(t, err)
+	return &gocelery.CeleryMessage{
+		Body:            body,
+		ContentType:     "application/json",
+		ContentEncoding: "utf-8",
+		Properties:      gocelery.CeleryProperties{BodyEncoding: "base64"},
+	}
+}
+
+func TestLevelDBBroker(t *testing.T) {
+	repo := newTestRepo(t)
+	broker, err := newLevelDBBroker(repo)
+	assert.NoError(t, err)
+	msg, err := broker.GetTaskMessage()
+	assert.NoError(t, err)
+	assert.Nil(t, msg)
+
+	// invalid message
+	assert.Error(t, broker.SendCeleryMessage(&gocelery.CeleryMessage{}))
+
+	now := time.Now().UTC()
+	assert.NoError(t, broker.SendCeleryMessage(celeryMessage(t, "1", 0, now)))
+	assert.NoError(t, broker.SendCeleryMessage(celeryMessage(t, "2", 0, now.Add(time.Hour))))
+	assert.NoError(t, broker.SendCeleryMessage(celeryMessage(t, "3", 1, now)))
+
+	// delayed messages stay queued
+	msg, err = broker.GetTaskMessage()
+	assert.NoError(t, err)
+	assert.Equal(t, "1", msg.ID)
+	assert.Equal(t, "value", msg.Kwargs["key"])
+	assert.Equal(t, false, msg.Kwargs[TaskLastTryParam])
+	assert.Nil(t, msg.Kwargs[TaskRestoredParam])
+	msg, err = broker.GetTaskMessage()
+	assert.NoError(t, err)
+	assert.Equal(t, "3", msg.ID)
+	assert.Equal(t, true, msg.Kwargs[TaskLastTryParam])
+	msg, err = broker.GetTaskMessage()
+	assert.NoError(t, err)
+	assert.Nil(t, msg)
+
+	// messages without results are queued again on restart
+	broker, err = newLevelDBBroker(repo)
+	assert.NoError(t, err)
+	backend, err := newLevelDBBackend(repo)
+	assert.NoError(t, err)
+	msg, err = broker.GetTaskMessage()
+	assert.NoError(t, err)
+	assert.Equal(t, "1", msg.ID)
+	assert.Equal(t, true, msg.Kwargs[TaskRestoredParam])
+	assert.NoError(t, backend.SetResult(msg.ID, &gocelery.ResultMessage{Result: "done"}))
+
+	// retries replace the message
+	msg, err = broker.GetTaskMessage()
+	assert.NoError(t, err)
+	assert.Equal(t, "3", msg.ID)
+	assert.NoError(t, broker.SendCeleryMessage(celeryMessage(t, "3", 2, now.Add(time.Hour))))
+
+	broker, err = newLevelDBBroker(repo)
+	assert.NoError(t, err)
+	msg, err = broker.GetTaskMessage()
+	assert.NoError(t, err)
+	assert.Nil(t, msg)
+	assert.Len(t, broker.(*levelDBBroker).queue, 2)
+	assert.Equal(t, uint(2), broker.(*levelDBBroker).queue[1].Message.Tries)
+}
+
+func TestLevelDBBackend(t *testing.T) {
+	repo := newTestRepo(t)
+	backend, err := newLevelDBBackend(repo)
+	assert.NoError(t, err)
+	_, err = backend.GetResult("1")
+	assert.Error(t, err)
+	assert.Error(t, backend.SetResult("1", nil))
+
+	// result is removed once read
+	assert.NoError(t, backend.SetResult("1", &gocelery.ResultMessage{Error: "failed"}))
+	res, err := backend.GetResult("1")
+	assert.NoError(t, err)
+	assert.Equal(t, "failed", res.Error)
+	_, err = backend.GetResult("1")
+	assert.Error(t, err)
+
+	// results of a previous run are removed
+	assert.NoError(t, backend.SetResult("2", &gocelery.ResultMessage{Result: "done"}))
+	backend, err = newLevelDBBackend(repo)
+	assert.NoError(t, err)
+	_, err = backend.GetResult("2")
+	assert.Error(t, err)
+}
@@ -0,0 +1,94 @@
+package queue
+
+import (
+	"github.com/centrifuge/go-centrifuge/identity"
+	"github.com/centrifuge/go-centrifuge/jobs"
+	"github.com/centrifuge/gocelery"
+)
+
+// JobTask is implemented by the tasks that update the status of a job.
+// A task restored after a node restart has no one waiting for its result, so the queue finishes its job instead.
+type JobTask interface {
+	// JobDetails returns the account and the ID of the job the task belongs to.
+	JobDetails() (identity.DID, jobs.JobID)
+}
+
+// persistentTask wraps a registered task of the persisted queue.
+// Tasks are delivered at least once. A task may run again after a restart if the node stopped before its result was stored.
+type persistentTask struct {
+	name   string
+	task   gocelery.CeleryTask
+	jobMan jobs.Manager
+
+	// restored is set if the task was queued before the node restarted
+	restored bool
+
+	// lastTry is set if the task isn't retried after this run
+	lastTry bool
+}
+
+// ParseKwargs parses the queue flags and the kwargs of the wrapped task.
+func (t *persistentTask) ParseKwargs(kwargs map[string]interface{}) error {
+	t.restored, _ = kwargs[TaskRestoredParam].(bool)
+	t.lastTry, _ = kwargs[TaskLastTryParam].(bool)
+	return t.task.ParseKwargs(kwargs)
+}
+
+// RunTask runs the wrapped task and finishes the job of a restored task once the task finishes.
+func (t *persistentTask) RunTask() (interface{}, error) {
+	res, err := t.task.RunTask()
+	if t.restored && (err != gocelery.ErrTaskRetryable || t.lastTry) {
+		t.finishJob()
+	}
+
+	return res, err
+}
+
+// finishJob updates the status of the job based on the status of the task.
+// Jobs waiting on other tasks, like signature collection, stay pending.
+func (t *persistentTask) finishJob() {
+	jt, ok := t.task.(JobTask)
+	if !ok || t.jobMan == nil {
+		return
+	}
+
+	accountID, jobID := jt.JobDetails()
+	job, err := t.jobMan.GetJob(accountID, jobID)
+	if err != nil {
+		log.Error(err)
+		return
+	}
+
+	if job.Status != jobs.Pending {
+		return
+	}
+
+	status := job.TaskStatus[t.name]
+	if status == jobs.Pending || status == "" {
+		return
+	}
+
+	err = t.jobMan.UpdateJobStatus(accountID, jobID, status, "task "+t.name+" finished after restart")
+	if err != nil {
+		log.Error(err)
+	}
+}
+
+// Copy returns a new wrapper around a copy of the wrapped task.
+func (t *persistentTask) Copy() (gocelery.CeleryTask, error) {
+	task, err := t.task.Copy()
+	if err != nil {
+		return nil, err
+	}
+
+	return &persistentTask{
+		name:   t.name,
+		task:   task,
+		jobMan: t.jobMan,
+	}, nil
+}
+
+// TaskTypeName returns the name of the wrapped task.
+func (t *persistentTask) TaskTypeName() string {
+	return t.name
+}
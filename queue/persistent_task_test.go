@@ -0,0 +1,122 @@
+// +build unit
+
+package queue
+
+import (
+	"testing"
+
+	"github.com/centrifuge/go-centrifuge/errors"
+	"github.com/centrifuge/go-centrifuge/identity"
+	"github.com/centrifuge/go-centrifuge/jobs"
+	"github.com/centrifuge/go-centrifuge/testingutils/identity"
+	"github.com/centrifuge/go-centrifuge/testingutils/testingjobs"
+	"github.com/centrifuge/gocelery"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type mockTask struct {
+	kwargs    map[string]interface{}
+	err       error
+	accountID identity.DID
+	jobID     jobs.JobID
+}
+
+func (m *mockTask) TaskTypeName() string {
+	return "mock task"
+}
+
+func (m *mockTask) ParseKwargs(kwargs map[string]interface{}) error {
+	m.kwargs = kwargs
+	return nil
+}
+
+func (m *mockTask) RunTask() (interface{}, error) {
+	return nil, m.err
+}
+
+func (m *mockTask) Copy() (gocelery.CeleryTask, error) {
+	return &mockTask{err: m.err, accountID: m.accountID, jobID: m.jobID}, nil
+}
+
+func (m *mockTask) JobDetails() (identity.DID, jobs.JobID) {
+	return m.accountID, m.jobID
+}
+
+func TestPersistentTask_RunTask(t *testing.T) {
+	jobMan := new(testingjobs.MockJobManager)
+	task := &mockTask{err: gocelery.ErrTaskRetryable}
+	srv := &Server{db: newTestRepo(t), jobMan: jobMan}
+	pt := srv.wrapTask(task).(*persistentTask)
+
+	// retried task, the kwargs are passed through
+	ct, err := pt.Copy()
+	assert.NoError(t, err)
+	assert.NoError(t, ct.ParseKwargs(map[string]interface{}{TaskRestoredParam: true, "key": "value"}))
+	assert.Equal(t, "value", ct.(*persistentTask).task.(*mockTask).kwargs["key"])
+	_, err = ct.RunTask()
+	assert.Equal(t, gocelery.ErrTaskRetryable, err)
+
+	// tasks that weren't restored don't finish their job
+	task.err = errors.New("failed")
+	ct, err = pt.Copy()
+	assert.NoError(t, err)
+	assert.NoError(t, ct.ParseKwargs(map[string]interface{}{TaskLastTryParam: true}))
+	_, err = ct.RunTask()
+	assert.EqualError(t, err, "failed")
+	jobMan.AssertNotCalled(t, "GetJob", mock.Anything, mock.Anything)
+
+	// not wrapped without a repository
+	srv = &Server{}
+	_, ok := srv.wrapTask(task).(*mockTask)
+	assert.True(t, ok)
+}
+
+func TestPersistentTask_finishJob(t *testing.T) {
+	jobMan := new(testingjobs.MockJobManager)
+	did := testingidentity.GenerateRandomDID()
+	jobID := jobs.NewJobID()
+	task := &mockTask{accountID: did, jobID: jobID}
+	srv := &Server{db: newTestRepo(t), jobMan: jobMan}
+	pt := srv.wrapTask(task).(*persistentTask)
+
+	job := jobs.NewJob(did, "mock job")
+	job.TaskStatus[task.TaskTypeName()] = jobs.Success
+	jobMan.On("GetJob", did, jobID).Return(job, nil).Once()
+	jobMan.On("UpdateJobStatus", did, jobID, jobs.Success, "task mock task finished after restart").Return(nil).Once()
+	ct, err := pt.Copy()
+	assert.NoError(t, err)
+	assert.NoError(t, ct.ParseKwargs(map[string]interface{}{TaskRestoredParam: true}))
+	_, err = ct.RunTask()
+	assert.NoError(t, err)
+	jobMan.AssertExpectations(t)
+
+	// task still pending
+	job.TaskStatus[task.TaskTypeName()] = jobs.Pending
+	jobMan.On("GetJob", did, jobID).Return(job, nil).Once()
+	ct, err = pt.Copy()
+	assert.NoError(t, err)
+	assert.NoError(t, ct.ParseKwargs(map[string]interface{}{TaskRestoredParam: true}))
+	_, err = ct.RunTask()
+	assert.NoError(t, err)
+	jobMan.AssertExpectations(t)
+
+	// retried task finishes its job on the last try only
+	task.err = gocelery.ErrTaskRetryable
+	ct, err = pt.Copy()
+	assert.NoError(t, err)
+	assert.NoError(t, ct.ParseKwargs(map[string]interface{}{TaskRestoredParam: true}))
+	_, err = ct.RunTask()
+	assert.Equal(t, gocelery.ErrTaskRetryable, err)
+	jobMan.AssertExpectations(t)
+
+	job.TaskStatus[task.TaskTypeName()] = jobs.Failed
+	jobMan.On("GetJob", did, jobID).Return(job, nil).Once()
+	jobMan.On("UpdateJobStatus", did, jobID, jobs.Failed, "task mock task finished after restart").Return(nil).Once()
+	ct, err = pt.Copy()
+	assert.NoError(t, err)
+	assert.NoError(t, ct.ParseKwargs(map[string]interface{}{TaskRestoredParam: true, TaskLastTryParam: true}))
+	_, err = ct.RunTask()
+	assert.Equal(t, gocelery.ErrTaskRetryable, err)
+	jobMan.AssertExpectations(t)
+}
@@ -6,6 +6,8 @@ import (
 	"time"
 
 	"github.com/centrifuge/go-centrifuge/errors"
+	"github.com/centrifuge/go-centrifuge/jobs"
+	"github.com/centrifuge/go-centrifuge/storage"
 	"github.com/centrifuge/gocelery"
 	logging "github.com/ipfs/go-log"
 )
@@ -45,12 +47,21 @@ type TaskResult interface {
 	Get(timeout time.Duration) (interface{}, error)
 }
 
-// Server represents the queue server currently implemented based on gocelery
+// Server represents the queue server currently implemented based on gocelery.
+// Enqueued tasks are persisted in the repository until they finish and are queued again when the server starts.
 type Server struct {
 	config    Config
 	lock      sync.RWMutex
 	queue     *gocelery.CeleryClient
 	taskTypes []TaskType
+	db        storage.Repository
+	jobMan    jobs.Manager
+}
+
+// NewServer returns a queue server that persists the tasks in the repository.
+// The tasks are kept in memory only if the repository is nil.
+func NewServer(config Config, db storage.Repository, jobMan jobs.Manager) *Server {
+	return &Server{config: config, taskTypes: []TaskType{}, db: db, jobMan: jobMan}
 }
 
 // Name of the queue server
@@ -62,10 +73,16 @@ func (qs *Server) Name() string {
 func (qs *Server) Start(ctx context.Context, wg *sync.WaitGroup, startupErr chan<- error) {
 	defer wg.Done()
 	qs.lock.Lock()
-	var err error
+	broker, backend, err := qs.newBrokerAndBackend()
+	if err != nil {
+		qs.lock.Unlock()
+		startupErr <- err
+		return
+	}
+
 	qs.queue, err = gocelery.NewCeleryClient(
-		gocelery.NewInMemoryBroker(),
-		gocelery.NewInMemoryBackend(),
+		broker,
+		backend,
 		qs.config.GetNumWorkers(),
 		qs.config.GetWorkerWaitTimeMS(),
 	)
@@ -73,7 +90,7 @@ func (qs *Server) Start(ctx context.Context, wg *sync.WaitGroup, startupErr chan
 		startupErr <- err
 	}
 	for _, task := range qs.taskTypes {
-		qs.queue.Register(task.TaskTypeName(), task)
+		qs.queue.Register(task.TaskTypeName(), qs.wrapTask(task))
 	}
 	// start the workers
 	qs.queue.StartWorker()
@@ -87,6 +104,25 @@ func (qs *Server) Start(ctx context.Context, wg *sync.WaitGroup, startupErr chan
 	log.Info("Queue server stopped")
 }
 
+// newBrokerAndBackend returns the broker and the backend of the queue, on the repository if there is one.
+func (qs *Server) newBrokerAndBackend() (gocelery.CeleryBroker, gocelery.CeleryBackend, error) {
+	if qs.db == nil {
+		return gocelery.NewInMemoryBroker(), gocelery.NewInMemoryBackend(), nil
+	}
+
+	broker, err := newLevelDBBroker(qs.db)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	backend, err := newLevelDBBackend(qs.db)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return broker, backend, nil
+}
+
 // RegisterTaskType registers a task type on the queue server
 func (qs *Server) RegisterTaskType(name string, task interface{}) {
 	qs.lock.Lock()
@@ -105,6 +141,19 @@ func (qs *Server) EnqueueJob(taskName string, params map[string]interface{}) (Ta
 	})
 }
 
+// EnqueueJobAt enqueues a job on the queue server for the given taskTypeName that runs once the given time is due.
+// Delayed jobs of the persisted queue are resumed after a restart.
+func (qs *Server) EnqueueJobAt(taskName string, params map[string]interface{}, at time.Time) error {
+	qs.lock.RLock()
+	defer qs.lock.RUnlock()
+
+	_, err := qs.enqueueJob(taskName, params, &gocelery.TaskSettings{
+		MaxTries: uint(qs.config.GetTaskRetries()),
+		Delay:    at.UTC(),
+	})
+	return err
+}
+
 func (qs *Server) enqueueJob(name string, params map[string]interface{}, settings *gocelery.TaskSettings) (TaskResult, error) {
 	if qs.queue == nil {
 		return nil, errors.New("queue hasn't been initialised")
@@ -117,6 +166,16 @@ func (qs *Server) enqueueJob(name string, params map[string]interface{}, setting
 	})
 }
 
+// wrapTask wraps the task so that the jobs of the tasks restored after a restart are finished.
+func (qs *Server) wrapTask(task TaskType) interface{} {
+	ct, ok := task.(gocelery.CeleryTask)
+	if !ok || qs.db == nil {
+		return task
+	}
+
+	return &persistentTask{name: task.TaskTypeName(), task: ct, jobMan: qs.jobMan}
+}
+
 // EnqueueJobWithMaxTries enqueues a job on the queue server for the given taskTypeName with maximum tries
 func (qs *Server) EnqueueJobWithMaxTries(taskName string, params map[string]interface{}) (TaskResult, error) {
 	qs.lock.RLock()
@@ -69,7 +69,7 @@ func (fi bindataFileInfo) Sys() interface{} {
 	return nil
 }
 
-var _goCentrifugeBuildConfigsDefault_configYaml = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\xec\x58\x4b\x6f\xdb\x30\x12\xbe\xeb\x57\x0c\x9c\x4b\xbb\x68\x6d\x4b\x7e\xc4\x31\xb0\x07\x27\x4e\xdc\x34\x0f\x38\x71\x9a\xb4\xbd\x2c\x68\x6a\x24\xb1\x96\x48\x95\xa4\xfc\xc8\xaf\x5f\x0c\x45\x39\x49\xd3\x6e\x77\xbb\xd8\x05\x16\xd8\x5c\x2c\x90\x9c\x6f\x86\x33\xdf\x3c\x98\x03\x98\x62\xc2\xaa\xdc\x42\x8c\x6b\xcc\x55\x59\xa0\xb4\x60\xd1\x58\x89\x16\x58\xca\x84\x34\x16\x56\x6a\xcd\x64\xc0\x51\x5a\x2d\x92\x2a\xc5\x6b\xb4\x1b\xa5\x57\x63\x48\x72\x21\x6d\xe0\x40\x84\x44\xb0\x19\x42\xec\xf1\x64\x7d\xc6\x80\xcd\x98\x85\x93\xbd\x2c\x14\x4c\x48\x4b\xb8\x41\x73\x64\x1c\x00\x1c\xc0\xa5\xe2\x2c\x77\xaa\x85\x4c\x81\x2b\x69\x35\xe3\x16\x58\x1c\x6b\x34\x06\x0d\x48\xc4\x18\xac\x82\x25\x82\x41\x0b\x1b\x61\x33\x40\xb9\x86\x35\xd3\x82\x2d\x73\x34\xed\x00\x1a\x79\x82\x04\x10\xf1\x18\x7a\xbd\x9e\xfb\x46\x9b\xa1\xc6\xaa\xf0\xb6\x9f\xc7\x63\x18\xf5\x46\xf5\xde\x52\x29\x6b\xac\x66\xe5\x1c\x51\x9b\x5a\xf6\x3d\xb4\x3a\xa2\xec\x77\xc2\xe8\xb0\xdd\x6d\x77\xdb\x61\xc7\xf2\xb2\xd3\x1b\x45\xdd\xa8\x23\xca\xc4\x74\x6e\x8a\xbb\x9b\xed\x72\xb3\xaa\xbe\x7e\xf9\x32\x4d\xaa\xc7\xbb\xe5\xf6\x74\x72\x8b\x77\xd7\x27\x97\xea\x71\xb7\x1b\x0c\x46\xeb\x1b\x99\xde\xaf\xe7\x57\xdf\x2e\xbf\xac\x5a\xbf\x01\xed\x35\xa0\xf7\xc9\xf0\xf4\x7a\x58\xac\xbe\x3f\xe0\xb7\x87\x8b\x87\xe8\xfb\xbc\x0a\x87\x9f\xcb\x78\xd6\x5b\x7d\x54\xe1\x5d\xaf\xc8\x58\x36\x3f\x1e\x2c\x70\x20\xc3\x1a\xb4\x71\xd5\xa4\xf1\x54\x7d\x01\xba\x3e\x4a\x2b\xec\xee\x8c\x71\xab\xf4\x6e\x0c\xad\x56\xe0\x5c\x7d\xc5\x84\x7c\x15\xf0\x26\x62\xf0\xe6\x82\xc2\xfd\x36\x80\x3a\xbc\x35\xda\x01\x5c\x57\x05\x6a\xc1\xe1\x7c\x0a\x2a\x71\xa1\x7e\x16\x54\x2f\xbb\xf7\x7a\x18\x79\xa9\xe3\xc6\xb5\x90\x0b\x63\x49\x52\xaa\x18\x5f\xb3\xa2\xd4\x6a\x2d\xdc\x86\x72\xd8\x4e\x75\x43\xc4\xdf\x06\xa9\x37\x68\x47\xfd\xa8\x1d\xf5\xba\xed\x30\x1c\xfe\x18\xa9\x30\x9a\xf6\x2e\x94\x7a\x58\x2c\xb7\xcb\x8b\x93\xe5\xd7\xec\xe8\xe3\xbd\x35\x37\xbb\xfb\x59\x7c\x37\xd7\xac\x7f\x5b\x2e\x26\x7d\xbb\x5c\x9b\x21\x93\x61\xf8\x6d\x33\x9b\x44\x8f\xad\x57\xf8\xbd\x7e\xfb\x30\x6a\x87\xd1\xe1\xaf\xe0\x6f\x8a\x88\x2f\x0a\x7d\x2a\xd8\xe2\xea\xbe\x9f\x7e\x5a\x1f\x3e\xcc\xb2\x32\xbd\xdd\xa8\xd1\x46\x9d\x2d\xcc\x87\xec\xeb\x6c\x39\x13\x3d\x36\x19\x6d\x5b\xde\x3d\xa7\x9e\x95\x7b\xe7\x9f\x4f\xe1\x3d\xb8\x00\xfc\x8a\xb5\xfd\xc6\xb5\x97\xcc\x85\x2d\xc6\x32\x57\x3b\x8c\x61\x51\x30\x6d\xe1\xc4\xb3\xc1\x40\xa2\xb4\x73\x65\x2a\xd6\x28\x5f\xb8\xf2\x5f\x60\x4c\x77\x1b\xf6\x86\xd1\x29\x3f\x4e\x46\xc3\xc3\xa3\xa8\xdf\x3b\x8d\xfa\xc9\xa4\x7b\x7a\xd2\x8f\x06\x71\x84\x61\x77\xd2\x1d\x45\x51\x8f\x1f\x4e\x9f\x73\xcb\x58\x96\x52\x16\xbf\xa6\x14\x2b\x96\xa8\xff\x8c\x52\xe1\xbf\x49\x29\xa7\xfa\xb7\x94\xfa\xcf\x93\xea\xff\xb4\xfa\x43\x5a\x51\x4b\x7a\x62\x45\x51\xaf\xfc\x19\x97\xba\xff\x4c\x49\x09\x8f\x46\xed\x30\x8a\xda\x61\xf8\xcb\xe0\x4c\xd2\xde\x29\x9f\x58\xfd\xe5\xfe\x64\xbb\x79\x1c\xae\x86\xe6\xee\x48\x7c\x5d\xdc\x3e\xda\xc7\xa3\xe9\xe1\xee\xd3\x63\x79\x3c\xbf\x3d\x3d\x7b\xd4\x9f\xd4\xfd\xeb\x92\x42\xec\x8a\xc2\x76\x18\xbe\x6a\x2e\x0d\xfe\xc5\x6c\x23\xb6\x9f\x51\x56\x9f\x27\xf7\xdf\x57\x1f\x2f\x0a\xf9\x61\x31\xf9\x38\xfd\xf6\x98\x1c\xe2\xec\x4a\x0d\xad\x56\x22\xfd\xba\x2d\x0e\x27\x83\xdb\x7f\x1c\x7c\xef\xae\x5f\x85\x3f\xfc\xef\x46\x7f\x72\xd6\x1f\x0c\x79\x38\xec\x8d\x86\x6c\xd8\x4f\xe2\xfe\x59\x7f\x39\x3c\x62\x49\xd8\x63\xa3\xe1\x34\xe9\x1e\x0f\x86\xd1\x84\x75\xbb\xad\x80\xa6\x0b\x66\x19\x2c\xac\xd2\x2c\xc5\xc0\xd4\xbf\xf5\xcc\x30\x67\x36\x73\x26\xe5\xd4\xcc\xa6\xc7\x90\x88\x1c\x03\x80\x92\xd9\x6c\x0c\x1d\x5b\x94\x9d\xa7\xa9\xe5\x6f\x31\xb3\xac\xed\x4e\xc6\x4b\xc2\x3d\x51\x32\x11\x69\xa5\x99\x15\x4a\xee\x15\x70\xb7\xba\xf8\x73\x35\x35\xc0\x2b\x6d\x13\xce\x55\x25\xad\x81\x15\xee\xc0\xdf\x22\x60\x7e\x91\xf4\xac\x70\x47\xcb\xe8\x11\x9b\x2d\x92\x3d\x97\x16\x75\xc2\x38\xc2\x86\x22\xe7\x22\x30\x99\x9f\x03\x93\x31\xcc\xa3\x39\x2c\x50\xaf\x51\xbb\x7a\x88\x92\x0a\x5e\x40\x25\xf1\x83\x32\x56\xb2\x02\xa9\x1d\xfb\x79\x23\x38\x80\xb9\xd2\xd6\xc3\x10\xc4\xcf\x45\xe9\xd0\x18\x46\xdd\x51\x44\xea\x29\x3d\xde\x5b\xf5\xbe\x44\xd4\xc0\x9f\x7b\xcd\x04\x65\x54\xd6\x4e\x5a\x94\xc8\x45\xb2\x83\xd3\xad\x45\x2d\x59\x0e\xe7\xf3\x67\xd6\x12\x28\x70\x26\x69\x7a\xd3\xc8\x78\x86\x31\x30\x0b\x22\x81\x25\x66\x42\xc6\x70\x3d\xb9\x23\x18\xf4\xd2\xe7\xf3\x31\x6c\xda\xdb\xf6\xae\xfd\x58\x87\x80\xac\xae\x0c\xc6\x7b\x06\xd2\xbd\x73\xb6\x43\x4d\x81\x70\xe6\xba\xfc\x71\xa7\xef\x44\x81\xaa\x72\xd7\x94\xa0\x4a\x94\x7e\xa4\x94\xc8\x9d\xd5\xd4\x12\xe8\x32\x26\x80\x66\xd9\x8b\x8c\xa1\xd5\xeb\x9a\x96\x43\x29\x84\x14\x45\x55\x40\x8c\x39\xdb\x39\xbd\xb8\x46\xbd\x83\x32\x2a\x41\xa3\x29\x95\x34\x48\x48\x6c\xad\x44\x0c\x56\x14\xa4\x85\x59\xcb\xf8\xca\x38\x00\x16\x7f\xab\x8c\x85\x25\x23\xbb\x95\x84\x4c\x19\x4b\x92\xaa\xd2\x1c\x0d\xbc\x59\x2c\xa6\xef\xe0\x64\xfe\xe9\x1d\x70\xa5\xd1\x40\xbb\xdd\x7e\xeb\x67\x61\xb5\x02\x21\x21\x57\xa9\x4b\xb9\x31\xb4\xc8\x3e\xb2\xd5\x54\x05\xc6\xb0\xdc\xd1\xb5\xea\x18\xb4\xc8\x8b\xdb\xbf\xbe\x59\xb3\xbc\xc2\x5b\x64\x31\xfc\x05\xa2\xb7\x20\x0c\xe4\x68\x5c\x5b\x94\xe0\xf6\x60\x89\xb9\xda\xbc\x23\xef\x49\xe0\x19\x93\x29\xee\xef\x31\x75\x77\xb4\x0a\xb6\x01\xbc\x5c\x1c\x43\x6b\xd0\xed\x16\xc6\xa5\xe2\x4d\x85\x15\xfe\x40\x01\xe7\x19\x66\x76\x92\x67\x5a\x49\x55\x19\xea\xbc\x1c\x8d\x11\x32\x0d\xbe\x93\x40\x4d\x90\xfa\x91\x60\x6a\x3a\x54\xae\x19\xab\x04\xa8\x00\xa1\x36\x1d\x7f\x35\xed\xfb\xf8\x46\xe4\x39\x71\x85\xe5\xb9\xe2\xcc\xd6\x6c\x31\x96\x69\x5b\x95\x01\x90\xfc\x43\x2d\x48\xc5\xbc\xeb\xf0\xcf\x34\xa2\x81\xaa\x24\x8f\x02\xdf\xf1\x1c\x4d\x4d\x80\x5a\x05\x39\x64\xc3\x84\x7b\x5d\xf8\x58\x52\x76\x81\xdf\x7e\x60\xc2\x71\xe0\x6a\x51\x17\x43\xd7\x51\xbc\x8d\x1a\xad\x16\x68\x9c\x31\x1b\x4f\x41\x06\x96\x19\xea\x28\xf4\x73\x5b\x1f\x70\x8d\x85\x0a\x0b\x4a\x7b\x92\xb9\x41\xc8\x25\x85\xe0\x2f\x5d\xe6\x9e\x52\xee\x00\x79\x86\x52\xe3\xd3\xed\xe5\x18\x36\x66\xdc\x79\x7a\x1a\x8c\x8f\x8e\xfa\xfd\xda\x10\xca\x1d\xab\x99\x34\xcc\xd1\x17\x4a\xa5\x72\x28\xd8\x76\x6f\x98\x55\x60\x50\xc6\x64\xd4\xb3\x63\x6a\xed\x92\xa3\x60\xdb\xbd\x7d\x91\xf7\xd5\xcf\x21\x05\x95\x99\x35\xcb\x1d\xee\xae\x76\x1e\x23\xd3\x79\xa5\xb5\x7b\x27\x3c\x93\xc8\x98\x81\x25\x22\x3d\x24\x2c\x72\x8b\x71\x00\x7b\x00\xd2\x47\xc4\x89\x7c\x26\x35\x8f\xcc\x5c\x24\xe8\xb9\x68\x15\xa5\x73\xad\x83\xab\xa2\x10\xd6\x45\x86\x49\x60\x92\x67\xe4\x60\xff\xf8\x24\xba\x90\xbf\xb8\x73\xe8\x7b\x08\x61\x87\x8c\xee\x55\x9f\xbb\x14\x09\x9a\x92\xc9\x31\xb4\x46\x87\xc3\x6e\xe6\x68\xba\x6f\x81\xbf\xf0\x7f\xd3\x00\x7d\xe5\xc2\x1c\xa9\xb7\x6d\x32\xc1\xb3\x7d\x73\x04\x5f\x80\x1b\x4b\xfd\x54\xa1\x88\xc2\x7e\xb4\x8c\x29\x47\x9d\x7d\x95\xb1\xaa\xf0\x4a\x9a\xee\xe0\x5f\xc2\xbe\xee\x5f\xbb\x42\xdc\xa2\x36\xdc\xda\xbf\x77\xeb\x30\xd5\xc0\x7b\xbd\x3c\x17\xe4\x6b\x57\x31\xdf\x6c\x28\x45\xbf\x57\x42\x23\x6c\x0c\x28\x0d\xa2\xe4\xfe\x11\x4c\x6f\x5e\xfa\xe4\xcc\x92\xd9\x8e\xce\x6f\x9f\xf3\x29\xb3\xb6\x1c\x77\x3a\x94\x40\x39\x95\x9e\xf1\xd1\xa0\x3f\xa8\x2b\x1b\xdb\xba\xca\xd6\x10\x3a\x65\x74\x27\xc1\x1d\x5e\xe9\x8b\xdd\x4b\x32\x09\x09\x1b\x14\x4e\x3a\xea\xc2\x6c\x83\x02\xa4\xda\xd4\xf4\x9a\x31\x33\x27\x69\xc7\xaf\xe6\xcf\x1d\x9d\x31\x03\xb9\x28\x84\x9f\x1c\x62\x91\x24\xe8\x98\xb4\x8f\xd0\xbe\x8c\x51\x2a\xa6\xcc\x5c\xba\xd3\xcd\xfb\xfd\x44\x23\xb3\xe8\x72\xdc\x63\xd2\xea\x24\x8e\x2f\x70\x37\x86\xde\xf3\xc5\x5b\x5c\xab\x15\xba\xf5\xc1\xa0\x59\xae\x39\x72\xe2\xf8\x35\x86\xd1\x0f\xeb\x73\x8d\xcd\x56\xf8\x04\x25\x13\x7b\x45\xef\x5e\x38\x7a\xb1\x76\x47\xce\x48\x50\x9f\x69\x55\x8c\x21\x1c\xec\xf7\x98\x31\x68\x17\x75\xe7\x1e\xd2\x2a\x1c\xec\xcb\x97\xc6\x42\xad\xa9\x78\x19\x30\x4a\x49\xfa\x5d\x6a\x11\xa7\x48\xd5\x88\xb2\x25\xd5\xac\x4e\x9d\xa7\xa6\x65\x95\xab\x53\x75\x0c\xe4\x13\x2f\x9e\x47\xc3\x33\x20\x8e\xeb\x7f\x89\x30\x58\xe6\x8a\xaf\xdc\x3c\x50\x13\x01\xac\x16\x69\x8a\xda\x61\xd3\x68\x86\x5b\xdb\x94\xb8\xba\xcd\x0d\xbb\x4d\x9f\xfb\x99\x62\x4d\x7d\x44\xc9\xfc\x59\x9f\x31\xfb\x94\x6c\x4c\x7a\x82\xa6\xb6\xf3\x12\x3e\x1c\x78\xf4\xff\xed\xea\x15\x1c\x00\x93\x3b\x88\x71\x59\xa5\xa9\x9f\x22\x28\xc7\x5d\x80\x53\x05\xe4\x88\xc0\xed\xd6\xb5\x04\xa5\x4b\x4b\xb7\x42\xed\x9b\x64\x02\xa0\xaf\x31\x24\x2c\x37\xe8\x4e\x95\xa5\x56\x49\x9d\x11\x0d\x30\x4d\x31\xb4\xda\x1c\x0b\x6a\x8a\xfa\xff\x65\x95\x1a\xb9\x67\xaa\xd5\x15\x06\x7f\x0f\x00\x00\xff\xff\x33\x64\x8a\xa8\xb8\x13\x00\x00")
+var _goCentrifugeBuildConfigsDefault_configYaml = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x02\xff\xed\x5a\xdb\x72\xdb\x48\x92\x7d\xd7\x57\x54\xc8\x0f\x63\x6f\xc8\x14\xc1\x9b\x28\x45\x4c\xec\xd2\xba\xb5\x2f\xd2\xd0\xa2\x6c\x77\xfb\x65\xa3\x08\x14\x48\x58\x00\x0a\x46\x01\xa2\xa8\x8d\xf9\xf7\x39\x99\x55\x05\x82\x94\xdc\xee\xe9\x89\xd9\x88\x8d\xd8\xee\x07\xd3\x05\x54\x66\x56\x66\xd6\xc9\x93\x09\xbf\x10\x67\x2a\x96\x75\x5a\x89\x48\xdd\xab\x54\x17\x99\xca\x2b\x51\x29\x53\xe5\xaa\x12\x72\x21\x93\xdc\x54\xe2\x4e\xdf\xcb\x7c\x2f\xc4\xa3\x32\x89\xeb\x85\xba\x56\xd5\x4a\x97\x77\x27\x22\x4e\x93\xbc\xda\x7b\x41\x42\x92\x5c\x89\x6a\xa9\x20\xc7\xca\xcb\xed\x3b\x06\x8b\xb2\x12\xa7\xcd\x5e\x91\x41\x66\x45\x72\xf7\xfc\x2b\x27\x7b\x42\xbc\x10\x1f\x74\x28\x53\x56\x9d\xe4\x0b\x11\x6a\x6c\x90\x21\x6c\x88\xa2\x52\x19\xa3\x0c\x24\xaa\x48\x54\x5a\xcc\x95\x30\x30\x6e\x95\x54\x4b\xa1\xf2\x7b\x71\x2f\xcb\x44\xce\x53\x65\x3a\x90\xe3\xf6\x93\x48\x21\x92\xe8\x44\xf4\xfb\x7d\xfe\xad\x60\x5c\xa9\xea\xcc\xd9\xfe\x16\x8f\xc6\xfd\xb1\x7d\x36\xd7\xba\x32\x50\x57\x4c\x95\x2a\x8d\xdd\xfb\x5a\xec\x1f\x26\xc5\xe0\x30\xe8\x1d\x75\xba\xf8\x3f\x38\xac\xc2\xe2\xb0\x3f\xee\x75\x7b\x58\x8f\xcd\xe1\xc7\xec\xf6\xe3\xc3\x7c\x75\x57\x7f\xfd\xed\xb7\xb3\xb8\x7e\xbc\x9d\x3f\x9c\x4f\x6e\xd4\xed\xf5\xe9\x07\xfd\xb8\x5e\x0f\x87\xe3\xfb\x8f\xf9\xe2\xf3\xfd\xf4\xea\xdb\x87\xdf\xee\xf6\x7f\x22\xb4\xef\x85\x7e\x8e\x47\xe7\xd7\xa3\xec\xee\xfb\x17\xf5\xed\xcb\xfb\x2f\xbd\xef\xd3\x3a\x18\xfd\x5a\x44\x97\xfd\xbb\x77\x3a\xb8\xed\x67\x4b\xb9\x9c\xbe\x19\xce\xd4\x30\x0f\xac\x50\xef\xaa\x89\xf7\x94\x3d\x00\x1d\x1f\x5e\x4f\xaa\xf5\x05\x1e\xea\x72\x7d\x22\xf6\xf7\xf7\xd8\xd5\x57\x70\xff\x93\x80\xfb\x88\x89\x97\xef\x29\xdc\xaf\xf0\x26\x87\xd7\x4a\x7b\x21\xae\xeb\x4c\x95\x49\x28\xde\x9e\x09\x1d\x73\xa8\x5b\x41\x75\x7b\x1b\xaf\x07\x3d\xb7\xeb\x8d\x77\xad\x48\x13\xe8\xc0\xce\x5c\x47\xea\x69\x56\x14\xa5\xbe\x4f\xf8\x81\x66\xd9\xac\xda\x27\xe2\x4f\x83\xd4\x1f\x76\x7a\x83\x5e\xa7\xd7\x87\x4b\x83\xd1\x6e\xa4\x82\xde\x59\xff\xbd\xd6\x5f\x66\xf3\x87\xf9\xfb\xd3\xf9\xd7\xe5\xf1\xbb\xcf\x95\xf9\xb8\xfe\x7c\x19\xdd\x4e\x4b\x39\xb8\x29\x66\x93\x41\x35\xbf\x37\x23\x99\x07\xc1\xb7\xd5\xe5\xa4\xf7\xb8\xff\x44\x7e\x7f\xd0\x39\xea\x75\x10\xb9\x1f\x89\xff\x98\xf5\xc2\x59\x56\x9e\x27\x72\x76\xf5\x79\xb0\xf8\x74\x7f\xf4\xe5\x72\x59\x2c\x6e\x56\x7a\xbc\xd2\x17\x33\xf3\xcb\xf2\xeb\xe5\xfc\x32\xe9\xcb\xc9\xf8\x61\xdf\xb9\xe7\xdc\x65\x65\xe3\x7c\x78\xf7\xb5\xe0\x00\xfc\x28\x6b\x07\xde\xb5\x1f\x24\x87\x2d\x52\x45\xaa\xd7\xb8\x1a\xb3\x4c\x96\xf0\xa9\xcb\x06\x23\x62\x5d\xb2\x2b\x17\xc9\xbd\xca\xb7\x5c\xf9\x4f\x64\x4c\xf7\x21\xe8\x8f\x7a\xe7\xe1\x9b\x78\x3c\x3a\x3a\xee\x0d\xfa\xe7\xbd\x41\x3c\xe9\x9e\x9f\x0e\x7a\xc3\xa8\xa7\x82\xee\xa4\x3b\xee\xf5\xfa\xe1\xd1\x59\x3b\xb7\x4c\x25\x17\x74\x8b\x9f\xa6\x94\xcc\xe6\xaa\xfc\x73\x29\x15\xfc\x8b\x29\xc5\xaa\x7f\x9a\x52\xff\xfe\xa4\xfa\xff\xb4\xfa\x93\x69\x45\x25\x69\x93\x15\x99\x5d\xf9\x73\xb9\xd4\xfd\x23\x90\x12\x1c\x8f\x11\x18\x04\x27\xf8\x61\x70\x26\x8b\xfe\x79\x38\xa9\xca\xdf\x3e\x9f\x3e\xac\x1e\x47\x77\x23\x73\x7b\x9c\x7c\x9d\xdd\x3c\x56\x8f\xc7\x67\x47\xeb\x4f\x8f\xc5\x9b\xe9\xcd\xf9\xc5\x63\xf9\x49\x7f\xde\x7f\x16\xb2\x7a\x01\xe4\x07\x3f\x92\xff\xfe\x72\x95\x3c\xfc\xaa\xf2\xfa\xd7\xc9\xe7\xef\x77\xef\xde\x67\xf9\x2f\xb3\xc9\xbb\xb3\x6f\x8f\xf1\x91\xba\xbc\xd2\xa3\xaa\xd4\xc9\xe2\xeb\x43\x76\x34\x19\xde\xfc\x7e\xf0\x9d\xbb\x7e\x14\xfe\xe0\x7f\x37\xfa\x93\x8b\xc1\x70\x14\x06\xa3\xfe\x78\x24\x47\x83\x38\x1a\x5c\x0c\xe6\xa3\x63\x19\x07\x7d\x39\x1e\x9d\xc5\xdd\x37\xc3\x51\x6f\x22\xbb\x5d\x44\x1f\xec\x42\x56\x52\xcc\xb0\x57\x2e\xd4\x9e\xb1\x7f\x5a\xce\xf0\x46\x86\x77\x2a\x8f\x7c\xc4\x23\x7a\x51\xe2\xef\xb0\x25\x4e\x16\x75\x29\xab\x44\x13\x1a\xd9\x2d\x22\xa5\x92\x17\xcd\x0f\xc4\x7c\xae\x41\x51\x70\x94\x42\x9b\x6a\x01\x73\x21\x6d\x6e\x65\x35\x6f\xb1\x82\xa9\x04\xc9\xa0\x33\xf3\xe2\xd9\x1b\x11\x27\xa9\x3a\xa0\x9d\x56\x04\xfd\x15\x2f\x16\x78\xed\x44\x1c\x56\x59\x71\xb8\x61\x49\xff\x4d\xe6\x74\x36\xd2\xbc\x2e\x9f\xb1\x70\x6a\xae\x42\x67\x61\x49\x70\xe9\x8e\x31\xb5\x2f\xce\x3e\x7e\xe0\x13\xcd\xa5\x81\x4a\xd5\x59\x74\x36\x22\x0e\x0f\x6b\x03\x1c\x2d\xa4\x31\x88\x60\xf4\x5f\x29\x91\xa7\x25\x9e\x9e\x0c\x07\xfd\x5e\xcb\x88\xff\x34\x26\xcd\x00\x8f\x7f\x8d\x12\x43\x24\xc9\xe9\xbe\x6d\x7b\x8b\x74\x6e\x7b\x4c\x96\x8a\xbd\x86\x04\x20\x34\x57\x85\xc4\x03\xf0\x3b\xa6\x59\x6d\x6f\x93\x6d\x1d\x96\x19\x99\xdc\x12\x0c\x3e\x58\x4b\x96\x0f\x9c\xd5\x30\x6b\x87\xef\x5f\xf2\xae\x95\xb7\xed\x64\x28\x9f\x84\xa1\xae\x73\x64\xea\x9d\x5a\xfb\xc8\xef\x49\xb7\x48\x6a\xb1\xce\x47\x73\x12\xfd\x23\xda\xfb\x36\xaf\x54\x19\xcb\x50\x89\x15\x5d\x10\x3e\xe5\x64\xfa\x96\x9d\x34\xed\x4d\xc5\x4c\x95\xf7\x28\x21\x54\x76\x54\x4e\x75\x65\x8f\x2a\xcf\x2f\x70\x7b\x2e\x33\x45\xac\xc7\xd1\x3a\xc8\x9a\x6a\xdc\x1b\x2b\x86\x44\x3c\xbf\x95\x5e\x02\x0f\x05\xd6\x91\x7a\x42\xa1\xd7\x95\x7e\x5d\xe0\xcf\xed\x80\x98\xbd\xa2\x57\x58\x9f\xcd\x0a\x15\x26\xf1\x5a\x9c\x3f\xc0\xd6\x1c\x8c\xf9\xed\xb4\x65\x2d\x09\x15\xa1\xcc\x89\x24\x97\x4a\x86\x4b\x44\x10\x55\x31\x89\xb1\xb0\x4c\x70\x8c\xeb\xc9\x2d\x89\x51\x6e\xf7\xdb\xe9\x89\x58\x75\x1e\x3a\xeb\xce\xa3\x8d\x08\x59\x8d\xd4\x8a\x9a\x8b\x4e\xe7\x4e\xe5\x5a\x95\x9c\xc1\x64\x2e\xc3\x14\xbf\x7d\x9b\x64\x4a\xd7\x7c\xcc\x5c\xe8\x42\xe5\x8e\xb9\xbb\xb4\xe6\xca\x4b\x87\xa1\xeb\xe5\x96\xdd\x16\xa4\x4a\xbf\x6b\xf6\x59\x4a\x96\xe4\x49\x06\xb8\x8a\x14\xf4\xb0\x5e\x44\xb3\x5c\x0b\x1c\x19\x67\x30\x05\x04\x29\x92\x24\xef\x75\x82\x64\x4d\x32\xd2\x22\xab\x0a\xf7\xd5\xb0\x00\x19\x7d\xab\x81\x59\x94\x8b\x80\x82\x5c\xd0\x3d\xa0\x9d\xba\x2e\x43\xa4\xeb\xcb\xd9\xec\xec\x40\x9c\x4e\x3f\x1d\xc0\x08\x2c\x8b\x4e\xa7\xf3\xca\xb5\x1c\xfa\x8e\x12\x3c\xd5\x0b\x46\x36\x58\x45\xf6\x91\xad\x06\xe5\x24\x12\xf3\x35\x1d\xcb\xc6\x60\x9f\xbc\xf8\xf0\xd7\x97\xf7\x32\xad\xd5\x8d\x92\x91\xf8\x0f\xd1\x7b\x25\x12\x83\xec\x35\xcc\x3e\x72\xc1\xcf\xe0\xea\x54\xaf\x0e\xc8\x7b\xb9\x08\xb1\xbc\x50\xcd\x39\xce\xf8\x8c\x38\xcc\x03\x0c\xd8\x5a\x84\xee\x61\xb7\x9b\x39\x9f\x5c\x41\x24\x12\xd7\x88\x79\xb2\x58\x10\x6b\x21\xe9\xd5\x12\xca\x4c\xf2\xa8\xc8\xe6\xf9\x1a\xe0\x6b\x2f\x2a\x31\xf8\x84\x54\x29\x78\xa4\xce\xc8\xf0\x70\x59\xe7\x77\xa6\x23\xba\xc2\xdd\x7b\x63\x97\xe0\x3a\xba\xae\xfc\x7b\x06\x49\xa8\xfb\xc1\xf1\xa0\xdf\x1d\xb0\xd6\xb3\xa4\x54\x8c\xd1\x2e\xa3\x92\x3c\xd4\xd6\xdb\x84\xb1\x75\xb5\xd0\x1c\x60\xda\x4c\xad\x58\x29\x73\x13\x23\xba\xd6\x8a\x42\xeb\x14\xab\x86\xc9\xd6\x7a\x93\x84\xec\x49\xea\xcc\xdc\xeb\xd3\x67\x6f\x73\x23\x8c\x0d\x39\x7d\xa2\xe2\x0f\xf8\xa1\x54\xdf\x60\x3d\x45\x4d\x21\x96\xe0\x7b\xf9\x1a\x6f\xc2\x60\xbc\x2d\x53\x42\x48\x7a\x88\xf4\x72\xa6\x32\x87\x78\xb8\x75\x2a\xac\x37\x7a\xa3\xf1\xa0\x3f\x44\x6d\xb2\xf9\x81\x5c\x03\x92\xc8\xa2\x48\x13\xdb\x7b\x36\x1e\x29\xd5\xf7\x1a\xc5\xcf\x20\xbf\x4b\xce\x71\xf6\x11\xfd\xe5\xec\xed\x19\xc5\x16\xca\x78\xbb\x47\xfb\x1b\xbf\x81\x4c\x59\x29\xfb\x2e\x44\xd5\x95\xda\x0a\x13\x03\x6d\x4a\x3b\x5d\xac\x44\xa3\x6b\xaa\xca\x2b\xde\x70\x22\x46\xdd\xee\xae\x5c\x78\x26\x4a\xed\x75\xa7\x7b\x6b\x80\x48\x74\x59\xb6\xa5\xd3\x13\x16\x6e\x25\xe3\xfc\x00\xeb\xb0\x2e\x4b\x44\xa2\xa1\x45\x2f\xc4\x85\x4c\x48\x14\xd2\x39\x89\x2c\x00\x09\x19\x03\x31\x90\x17\x49\xb8\x14\xd2\x9e\x18\x7e\xad\x54\x06\x50\x40\x03\x9e\xae\x71\xff\x70\xc3\xa3\x2d\x75\x58\x32\x56\x13\x7e\xdd\x2e\x91\x0c\x4b\x9d\x46\x2d\x45\xbf\xe8\x15\x6e\x1f\x65\x98\xdb\xde\x48\x2e\x55\x4c\x38\xe4\x37\x9f\x39\x24\xc4\x35\x09\xba\x19\x97\x99\x59\xb2\xc8\x65\x55\x97\x74\x5f\xd3\xd4\x55\xd2\xa8\x2e\x6d\xc2\x86\x4b\x4d\xbf\xf6\x22\x1d\xd6\xd4\xe3\x32\xf6\x1b\xbf\xa5\x29\xc2\x95\xc7\x23\xbb\xc3\x4e\x15\xd8\x7f\xcd\xab\x30\x25\x54\xa0\x3b\x11\x3f\x44\xca\xe9\x5c\x31\x38\xb9\xbd\x4e\x12\xe2\x0a\x2c\x95\x09\xf0\x13\x74\x27\x75\x20\x46\xa6\xc9\x39\x5c\x84\x6b\x85\x10\x19\x96\xeb\x8e\xf5\x42\x7c\xaf\x75\x59\x67\x5b\xdb\x18\xc5\x6b\x6e\x55\x50\x67\xdb\xdb\x8d\x05\x96\x5d\x29\x54\xdd\x4f\x76\x6c\x39\x6d\x6b\xdd\x3a\xc9\xf7\x3a\x71\x55\xdd\xea\xe6\xed\x14\x33\xf7\xc8\x3c\x63\xb7\x8d\xa0\xb7\xd5\x87\x8e\x91\x72\x0e\x06\xa9\x00\x73\xcd\x75\x70\xbd\xd6\xb6\xdd\xdc\x99\x2d\x25\x18\xe3\x5f\x2a\x67\xba\x58\x3b\xda\x58\xaa\xaa\x5c\x73\xe1\x45\xb6\x51\x74\x33\xcf\x63\x27\x3e\x86\x22\x46\x3a\x1a\x82\x5f\x12\xdd\x9c\xa1\x75\x2e\xba\xfe\xb9\xae\x7c\x22\x6c\x42\xc5\x50\x41\xbe\x61\x99\x4d\xb0\xf7\x7b\x83\x25\xe7\xd0\xc7\x5a\xd5\x3b\xe4\xc7\x72\x5c\x69\xd6\x50\x5f\xea\x5c\xd7\x86\x3a\x49\x14\x12\x43\xe9\xf4\x9d\x36\xd8\x4a\x6c\x87\x5e\x66\x27\x62\x44\xa8\x01\x58\x87\xae\x86\xf8\xd3\xaf\x92\x34\x25\x3c\xdc\x00\x11\x16\xd1\x26\x97\x55\x5d\x40\x1a\xf6\x7f\xb1\x1b\xe9\x72\x74\x59\xfe\x45\xa9\x20\xbd\x2e\xa8\x74\x89\x70\x1d\xd2\x85\xe2\x4a\x6b\x55\xd0\x25\xa1\xbc\x61\x07\xd9\xa2\x49\x34\x46\xb8\xc7\x5f\xf0\x88\x42\x74\x35\xb3\xe4\x9e\x3b\x24\x67\x23\xf9\x3c\x51\x1b\x28\xe2\x03\x83\xdd\x19\xea\x90\xe8\x8f\x1b\xfb\x02\x5f\x54\x78\xe9\x9d\x9e\xd3\x1e\xa2\xf1\x44\x0e\x01\x74\xb2\x0c\x97\x09\x02\xb6\xf7\x4d\xcf\xdd\xfc\xae\xb9\xca\x40\xc8\x22\x55\x74\x44\x7a\xc8\xb1\xb9\x53\x45\xc5\x80\x87\x13\x57\x35\xd7\xa5\x3b\xa5\x0a\xf6\x5d\x46\xea\x29\xe9\x3a\x20\x40\x79\x44\xc7\x69\xf6\xe5\xb4\x0e\xf7\xd7\x04\x2c\x5c\x31\x9d\x11\xf6\xfa\x9a\x3a\xa4\xb8\x50\xd6\x8c\xc6\x4b\x9b\x37\x31\x43\x17\x85\x38\x18\x75\xdd\x1a\x6a\x51\xa8\x52\xbb\xec\xdf\x74\x6c\x0f\x67\x68\x92\x18\xcd\x90\x80\xaa\x86\xe0\xc2\x0c\xab\x9b\xb9\x0f\xfd\x68\xe7\xa9\x93\xb2\xa9\x99\xb4\xc5\x9a\xba\x39\x80\xf5\x93\xad\x1d\xb8\xb6\x8b\xc7\xa4\x60\xff\x70\x87\x14\x89\x77\xb3\xbf\x5d\x7f\x60\x9a\x0b\x9f\x4c\x77\xf6\x82\x0f\xb1\x17\x91\xf7\x40\xd9\x6a\x4d\x0e\x70\xf2\x6c\x0d\xb5\x6c\xfb\x5a\x57\x49\x9c\x84\xae\xd5\x61\x76\x88\x36\x78\x2b\xa5\xc1\x35\x37\xef\x70\xb8\x56\x6a\xbe\x04\xf9\x69\x35\xcf\x2e\x35\xe8\x8a\x40\xbd\x7f\x81\x8c\x48\x18\x10\xa8\x88\x56\x04\xf7\xad\x0a\x0b\xd0\xc2\x72\xa6\xdd\x01\xed\xf8\x57\x46\xaf\x61\x77\xe5\xc8\xae\x2f\x33\x13\xbb\x17\xb1\x1a\x3b\x9d\x94\xa0\x5e\x10\x4f\xfc\x92\x92\x69\x1b\xe0\x80\x0c\x91\x2e\x92\x8d\x05\x1d\x6e\x5a\x18\x29\x23\x5d\x73\x79\xb1\x23\x60\x36\xcf\xd9\xd6\xd9\x60\x0a\xf5\x86\x3a\x8e\xb9\x60\x18\x8f\x29\x57\xf2\x81\xb9\xe6\xca\x2a\xdf\xc4\xdd\xed\x37\x56\xb5\xd7\xe9\xad\xbf\xd9\x11\xb8\xdc\x6f\x81\x20\x31\x60\xde\xe5\x7d\xe6\xc0\x70\x07\x72\xd8\x0a\xea\x8e\x90\xc1\xa7\x4b\x9e\x92\x3d\x1f\xac\xd0\xbf\x40\xd1\x21\x42\xff\xe9\xe6\x03\x4a\x04\x35\x7d\x4d\x83\x71\x72\x7c\x3c\xb0\x9c\xed\x9a\x18\x3f\xf3\x24\x69\x2b\x20\x91\x1b\xb2\xb9\xb9\xe5\x88\x8c\xa1\x26\x59\x6e\xbd\x86\xa0\x95\x7b\xcd\xe1\xf8\xb2\xf7\x1c\xf0\x3c\x2f\x32\xf1\xd7\xc5\x86\x88\x91\x48\x92\xe9\x8e\x3e\x6c\xed\xa0\x1a\x35\x27\xdf\x46\x48\x61\x02\x64\x08\xf6\x02\xd8\x99\x74\x41\x1d\xd7\xf5\x5f\x20\xd2\x24\x56\x8e\x41\xc3\x64\x14\x7f\xab\x03\xd7\x05\x7c\xa5\xb2\x55\xdd\x97\x69\xff\x65\x82\x6b\x0d\x94\x87\xec\xd0\xd7\x22\x40\x5d\x91\x74\x2e\xfb\xde\x07\x88\x34\x85\x24\xd6\x30\x3e\x72\x70\xd0\xce\x76\x00\x38\x28\xce\x23\x65\x34\x60\xf9\xce\x1c\x10\xc1\x4b\x6b\x06\x21\x12\xcd\xab\x1c\xdc\x5c\xa0\x65\x42\x31\x32\x49\x78\xb0\x29\x41\xad\xf4\x6d\x1e\xd3\x95\xe0\x80\x96\x99\x05\x2d\xdb\x6d\xb3\x60\xc3\x9e\x8f\x9f\xd9\x40\xdc\x9d\x8a\x02\x9d\x0b\x04\x23\xf4\x1f\x53\x0a\x64\x38\xb3\x21\xee\xe5\x3a\xe2\xab\x2a\x35\xf2\x5f\x19\x2a\xa5\x9c\xc5\x04\xde\xf6\x1c\x16\x22\x9c\x6e\xce\xa5\x33\xda\xef\xf1\xff\x6d\x1e\xa9\x07\x9c\x3b\x52\xa1\x6e\x4e\x68\xab\x86\xb7\x69\xe3\x4a\xeb\x0f\x4f\x26\xdb\x32\x9d\x51\xbe\x3f\x34\xf5\xdc\xa0\xa1\x56\xd9\xa6\x1a\xe9\x9c\x71\x1d\x4a\x59\xba\xd3\xeb\xb1\xa6\x81\x5d\xaa\x8a\x4c\x27\x2b\x76\x8f\x35\x2e\xc5\x05\xb2\x15\x38\x57\x2b\x67\x85\x3f\x75\x9b\xc4\xba\xf7\x0f\xfc\x6a\xe4\xe8\x93\xdc\x18\xc4\xf6\x18\xb2\xc6\xd3\xdd\x64\x03\xdf\xbe\xf9\x24\x12\xe9\x90\xaf\x1d\x93\x67\x3c\xd2\x0c\x0a\x2c\x8d\xdc\x1c\xc8\x0f\x14\x78\xd9\xb4\x18\xa4\x0a\x71\x55\xbc\x20\xb7\xfd\x40\x2c\xab\xaa\xe0\x0b\x69\x76\xd2\xa0\x90\xeb\x54\xcb\x88\x2f\xac\x44\xc4\x33\x5d\x59\x99\xe4\x53\x03\xc3\x93\x50\x39\x3a\xec\x26\x55\xfb\x5e\xb5\x87\x23\x20\x85\xd7\xf7\x3b\xfb\xeb\x32\xf5\xc5\xa3\x99\x15\xfe\x00\x8b\xfc\xa4\xd0\xcd\x1e\x14\xb1\x2b\xe3\xc2\xe6\x9f\xf9\xa3\xf9\x5b\xeb\x0c\xd0\xc4\x8d\xdc\x0c\x9e\x09\x27\xbb\x13\x8d\xba\xce\x9c\x12\x3f\xdf\x71\x9f\x0c\xdd\xe4\xe6\x9a\x47\x29\xfb\x34\xaf\xdc\x6f\x3e\x0c\x56\xed\x8b\xe3\xf5\x86\xe8\xca\xa0\x96\x67\x1e\x2f\x57\x0d\x33\x04\x54\x52\x97\x97\x14\xa1\xfb\x5a\x48\xf9\x41\x3f\x21\x26\x5c\xba\x8c\x7f\xd5\xc6\x56\x0a\x09\xd0\x75\x33\x44\x3b\x46\x0b\x68\x67\x13\xae\x5e\x78\xa6\xb4\x90\x74\xa6\x24\x64\x79\x85\x1b\x57\x6c\x03\x2b\x4e\xba\x52\x09\xef\xee\x75\xc5\x25\x7e\x43\xd1\xca\x42\xed\xa5\x34\x53\xda\xcd\x58\xeb\xff\xe3\x57\xf1\xc4\x36\x66\x36\xf9\xa3\x24\x46\x4f\x4a\xa7\x6b\x22\xd4\x0c\x22\x28\x03\x61\x87\x6d\x4e\xfd\x87\xce\x53\x20\x04\x35\x86\x41\x23\x93\x56\x27\x51\xf4\x5e\x01\x6b\xfb\xed\xc5\x1b\x75\xaf\xef\x14\xaf\x0f\x87\x7e\xd9\xe2\xe5\x29\x63\x2d\x6a\xf4\xce\xfa\xb4\x54\xfe\x51\xb0\x11\x95\xc7\xd5\x15\x7d\x20\x14\xc7\x5b\x6b\xbe\xa3\xbe\x28\x35\xda\x85\x60\xd8\x3c\x93\xe0\x3c\xd5\xcc\xce\xde\x46\xb4\x8a\x73\x7b\x5e\x4c\xe9\x4a\x34\x82\x7a\x1b\x4d\x24\x13\xf5\xa3\x4c\xa2\x85\x22\xf4\xa3\x7b\xbb\xa0\xc6\x38\xda\x1a\x3b\x21\x04\x0d\x02\x12\x40\x7b\x4f\xb5\xa3\xe1\x32\x20\x8a\x1c\x07\x73\x90\x4e\x1c\xd6\x41\x1f\xae\x1f\x8d\x16\x58\x36\xcd\xb0\x71\x21\x3d\x77\xb6\x85\x1b\xa6\x3a\xb0\x78\x4e\x71\x49\x93\x20\x9d\xa7\xad\x49\x91\x69\xca\x93\x37\x69\x23\x9a\x06\x47\xdb\xe2\x83\xa1\x93\xfe\x7f\xbf\x92\x6f\x0a\xeb\xcf\xca\x69\x5b\xc5\xf3\xf5\x74\xeb\x4a\xfd\xb1\x8a\xba\xbb\xe5\x8f\xd5\x54\xf2\xaa\x3d\x9b\xb0\x40\x5f\x2a\x5d\x2e\x7c\xd1\x71\x7a\x8d\x2b\x6a\x0d\x4b\x0d\x55\x62\xd9\xe6\x33\xe5\xd6\x0e\x8f\x6e\x5b\xd6\x64\x32\x97\x34\xbe\xc2\x0d\x70\x78\x6c\x87\xb5\x79\xb8\x19\xa7\x33\x8c\xf9\x22\xc3\x09\x5a\xaa\x22\x95\x21\xa3\x77\x55\xc3\x71\xad\xf3\x11\x06\x54\x0f\x57\x56\xec\xef\x57\xd6\xc2\x35\x55\xed\xdd\xdc\x5f\x38\xff\xd8\x0e\x89\x7e\xb7\xbb\x9b\xa1\x69\x53\xdc\x27\xd3\x9f\x27\x22\xc9\xa1\x3c\x88\xb4\xde\xe6\x22\x28\xc5\x32\x59\xe0\x02\x6c\x50\xf3\x00\x18\x5c\x14\x76\xb0\xda\x82\x43\x5b\xd5\xea\xac\xd8\xdc\x8a\x61\x33\x06\x98\xaa\x92\x8a\x30\x0e\x6a\x3f\x3c\x35\x08\xcc\x79\x64\x7d\xe4\x47\x85\x1b\x5b\x4a\x99\x18\xd6\x73\xb0\xc1\x05\xfb\x99\xd8\x57\x09\x38\x29\x55\x12\xc1\x74\xc3\xa8\x85\x33\xe6\x0d\xec\xa0\x1b\xf4\x0c\x3d\xd8\x72\xe1\x6e\x35\xfa\x39\x3f\x00\xf1\x2a\xd7\xd4\x63\xd0\x3e\xfa\x3c\xb1\x4b\x0d\xd0\xe2\xc7\x6d\x16\x21\xed\x0a\x35\x8c\xc8\x2f\xaa\x62\xd4\x30\xbe\xbe\x99\x9e\x3a\x15\x07\x4e\xc5\x0e\xa7\xd8\xb9\xbf\x4b\xf5\xbb\x9c\xa2\x23\x6e\x36\xeb\x34\xb0\x60\x34\xb3\xff\xda\x66\xa9\xfc\x3f\xc0\xd9\x31\xb6\xf3\xa7\x98\x08\x03\xd5\x16\x01\xb9\xbe\xb8\xfd\x61\xd3\x1a\x57\x96\x76\x4c\x22\x89\xae\xb3\x64\x13\xe8\xfd\x52\x2d\x12\xe3\x10\x91\x66\x2b\x91\x26\x32\x9c\xd0\xd4\xc1\xfe\x6b\x16\x4a\x13\x95\xf3\xe7\xe0\x8c\xfe\x19\xc9\xd3\x0f\xc2\xe7\x37\xa7\xaf\x8f\x7a\x81\x17\x85\x2c\xc1\x01\x6c\x5e\xfa\x25\x7f\x70\x0b\x38\xb3\xba\xa0\xaf\x1f\x04\x27\xce\x9a\x13\x81\xc4\x24\x19\x2f\x1d\x7f\x79\x65\xab\x4a\x19\x06\xc1\x70\x68\x3f\xd8\xf1\x56\x2f\xd0\x1f\xc3\x1e\x4a\xf0\x47\xf0\x20\x18\x0f\x87\x47\xc3\x63\xd9\x3f\x8e\xe7\x47\xc3\x38\x3c\xea\x0f\x82\x00\x7f\x19\x46\x47\x58\x3b\x1a\x44\x83\x48\x76\xc7\xfb\x27\x5e\x2e\x0f\x41\x76\xe4\x89\xff\xf9\xfb\x9e\xed\xa8\xc1\x6f\x1c\x3f\xf6\x07\xf4\xc5\x78\x87\xe8\xc3\x8d\x86\x7d\x63\xcf\xfc\x04\x7f\x5a\xf3\x7a\x2b\xf5\x29\xc8\xb4\xbb\x67\xfb\xe9\x61\x13\xf0\x26\x3e\x9e\xc8\x37\x83\xfc\xe7\xb8\xfc\xca\x6a\x78\xc2\xd1\xfb\x9b\xbe\x7d\xd6\xe4\xb6\x9f\xe7\xfa\x81\x03\x49\xd7\x2b\x77\x49\xc1\x02\x28\x41\xdc\xa4\xd0\x2e\xd3\xe0\xa2\x1d\x7a\xff\x39\xda\xfb\x41\x80\x59\x90\x67\xb8\x90\x4b\x1e\x28\x41\xb6\x35\xa6\xa8\xcd\xf2\xac\x19\x20\x8b\x58\xa6\x46\x51\xd6\xca\x7c\x8d\xdb\x3c\xaf\x17\x0b\xf7\xc5\x8b\xd8\x2c\x53\x99\x85\x16\x84\x07\x7b\xfc\xd4\x46\x5a\xe5\x4c\x40\x79\x85\x3e\x35\xd1\x1e\x3c\xc0\x2f\x2f\x91\xde\x2a\x40\x95\x63\xcb\xfd\xbc\x60\x9a\x3a\xd1\x6a\xa3\xb8\x99\x6a\x9f\xf0\x40\x4a\x85\x8e\x93\x55\x65\xad\xf6\xfe\x01\x1f\x1d\x7f\x9d\xcb\x27\x00\x00")
 
 func goCentrifugeBuildConfigsDefault_configYamlBytes() ([]byte, error) {
 	return bindataRead(
@@ -84,12 +84,12 @@ func goCentrifugeBuildConfigsDefault_configYaml() (*asset, error) {
 		return nil, err
 	}
 
-	info := bindataFileInfo{name: "go-centrifuge/build/configs/default_config.yaml", size: 5048, mode: os.FileMode(420), modTime: time.Unix(1580138762, 0)}
+	info := bindataFileInfo{name: "go-centrifuge/build/configs/default_config.yaml", size: 10187, mode: os.FileMode(420), modTime: time.Unix(1580138762, 0)}
 	a := &asset{bytes: bytes, info: info}
 	return a, nil
 }
 
-var _goCentrifugeBuildConfigsTesting_configYaml = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x94\x53\xc9\x6e\x1c\x37\x10\xbd\xf7\x57\x10\xcc\xc1\x97\x59\xb8\x6f\x37\x43\xf1\x12\x08\x11\xe2\x38\x80\x9c\x63\x91\xac\x96\x1a\xa3\x5e\xc2\x66\x4b\x1e\x1b\xfe\xf7\xa0\x67\x46\xb6\x6f\x51\x6e\x64\x75\xbd\xf7\x58\xd5\xef\x25\x1c\x6a\xe9\xda\xe5\x0e\x6f\xb0\x3e\x8d\xe5\x10\x48\xc5\xb9\x76\xc3\x5d\x83\xf5\x1e\x0b\x2e\x7d\x68\x08\x81\x94\xc6\x65\xa8\xf3\x7a\x26\xa4\x87\x6e\x08\xe4\x74\x24\xe4\x80\xc7\x40\x5e\x7d\xa5\x90\x73\xc1\x79\xa6\x81\x3a\x1f\x19\x38\xa3\x9d\x4c\x4a\x29\x05\xa9\xcd\x96\x47\x65\x24\xb2\x2c\x93\xd6\x80\x5c\x71\x01\x9a\x6e\x68\x2a\xc7\xa9\x8e\x34\x7c\xa5\xa9\x9b\xee\xb1\xd0\x40\x01\xe7\x2d\x17\x6e\x9b\x6a\x59\x1b\x4e\xe5\x8a\x9f\x2b\x0d\x34\x59\xeb\x5b\x27\xad\xcf\xd6\xb2\xec\x45\x6a\x13\xcf\x39\x2b\x70\xad\xe4\x59\x03\x83\x9c\x5c\x2b\x80\x45\x01\x5c\x31\x2e\x2d\xcb\xd2\x48\xd6\x4a\x97\x58\x72\xf0\x9d\x6f\x82\x02\xfd\xbc\xca\x76\x8f\x34\x50\x69\x12\x37\x0e\xad\x8c\xad\x77\xac\x45\xab\x23\xb3\xc2\xb6\xce\x33\xb0\x1c\x32\xfd\xb6\xa1\x87\xdc\xd2\x40\xe7\xd3\x83\xe9\xe9\xfa\x83\x24\x1f\x1e\x70\xa0\x41\x8a\x0d\x1d\x68\x10\x46\x70\xa5\x36\x74\xa2\x81\x6f\x68\xa1\xc1\x6d\xe8\x0c\x0f\xeb\x00\x19\x79\x44\x6e\x50\x26\xef\xb8\x57\x2a\x73\x4c\x20\xa2\x8b\xc2\xa2\x42\x83\x2c\xea\xd8\x46\x25\x23\x32\x69\x0d\xe8\xec\x9c\xf3\x2d\x18\xeb\x41\x38\x2e\xc4\xfa\x90\x1e\xd2\xba\x8a\xc4\x85\x8b\x8e\x6b\xad\x75\x04\x8e\x90\x6d\x02\xf4\xcc\x30\x74\x4e\x09\x68\x13\x38\xa9\x4d\x66\x46\x69\x1d\xb3\x07\x6d\xb5\x88\x60\xda\x94\x98\x17\xd8\xae\x4c\x5d\xa6\x81\x2a\x8d\xcc\x30\x30\xdb\x2c\x00\xb7\x4a\x46\xb7\xf5\x42\xb4\x5b\xa5\x9c\xf0\xca\xfb\x2c\x6d\xa6\x1b\xfa\x88\x65\xee\xc6\x75\xc8\x6f\xaf\x2e\x3f\x7e\x82\x79\x7e\x1a\x4b\x0e\xe4\xd5\x73\xe9\xe2\x81\x40\x5e\x6a\x81\xa6\xe9\x32\x0e\xb5\xab\xc7\xdf\x72\x20\x94\x7d\x7e\xb1\x77\x9a\x66\xb5\xee\xd5\xfd\x6a\xc5\x1f\x06\x3d\xfb\xb3\x3b\x73\x65\x25\xb5\x97\xc9\x72\xdd\xe6\x2c\x79\x32\x9c\x2b\x0e\x31\x33\x05\xde\xb7\xd9\x38\x21\x92\xd3\xda\x39\xad\x52\xca\x28\x3d\x68\xe3\x14\x5a\xd0\x26\x83\xb0\x99\x9e\xc8\x66\x4c\x05\x6b\x20\x74\xbf\x7f\xfd\xd0\x25\x3c\x57\xbf\x4f\x4a\xf5\xbb\xf2\xf4\x08\x6f\xde\xea\x2f\x9f\xa2\x30\x6f\xbf\xf8\x92\x3e\x4c\xbf\xde\x7e\xd4\xf6\xaa\xbe\xf9\xf3\xfd\x74\x83\xf7\x9f\xae\xfe\x48\x37\xe3\xfb\x77\xd7\x4b\xfd\xf0\x37\x6d\x9a\x5f\xc8\xeb\x4b\x9e\xd6\xf4\x90\xb9\x8e\x05\xee\xb0\xf9\x39\x64\x07\x3c\xae\x65\x0c\x64\x5f\xfb\x69\xff\xfc\xa9\x69\xfe\x59\x70\xc1\xb5\x63\x58\xfa\xdb\xb1\x1c\xb0\xcc\x81\x88\x86\x90\xa7\xd3\xe5\x16\xba\xfa\x57\xd7\xe3\xef\x1f\x03\xe1\x4d\xb3\xd2\xac\xcd\x93\x98\xce\xab\x99\x96\xf8\xd0\xa5\xeb\x35\xb3\xbb\xdd\x7e\xb7\xdb\xc7\xa5\x7b\xc8\xfb\x82\xf3\xb8\x94\x84\xf3\x7e\x12\xd3\x35\x1e\x77\xd3\x12\x77\x13\xf6\x67\x4c\xe9\x1e\xa1\xe2\x7f\x83\x0e\x2b\xf0\x04\x9a\xbb\xbb\xa1\x1b\xee\x5e\xa8\x79\xe9\xfe\xff\xba\x3f\x01\x9f\xb5\x1b\x18\xd2\xfd\x58\x2e\xe2\x53\xc1\x34\xf6\x7d\x57\x03\xa9\x65\xc1\xe6\xdf\x00\x00\x00\xff\xff\xdc\x3c\xc5\xc4\xef\x04\x00\x00")
+var _goCentrifugeBuildConfigsTesting_configYaml = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x02\xff\x95\x54\xc9\x6e\xdc\x38\x10\xbd\xeb\x2b\x04\xcd\x21\x97\x6e\x37\xf7\x45\xb7\xc0\x59\x11\xc4\x48\x26\x03\x38\x73\x2c\x91\x45\xb7\xd0\xd6\x32\x12\x65\xc7\x09\xf2\xef\x29\x75\xb7\x93\x5c\x06\xe3\x21\x04\x48\x2c\xbe\xf7\x8a\x45\xbe\x52\xc0\x3e\x4f\x6d\x5a\x6e\xf0\x0a\xf3\xfd\x30\x1d\xea\x32\xe3\x9c\xdb\xfe\xa6\xc0\xbc\xc7\x09\x97\xae\x2e\xca\x32\x0c\x7d\x6a\xa7\x0e\x72\x3b\xf4\x2f\x70\xcc\xfb\xba\x64\x14\x86\x10\x86\xa5\xcf\xf3\x0a\x29\xcb\x0e\xda\xbe\x2e\x8f\x9f\x65\x79\xc0\x87\xba\x7c\xf6\xad\x82\x18\x27\x9c\xe7\xaa\xae\x9c\x6f\x18\x38\xa3\x9d\x0c\x8a\x06\x84\x14\x2d\x6f\x94\x91\xc8\xa2\x0c\x5a\x03\x72\xc5\x05\xe8\x6a\x53\x85\xe9\x61\xcc\x43\x55\x7f\xab\x42\x3b\xd2\x2e\x88\x0d\x38\x6f\xb9\x70\xdb\x90\xa7\x15\x70\x0c\x67\xfc\x92\x69\x29\x58\xeb\x93\x93\xd6\x47\x6b\x59\xf4\x22\xa4\xc0\x63\x8c\x0a\x5c\x92\x3c\x6a\x60\x10\x83\x4b\x02\x58\x23\x80\x2b\xc6\x25\xa1\xa4\x91\x2c\x49\x17\x58\x70\xf0\x53\x6f\x84\x09\xba\x79\x4d\xdb\xde\x91\xae\x34\x81\x1b\x87\x56\x36\xc9\x3b\x96\xd0\xea\x86\x59\x61\x93\xf3\x0c\x2c\x87\x58\x7d\xdf\x54\x87\x98\x08\x39\x1f\x37\x5c\x1d\xa7\xbf\x44\xe2\xe1\x16\xfb\xaa\x96\x62\x53\xd1\x4b\x18\xc1\x95\xda\x54\x63\x55\xf3\x4d\x45\x25\xb9\x4d\x35\xc3\xed\x5a\x40\x44\xde\x20\x37\x28\x83\x77\xdc\x2b\x15\x39\x06\x10\x8d\x6b\x84\x45\x85\x06\x59\xa3\x9b\xd4\x28\xd9\x20\x93\xd6\x80\x8e\xce\x39\x9f\xc0\x58\x0f\xc2\x71\x21\xd6\x8d\x74\x10\xd6\xa3\x08\x74\x46\x8d\xe3\x9a\x46\x03\x1c\x21\xda\x00\xe8\x99\x61\xe8\x9c\x12\x90\x02\x38\xa9\x4d\x64\x46\x11\x20\x7a\xd0\x56\x8b\x06\x4c\x0a\x81\x79\x81\x69\x55\x6a\x23\x09\x29\x8d\x44\x02\xb3\x8d\x02\x70\x4b\xa9\xdd\xd6\x0b\x91\xb6\x4a\x39\xe1\x95\xf7\x51\xda\x48\xf5\xde\xe1\x34\x93\x25\xa8\xc8\xef\xcf\xce\x17\x3f\xc2\x3c\x93\x91\x22\xdd\xfe\x63\xe8\xec\x81\xba\x7c\xaa\x05\x8a\xa2\x8d\x64\xcc\x36\x3f\xbc\x25\x9d\x8a\x7d\x79\xb2\x77\x8a\x22\x10\xf1\x72\xbf\x5a\xf1\x97\x41\x4f\xfe\x6c\x4f\x5a\x51\x49\xed\x65\xb0\x5c\xa7\x18\x25\x0f\x86\x13\x17\x9a\xc8\x14\x78\x9f\xa2\x71\x42\x04\xa7\xb5\x73\x5a\x85\x10\x51\xd2\x21\x19\xa7\xd0\xd2\x2b\x82\xa0\xb2\x8f\x62\x33\x86\x09\x33\x09\xee\x76\xcf\x6f\xdb\x80\xa7\xe8\xcf\x4a\x2b\xfd\x7a\xba\xbf\x83\x97\xaf\xf4\xd7\xcf\x8d\x30\xaf\xbe\xfa\x29\x7c\x1c\x5f\x5c\x7f\xd2\xf6\x32\xbf\xfc\xf3\xcd\x78\x85\xfb\xcf\x97\x1f\xc2\xd5\xf0\xe6\xf5\xbb\x25\x7f\xfc\xbb\xfa\xb7\x2e\x2b\xfe\x28\x9f\x9f\xdb\x6c\x6d\xaa\x72\xce\xc3\x04\x37\x58\xfc\xde\x7b\x14\x5f\xc3\x58\x97\xbb\xdc\x8d\xbb\xc7\xa5\xa2\xf8\x67\xc1\x05\x57\x44\xbf\x74\xd7\xd4\xdd\x74\x5d\x75\x29\x68\x7e\x7f\x9c\x5c\x43\x9b\xff\x6a\x3b\x7c\xff\xa9\x2e\x79\x51\xac\x32\x2b\x78\x14\xe3\xe9\xc4\xc6\xa5\xa1\xda\xde\xad\xad\x7c\x71\xb1\xa3\xa7\x59\xda\xdb\xb8\xa3\x12\x87\x65\x0a\x38\xef\x08\x49\xab\x17\x84\xbb\x18\xb1\x3b\x71\xa6\xf6\x0e\x32\xfe\x37\xe9\xb0\x12\x8f\xa4\xb9\xbd\xe9\xe9\x8f\xf3\xc4\x9c\x67\xf4\xff\xcf\xfb\x1b\xf1\x31\x77\x01\x7d\xd8\x0f\xd3\x39\xf9\x38\x61\x18\xba\xae\xa5\x6b\xcd\xd3\x82\xc5\x0f\x59\x0c\x40\xb6\x1d\x05\x00\x00")
 
 func goCentrifugeBuildConfigsTesting_configYamlBytes() ([]byte, error) {
 	return bindataRead(
@@ -104,7 +104,7 @@ func goCentrifugeBuildConfigsTesting_configYaml() (*asset, error) {
 		return nil, err
 	}
 
-	info := bindataFileInfo{name: "go-centrifuge/build/configs/testing_config.yaml", size: 1263, mode: os.FileMode(420), modTime: time.Unix(1578923703, 0)}
+	info := bindataFileInfo{name: "go-centrifuge/build/configs/testing_config.yaml", size: 1309, mode: os.FileMode(420), modTime: time.Unix(1578923703, 0)}
 	a := &asset{bytes: bytes, info: info}
 	return a, nil
 }
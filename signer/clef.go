@@ -0,0 +1,118 @@
+package signer
+
+import (
+	"bytes"
+	"context"
+
+	"github.com/centrifuge/go-centrifuge/errors"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/rlp"
+	"github.com/ethereum/go-ethereum/rpc"
+)
+
+// clefTxArgs are the transaction arguments of account_signTransaction.
+type clefTxArgs struct {
+	From     common.Address  `json:"from"`
+	To       *common.Address `json:"to,omitempty"`
+	Gas      hexutil.Uint64  `json:"gas"`
+	GasPrice *hexutil.Big    `json:"gasPrice"`
+	Value    *hexutil.Big    `json:"value"`
+	Nonce    hexutil.Uint64  `json:"nonce"`
+	Data     hexutil.Bytes   `json:"data"`
+}
+
+// clefSignResult is the result of account_signTransaction.
+type clefSignResult struct {
+	Raw hexutil.Bytes `json:"raw"`
+}
+
+// clefSigner signs ethereum transactions with a clef compatible JSON-RPC signer.
+// Clef applies its own chain ID, so the transactions are signed with EIP155 replay protection.
+type clefSigner struct {
+	address common.Address
+	client  *rpc.Client
+}
+
+func newClefSigner(url string, address common.Address) (EthereumSigner, error) {
+	if url == "" {
+		return nil, errors.NewTypedError(ErrSignerURL, errors.New("clef URL not provided"))
+	}
+
+	client, err := rpc.Dial(url)
+	if err != nil {
+		return nil, errors.NewTypedError(ErrSignerURL, err)
+	}
+
+	return clefSigner{address: address, client: client}, nil
+}
+
+// Address returns the address of the account held by clef.
+func (c clefSigner) Address() common.Address {
+	return c.address
+}
+
+// SignTx asks clef to sign the transaction and checks that the signed transaction is the one requested.
+func (c clefSigner) SignTx(signer types.Signer, tx *types.Transaction) (*types.Transaction, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), remoteSignTimeout)
+	defer cancel()
+
+	args := clefTxArgs{
+		From:     c.address,
+		To:       tx.To(),
+		Gas:      hexutil.Uint64(tx.Gas()),
+		GasPrice: (*hexutil.Big)(tx.GasPrice()),
+		Value:    (*hexutil.Big)(tx.Value()),
+		Nonce:    hexutil.Uint64(tx.Nonce()),
+		Data:     tx.Data(),
+	}
+
+	var res clefSignResult
+	if err := c.client.CallContext(ctx, &res, "account_signTransaction", args); err != nil {
+		return nil, errors.NewTypedError(ErrRemoteSigner, err)
+	}
+
+	signed := new(types.Transaction)
+	if err := rlp.DecodeBytes(res.Raw, signed); err != nil {
+		return nil, errors.NewTypedError(ErrRemoteSigner, err)
+	}
+
+	if signed.Nonce() != tx.Nonce() || signed.GasPrice().Cmp(tx.GasPrice()) != 0 || signed.Gas() != tx.Gas() ||
+		signed.Value().Cmp(tx.Value()) != 0 || !sameRecipient(signed.To(), tx.To()) || !bytes.Equal(signed.Data(), tx.Data()) {
+		return nil, errors.NewTypedError(ErrRemoteSigner, errors.New("signed transaction differs from the request"))
+	}
+
+	if err := verifySender(signer, signed, c.address); err != nil {
+		return nil, err
+	}
+
+	return signed, nil
+}
+
+func sameRecipient(a, b *common.Address) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+
+	return *a == *b
+}
+
+// verifySender checks that the transaction is signed by the address.
+// Transactions with EIP155 replay protection are checked with their own chain ID.
+func verifySender(signer types.Signer, tx *types.Transaction, address common.Address) error {
+	if tx.Protected() {
+		signer = types.NewEIP155Signer(tx.ChainId())
+	}
+
+	from, err := types.Sender(signer, tx)
+	if err != nil {
+		return errors.NewTypedError(ErrSignatureMismatch, err)
+	}
+
+	if from != address {
+		return errors.NewTypedError(ErrSignatureMismatch, errors.New("signed by %s", from.Hex()))
+	}
+
+	return nil
+}
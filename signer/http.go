@@ -0,0 +1,150 @@
+package signer
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/url"
+
+	"github.com/centrifuge/go-centrifuge/errors"
+	"github.com/centrifuge/go-centrifuge/utils"
+	"github.com/centrifuge/go-substrate-rpc-client/signature"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+// SignRequest is the request posted to the HTTP signing service.
+// Key identifies the signing key: the address of ethereum accounts and the public key of Centrifuge chain accounts.
+type SignRequest struct {
+	Scheme  string        `json:"scheme"`
+	Key     hexutil.Bytes `json:"key"`
+	Payload hexutil.Bytes `json:"payload"`
+}
+
+// SignResponse is the response of the HTTP signing service.
+// Secp256k1 signatures are 65 bytes [R || S || V] with V 0 or 1, and sr25519 signatures are 64 bytes.
+type SignResponse struct {
+	Signature hexutil.Bytes `json:"signature"`
+}
+
+// httpSigner posts the payloads to a generic HTTP signing service.
+type httpSigner struct {
+	url    string
+	client *http.Client
+}
+
+func newHTTPSigner(rawURL string) (httpSigner, error) {
+	u, err := url.Parse(rawURL)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return httpSigner{}, errors.NewTypedError(ErrSignerURL, errors.New("signing service URL %q", rawURL))
+	}
+
+	return httpSigner{url: rawURL, client: &http.Client{Timeout: remoteSignTimeout}}, nil
+}
+
+func (h httpSigner) sign(scheme string, key, payload []byte) ([]byte, error) {
+	body, err := json.Marshal(SignRequest{Scheme: scheme, Key: key, Payload: payload})
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := h.client.Post(h.url, "application/json", bytes.NewReader(body))
+	if err != nil {
+		return nil, errors.NewTypedError(ErrRemoteSigner, err)
+	}
+	defer resp.Body.Close()
+
+	if !utils.InRange(resp.StatusCode, 200, 299) {
+		return nil, errors.NewTypedError(ErrRemoteSigner, errors.New("status = %v", resp.StatusCode))
+	}
+
+	var res SignResponse
+	if err := json.NewDecoder(resp.Body).Decode(&res); err != nil {
+		return nil, errors.NewTypedError(ErrRemoteSigner, err)
+	}
+
+	return res.Signature, nil
+}
+
+// httpEthereumSigner signs the hashes of ethereum transactions with the HTTP signing service.
+type httpEthereumSigner struct {
+	httpSigner
+	address common.Address
+}
+
+func newHTTPEthereumSigner(url string, address common.Address) (EthereumSigner, error) {
+	h, err := newHTTPSigner(url)
+	if err != nil {
+		return nil, err
+	}
+
+	return httpEthereumSigner{httpSigner: h, address: address}, nil
+}
+
+// Address returns the address of the account held by the signing service.
+func (h httpEthereumSigner) Address() common.Address {
+	return h.address
+}
+
+// SignTx signs the signing hash of the transaction with the signing service and checks the signer of the signature.
+func (h httpEthereumSigner) SignTx(signer types.Signer, tx *types.Transaction) (*types.Transaction, error) {
+	sig, err := h.sign(SchemeSecp256k1, h.address.Bytes(), signer.Hash(tx).Bytes())
+	if err != nil {
+		return nil, err
+	}
+
+	signed, err := tx.WithSignature(signer, sig)
+	if err != nil {
+		return nil, errors.NewTypedError(ErrSignatureMismatch, err)
+	}
+
+	if err := verifySender(signer, signed, h.address); err != nil {
+		return nil, err
+	}
+
+	return signed, nil
+}
+
+// httpSubstrateSigner signs extrinsic payloads with the HTTP signing service.
+type httpSubstrateSigner struct {
+	httpSigner
+	publicKey []byte
+}
+
+func newHTTPSubstrateSigner(url string, publicKey []byte) (SubstrateSigner, error) {
+	h, err := newHTTPSigner(url)
+	if err != nil {
+		return nil, err
+	}
+
+	return httpSubstrateSigner{httpSigner: h, publicKey: publicKey}, nil
+}
+
+// PublicKey returns the public key of the account held by the signing service.
+func (h httpSubstrateSigner) PublicKey() []byte {
+	return h.publicKey
+}
+
+// Sign signs the payload with the signing service and verifies the signature against the public key.
+func (h httpSubstrateSigner) Sign(payload []byte) ([]byte, error) {
+	sig, err := h.sign(SchemeSr25519, h.publicKey, payload)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(sig) != 64 {
+		return nil, errors.NewTypedError(ErrSignatureMismatch, errors.New("invalid sr25519 signature length %d", len(sig)))
+	}
+
+	ok, err := signature.Verify(payload, sig, hexutil.Encode(h.publicKey))
+	if err != nil {
+		return nil, errors.NewTypedError(ErrSignatureMismatch, err)
+	}
+
+	if !ok {
+		return nil, errors.NewTypedError(ErrSignatureMismatch, errors.New("not signed by %s", hexutil.Encode(h.publicKey)))
+	}
+
+	return sig, nil
+}
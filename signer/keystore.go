@@ -0,0 +1,51 @@
+package signer
+
+import (
+	"crypto/ecdsa"
+
+	"github.com/centrifuge/go-centrifuge/errors"
+	"github.com/centrifuge/go-substrate-rpc-client/signature"
+	"github.com/ethereum/go-ethereum/accounts/keystore"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+// keystoreSigner signs with the decrypted key of an ethereum keystore.
+type keystoreSigner struct {
+	address common.Address
+	key     *ecdsa.PrivateKey
+}
+
+func newKeystoreSigner(keyJSON, password string) (EthereumSigner, error) {
+	key, err := keystore.DecryptKey([]byte(keyJSON), password)
+	if err != nil {
+		return nil, errors.NewTypedError(ErrMissingKey, err)
+	}
+
+	return keystoreSigner{address: key.Address, key: key.PrivateKey}, nil
+}
+
+// Address returns the address of the keystore account.
+func (k keystoreSigner) Address() common.Address {
+	return k.address
+}
+
+// SignTx signs the transaction with the keystore key.
+func (k keystoreSigner) SignTx(signer types.Signer, tx *types.Transaction) (*types.Transaction, error) {
+	return types.SignTx(tx, signer, k.key)
+}
+
+// keyringSigner signs with the secret seed of a Centrifuge chain keyring pair.
+type keyringSigner struct {
+	krp signature.KeyringPair
+}
+
+// PublicKey returns the public key of the keyring pair.
+func (k keyringSigner) PublicKey() []byte {
+	return k.krp.PublicKey
+}
+
+// Sign signs the payload with the secret seed of the keyring pair.
+func (k keyringSigner) Sign(payload []byte) ([]byte, error) {
+	return signature.Sign(payload, k.krp.URI)
+}
@@ -0,0 +1,149 @@
+// Package signer signs the ethereum transactions and the Centrifuge chain extrinsics of the node accounts.
+// The keys are either kept on the node host, as keystores and secret seeds in the config, or held by a remote signer:
+// a clef compatible JSON-RPC signer or a generic HTTP signing service.
+package signer
+
+import (
+	"strings"
+	"time"
+
+	"github.com/centrifuge/go-centrifuge/config"
+	"github.com/centrifuge/go-centrifuge/errors"
+	"github.com/centrifuge/go-substrate-rpc-client/signature"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+const (
+	// BackendKeystore signs with the keys of the node config: ethereum keystores and centchain secret seeds
+	BackendKeystore = "keystore"
+
+	// BackendClef signs ethereum transactions with a clef compatible JSON-RPC signer
+	BackendClef = "clef"
+
+	// BackendHTTP signs with a generic HTTP signing service
+	BackendHTTP = "http"
+
+	// SchemeSecp256k1 is the signature scheme of ethereum accounts
+	SchemeSecp256k1 = "secp256k1"
+
+	// SchemeSr25519 is the signature scheme of Centrifuge chain accounts
+	SchemeSr25519 = "sr25519"
+)
+
+const (
+	// ErrUnknownBackend must be used when the configured signer backend is not supported
+	ErrUnknownBackend = errors.Error("unknown signer backend")
+
+	// ErrSignerURL must be used when a remote signer backend has no valid URL
+	ErrSignerURL = errors.Error("invalid remote signer URL")
+
+	// ErrMissingKey must be used when the account has no key or address for the signer backend
+	ErrMissingKey = errors.Error("account key not provided")
+
+	// ErrWrongSigner must be used when a signer is asked to sign for another account
+	ErrWrongSigner = errors.Error("signer doesn't hold the key of the account")
+
+	// ErrSignatureMismatch must be used when a remote signer returns a signature of another key
+	ErrSignatureMismatch = errors.Error("signature doesn't match the account")
+
+	// ErrRemoteSigner must be used when the remote signer fails to sign
+	ErrRemoteSigner = errors.Error("remote signer failed to sign")
+)
+
+// remoteSignTimeout is the time a remote signer is given to sign, which includes any manual approval on the signer.
+const remoteSignTimeout = 2 * time.Minute
+
+// EthereumSigner signs the transactions of an ethereum account.
+type EthereumSigner interface {
+	// Address returns the address of the account.
+	Address() common.Address
+
+	// SignTx returns the transaction signed by the account.
+	// Remote signers may sign with their own replay protection instead of the signer passed.
+	SignTx(signer types.Signer, tx *types.Transaction) (*types.Transaction, error)
+}
+
+// SubstrateSigner signs the extrinsic payloads of a Centrifuge chain account.
+type SubstrateSigner interface {
+	// PublicKey returns the sr25519 public key of the account.
+	PublicKey() []byte
+
+	// Sign returns the sr25519 signature of the payload.
+	Sign(payload []byte) ([]byte, error)
+}
+
+// NewEthereumSigner returns the signer of the ethereum account with the backend.
+// The keystore backend decrypts the key of the account, remote backends only need its address.
+func NewEthereumSigner(backend, url string, acc *config.AccountConfig) (EthereumSigner, error) {
+	switch strings.ToLower(backend) {
+	case "", BackendKeystore:
+		if acc.Key == "" {
+			return nil, errors.NewTypedError(ErrMissingKey, errors.New("ethereum keystore"))
+		}
+
+		return newKeystoreSigner(acc.Key, acc.Password)
+	case BackendClef:
+		addr, err := accountAddress(acc)
+		if err != nil {
+			return nil, err
+		}
+
+		return newClefSigner(url, addr)
+	case BackendHTTP:
+		addr, err := accountAddress(acc)
+		if err != nil {
+			return nil, err
+		}
+
+		return newHTTPEthereumSigner(url, addr)
+	default:
+		return nil, errors.NewTypedError(ErrUnknownBackend, errors.New("ethereum signer %s", backend))
+	}
+}
+
+func accountAddress(acc *config.AccountConfig) (common.Address, error) {
+	if !common.IsHexAddress(acc.Address) {
+		return common.Address{}, errors.NewTypedError(ErrMissingKey, errors.New("invalid ethereum address %q", acc.Address))
+	}
+
+	return common.HexToAddress(acc.Address), nil
+}
+
+// NewSubstrateSigner returns the signer of the Centrifuge chain account of the keyring pair with the backend.
+// The keystore backend signs with the secret seed of the keyring pair, remote backends only need its public key.
+// Clef doesn't support sr25519 keys, so it is not a substrate signer backend.
+func NewSubstrateSigner(backend, url string, krp signature.KeyringPair) (SubstrateSigner, error) {
+	if len(krp.PublicKey) == 0 {
+		return nil, errors.NewTypedError(ErrMissingKey, errors.New("centchain public key"))
+	}
+
+	switch strings.ToLower(backend) {
+	case "", BackendKeystore:
+		if krp.URI == "" {
+			return nil, errors.NewTypedError(ErrMissingKey, errors.New("centchain secret"))
+		}
+
+		return keyringSigner{krp: krp}, nil
+	case BackendHTTP:
+		return newHTTPSubstrateSigner(url, krp.PublicKey)
+	default:
+		return nil, errors.NewTypedError(ErrUnknownBackend, errors.New("centchain signer %s", backend))
+	}
+}
+
+// TransactOpts returns the options of the contract bindings signing the transactions with the signer.
+func TransactOpts(s EthereumSigner) *bind.TransactOpts {
+	from := s.Address()
+	return &bind.TransactOpts{
+		From: from,
+		Signer: func(signer types.Signer, address common.Address, tx *types.Transaction) (*types.Transaction, error) {
+			if address != from {
+				return nil, errors.NewTypedError(ErrWrongSigner, errors.New("address %s", address.Hex()))
+			}
+
+			return s.SignTx(signer, tx)
+		},
+	}
+}
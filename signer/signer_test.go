@@ -0,0 +1,230 @@
+// +build unit
+
+package signer
+
+import (
+	"crypto/ecdsa"
+	"math/big"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/centrifuge/go-centrifuge/config"
+	"github.com/centrifuge/go-centrifuge/errors"
+	"github.com/centrifuge/go-substrate-rpc-client/signature"
+	"github.com/ethereum/go-ethereum/accounts/keystore"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/rlp"
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/pborman/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func testKeyAccount(t *testing.T) (*ecdsa.PrivateKey, *config.AccountConfig) {
+	pk, err := crypto.GenerateKey()
+	assert.NoError(t, err)
+	addr := crypto.PubkeyToAddress(pk.PublicKey)
+	key := &keystore.Key{Id: uuid.NewRandom(), Address: addr, PrivateKey: pk}
+	keyJSON, err := keystore.EncryptKey(key, "secret", keystore.LightScryptN, keystore.LightScryptP)
+	assert.NoError(t, err)
+	return pk, &config.AccountConfig{Address: addr.Hex(), Key: string(keyJSON), Password: "secret"}
+}
+
+func testTx() *types.Transaction {
+	return types.NewTransaction(3, common.Address{1}, big.NewInt(10), 100000, big.NewInt(20), []byte{1, 2})
+}
+
+func TestNewEthereumSigner(t *testing.T) {
+	_, acc := testKeyAccount(t)
+
+	// unknown backend
+	_, err := NewEthereumSigner("ledger", "", acc)
+	assert.True(t, errors.IsOfType(ErrUnknownBackend, err))
+
+	// missing keystore
+	_, err = NewEthereumSigner(BackendKeystore, "", &config.AccountConfig{Address: acc.Address})
+	assert.True(t, errors.IsOfType(ErrMissingKey, err))
+
+	// wrong password
+	_, err = NewEthereumSigner(BackendKeystore, "", &config.AccountConfig{Key: acc.Key, Password: "wrong"})
+	assert.True(t, errors.IsOfType(ErrMissingKey, err))
+
+	// remote backends need the address but not the key
+	_, err = NewEthereumSigner(BackendHTTP, "http://localhost:8545", &config.AccountConfig{Key: acc.Key})
+	assert.True(t, errors.IsOfType(ErrMissingKey, err))
+	_, err = NewEthereumSigner(BackendHTTP, "localhost", &config.AccountConfig{Address: acc.Address})
+	assert.True(t, errors.IsOfType(ErrSignerURL, err))
+	_, err = NewEthereumSigner(BackendClef, "", &config.AccountConfig{Address: acc.Address})
+	assert.True(t, errors.IsOfType(ErrSignerURL, err))
+	s, err := NewEthereumSigner(BackendHTTP, "http://localhost:8545", &config.AccountConfig{Address: acc.Address})
+	assert.NoError(t, err)
+	assert.Equal(t, common.HexToAddress(acc.Address), s.Address())
+}
+
+func TestNewSubstrateSigner(t *testing.T) {
+	krp := signature.KeyringPair{URI: "//Alice", PublicKey: []byte{1, 2}}
+	_, err := NewSubstrateSigner(BackendKeystore, "", signature.KeyringPair{URI: "//Alice"})
+	assert.True(t, errors.IsOfType(ErrMissingKey, err))
+	_, err = NewSubstrateSigner(BackendKeystore, "", signature.KeyringPair{PublicKey: krp.PublicKey})
+	assert.True(t, errors.IsOfType(ErrMissingKey, err))
+	_, err = NewSubstrateSigner(BackendClef, "http://localhost:8550", krp)
+	assert.True(t, errors.IsOfType(ErrUnknownBackend, err))
+
+	s, err := NewSubstrateSigner("", "", krp)
+	assert.NoError(t, err)
+	assert.Equal(t, krp.PublicKey, s.PublicKey())
+	s, err = NewSubstrateSigner(BackendHTTP, "http://localhost:8550", signature.KeyringPair{PublicKey: krp.PublicKey})
+	assert.NoError(t, err)
+	assert.Equal(t, krp.PublicKey, s.PublicKey())
+}
+
+func TestKeystoreSigner_SignTx(t *testing.T) {
+	_, acc := testKeyAccount(t)
+	s, err := NewEthereumSigner(BackendKeystore, "", acc)
+	assert.NoError(t, err)
+	opts := TransactOpts(s)
+	assert.Equal(t, s.Address(), opts.From)
+
+	signed, err := opts.Signer(types.HomesteadSigner{}, opts.From, testTx())
+	assert.NoError(t, err)
+	from, err := types.Sender(types.HomesteadSigner{}, signed)
+	assert.NoError(t, err)
+	assert.Equal(t, opts.From, from)
+
+	_, err = opts.Signer(types.HomesteadSigner{}, common.Address{1}, testTx())
+	assert.True(t, errors.IsOfType(ErrWrongSigner, err))
+}
+
+func TestHTTPSigner_SignTx(t *testing.T) {
+	pk, acc := testKeyAccount(t)
+	stub := NewSigningServiceStub()
+	defer stub.Close()
+	s, err := NewEthereumSigner(BackendHTTP, stub.URL, acc)
+	assert.NoError(t, err)
+
+	// unknown key
+	_, err = s.SignTx(types.HomesteadSigner{}, testTx())
+	assert.True(t, errors.IsOfType(ErrRemoteSigner, err))
+
+	// signed by the service
+	stub.AddEthereumKey(pk)
+	for _, signer := range []types.Signer{types.HomesteadSigner{}, types.NewEIP155Signer(big.NewInt(1337))} {
+		signed, err := s.SignTx(signer, testTx())
+		assert.NoError(t, err)
+		from, err := types.Sender(signer, signed)
+		assert.NoError(t, err)
+		assert.Equal(t, s.Address(), from)
+		assert.Equal(t, testTx().Data(), signed.Data())
+	}
+
+	// signed with another key
+	other, err := crypto.GenerateKey()
+	assert.NoError(t, err)
+	stub.mu.Lock()
+	stub.ethKeys[hexutil.Encode(s.Address().Bytes())] = other
+	stub.mu.Unlock()
+	_, err = s.SignTx(types.HomesteadSigner{}, testTx())
+	assert.True(t, errors.IsOfType(ErrSignatureMismatch, err))
+}
+
+func TestHTTPSubstrateSigner_Sign(t *testing.T) {
+	krp := signature.TestKeyringPairAlice
+	stub := NewSigningServiceStub()
+	defer stub.Close()
+	s, err := NewSubstrateSigner(BackendHTTP, stub.URL, signature.KeyringPair{PublicKey: krp.PublicKey})
+	assert.NoError(t, err)
+	payload := []byte("extrinsic payload")
+
+	// unknown key
+	_, err = s.Sign(payload)
+	assert.True(t, errors.IsOfType(ErrRemoteSigner, err))
+
+	// signed by the service
+	stub.AddSubstrateKey(krp.PublicKey, krp.URI)
+	sig, err := s.Sign(payload)
+	assert.NoError(t, err)
+	ok, err := signature.Verify(payload, sig, krp.URI)
+	assert.NoError(t, err)
+	assert.True(t, ok)
+
+	// signed with another key
+	stub.AddSubstrateKey(krp.PublicKey, "//Bob")
+	_, err = s.Sign(payload)
+	assert.True(t, errors.IsOfType(ErrSignatureMismatch, err))
+}
+
+// ClefSignArgs and ClefSignResult are exported for the rpc server to accept the stub method.
+type (
+	ClefSignArgs   clefTxArgs
+	ClefSignResult clefSignResult
+)
+
+type clefStub struct {
+	key        *ecdsa.PrivateKey
+	chainID    *big.Int
+	tamper     bool
+	tamperData bool
+}
+
+func (c *clefStub) SignTransaction(args ClefSignArgs) (*ClefSignResult, error) {
+	nonce := uint64(args.Nonce)
+	if c.tamper {
+		nonce++
+	}
+
+	data := []byte(args.Data)
+	if c.tamperData {
+		data = append(data, 1)
+	}
+
+	tx := types.NewTransaction(nonce, *args.To, args.Value.ToInt(), uint64(args.Gas), args.GasPrice.ToInt(), data)
+	signed, err := types.SignTx(tx, types.NewEIP155Signer(c.chainID), c.key)
+	if err != nil {
+		return nil, err
+	}
+
+	raw, err := rlp.EncodeToBytes(signed)
+	if err != nil {
+		return nil, err
+	}
+
+	return &ClefSignResult{Raw: raw}, nil
+}
+
+func TestClefSigner_SignTx(t *testing.T) {
+	pk, acc := testKeyAccount(t)
+	stub := &clefStub{key: pk, chainID: big.NewInt(1337)}
+	srv := rpc.NewServer()
+	assert.NoError(t, srv.RegisterName("account", stub))
+	hs := httptest.NewServer(srv)
+	defer hs.Close()
+	s, err := NewEthereumSigner(BackendClef, hs.URL, acc)
+	assert.NoError(t, err)
+
+	// signed with the chain ID of clef
+	signed, err := s.SignTx(types.HomesteadSigner{}, testTx())
+	assert.NoError(t, err)
+	assert.True(t, signed.Protected())
+	assert.Equal(t, big.NewInt(1337), signed.ChainId())
+	assert.Equal(t, testTx().Nonce(), signed.Nonce())
+
+	// clef signed another transaction
+	stub.tamper = true
+	_, err = s.SignTx(types.HomesteadSigner{}, testTx())
+	assert.True(t, errors.IsOfType(ErrRemoteSigner, err))
+
+	// clef signed other call data
+	stub.tamper = false
+	stub.tamperData = true
+	_, err = s.SignTx(types.HomesteadSigner{}, testTx())
+	assert.True(t, errors.IsOfType(ErrRemoteSigner, err))
+
+	// clef signed with another key
+	stub.tamperData = false
+	stub.key, err = crypto.GenerateKey()
+	assert.NoError(t, err)
+	_, err = s.SignTx(types.HomesteadSigner{}, testTx())
+	assert.True(t, errors.IsOfType(ErrSignatureMismatch, err))
+}
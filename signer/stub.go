@@ -0,0 +1,90 @@
+// +build integration unit
+
+package signer
+
+import (
+	"crypto/ecdsa"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+
+	"github.com/centrifuge/go-substrate-rpc-client/signature"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// SigningServiceStub is a local HTTP signing service holding the keys in memory.
+type SigningServiceStub struct {
+	*httptest.Server
+
+	mu        sync.RWMutex
+	ethKeys   map[string]*ecdsa.PrivateKey
+	substrate map[string]string
+}
+
+// NewSigningServiceStub starts a local HTTP signing service.
+func NewSigningServiceStub() *SigningServiceStub {
+	s := &SigningServiceStub{
+		ethKeys:   make(map[string]*ecdsa.PrivateKey),
+		substrate: make(map[string]string),
+	}
+	s.Server = httptest.NewServer(http.HandlerFunc(s.serveSign))
+	return s
+}
+
+// AddEthereumKey adds the ethereum key to the signing service.
+func (s *SigningServiceStub) AddEthereumKey(key *ecdsa.PrivateKey) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.ethKeys[hexutil.Encode(crypto.PubkeyToAddress(key.PublicKey).Bytes())] = key
+}
+
+// AddSubstrateKey adds the secret URI of the Centrifuge chain public key to the signing service.
+func (s *SigningServiceStub) AddSubstrateKey(publicKey []byte, uri string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.substrate[hexutil.Encode(publicKey)] = uri
+}
+
+func (s *SigningServiceStub) serveSign(w http.ResponseWriter, r *http.Request) {
+	var req SignRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	var sig []byte
+	var err error
+	switch req.Scheme {
+	case SchemeSecp256k1:
+		key, ok := s.ethKeys[req.Key.String()]
+		if !ok {
+			http.Error(w, "unknown key", http.StatusNotFound)
+			return
+		}
+
+		sig, err = crypto.Sign(req.Payload, key)
+	case SchemeSr25519:
+		uri, ok := s.substrate[req.Key.String()]
+		if !ok {
+			http.Error(w, "unknown key", http.StatusNotFound)
+			return
+		}
+
+		sig, err = signature.Sign(req.Payload, uri)
+	default:
+		http.Error(w, "unknown scheme", http.StatusBadRequest)
+		return
+	}
+
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(SignResponse{Signature: sig})
+}
@@ -0,0 +1,105 @@
+package backend
+
+import (
+	"github.com/centrifuge/go-centrifuge/bootstrap"
+	"github.com/centrifuge/go-centrifuge/errors"
+	"github.com/centrifuge/go-centrifuge/storage"
+	"github.com/centrifuge/go-centrifuge/storage/boltdb"
+	"github.com/centrifuge/go-centrifuge/storage/leveldb"
+	"github.com/centrifuge/go-centrifuge/storage/postgres"
+)
+
+const (
+	// LevelDB is the default backend storing the databases in LevelDB directories at the storage paths.
+	LevelDB = "leveldb"
+
+	// BoltDB is the backend storing the databases in bbolt files at the storage paths.
+	BoltDB = "bbolt"
+
+	// Postgres is the backend storing the databases in tables of a PostgreSQL database.
+	Postgres = "postgres"
+
+	// dataTable and configTable are the PostgreSQL tables of the databases.
+	dataTable   = "centrifuge_data"
+	configTable = "centrifuge_config"
+
+	// ErrUnknownBackend must be used when the configured storage backend is not supported
+	ErrUnknownBackend = errors.Error("unknown storage backend")
+)
+
+// Config holds the storage configuration of the node.
+type Config interface {
+	GetStorageBackend() string
+	GetStoragePath() string
+	GetConfigStoragePath() string
+	GetStoragePostgresDSN() string
+}
+
+// Bootstrapper implements bootstrap.Bootstrapper.
+type Bootstrapper struct{}
+
+// Bootstrap initialises the config and the data repositories of the configured storage backend.
+func (*Bootstrapper) Bootstrap(context map[string]interface{}) error {
+	if _, ok := context[bootstrap.BootstrappedConfig]; !ok {
+		return errors.New("config not initialised")
+	}
+	cfg := context[bootstrap.BootstrappedConfig].(Config)
+
+	configRepo, err := NewConfigRepository(cfg)
+	if err != nil {
+		return errors.New("failed to init config db: %v", err)
+	}
+	context[storage.BootstrappedConfigDB] = configRepo
+
+	repo, err := NewDataRepository(cfg)
+	if err != nil {
+		return errors.New("failed to init db: %v", err)
+	}
+	context[storage.BootstrappedDB] = repo
+	return nil
+}
+
+// NewConfigRepository opens the repository of the node and account configs of the configured backend.
+func NewConfigRepository(cfg Config) (storage.Repository, error) {
+	return newRepository(cfg, cfg.GetConfigStoragePath(), configTable)
+}
+
+// NewDataRepository opens the repository of the node data of the configured backend.
+func NewDataRepository(cfg Config) (storage.Repository, error) {
+	return newRepository(cfg, cfg.GetStoragePath(), dataTable)
+}
+
+// newRepository opens the database at the path for the file backends, or in the table for PostgreSQL.
+func newRepository(cfg Config, path, table string) (storage.Repository, error) {
+	switch cfg.GetStorageBackend() {
+	case LevelDB, "":
+		db, err := leveldb.NewLevelDBStorage(path)
+		if err != nil {
+			return nil, err
+		}
+
+		return leveldb.NewLevelDBRepository(db), nil
+	case BoltDB:
+		db, err := boltdb.NewBoltStorage(path)
+		if err != nil {
+			return nil, err
+		}
+
+		return boltdb.NewBoltRepository(db), nil
+	case Postgres:
+		db, err := postgres.NewPostgresStorage(cfg.GetStoragePostgresDSN())
+		if err != nil {
+			return nil, err
+		}
+
+		repo, err := postgres.NewPostgresRepository(db, table)
+		if err != nil {
+			db.Close()
+			return nil, err
+		}
+
+		return repo, nil
+	default:
+		return nil, errors.NewTypedError(ErrUnknownBackend, errors.New("%s", cfg.GetStorageBackend()))
+	}
+}
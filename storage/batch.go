@@ -0,0 +1,110 @@
+package storage
+
+import (
+	"github.com/centrifuge/go-centrifuge/errors"
+)
+
+type opType int
+
+const (
+	opCreate opType = iota
+	opUpdate
+	opPut
+	opDelete
+)
+
+type op struct {
+	tp    opType
+	key   []byte
+	model Model
+}
+
+// Batch holds writes that are applied atomically by Repository.Write, in the order they were added.
+// Either all the writes of the batch are applied or none.
+type Batch struct {
+	ops []op
+}
+
+// NewBatch returns an empty batch.
+func NewBatch() *Batch {
+	return new(Batch)
+}
+
+// Create adds the creation of the model. The batch fails if the key exists.
+func (b *Batch) Create(key []byte, model Model) {
+	b.ops = append(b.ops, op{tp: opCreate, key: key, model: model})
+}
+
+// Update adds the update of the model. The batch fails if the key doesn't exist.
+func (b *Batch) Update(key []byte, model Model) {
+	b.ops = append(b.ops, op{tp: opUpdate, key: key, model: model})
+}
+
+// Put adds the creation or the update of the model.
+func (b *Batch) Put(key []byte, model Model) {
+	b.ops = append(b.ops, op{tp: opPut, key: key, model: model})
+}
+
+// Delete adds the deletion of the key.
+func (b *Batch) Delete(key []byte) {
+	b.ops = append(b.ops, op{tp: opDelete, key: key})
+}
+
+// Len returns the number of writes in the batch.
+func (b *Batch) Len() int {
+	if b == nil {
+		return 0
+	}
+
+	return len(b.ops)
+}
+
+// Apply checks the writes of the batch against the keys in the db and the earlier writes of the batch,
+// and passes them to put and del in order. Backends apply the writes in a single db transaction
+// and discard it if Apply fails.
+func (b *Batch) Apply(exists func(key []byte) (bool, error), put func(key, value []byte) error, del func(key []byte) error) error {
+	if b == nil {
+		return nil
+	}
+
+	// existence of the keys written by the batch
+	written := make(map[string]bool)
+	for _, o := range b.ops {
+		if o.tp == opDelete {
+			written[string(o.key)] = false
+			if err := del(o.key); err != nil {
+				return err
+			}
+			continue
+		}
+
+		found, ok := written[string(o.key)]
+		if !ok && o.tp != opPut {
+			var err error
+			found, err = exists(o.key)
+			if err != nil {
+				return err
+			}
+		}
+
+		if o.tp == opCreate && found {
+			return errors.NewTypedError(ErrRepositoryModelCreateKeyExists, errors.New("%x", o.key))
+		}
+
+		if o.tp == opUpdate && !found {
+			return errors.NewTypedError(ErrRepositoryModelUpdateKeyNotFound, errors.New("%x", o.key))
+		}
+
+		data, err := marshalValue(o.model)
+		if err != nil {
+			return err
+		}
+
+		written[string(o.key)] = true
+		if err := put(o.key, data); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
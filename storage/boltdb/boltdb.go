@@ -0,0 +1,176 @@
+package boltdb
+
+import (
+	"bytes"
+	"time"
+
+	"github.com/centrifuge/go-centrifuge/errors"
+	"github.com/centrifuge/go-centrifuge/storage"
+	logging "github.com/ipfs/go-log"
+	bolt "go.etcd.io/bbolt"
+)
+
+var log = logging.Logger("storage")
+
+// bucket holds all the models of a database.
+var bucket = []byte("centrifuge")
+
+// openTimeout is the time to wait for the file lock of a database opened by another process.
+const openTimeout = 5 * time.Second
+
+// NewBoltStorage opens the bbolt database at the path, creating it if it doesn't exist.
+func NewBoltStorage(path string) (*bolt.DB, error) {
+	db, err := bolt.Open(path, 0600, &bolt.Options{Timeout: openTimeout})
+	if err != nil {
+		return nil, err
+	}
+
+	err = db.Update(func(tx *bolt.Tx) error {
+		_, err := tx.CreateBucketIfNotExists(bucket)
+		return err
+	})
+	if err != nil {
+		db.Close()
+		return nil, err
+	}
+
+	return db, nil
+}
+
+// boltRepo implements Repository using bbolt as storage layer
+type boltRepo struct {
+	db     *bolt.DB
+	models *storage.Models
+}
+
+// NewBoltRepository returns bbolt implementation of Repository
+func NewBoltRepository(db *bolt.DB) storage.Repository {
+	return &boltRepo{
+		db:     db,
+		models: storage.NewModels(),
+	}
+}
+
+// Register registers the model so that the DB can return the model without knowing the type
+func (b *boltRepo) Register(model storage.Model) {
+	b.models.Register(model)
+}
+
+// Exists checks whether the key exists in db
+func (b *boltRepo) Exists(key []byte) bool {
+	var exists bool
+	err := b.db.View(func(tx *bolt.Tx) error {
+		exists = tx.Bucket(bucket).Get(key) != nil
+		return nil
+	})
+	return err == nil && exists
+}
+
+// Get retrieves model by key, otherwise returns error
+func (b *boltRepo) Get(key []byte) (storage.Model, error) {
+	var data []byte
+	err := b.db.View(func(tx *bolt.Tx) error {
+		v := tx.Bucket(bucket).Get(key)
+		if v == nil {
+			return errors.New("key not found")
+		}
+
+		// values are only valid within the transaction
+		data = append([]byte(nil), v...)
+		return nil
+	})
+	if err != nil {
+		return nil, errors.NewTypedError(storage.ErrModelRepositoryNotFound, err)
+	}
+
+	return b.models.Unmarshal(data)
+}
+
+// GetAllByPrefix returns all models which keys match the provided prefix, ordered by key
+// If an error is found parsing one of the matched models, logs warning and continues
+func (b *boltRepo) GetAllByPrefix(prefix string) ([]storage.Model, error) {
+	var models []storage.Model
+	p := []byte(prefix)
+	err := b.db.View(func(tx *bolt.Tx) error {
+		c := tx.Bucket(bucket).Cursor()
+		for k, v := c.Seek(p); k != nil && bytes.HasPrefix(k, p); k, v = c.Next() {
+			model, err := b.models.Unmarshal(v)
+			if err != nil {
+				log.Warningf("Error parsing model: %v", err)
+				continue
+			}
+			models = append(models, model)
+		}
+		return nil
+	})
+	return models, err
+}
+
+// put stores the model in a single transaction after checking the existence of the key.
+func (b *boltRepo) put(key []byte, model storage.Model, exists bool) error {
+	data, err := b.models.Marshal(model)
+	if err != nil {
+		return err
+	}
+
+	return b.db.Update(func(tx *bolt.Tx) error {
+		bkt := tx.Bucket(bucket)
+		found := bkt.Get(key) != nil
+		if found != exists {
+			if found {
+				return storage.ErrRepositoryModelCreateKeyExists
+			}
+
+			return storage.ErrRepositoryModelUpdateKeyNotFound
+		}
+
+		err := bkt.Put(key, data)
+		if err != nil {
+			return errors.NewTypedError(storage.ErrRepositoryModelSave, errors.New("%v", err))
+		}
+
+		return nil
+	})
+}
+
+// Create creates a model indexed by the key provided
+// errors out if key already exists
+func (b *boltRepo) Create(key []byte, model storage.Model) error {
+	return b.put(key, model, false)
+}
+
+// Update updates a model indexed by the key provided
+// errors out if key doesn't exists
+func (b *boltRepo) Update(key []byte, model storage.Model) error {
+	return b.put(key, model, true)
+}
+
+// Delete deletes a model by the key provided
+func (b *boltRepo) Delete(key []byte) error {
+	return b.db.Update(func(tx *bolt.Tx) error {
+		return tx.Bucket(bucket).Delete(key)
+	})
+}
+
+// Write applies the writes of the batch atomically in a single bbolt transaction
+func (b *boltRepo) Write(batch *storage.Batch) error {
+	return b.db.Update(func(tx *bolt.Tx) error {
+		bkt := tx.Bucket(bucket)
+		return batch.Apply(
+			func(key []byte) (bool, error) {
+				return bkt.Get(key) != nil, nil
+			},
+			func(key, value []byte) error {
+				if err := bkt.Put(key, value); err != nil {
+					return errors.NewTypedError(storage.ErrRepositoryModelSave, errors.New("%v", err))
+				}
+				return nil
+			},
+			bkt.Delete)
+	})
+}
+
+// Close closes the database
+func (b *boltRepo) Close() error {
+	return b.db.Close()
+}
@@ -0,0 +1,72 @@
+// +build unit
+
+package boltdb
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"reflect"
+	"testing"
+
+	"github.com/centrifuge/go-centrifuge/storage"
+	"github.com/centrifuge/go-centrifuge/storage/storagetest"
+	"github.com/centrifuge/go-centrifuge/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+type testModel struct {
+	Value string `json:"value"`
+}
+
+func (m *testModel) JSON() ([]byte, error) {
+	return json.Marshal(m)
+}
+
+func (m *testModel) FromJSON(data []byte) error {
+	return json.Unmarshal(data, m)
+}
+
+func (m *testModel) Type() reflect.Type {
+	return reflect.TypeOf(m)
+}
+
+func getRandomTestStoragePath() string {
+	return fmt.Sprintf("/tmp/centrifuge_data.bolt_TESTING_%x", utils.RandomByte32())
+}
+
+func TestNewBoltStorage(t *testing.T) {
+	path := getRandomTestStoragePath()
+	defer os.Remove(path)
+	db, err := NewBoltStorage(path)
+	assert.NoError(t, err)
+	assert.NotNil(t, db)
+
+	// data survives reopening
+	repo := NewBoltRepository(db)
+	repo.Register(&testModel{})
+	assert.NoError(t, repo.Create([]byte("key"), &testModel{Value: "value"}))
+	assert.NoError(t, repo.Close())
+
+	db, err = NewBoltStorage(path)
+	assert.NoError(t, err)
+	repo = NewBoltRepository(db)
+	repo.Register(&testModel{})
+	m, err := repo.Get([]byte("key"))
+	assert.NoError(t, err)
+	assert.Equal(t, "value", m.(*testModel).Value)
+	assert.NoError(t, repo.Close())
+
+	// invalid path
+	_, err = NewBoltStorage("/non/existing/dir/db.bolt")
+	assert.Error(t, err)
+}
+
+func TestBoltRepo_Conformance(t *testing.T) {
+	storagetest.RunRepositoryTests(t, func(t *testing.T) storage.Repository {
+		path := getRandomTestStoragePath()
+		db, err := NewBoltStorage(path)
+		assert.NoError(t, err)
+		return NewBoltRepository(db)
+	})
+}
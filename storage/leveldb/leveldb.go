@@ -29,6 +29,9 @@ type levelDBRepo struct {
 	db     *leveldb.DB
 	models map[string]reflect.Type
 	mu     sync.RWMutex // to protect the models
+
+	// writeMu serialises the writes so that the key checks of the writes are consistent
+	writeMu sync.Mutex
 }
 
 // value is an internal representation of how levelDb stores the model.
@@ -152,6 +155,8 @@ func (l *levelDBRepo) save(key []byte, model storage.Model) error {
 // Create creates a model indexed by the key provided
 // errors out if key already exists
 func (l *levelDBRepo) Create(key []byte, model storage.Model) error {
+	l.writeMu.Lock()
+	defer l.writeMu.Unlock()
 	if l.Exists(key) {
 		return storage.ErrRepositoryModelCreateKeyExists
 	}
@@ -161,6 +166,8 @@ func (l *levelDBRepo) Create(key []byte, model storage.Model) error {
 // Update updates a model indexed by the key provided
 // errors out if key doesn't exists
 func (l *levelDBRepo) Update(key []byte, model storage.Model) error {
+	l.writeMu.Lock()
+	defer l.writeMu.Unlock()
 	if !l.Exists(key) {
 		return storage.ErrRepositoryModelUpdateKeyNotFound
 	}
@@ -169,9 +176,40 @@ func (l *levelDBRepo) Update(key []byte, model storage.Model) error {
 
 // Delete deletes a model by the key provided
 func (l *levelDBRepo) Delete(key []byte) error {
+	l.writeMu.Lock()
+	defer l.writeMu.Unlock()
 	return l.db.Delete(key, nil)
 }
 
+// Write applies the writes of the batch atomically using a LevelDB write batch
+func (l *levelDBRepo) Write(batch *storage.Batch) error {
+	l.writeMu.Lock()
+	defer l.writeMu.Unlock()
+	wb := new(leveldb.Batch)
+	err := batch.Apply(
+		func(key []byte) (bool, error) {
+			return l.db.Has(key, nil)
+		},
+		func(key, value []byte) error {
+			wb.Put(key, value)
+			return nil
+		},
+		func(key []byte) error {
+			wb.Delete(key)
+			return nil
+		})
+	if err != nil {
+		return err
+	}
+
+	err = l.db.Write(wb, nil)
+	if err != nil {
+		return errors.NewTypedError(storage.ErrRepositoryModelSave, errors.New("%v", err))
+	}
+
+	return nil
+}
+
 // Close closes the database
 func (l *levelDBRepo) Close() error {
 	return l.db.Close()
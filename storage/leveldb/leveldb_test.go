@@ -8,6 +8,7 @@ import (
 	"testing"
 
 	"github.com/centrifuge/go-centrifuge/storage"
+	"github.com/centrifuge/go-centrifuge/storage/storagetest"
 
 	"github.com/centrifuge/go-centrifuge/errors"
 	"github.com/centrifuge/go-centrifuge/utils"
@@ -189,3 +190,11 @@ func TestLevelDBRepo_Delete(t *testing.T) {
 	_, err = repo.Get(id)
 	assert.True(t, errors.IsOfType(storage.ErrModelRepositoryNotFound, err))
 }
+
+func TestLevelDBRepo_Conformance(t *testing.T) {
+	storagetest.RunRepositoryTests(t, func(t *testing.T) storage.Repository {
+		repo, _, err := getRandomRepository()
+		assert.NoError(t, err)
+		return repo
+	})
+}
@@ -0,0 +1,93 @@
+package storage
+
+import (
+	"encoding/json"
+	"reflect"
+	"sync"
+
+	"github.com/centrifuge/go-centrifuge/errors"
+)
+
+// value is the representation of a stored model. It is the same for all the backends.
+type value struct {
+	Type string          `json:"type"`
+	Data json.RawMessage `json:"data"`
+}
+
+// Models holds the registered model types of a repository so that the repository can return the models
+// without knowing their type. Repository backends use it to marshal and unmarshal the stored values.
+type Models struct {
+	mu    sync.RWMutex
+	types map[string]reflect.Type
+}
+
+// NewModels returns an empty model registry.
+func NewModels() *Models {
+	return &Models{types: make(map[string]reflect.Type)}
+}
+
+// Register registers the type of the model.
+func (m *Models) Register(model Model) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	tp := getTypeIndirect(model.Type())
+	m.types[tp.String()] = tp
+}
+
+// Marshal returns the stored value of the model.
+func (m *Models) Marshal(model Model) ([]byte, error) {
+	return marshalValue(model)
+}
+
+// marshalValue returns the stored value of the model.
+func marshalValue(model Model) ([]byte, error) {
+	data, err := model.JSON()
+	if err != nil {
+		return nil, errors.NewTypedError(ErrModelRepositorySerialisation, errors.New("failed to marshall model: %v", err))
+	}
+
+	v := value{
+		Type: getTypeIndirect(model.Type()).String(),
+		Data: json.RawMessage(data),
+	}
+
+	data, err = json.Marshal(v)
+	if err != nil {
+		return nil, errors.NewTypedError(ErrModelRepositorySerialisation, errors.New("failed to marshall value: %v", err))
+	}
+
+	return data, nil
+}
+
+// Unmarshal returns a new model of the registered type of the stored value.
+func (m *Models) Unmarshal(data []byte) (Model, error) {
+	v := new(value)
+	err := json.Unmarshal(data, v)
+	if err != nil {
+		return nil, errors.NewTypedError(ErrModelRepositorySerialisation, errors.New("failed to unmarshal to value: %v", err))
+	}
+
+	m.mu.RLock()
+	tp, ok := m.types[v.Type]
+	m.mu.RUnlock()
+	if !ok {
+		return nil, errors.NewTypedError(ErrModelTypeNotRegistered, errors.New("%s", v.Type))
+	}
+
+	nm := reflect.New(tp).Interface().(Model)
+	err = nm.FromJSON([]byte(v.Data))
+	if err != nil {
+		return nil, errors.NewTypedError(ErrModelRepositorySerialisation, errors.New("failed to unmarshal to model: %v", err))
+	}
+
+	return nm, nil
+}
+
+// getTypeIndirect returns the type of the model without pointers.
+func getTypeIndirect(tp reflect.Type) reflect.Type {
+	if tp.Kind() == reflect.Ptr {
+		return getTypeIndirect(tp.Elem())
+	}
+
+	return tp
+}
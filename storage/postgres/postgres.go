@@ -0,0 +1,219 @@
+package postgres
+
+import (
+	"database/sql"
+	"fmt"
+
+	"github.com/centrifuge/go-centrifuge/errors"
+	"github.com/centrifuge/go-centrifuge/storage"
+	logging "github.com/ipfs/go-log"
+	"github.com/lib/pq"
+)
+
+var log = logging.Logger("storage")
+
+// NewPostgresStorage connects to the PostgreSQL database of the connection string.
+func NewPostgresStorage(dsn string) (*sql.DB, error) {
+	db, err := sql.Open("postgres", dsn)
+	if err != nil {
+		return nil, err
+	}
+
+	err = db.Ping()
+	if err != nil {
+		db.Close()
+		return nil, err
+	}
+
+	return db, nil
+}
+
+// postgresRepo implements Repository using a PostgreSQL table as storage layer
+type postgresRepo struct {
+	db     *sql.DB
+	table  string
+	models *storage.Models
+}
+
+// NewPostgresRepository returns PostgreSQL implementation of Repository storing the models in the table.
+// The table is created if it doesn't exist.
+func NewPostgresRepository(db *sql.DB, table string) (storage.Repository, error) {
+	table = pq.QuoteIdentifier(table)
+	_, err := db.Exec(fmt.Sprintf("CREATE TABLE IF NOT EXISTS %s (key BYTEA PRIMARY KEY, value BYTEA NOT NULL)", table))
+	if err != nil {
+		return nil, errors.New("failed to create table %s: %v", table, err)
+	}
+
+	return &postgresRepo{
+		db:     db,
+		table:  table,
+		models: storage.NewModels(),
+	}, nil
+}
+
+// Register registers the model so that the DB can return the model without knowing the type
+func (p *postgresRepo) Register(model storage.Model) {
+	p.models.Register(model)
+}
+
+// Exists checks whether the key exists in db
+func (p *postgresRepo) Exists(key []byte) bool {
+	var exists bool
+	err := p.db.QueryRow(fmt.Sprintf("SELECT EXISTS (SELECT 1 FROM %s WHERE key = $1)", p.table), key).Scan(&exists)
+	return err == nil && exists
+}
+
+// Get retrieves model by key, otherwise returns error
+func (p *postgresRepo) Get(key []byte) (storage.Model, error) {
+	var data []byte
+	err := p.db.QueryRow(fmt.Sprintf("SELECT value FROM %s WHERE key = $1", p.table), key).Scan(&data)
+	if err != nil {
+		return nil, errors.NewTypedError(storage.ErrModelRepositoryNotFound, err)
+	}
+
+	return p.models.Unmarshal(data)
+}
+
+// GetAllByPrefix returns all models which keys match the provided prefix, ordered by key
+// If an error is found parsing one of the matched models, logs warning and continues
+func (p *postgresRepo) GetAllByPrefix(prefix string) ([]storage.Model, error) {
+	// a key range instead of LIKE so that the primary key index is used and binary keys need no escaping
+	query := fmt.Sprintf("SELECT value FROM %s WHERE key >= $1 ORDER BY key", p.table)
+	args := []interface{}{[]byte(prefix)}
+	if limit := prefixLimit([]byte(prefix)); limit != nil {
+		query = fmt.Sprintf("SELECT value FROM %s WHERE key >= $1 AND key < $2 ORDER BY key", p.table)
+		args = append(args, limit)
+	}
+
+	rows, err := p.db.Query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var models []storage.Model
+	for rows.Next() {
+		var data []byte
+		err = rows.Scan(&data)
+		if err != nil {
+			return nil, err
+		}
+
+		model, err := p.models.Unmarshal(data)
+		if err != nil {
+			log.Warningf("Error parsing model: %v", err)
+			continue
+		}
+		models = append(models, model)
+	}
+
+	return models, rows.Err()
+}
+
+// Create creates a model indexed by the key provided
+// errors out if key already exists
+func (p *postgresRepo) Create(key []byte, model storage.Model) error {
+	data, err := p.models.Marshal(model)
+	if err != nil {
+		return err
+	}
+
+	res, err := p.db.Exec(fmt.Sprintf("INSERT INTO %s (key, value) VALUES ($1, $2) ON CONFLICT (key) DO NOTHING", p.table), key, data)
+	return checkAffected(res, err, storage.ErrRepositoryModelCreateKeyExists)
+}
+
+// Update updates a model indexed by the key provided
+// errors out if key doesn't exists
+func (p *postgresRepo) Update(key []byte, model storage.Model) error {
+	data, err := p.models.Marshal(model)
+	if err != nil {
+		return err
+	}
+
+	res, err := p.db.Exec(fmt.Sprintf("UPDATE %s SET value = $2 WHERE key = $1", p.table), key, data)
+	return checkAffected(res, err, storage.ErrRepositoryModelUpdateKeyNotFound)
+}
+
+// Delete deletes a model by the key provided
+func (p *postgresRepo) Delete(key []byte) error {
+	_, err := p.db.Exec(fmt.Sprintf("DELETE FROM %s WHERE key = $1", p.table), key)
+	return err
+}
+
+// Write applies the writes of the batch atomically in a single PostgreSQL transaction
+func (p *postgresRepo) Write(batch *storage.Batch) error {
+	tx, err := p.db.Begin()
+	if err != nil {
+		return errors.NewTypedError(storage.ErrRepositoryModelSave, errors.New("%v", err))
+	}
+
+	err = batch.Apply(
+		func(key []byte) (bool, error) {
+			// locks the row of an existing key until the transaction ends
+			var one int
+			err := tx.QueryRow(fmt.Sprintf("SELECT 1 FROM %s WHERE key = $1 FOR UPDATE", p.table), key).Scan(&one)
+			if err == sql.ErrNoRows {
+				return false, nil
+			}
+
+			return err == nil, err
+		},
+		func(key, value []byte) error {
+			_, err := tx.Exec(fmt.Sprintf("INSERT INTO %s (key, value) VALUES ($1, $2) ON CONFLICT (key) DO UPDATE SET value = EXCLUDED.value", p.table), key, value)
+			return err
+		},
+		func(key []byte) error {
+			_, err := tx.Exec(fmt.Sprintf("DELETE FROM %s WHERE key = $1", p.table), key)
+			return err
+		})
+	if err != nil {
+		if rerr := tx.Rollback(); rerr != nil {
+			log.Warningf("failed to rollback batch: %v", rerr)
+		}
+		return err
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return errors.NewTypedError(storage.ErrRepositoryModelSave, errors.New("%v", err))
+	}
+
+	return nil
+}
+
+// Close closes the database
+func (p *postgresRepo) Close() error {
+	return p.db.Close()
+}
+
+// checkAffected returns noRowsErr if the statement didn't change a row.
+func checkAffected(res sql.Result, err error, noRowsErr error) error {
+	if err != nil {
+		return errors.NewTypedError(storage.ErrRepositoryModelSave, errors.New("%v", err))
+	}
+
+	n, err := res.RowsAffected()
+	if err != nil {
+		return errors.NewTypedError(storage.ErrRepositoryModelSave, errors.New("%v", err))
+	}
+
+	if n == 0 {
+		return noRowsErr
+	}
+
+	return nil
+}
+
+// prefixLimit returns the smallest key greater than all the keys with the prefix, nil if there is none.
+func prefixLimit(prefix []byte) []byte {
+	limit := make([]byte, len(prefix))
+	copy(limit, prefix)
+	for i := len(limit) - 1; i >= 0; i-- {
+		if limit[i] < 0xff {
+			limit[i]++
+			return limit[:i+1]
+		}
+	}
+
+	return nil
+}
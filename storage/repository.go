@@ -32,5 +32,8 @@ type Repository interface {
 	Create(key []byte, model Model) error
 	Update(key []byte, model Model) error
 	Delete(key []byte) error
+
+	// Write applies the writes of the batch atomically.
+	Write(batch *Batch) error
 	Close() error
 }
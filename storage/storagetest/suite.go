@@ -0,0 +1,307 @@
+// +build integration unit
+
+package storagetest
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	"github.com/centrifuge/go-centrifuge/errors"
+	"github.com/centrifuge/go-centrifuge/storage"
+	"github.com/centrifuge/go-centrifuge/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+type doc struct {
+	SomeString string `json:"some_string"`
+}
+
+func (m *doc) JSON() ([]byte, error) {
+	return json.Marshal(m)
+}
+
+func (m *doc) FromJSON(data []byte) error {
+	return json.Unmarshal(data, m)
+}
+
+func (m *doc) Type() reflect.Type {
+	return reflect.TypeOf(m)
+}
+
+type other struct {
+	Number int `json:"number"`
+}
+
+func (m *other) JSON() ([]byte, error) {
+	return json.Marshal(m)
+}
+
+func (m *other) FromJSON(data []byte) error {
+	return json.Unmarshal(data, m)
+}
+
+func (m *other) Type() reflect.Type {
+	return reflect.TypeOf(m)
+}
+
+// RunRepositoryTests runs the conformance tests every storage.Repository backend must pass.
+// newRepo must return a new empty repository on every call. The repositories are closed by the tests.
+func RunRepositoryTests(t *testing.T, newRepo func(t *testing.T) storage.Repository) {
+	tests := map[string]func(t *testing.T, repo storage.Repository){
+		"exists":                     testExists,
+		"get":                        testGet,
+		"get_all_by_prefix":          testGetAllByPrefix,
+		"get_all_by_prefix_skipping": testGetAllByPrefixUnregistered,
+		"create":                     testCreate,
+		"update":                     testUpdate,
+		"delete":                     testDelete,
+		"multiple_types":             testMultipleTypes,
+		"binary_keys":                testBinaryKeys,
+		"batch":                      testBatch,
+		"batch_atomic":               testBatchAtomic,
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			repo := newRepo(t)
+			defer func() {
+				assert.NoError(t, repo.Close())
+			}()
+			test(t, repo)
+		})
+	}
+}
+
+func testExists(t *testing.T, repo storage.Repository) {
+	id := utils.RandomSlice(32)
+
+	// Key doesnt exist
+	assert.False(t, repo.Exists(id))
+
+	err := repo.Create(id, &doc{SomeString: "Hello, Repo!"})
+	assert.NoError(t, err)
+
+	// Key exists
+	assert.True(t, repo.Exists(id))
+}
+
+func testGet(t *testing.T, repo storage.Repository) {
+	id := utils.RandomSlice(32)
+
+	// Key doesnt exist
+	_, err := repo.Get(id)
+	assert.True(t, errors.IsOfType(storage.ErrModelRepositoryNotFound, err))
+
+	d := &doc{SomeString: "Hello, Repo!"}
+	err = repo.Create(id, d)
+	assert.NoError(t, err)
+
+	// Model not registered
+	_, err = repo.Get(id)
+	assert.True(t, errors.IsOfType(storage.ErrModelTypeNotRegistered, err))
+
+	// Success
+	repo.Register(&doc{})
+	m, err := repo.Get(id)
+	assert.NoError(t, err)
+	assert.Equal(t, d, m)
+}
+
+func testGetAllByPrefix(t *testing.T, repo storage.Repository) {
+	prefix := "prefix-"
+	repo.Register(&doc{})
+
+	// No match
+	models, err := repo.GetAllByPrefix(prefix)
+	assert.NoError(t, err)
+	assert.Len(t, models, 0)
+
+	// created out of order, returned ordered by key
+	assert.NoError(t, repo.Create([]byte(prefix+"b"), &doc{SomeString: "b"}))
+	assert.NoError(t, repo.Create([]byte(prefix+"a"), &doc{SomeString: "a"}))
+	assert.NoError(t, repo.Create([]byte(prefix+"c"), &doc{SomeString: "c"}))
+	assert.NoError(t, repo.Create([]byte("prefix"), &doc{SomeString: "shorter"}))
+	assert.NoError(t, repo.Create([]byte("prefix,"), &doc{SomeString: "before"}))
+	assert.NoError(t, repo.Create([]byte("prefix."), &doc{SomeString: "after"}))
+
+	models, err = repo.GetAllByPrefix(prefix)
+	assert.NoError(t, err)
+	assert.Equal(t, []storage.Model{&doc{SomeString: "a"}, &doc{SomeString: "b"}, &doc{SomeString: "c"}}, models)
+
+	// empty prefix returns all
+	models, err = repo.GetAllByPrefix("")
+	assert.NoError(t, err)
+	assert.Len(t, models, 6)
+}
+
+func testGetAllByPrefixUnregistered(t *testing.T, repo storage.Repository) {
+	repo.Register(&doc{})
+	assert.NoError(t, repo.Create([]byte("prefix-a"), &doc{SomeString: "a"}))
+	assert.NoError(t, repo.Create([]byte("prefix-b"), &other{Number: 1}))
+
+	// models that cant be parsed are skipped
+	models, err := repo.GetAllByPrefix("prefix-")
+	assert.NoError(t, err)
+	assert.Equal(t, []storage.Model{&doc{SomeString: "a"}}, models)
+}
+
+func testCreate(t *testing.T, repo storage.Repository) {
+	id := utils.RandomSlice(32)
+	repo.Register(&doc{})
+
+	err := repo.Create(id, &doc{SomeString: "Hello, Repo!"})
+	assert.NoError(t, err)
+
+	// Already exists
+	err = repo.Create(id, &doc{SomeString: "Hello, again!"})
+	assert.True(t, errors.IsOfType(storage.ErrRepositoryModelCreateKeyExists, err))
+
+	// not overwritten
+	m, err := repo.Get(id)
+	assert.NoError(t, err)
+	assert.Equal(t, "Hello, Repo!", m.(*doc).SomeString)
+}
+
+func testUpdate(t *testing.T, repo storage.Repository) {
+	id := utils.RandomSlice(32)
+	repo.Register(&doc{})
+
+	// Doesn't exist
+	err := repo.Update(id, &doc{SomeString: "Hello, Repo!"})
+	assert.True(t, errors.IsOfType(storage.ErrRepositoryModelUpdateKeyNotFound, err))
+	assert.False(t, repo.Exists(id))
+
+	err = repo.Create(id, &doc{SomeString: "Hello, Repo!"})
+	assert.NoError(t, err)
+
+	// Exists
+	err = repo.Update(id, &doc{SomeString: "Hello, Update!"})
+	assert.NoError(t, err)
+	m, err := repo.Get(id)
+	assert.NoError(t, err)
+	assert.Equal(t, "Hello, Update!", m.(*doc).SomeString)
+}
+
+func testDelete(t *testing.T, repo storage.Repository) {
+	id := utils.RandomSlice(32)
+	d := &doc{SomeString: "Hello, Repo!"}
+	repo.Register(d)
+
+	// Doesnt fail on key that doesnt exist
+	err := repo.Delete(id)
+	assert.NoError(t, err)
+
+	err = repo.Create(id, d)
+	assert.NoError(t, err)
+
+	// Entry exists
+	m, err := repo.Get(id)
+	assert.NoError(t, err)
+	assert.Equal(t, d, m)
+
+	err = repo.Delete(id)
+	assert.NoError(t, err)
+
+	// Entry doesnt exist
+	_, err = repo.Get(id)
+	assert.True(t, errors.IsOfType(storage.ErrModelRepositoryNotFound, err))
+	assert.False(t, repo.Exists(id))
+
+	// can be created again
+	assert.NoError(t, repo.Create(id, d))
+}
+
+func testMultipleTypes(t *testing.T, repo storage.Repository) {
+	repo.Register(&doc{})
+	repo.Register(&other{})
+	assert.NoError(t, repo.Create([]byte("model_doc"), &doc{SomeString: "doc"}))
+	assert.NoError(t, repo.Create([]byte("model_other"), &other{Number: 42}))
+
+	m, err := repo.Get([]byte("model_doc"))
+	assert.NoError(t, err)
+	assert.Equal(t, &doc{SomeString: "doc"}, m)
+
+	m, err = repo.Get([]byte("model_other"))
+	assert.NoError(t, err)
+	assert.Equal(t, &other{Number: 42}, m)
+
+	models, err := repo.GetAllByPrefix("model_")
+	assert.NoError(t, err)
+	assert.Equal(t, []storage.Model{&doc{SomeString: "doc"}, &other{Number: 42}}, models)
+}
+
+func testBinaryKeys(t *testing.T, repo storage.Repository) {
+	repo.Register(&doc{})
+	prefix := string([]byte{0x00, 0xff})
+	keys := [][]byte{
+		{0x00, 0xff},
+		{0x00, 0xff, 0x00},
+		{0x00, 0xff, 0xff, 0xff},
+		{0x01},
+	}
+
+	for i, k := range keys {
+		assert.NoError(t, repo.Create(k, &doc{SomeString: string(rune('a' + i))}))
+	}
+
+	models, err := repo.GetAllByPrefix(prefix)
+	assert.NoError(t, err)
+	assert.Equal(t, []storage.Model{&doc{SomeString: "a"}, &doc{SomeString: "b"}, &doc{SomeString: "c"}}, models)
+
+	models, err = repo.GetAllByPrefix(string([]byte{0xff}))
+	assert.NoError(t, err)
+	assert.Len(t, models, 0)
+}
+
+func testBatch(t *testing.T, repo storage.Repository) {
+	repo.Register(&doc{})
+	assert.NoError(t, repo.Create([]byte("a"), &doc{SomeString: "a"}))
+	assert.NoError(t, repo.Create([]byte("b"), &doc{SomeString: "b"}))
+
+	// empty batch
+	assert.NoError(t, repo.Write(storage.NewBatch()))
+
+	batch := storage.NewBatch()
+	batch.Update([]byte("a"), &doc{SomeString: "a2"})
+	batch.Delete([]byte("b"))
+	batch.Create([]byte("b"), &doc{SomeString: "b2"})
+	batch.Create([]byte("c"), &doc{SomeString: "c"})
+	batch.Update([]byte("c"), &doc{SomeString: "c2"})
+	batch.Put([]byte("d"), &doc{SomeString: "d"})
+	batch.Create([]byte("e"), &doc{SomeString: "e"})
+	batch.Delete([]byte("e"))
+	assert.Equal(t, 8, batch.Len())
+	assert.NoError(t, repo.Write(batch))
+
+	models, err := repo.GetAllByPrefix("")
+	assert.NoError(t, err)
+	assert.Equal(t, []storage.Model{
+		&doc{SomeString: "a2"}, &doc{SomeString: "b2"}, &doc{SomeString: "c2"}, &doc{SomeString: "d"}}, models)
+}
+
+func testBatchAtomic(t *testing.T, repo storage.Repository) {
+	repo.Register(&doc{})
+	assert.NoError(t, repo.Create([]byte("a"), &doc{SomeString: "a"}))
+
+	// create of an existing key
+	batch := storage.NewBatch()
+	batch.Update([]byte("a"), &doc{SomeString: "a2"})
+	batch.Create([]byte("b"), &doc{SomeString: "b"})
+	batch.Create([]byte("a"), &doc{SomeString: "a3"})
+	err := repo.Write(batch)
+	assert.True(t, errors.IsOfType(storage.ErrRepositoryModelCreateKeyExists, err))
+
+	// update of a deleted key
+	batch = storage.NewBatch()
+	batch.Create([]byte("b"), &doc{SomeString: "b"})
+	batch.Delete([]byte("a"))
+	batch.Update([]byte("a"), &doc{SomeString: "a2"})
+	err = repo.Write(batch)
+	assert.True(t, errors.IsOfType(storage.ErrRepositoryModelUpdateKeyNotFound, err))
+
+	// nothing written
+	models, err := repo.GetAllByPrefix("")
+	assert.NoError(t, err)
+	assert.Equal(t, []storage.Model{&doc{SomeString: "a"}}, models)
+}
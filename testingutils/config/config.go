@@ -47,6 +47,61 @@ func (m *MockConfig) GetP2PResponseDelay() time.Duration {
 	return args.Get(0).(time.Duration)
 }
 
+func (m *MockConfig) GetP2PChunkSize() int {
+	args := m.Called()
+	return args.Get(0).(int)
+}
+
+func (m *MockConfig) GetP2PTransferPath() string {
+	args := m.Called()
+	return args.Get(0).(string)
+}
+
+func (m *MockConfig) GetP2PMaxTransferSize() int {
+	args := m.Called()
+	return args.Get(0).(int)
+}
+
+func (m *MockConfig) GetP2PRateLimit() int {
+	args := m.Called()
+	return args.Get(0).(int)
+}
+
+func (m *MockConfig) GetP2PMaxConcurrentRequests() int {
+	args := m.Called()
+	return args.Get(0).(int)
+}
+
+func (m *MockConfig) GetP2PBanThreshold() int {
+	args := m.Called()
+	return args.Get(0).(int)
+}
+
+func (m *MockConfig) GetP2PBanDuration() time.Duration {
+	args := m.Called()
+	return args.Get(0).(time.Duration)
+}
+
+func (m *MockConfig) GetSignatureCollectionMode() string {
+	args := m.Called()
+	return args.Get(0).(string)
+}
+
+func (m *MockConfig) GetSignatureQuorum() int {
+	args := m.Called()
+	return args.Get(0).(int)
+}
+
+func (m *MockConfig) GetSignatureRetryInterval() time.Duration {
+	args := m.Called()
+	return args.Get(0).(time.Duration)
+}
+
+func (m *MockConfig) GetSignatureCollectionTimeout() time.Duration {
+	args := m.Called()
+	return args.Get(0).(time.Duration)
+}
+
 func (m *MockConfig) GetReceiveEventNotificationEndpoint() string {
 	args := m.Called()
 	return args.Get(0).(string)
@@ -72,6 +127,61 @@ func (m *MockConfig) GetWorkerWaitTimeMS() int {
 	return args.Get(0).(int)
 }
 
+func (m *MockConfig) GetJobRetentionSuccess() time.Duration {
+	args := m.Called()
+	return args.Get(0).(time.Duration)
+}
+
+func (m *MockConfig) GetJobRetentionFailed() time.Duration {
+	args := m.Called()
+	return args.Get(0).(time.Duration)
+}
+
+func (m *MockConfig) GetJobRetentionCancelled() time.Duration {
+	args := m.Called()
+	return args.Get(0).(time.Duration)
+}
+
+func (m *MockConfig) GetJobPruneInterval() time.Duration {
+	args := m.Called()
+	return args.Get(0).(time.Duration)
+}
+
+func (m *MockConfig) GetJobArchivePath() string {
+	args := m.Called()
+	return args.Get(0).(string)
+}
+
+func (m *MockConfig) GetWebhookMaxAttempts() int {
+	args := m.Called()
+	return args.Get(0).(int)
+}
+
+func (m *MockConfig) GetWebhookRetryBackoff() time.Duration {
+	args := m.Called()
+	return args.Get(0).(time.Duration)
+}
+
+func (m *MockConfig) GetWebhookMaxRetryBackoff() time.Duration {
+	args := m.Called()
+	return args.Get(0).(time.Duration)
+}
+
+func (m *MockConfig) GetWebhookTimeout() time.Duration {
+	args := m.Called()
+	return args.Get(0).(time.Duration)
+}
+
+func (m *MockConfig) GetStorageBackend() string {
+	args := m.Called()
+	return args.Get(0).(string)
+}
+
+func (m *MockConfig) GetStoragePostgresDSN() string {
+	args := m.Called()
+	return args.Get(0).(string)
+}
+
 func (m *MockConfig) GetEthereumNodeURL() string {
 	args := m.Called()
 	return args.Get(0).(string)
@@ -97,6 +207,36 @@ func (m *MockConfig) GetEthereumMaxRetries() int {
 	return args.Get(0).(int)
 }
 
+func (m *MockConfig) GetEthereumTxCheckInterval() time.Duration {
+	args := m.Called()
+	return args.Get(0).(time.Duration)
+}
+
+func (m *MockConfig) GetEthereumTxBumpTimeout() time.Duration {
+	args := m.Called()
+	return args.Get(0).(time.Duration)
+}
+
+func (m *MockConfig) GetEthereumGasPriceBump() int {
+	args := m.Called()
+	return args.Get(0).(int)
+}
+
+func (m *MockConfig) GetEthereumSignerBackend() string {
+	args := m.Called()
+	return args.Get(0).(string)
+}
+
+func (m *MockConfig) GetEthereumSignerURL() string {
+	args := m.Called()
+	return args.Get(0).(string)
+}
+
+func (m *MockConfig) GetEthereumConfirmationDepth() int {
+	args := m.Called()
+	return args.Get(0).(int)
+}
+
 func (m *MockConfig) GetEthereumMaxGasPrice() *big.Int {
 	args := m.Called()
 	return args.Get(0).(*big.Int)
@@ -172,6 +312,21 @@ func (m *MockConfig) GetLowEntropyNFTTokenEnabled() bool {
 	return args.Get(0).(bool)
 }
 
+func (m *MockConfig) GetNFTRegistryAdapters() map[string]string {
+	args := m.Called()
+	return args.Get(0).(map[string]string)
+}
+
+func (m *MockConfig) GetNFTTransferWatchInterval() time.Duration {
+	args := m.Called()
+	return args.Get(0).(time.Duration)
+}
+
+func (m *MockConfig) GetNFTTransferPushDocuments() bool {
+	args := m.Called()
+	return args.Get(0).(bool)
+}
+
 func (m *MockConfig) IsDebugLogEnabled() bool {
 	args := m.Called()
 	return args.Get(0).(bool)
@@ -192,6 +347,26 @@ func (m *MockConfig) GetCentChainMaxRetries() int {
 	return args.Get(0).(int)
 }
 
+func (m *MockConfig) GetCentChainSignerBackend() string {
+	args := m.Called()
+	return args.Get(0).(string)
+}
+
+func (m *MockConfig) GetCentChainSignerURL() string {
+	args := m.Called()
+	return args.Get(0).(string)
+}
+
+func (m *MockConfig) GetCentChainConfirmationDepth() int {
+	args := m.Called()
+	return args.Get(0).(int)
+}
+
+func (m *MockConfig) GetCentChainEventIndexerInterval() time.Duration {
+	args := m.Called()
+	return args.Get(0).(time.Duration)
+}
+
 func (m *MockConfig) GetCentChainNodeURL() string {
 	args := m.Called()
 	return args.Get(0).(string)
@@ -11,6 +11,7 @@ import (
 	"github.com/centrifuge/go-centrifuge/documents"
 	"github.com/centrifuge/go-centrifuge/identity"
 	"github.com/centrifuge/go-centrifuge/jobs"
+	"github.com/centrifuge/go-centrifuge/storage"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/stretchr/testify/mock"
 )
@@ -91,6 +92,12 @@ func (m *MockService) Commit(ctx context.Context, doc documents.Model) (jobs.Job
 	return jobID, args.Error(1)
 }
 
+func (m *MockService) CommitWithBatch(ctx context.Context, doc documents.Model, batch *storage.Batch) (jobs.JobID, error) {
+	args := m.Called(ctx, doc, batch)
+	jobID, _ := args.Get(0).(jobs.JobID)
+	return jobID, args.Error(1)
+}
+
 func (m *MockService) Derive(ctx context.Context, payload documents.UpdatePayload) (documents.Model, error) {
 	args := m.Called(ctx, payload)
 	model, _ := args.Get(0).(documents.Model)
@@ -134,6 +141,11 @@ func (m *MockModel) PackCoreDocument() (coredocumentpb.CoreDocument, error) {
 	return dm, args.Error(1)
 }
 
+func (m *MockModel) NFTOwnerCanRead(tokenRegistry documents.TokenRegistry, registry common.Address, tokenID []byte, account identity.DID) error {
+	args := m.Called(registry, tokenID, account)
+	return args.Error(0)
+}
+
 func (m *MockModel) UnpackCoreDocument(cd coredocumentpb.CoreDocument) error {
 	args := m.Called(cd)
 	return args.Error(0)
@@ -230,3 +242,8 @@ func (m MockRegistry) OwnerOf(registry common.Address, tokenID []byte) (common.A
 	addr, _ := args.Get(0).(common.Address)
 	return addr, args.Error(1)
 }
+
+func (m MockRegistry) IsOwner(registry, owner common.Address, tokenID []byte) (bool, error) {
+	args := m.Called(registry, owner, tokenID)
+	return args.Bool(0), args.Error(1)
+}
@@ -36,8 +36,50 @@ func (m *MockNFTService) OwnerOf(registry common.Address, tokenID []byte) (owner
 	return resp, args.Error(1)
 }
 
+func (m *MockNFTService) IsOwner(registry, owner common.Address, tokenID []byte) (owned bool, err error) {
+	args := m.Called(registry, owner, tokenID)
+	return args.Bool(0), args.Error(1)
+}
+
 func (m *MockNFTService) CurrentIndexOfToken(registry common.Address, tokenID []byte) (*big.Int, error) {
 	args := m.Called(registry, tokenID)
 	resp, _ := args.Get(0).(*big.Int)
 	return resp, args.Error(1)
 }
+
+func (m *MockNFTService) GetAccountTokens(ctx context.Context) ([]nft.TokenInfo, error) {
+	args := m.Called(ctx)
+	resp, _ := args.Get(0).([]nft.TokenInfo)
+	return resp, args.Error(1)
+}
+
+func (m *MockNFTService) GetDocumentTokens(ctx context.Context, documentID []byte) ([]nft.TokenInfo, error) {
+	args := m.Called(ctx, documentID)
+	resp, _ := args.Get(0).([]nft.TokenInfo)
+	return resp, args.Error(1)
+}
+
+func (m *MockNFTService) GetTokenMetadata(ctx context.Context, registry common.Address, tokenID nft.TokenID) (*nft.Metadata, error) {
+	args := m.Called(ctx, registry, tokenID)
+	resp, _ := args.Get(0).(*nft.Metadata)
+	return resp, args.Error(1)
+}
+
+func (m *MockNFTService) RemintNFT(ctx context.Context, request nft.RemintNFTRequest) (*nft.TokenResponse, chan error, error) {
+	args := m.Called(ctx, request)
+	resp, _ := args.Get(0).(*nft.TokenResponse)
+	done, _ := args.Get(1).(chan error)
+	return resp, done, args.Error(2)
+}
+
+func (m *MockNFTService) PreviewMint(ctx context.Context, request nft.MintNFTRequest) (*nft.MintPreview, error) {
+	args := m.Called(ctx, request)
+	resp, _ := args.Get(0).(*nft.MintPreview)
+	return resp, args.Error(1)
+}
+
+func (m *MockNFTService) GetTokenTransfers(ctx context.Context, registry common.Address, tokenID nft.TokenID) ([]nft.Transfer, error) {
+	args := m.Called(ctx, registry, tokenID)
+	resp, _ := args.Get(0).([]nft.Transfer)
+	return resp, args.Error(1)
+}
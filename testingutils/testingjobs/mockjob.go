@@ -4,6 +4,7 @@ package testingjobs
 
 import (
 	"context"
+	"time"
 
 	"github.com/centrifuge/go-centrifuge/identity"
 	"github.com/centrifuge/go-centrifuge/jobs"
@@ -15,7 +16,7 @@ type MockJobManager struct {
 	jobs.Manager
 }
 
-func (m MockJobManager) ExecuteWithinJob(ctx context.Context, accountID identity.DID, existingTxID jobs.JobID, desc string, work func(accountID identity.DID, txID jobs.JobID, txMan jobs.Manager, err chan<- error)) (txID jobs.JobID, done chan error, err error) {
+func (m MockJobManager) ExecuteWithinJob(ctx context.Context, accountID identity.DID, existingTxID jobs.JobID, desc string, work func(ctx context.Context, accountID identity.DID, txID jobs.JobID, txMan jobs.Manager, err chan<- error)) (txID jobs.JobID, done chan error, err error) {
 	args := m.Called(ctx, accountID, existingTxID, desc, work)
 	return args.Get(0).(jobs.JobID), args.Get(1).(chan error), args.Error(2)
 }
@@ -30,3 +31,50 @@ func (m MockJobManager) UpdateTaskStatus(accountID identity.DID, id jobs.JobID,
 	args := m.Called(accountID, id, status, taskName, message)
 	return args.Error(0)
 }
+
+func (m MockJobManager) UpdateJobInclusion(accountID identity.DID, id jobs.JobID, inclusion jobs.Inclusion) error {
+	args := m.Called(accountID, id, inclusion)
+	return args.Error(0)
+}
+
+func (m MockJobManager) GetJob(accountID identity.DID, id jobs.JobID) (*jobs.Job, error) {
+	args := m.Called(accountID, id)
+	job, _ := args.Get(0).(*jobs.Job)
+	return job, args.Error(1)
+}
+
+func (m MockJobManager) UpdateJobStatus(accountID identity.DID, id jobs.JobID, status jobs.Status, message string) error {
+	args := m.Called(accountID, id, status, message)
+	return args.Error(0)
+}
+
+func (m MockJobManager) ListJobs(accountID identity.DID, filter jobs.Filter) ([]*jobs.Job, int, error) {
+	args := m.Called(accountID, filter)
+	list, _ := args.Get(0).([]*jobs.Job)
+	return list, args.Int(1), args.Error(2)
+}
+
+func (m MockJobManager) CancelJob(accountID identity.DID, id jobs.JobID) error {
+	args := m.Called(accountID, id)
+	return args.Error(0)
+}
+
+func (m MockJobManager) RegisterRetrier(taskName string, retrier jobs.Retrier) {
+	m.Called(taskName, retrier)
+}
+
+func (m MockJobManager) RetryJob(ctx context.Context, accountID identity.DID, id jobs.JobID) (chan error, error) {
+	args := m.Called(ctx, accountID, id)
+	done, _ := args.Get(0).(chan error)
+	return done, args.Error(1)
+}
+
+func (m MockJobManager) UpdateJobWithValue(accountID identity.DID, id jobs.JobID, key string, value []byte) error {
+	args := m.Called(accountID, id, key, value)
+	return args.Error(0)
+}
+
+func (m MockJobManager) GetDefaultTaskTimeout() time.Duration {
+	args := m.Called()
+	return args.Get(0).(time.Duration)
+}
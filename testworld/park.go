@@ -277,6 +277,9 @@ func (h *host) init() error {
 		values := map[string]interface{}{
 			"ethereum.accounts.main.key":      os.Getenv("CENT_ETHEREUM_ACCOUNTS_MAIN_KEY"),
 			"ethereum.accounts.main.password": os.Getenv("CENT_ETHEREUM_ACCOUNTS_MAIN_PASSWORD"),
+			// the dev chains only produce blocks on new transactions
+			"ethereum.confirmationDepth":  0,
+			"centChain.confirmationDepth": 0,
 		}
 		err = updateConfig(h.dir, values)
 		if err != nil {